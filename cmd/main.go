@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
+	invoiceDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
 	db "github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/document"
 	loggerAdapter "github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	routes "github.com/ln0rd/tech_challenge_12soat/internal/interface/http"
@@ -12,9 +15,19 @@ import (
 	"github.com/ln0rd/tech_challenge_12soat/internal/interface/http/middleware"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/customer"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/labor_service"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_access"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_adjustment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_assignment"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_service"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_status_history"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/report"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/user"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/vehicle"
 
@@ -25,7 +38,9 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	authDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
 	authInfra "github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/mail"
 	authUseCase "github.com/ln0rd/tech_challenge_12soat/internal/usecase/auth"
 )
 
@@ -72,9 +87,9 @@ func main() {
 	logger.Info("Initializing the application...")
 	r := mux.NewRouter()
 
-	customerController, healthController, userController, authController, vehicleController, inputController, orderController, authMiddleware, authzMiddleware := InitInstances()
+	customerController, healthController, userController, authController, vehicleController, inputController, orderController, laborServiceController, pricingController, invoiceController, reportController, authMiddleware, authzMiddleware := InitInstances()
 
-	rt := routes.NewRouter(logger, customerController, userController, authController, healthController, vehicleController, inputController, orderController, authMiddleware, authzMiddleware)
+	rt := routes.NewRouter(logger, customerController, userController, authController, healthController, vehicleController, inputController, orderController, laborServiceController, pricingController, invoiceController, reportController, authMiddleware, authzMiddleware)
 	rt.SetupRouter(r)
 
 	logger.Info("Server starting", zap.String("port", httpPort))
@@ -90,7 +105,7 @@ func getCurrentDir() string {
 	return dir
 }
 
-func InitInstances() (*controller.CustomerController, *controller.HealthController, *controller.UserController, *controller.AuthController, *controller.VehicleController, *controller.InputController, *controller.OrderController, *middleware.AuthMiddleware, *middleware.AuthorizationMiddleware) {
+func InitInstances() (*controller.CustomerController, *controller.HealthController, *controller.UserController, *controller.AuthController, *controller.VehicleController, *controller.InputController, *controller.OrderController, *controller.LaborServiceController, *controller.PricingController, *controller.InvoiceController, *controller.ReportController, *middleware.AuthMiddleware, *middleware.AuthorizationMiddleware) {
 	// Cria os repositories
 	customerRepository := repository.NewCustomerRepositoryAdapter(db.DB)
 	userRepository := repository.NewUserRepositoryAdapter(db.DB)
@@ -99,6 +114,19 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 	orderRepository := repository.NewOrderRepositoryAdapter(db.DB)
 	orderInputRepository := repository.NewOrderInputRepositoryAdapter(db.DB)
 	orderStatusHistoryRepository := repository.NewOrderStatusHistoryRepositoryAdapter(db.DB)
+	inventoryMovementRepository := repository.NewInventoryMovementRepositoryAdapter(db.DB)
+	laborServiceRepository := repository.NewLaborServiceRepositoryAdapter(db.DB)
+	orderServiceRepository := repository.NewOrderServiceRepositoryAdapter(db.DB)
+	quoteRepository := repository.NewQuoteRepositoryAdapter(db.DB)
+	orderAdjustmentRepository := repository.NewOrderAdjustmentRepositoryAdapter(db.DB)
+	pricingRepository := repository.NewPricingRepositoryAdapter(db.DB)
+	invoiceRepository := repository.NewInvoiceRepositoryAdapter(db.DB)
+	paymentRepository := repository.NewPaymentRepositoryAdapter(db.DB)
+	orderAssignmentRepository := repository.NewOrderAssignmentRepositoryAdapter(db.DB)
+	reportRepository := repository.NewReportRepositoryAdapter(db.DB)
+
+	// Cria a unidade de trabalho para operações transacionais
+	unitOfWork := repository.NewGormUnitOfWork(db.DB)
 
 	// Cria o logger adapter
 	loggerAdapter := loggerAdapter.NewZapAdapter(logger)
@@ -118,7 +146,21 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		UpdateByIdCustomer: updateByIdCustomerUC,
 	}
 
-	createUserUC := &user.CreateUser{UserRepository: userRepository, Logger: loggerAdapter}
+	// E-mails transacionais e confirmação de e-mail no cadastro
+	mailer, err := mail.NewMailerFromEnv(logger)
+	if err != nil {
+		logger.Fatal("Invalid mailer configuration", zap.Error(err))
+	}
+	// APP_URL é o endereço usado nos links enviados por e-mail
+	appURL := os.Getenv("APP_URL")
+	if appURL == "" {
+		appURL = "http://localhost:8080"
+	}
+	authRepository := authInfra.NewAuthRepository(db.DB, logger)
+	userTokenRepository := authInfra.NewUserTokenRepository(db.DB, logger)
+	sendEmailVerificationUseCase := authUseCase.NewSendEmailVerificationUseCase(authRepository, userTokenRepository, mailer, appURL, loggerAdapter)
+
+	createUserUC := &user.CreateUser{UserRepository: userRepository, EmailVerification: sendEmailVerificationUseCase, Logger: loggerAdapter}
 	userController := &controller.UserController{
 		Logger:     logger,
 		CreateUser: createUserUC,
@@ -139,11 +181,28 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		DeleteByIdVehicle:       deleteByIdVehicleUC,
 	}
 
-	createInputUC := &input.CreateInput{InputRepository: inputRepository, Logger: loggerAdapter}
+	// Ledger de movimentações de estoque
+	recordInventoryMovementUC := &input.RecordInventoryMovement{InventoryMovementRepository: inventoryMovementRepository, Logger: loggerAdapter}
+
+	// Input quantity usecases
+	increaseQuantityInputUC := &input.IncreaseQuantityInput{InputRepository: inputRepository, Logger: loggerAdapter, UnitOfWork: unitOfWork, RecordInventoryMovement: recordInventoryMovementUC}
+	decreaseQuantityInputUC := &input.DecreaseQuantityInput{InputRepository: inputRepository, Logger: loggerAdapter, UnitOfWork: unitOfWork, RecordInventoryMovement: recordInventoryMovementUC}
+	manageInputReservationUC := &input.ManageInputReservation{InputRepository: inputRepository, Logger: loggerAdapter, RecordInventoryMovement: recordInventoryMovementUC}
+
+	createInputUC := &input.CreateInput{InputRepository: inputRepository, Logger: loggerAdapter, RecordInventoryMovement: recordInventoryMovementUC, UnitOfWork: unitOfWork}
 	findAllInputsUC := &input.FindAllInputs{InputRepository: inputRepository, Logger: loggerAdapter}
 	findByIdInputUC := &input.FindByIdInput{InputRepository: inputRepository, Logger: loggerAdapter}
-	updateByIdInputUC := &input.UpdateByIdInput{InputRepository: inputRepository, Logger: loggerAdapter}
+	updateByIdInputUC := &input.UpdateByIdInput{InputRepository: inputRepository, Logger: loggerAdapter, RecordInventoryMovement: recordInventoryMovementUC, UnitOfWork: unitOfWork}
 	deleteByIdInputUC := &input.DeleteByIdInput{InputRepository: inputRepository, Logger: loggerAdapter}
+	findInputMovementsUC := &input.FindInputMovements{InputRepository: inputRepository, InventoryMovementRepository: inventoryMovementRepository, Logger: loggerAdapter}
+	adjustInputStockUC := &input.AdjustInputStock{
+		InputRepository:         inputRepository,
+		Logger:                  loggerAdapter,
+		IncreaseQuantityInput:   increaseQuantityInputUC,
+		DecreaseQuantityInput:   decreaseQuantityInputUC,
+		RecordInventoryMovement: recordInventoryMovementUC,
+		UnitOfWork:              unitOfWork,
+	}
 
 	inputController := &controller.InputController{
 		Logger:          logger,
@@ -152,6 +211,155 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		FindByIdInput:   findByIdInputUC,
 		UpdateByIdInput: updateByIdInputUC,
 		DeleteByIdInput: deleteByIdInputUC,
+
+		FindInputMovements: findInputMovementsUC,
+		AdjustInputStock:   adjustInputStockUC,
+	}
+
+	// Catálogo de serviços de mão de obra
+	createLaborServiceUC := &labor_service.CreateLaborService{LaborServiceRepository: laborServiceRepository, Logger: loggerAdapter}
+	findAllLaborServicesUC := &labor_service.FindAllLaborServices{LaborServiceRepository: laborServiceRepository, Logger: loggerAdapter}
+	findByIdLaborServiceUC := &labor_service.FindByIdLaborService{LaborServiceRepository: laborServiceRepository, Logger: loggerAdapter}
+	updateByIdLaborServiceUC := &labor_service.UpdateByIdLaborService{LaborServiceRepository: laborServiceRepository, Logger: loggerAdapter}
+	deleteByIdLaborServiceUC := &labor_service.DeleteByIdLaborService{LaborServiceRepository: laborServiceRepository, Logger: loggerAdapter}
+
+	laborServiceController := &controller.LaborServiceController{
+		Logger:                 logger,
+		CreateLaborService:     createLaborServiceUC,
+		FindAllLaborServices:   findAllLaborServicesUC,
+		FindByIdLaborService:   findByIdLaborServiceUC,
+		UpdateByIdLaborService: updateByIdLaborServiceUC,
+		DeleteByIdLaborService: deleteByIdLaborServiceUC,
+	}
+
+	// Preços: ajustes, impostos e limite de desconto
+	calculateOrderPricingUC := &pricing.CalculateOrderPricing{
+		OrderAdjustmentRepository: orderAdjustmentRepository,
+		PricingRepository:         pricingRepository,
+		Logger:                    loggerAdapter,
+	}
+	findPricingSettingsUC := &pricing.FindPricingSettings{PricingRepository: pricingRepository, Logger: loggerAdapter}
+	updatePricingSettingsUC := &pricing.UpdatePricingSettings{Logger: loggerAdapter, UnitOfWork: unitOfWork}
+
+	pricingController := &controller.PricingController{
+		Logger:                  logger,
+		FindPricingSettingsUC:   findPricingSettingsUC,
+		UpdatePricingSettingsUC: updatePricingSettingsUC,
+	}
+
+	addOrderAdjustmentUC := &order_adjustment.AddOrderAdjustment{
+		OrderRepository:           orderRepository,
+		OrderInputRepository:      orderInputRepository,
+		OrderServiceRepository:    orderServiceRepository,
+		OrderAdjustmentRepository: orderAdjustmentRepository,
+		PricingSettings:           findPricingSettingsUC,
+		Pricing:                   calculateOrderPricingUC,
+		Logger:                    loggerAdapter,
+		UnitOfWork:                unitOfWork,
+	}
+	approveOrderAdjustmentUC := &order_adjustment.ApproveOrderAdjustment{
+		OrderRepository:           orderRepository,
+		OrderAdjustmentRepository: orderAdjustmentRepository,
+		Logger:                    loggerAdapter,
+	}
+	removeOrderAdjustmentUC := &order_adjustment.RemoveOrderAdjustment{
+		OrderRepository:           orderRepository,
+		OrderAdjustmentRepository: orderAdjustmentRepository,
+		Logger:                    loggerAdapter,
+	}
+
+	// Orçamentos versionados
+	snapshotOrderItemsUC := &quote.SnapshotOrderItems{
+		OrderInputRepository:   orderInputRepository,
+		InputRepository:        inputRepository,
+		OrderServiceRepository: orderServiceRepository,
+		LaborServiceRepository: laborServiceRepository,
+		Logger:                 loggerAdapter,
+		Pricing:                calculateOrderPricingUC,
+	}
+
+	generateQuoteUC := &quote.GenerateQuote{
+		OrderRepository:    orderRepository,
+		QuoteRepository:    quoteRepository,
+		Logger:             loggerAdapter,
+		SnapshotOrderItems: snapshotOrderItemsUC,
+		UnitOfWork:         unitOfWork,
+	}
+	// Restringe as consultas do dono de veículo às orders do seu customer
+	validateOrderAccessUC := &order_access.ValidateOrderAccess{
+		OrderRepository:   orderRepository,
+		UserRepository:    userRepository,
+		InvoiceRepository: invoiceRepository,
+		Logger:            loggerAdapter,
+	}
+	findQuotesByOrderIdUC := &quote.FindQuotesByOrderId{OrderRepository: orderRepository, QuoteRepository: quoteRepository, Logger: loggerAdapter}
+	findQuoteByVersionUC := &quote.FindQuoteByVersion{QuoteRepository: quoteRepository, Logger: loggerAdapter}
+	verifyApprovedQuoteUC := &quote.VerifyApprovedQuote{
+		QuoteRepository:    quoteRepository,
+		SnapshotOrderItems: snapshotOrderItemsUC,
+		Logger:             loggerAdapter,
+	}
+
+	// Notas de serviço e notas de crédito, numeradas por oficina
+	workshopCode := os.Getenv("WORKSHOP_CODE")
+	if workshopCode == "" {
+		workshopCode = invoiceDomain.DefaultWorkshopCode
+	}
+	// Identificação da oficina impressa no cabeçalho dos documentos
+	workshop := document.Workshop{
+		Name:           os.Getenv("WORKSHOP_NAME"),
+		DocumentNumber: os.Getenv("WORKSHOP_DOCUMENT"),
+		Address:        os.Getenv("WORKSHOP_ADDRESS"),
+		Phone:          os.Getenv("WORKSHOP_PHONE"),
+	}
+	issueInvoiceUC := &invoice.IssueInvoice{
+		OrderRepository:    orderRepository,
+		CustomerRepository: customerRepository,
+		VehicleRepository:  vehicleRepository,
+		QuoteRepository:    quoteRepository,
+		InvoiceRepository:  invoiceRepository,
+		SnapshotOrderItems: snapshotOrderItemsUC,
+		WorkshopCode:       workshopCode,
+		Logger:             loggerAdapter,
+		UnitOfWork:         unitOfWork,
+	}
+	findInvoiceByIdUC := &invoice.FindInvoiceById{InvoiceRepository: invoiceRepository, Logger: loggerAdapter}
+	findInvoiceByOrderIdUC := &invoice.FindInvoiceByOrderId{InvoiceRepository: invoiceRepository, Logger: loggerAdapter}
+	renderInvoiceUC := &invoice.RenderInvoice{FindInvoiceById: findInvoiceByIdUC, Workshop: workshop, Logger: loggerAdapter}
+	issueCreditNoteUC := &invoice.IssueCreditNote{
+		InvoiceRepository: invoiceRepository,
+		WorkshopCode:      workshopCode,
+		Logger:            loggerAdapter,
+		UnitOfWork:        unitOfWork,
+	}
+
+	invoiceController := &controller.InvoiceController{
+		Logger:                 logger,
+		FindInvoiceByIdUC:      findInvoiceByIdUC,
+		FindInvoiceByOrderIdUC: findInvoiceByOrderIdUC,
+		RenderInvoiceUC:        renderInvoiceUC,
+		IssueCreditNoteUC:      issueCreditNoteUC,
+		ValidateOrderAccessUC:  validateOrderAccessUC,
+	}
+
+	// Pagamentos e saldo das orders
+	calculateOrderBalanceUC := &payment.CalculateOrderBalance{
+		InvoiceRepository: invoiceRepository,
+		QuoteRepository:   quoteRepository,
+		PaymentRepository: paymentRepository,
+		Logger:            loggerAdapter,
+	}
+	recordPaymentUC := &payment.RecordPayment{
+		OrderRepository:   orderRepository,
+		PaymentRepository: paymentRepository,
+		Balance:           calculateOrderBalanceUC,
+		Logger:            loggerAdapter,
+		UnitOfWork:        unitOfWork,
+	}
+	findPaymentsByOrderIdUC := &payment.FindPaymentsByOrderId{
+		OrderRepository: orderRepository,
+		Balance:         calculateOrderBalanceUC,
+		Logger:          loggerAdapter,
 	}
 
 	// Order usecases
@@ -166,6 +374,7 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		VehicleRepository:    vehicleRepository,
 		Logger:               loggerAdapter,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork:           unitOfWork,
 	}
 
 	findOrderOverviewByIdUC := &order.FindOrderOverviewById{
@@ -174,12 +383,55 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		OrderInputRepository:         orderInputRepository,
 		OrderStatusHistoryRepository: orderStatusHistoryRepository,
 		InputRepository:              inputRepository,
+		OrderServiceRepository:       orderServiceRepository,
+		LaborServiceRepository:       laborServiceRepository,
 		Logger:                       loggerAdapter,
+		Pricing:                      calculateOrderPricingUC,
+		Balance:                      calculateOrderBalanceUC,
+		OrderAssignmentRepository:    orderAssignmentRepository,
 	}
 
-	// Input quantity usecases
-	increaseQuantityInputUC := &input.IncreaseQuantityInput{InputRepository: inputRepository, Logger: loggerAdapter}
-	decreaseQuantityInputUC := &input.DecreaseQuantityInput{InputRepository: inputRepository, Logger: loggerAdapter}
+	renderOrderDocumentUC := &order.RenderOrderDocument{
+		OrderRepository:       orderRepository,
+		CustomerRepository:    customerRepository,
+		QuoteRepository:       quoteRepository,
+		InvoiceRepository:     invoiceRepository,
+		FindOrderOverviewById: findOrderOverviewByIdUC,
+		Balance:               calculateOrderBalanceUC,
+		Workshop:              workshop,
+		Logger:                loggerAdapter,
+	}
+
+	// Mecânicos designados e fila de trabalho
+	assignMechanicUC := &order_assignment.AssignMechanic{
+		OrderRepository:           orderRepository,
+		UserRepository:            userRepository,
+		OrderAssignmentRepository: orderAssignmentRepository,
+		Logger:                    loggerAdapter,
+		UnitOfWork:                unitOfWork,
+	}
+	unassignMechanicUC := &order_assignment.UnassignMechanic{
+		OrderRepository:           orderRepository,
+		OrderAssignmentRepository: orderAssignmentRepository,
+		Logger:                    loggerAdapter,
+		UnitOfWork:                unitOfWork,
+	}
+	findOrderAssignmentsUC := &order_assignment.FindOrderAssignments{
+		OrderRepository:           orderRepository,
+		OrderAssignmentRepository: orderAssignmentRepository,
+		Logger:                    loggerAdapter,
+	}
+	findMechanicOrdersUC := &order_assignment.FindMechanicOrders{
+		UserRepository:  userRepository,
+		OrderRepository: orderRepository,
+		Logger:          loggerAdapter,
+	}
+
+	findAllOrdersUC := &order.FindAllOrders{
+		OrderRepository: orderRepository,
+		UserRepository:  userRepository,
+		Logger:          loggerAdapter,
+	}
 
 	// Order input usecases
 	addInputToOrderUC := &order_input.AddInputToOrder{
@@ -188,6 +440,9 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		OrderInputRepository:  orderInputRepository,
 		Logger:                loggerAdapter,
 		DecreaseQuantityInput: decreaseQuantityInputUC,
+		UnitOfWork:            unitOfWork,
+
+		ManageInputReservation: manageInputReservationUC,
 	}
 
 	removeInputFromOrderUC := &order_input.RemoveInputFromOrder{
@@ -196,6 +451,53 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		OrderInputRepository:  orderInputRepository,
 		Logger:                loggerAdapter,
 		IncreaseQuantityInput: increaseQuantityInputUC,
+		UnitOfWork:            unitOfWork,
+
+		ManageInputReservation: manageInputReservationUC,
+	}
+
+	flagOrderInputConditionUC := &order_input.FlagOrderInputCondition{
+		OrderRepository:      orderRepository,
+		OrderInputRepository: orderInputRepository,
+		Logger:               loggerAdapter,
+	}
+
+	// Order service usecases
+	addServiceToOrderUC := &order_service.AddServiceToOrder{
+		OrderRepository:        orderRepository,
+		LaborServiceRepository: laborServiceRepository,
+		OrderServiceRepository: orderServiceRepository,
+		UserRepository:         userRepository,
+		Logger:                 loggerAdapter,
+	}
+
+	updateOrderServiceUC := &order_service.UpdateOrderService{
+		OrderRepository:        orderRepository,
+		OrderServiceRepository: orderServiceRepository,
+		UserRepository:         userRepository,
+		Logger:                 loggerAdapter,
+	}
+
+	removeServiceFromOrderUC := &order_service.RemoveServiceFromOrder{
+		OrderRepository:        orderRepository,
+		OrderServiceRepository: orderServiceRepository,
+		Logger:                 loggerAdapter,
+	}
+
+	// Reservas de estoque consumidas quando a order entra em execução
+	manageOrderReservationsUC := &order_input.ManageOrderReservations{
+		OrderInputRepository:   orderInputRepository,
+		Logger:                 loggerAdapter,
+		ManageInputReservation: manageInputReservationUC,
+	}
+
+	// Devolução das peças ao estoque quando a order é cancelada
+	cancelOrderInputsUC := &order_input.CancelOrderInputs{
+		OrderInputRepository:   orderInputRepository,
+		InputRepository:        inputRepository,
+		Logger:                 loggerAdapter,
+		ManageInputReservation: manageInputReservationUC,
+		IncreaseQuantityInput:  increaseQuantityInputUC,
 	}
 
 	// Order status usecase
@@ -203,32 +505,134 @@ func InitInstances() (*controller.CustomerController, *controller.HealthControll
 		OrderRepository:      orderRepository,
 		Logger:               loggerAdapter,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork:           unitOfWork,
+
+		OrderReservations: manageOrderReservationsUC,
+		CancelOrderInputs: cancelOrderInputsUC,
+		ApprovedQuote:     verifyApprovedQuoteUC,
+		IssueInvoice:      issueInvoiceUC,
+		Balance:           calculateOrderBalanceUC,
+	}
+
+	// Order approval usecases
+	approveOrderUC := &order.ApproveOrder{
+		OrderRepository:   orderRepository,
+		UserRepository:    userRepository,
+		Logger:            loggerAdapter,
+		UpdateOrderStatus: updateOrderStatusUC,
+		UnitOfWork:        unitOfWork,
+
+		QuoteRepository:    quoteRepository,
+		SnapshotOrderItems: snapshotOrderItemsUC,
+	}
+
+	rejectOrderUC := &order.RejectOrder{
+		OrderRepository:   orderRepository,
+		UserRepository:    userRepository,
+		Logger:            loggerAdapter,
+		UpdateOrderStatus: updateOrderStatusUC,
+		UnitOfWork:        unitOfWork,
 	}
 
 	orderController := &controller.OrderController{
 		Logger:                  logger,
 		CreateOrder:             createOrderUC,
 		FindOrderOverviewByIdUC: findOrderOverviewByIdUC,
+		FindAllOrdersUC:         findAllOrdersUC,
 		AddInputToOrderUC:       addInputToOrderUC,
 		RemoveInputFromOrderUC:  removeInputFromOrderUC,
 		UpdateOrderStatusUC:     updateOrderStatusUC,
+		ApproveOrderUC:          approveOrderUC,
+		RejectOrderUC:           rejectOrderUC,
+
+		FlagOrderInputConditionUC: flagOrderInputConditionUC,
+
+		AddServiceToOrderUC:      addServiceToOrderUC,
+		UpdateOrderServiceUC:     updateOrderServiceUC,
+		RemoveServiceFromOrderUC: removeServiceFromOrderUC,
+
+		GenerateQuoteUC:       generateQuoteUC,
+		FindQuotesByOrderIdUC: findQuotesByOrderIdUC,
+		FindQuoteByVersionUC:  findQuoteByVersionUC,
+
+		AddOrderAdjustmentUC:     addOrderAdjustmentUC,
+		ApproveOrderAdjustmentUC: approveOrderAdjustmentUC,
+		RemoveOrderAdjustmentUC:  removeOrderAdjustmentUC,
+
+		RecordPaymentUC:         recordPaymentUC,
+		FindPaymentsByOrderIdUC: findPaymentsByOrderIdUC,
+
+		RenderOrderDocumentUC: renderOrderDocumentUC,
+
+		AssignMechanicUC:       assignMechanicUC,
+		UnassignMechanicUC:     unassignMechanicUC,
+		FindOrderAssignmentsUC: findOrderAssignmentsUC,
+		FindMechanicOrdersUC:   findMechanicOrdersUC,
+
+		ValidateOrderAccessUC: validateOrderAccessUC,
+	}
+
+	// Relatórios operacionais
+	findStatusDurationReportUC := &report.FindStatusDurationReport{ReportRepository: reportRepository, Logger: loggerAdapter}
+	reportController := &controller.ReportController{
+		Logger:                     logger,
+		FindStatusDurationReportUC: findStatusDurationReportUC,
 	}
 
 	healthController := &controller.HealthController{}
 
 	// Auth components
-	authRepository := authInfra.NewAuthRepository(db.DB, logger)
-	jwtService := authInfra.NewJWTService(logger)
-	loginUseCase := authUseCase.NewLoginUseCase(authRepository, jwtService, loggerAdapter)
+	// Fora de desenvolvimento, subir sem chave de assinatura é erro de configuração
+	keyConfig, err := authInfra.KeyConfigFromEnv()
+	if err != nil {
+		logger.Fatal("Invalid JWT key configuration", zap.Error(err))
+	}
+	jwtService, err := authInfra.NewJWTService(keyConfig, logger)
+	if err != nil {
+		logger.Fatal("Failed to load JWT signing keys", zap.Error(err))
+	}
+	refreshTokenRepository := authInfra.NewRefreshTokenRepository(db.DB, logger)
+	authUnitOfWork := authInfra.NewGormUnitOfWork(db.DB, logger)
+	// Tentativas de login: backoff por e-mail e IP, bloqueio temporário da conta e auditoria
+	loginAttemptRepository := authInfra.NewLoginAttemptRepository(db.DB, logger)
+	authEventRepository := authInfra.NewAuthEventRepository(db.DB, logger)
+	loginUseCase := authUseCase.NewLoginUseCase(authRepository, refreshTokenRepository, jwtService, loginAttemptRepository, authEventRepository, authDomain.DefaultLoginThrottlePolicy(), loggerAdapter)
+	refreshTokenUseCase := authUseCase.NewRefreshTokenUseCase(authRepository, refreshTokenRepository, authUnitOfWork, jwtService, loggerAdapter)
+
+	// Lista de revogação dos tokens de acesso; "memory" dispensa o banco, mas não sobrevive a reinícios
+	var revocationStore authDomain.TokenRevocationStore = authInfra.NewRevocationStore(db.DB, logger)
+	if os.Getenv("TOKEN_REVOCATION_STORE") == "memory" {
+		revocationStore = authInfra.NewMemoryRevocationStore()
+	}
+	authInfra.StartRevocationPruner(context.Background(), revocationStore, authInfra.DefaultPruneInterval, logger)
+
+	logoutUseCase := authUseCase.NewLogoutUseCase(refreshTokenRepository, revocationStore, loggerAdapter)
+	logoutAllUseCase := authUseCase.NewLogoutAllUseCase(refreshTokenRepository, revocationStore, loggerAdapter)
+	requestPasswordResetUseCase := authUseCase.NewRequestPasswordResetUseCase(authRepository, userTokenRepository, mailer, appURL, loggerAdapter)
+	resetPasswordUseCase := authUseCase.NewResetPasswordUseCase(authUnitOfWork, revocationStore, loggerAdapter)
+	verifyEmailUseCase := authUseCase.NewVerifyEmailUseCase(authRepository, userTokenRepository, loggerAdapter)
+	unlockAccountUseCase := authUseCase.NewUnlockAccountUseCase(authRepository, loginAttemptRepository, authEventRepository, loggerAdapter)
+	listAuthEventsUseCase := authUseCase.NewListAuthEventsUseCase(authEventRepository, loggerAdapter)
 
 	authController := &controller.AuthController{
-		Logger:       logger,
-		LoginUseCase: loginUseCase,
+		Logger:                       logger,
+		LoginUseCase:                 loginUseCase,
+		RefreshTokenUseCase:          refreshTokenUseCase,
+		LogoutUseCase:                logoutUseCase,
+		LogoutAllUseCase:             logoutAllUseCase,
+		KeySet:                       jwtService,
+		RequestPasswordResetUseCase:  requestPasswordResetUseCase,
+		ResetPasswordUseCase:         resetPasswordUseCase,
+		SendEmailVerificationUseCase: sendEmailVerificationUseCase,
+		VerifyEmailUseCase:           verifyEmailUseCase,
+		UnlockAccountUseCase:         unlockAccountUseCase,
+		ListAuthEventsUseCase:        listAuthEventsUseCase,
+		TrustProxyHeaders:            os.Getenv("TRUST_PROXY_HEADERS") == "true",
 	}
 
 	// Auth middleware
-	authMiddleware := middleware.NewAuthMiddleware(jwtService, logger)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, revocationStore, logger)
 	authzMiddleware := middleware.NewAuthorizationMiddleware(logger)
 
-	return customerController, healthController, userController, authController, vehicleController, inputController, orderController, authMiddleware, authzMiddleware
+	return customerController, healthController, userController, authController, vehicleController, inputController, orderController, laborServiceController, pricingController, invoiceController, reportController, authMiddleware, authzMiddleware
 }
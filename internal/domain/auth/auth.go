@@ -1,35 +1,120 @@
 package auth
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// AccessTokenTTL é a validade do token de acesso
+const AccessTokenTTL = 24 * time.Hour
+
+// DefaultRefreshTokenTTL é a validade de cada refresh token; a rotação emite um novo com o mesmo prazo
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+const (
+	// PurposePasswordReset identifica o token enviado em "esqueci minha senha"
+	PurposePasswordReset = "password_reset"
+	// PurposeEmailVerification identifica o token enviado para confirmar o e-mail
+	PurposeEmailVerification = "email_verification"
+
+	PasswordResetTokenTTL     = time.Hour
+	EmailVerificationTokenTTL = 48 * time.Hour
+)
+
+var (
+	ErrInvalidUserToken    = errors.New("invalid or expired token")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReuse   = errors.New("refresh token reuse detected")
+)
+
 // LoginRequest representa a requisição de login
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Device identifica o aparelho ou cliente que recebe o refresh token
+	Device string `json:"device"`
+	// IP e UserAgent vêm da requisição HTTP, para o controle de tentativas e a auditoria
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// RefreshRequest troca um refresh token por um novo par de tokens
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	Device       string `json:"device"`
 }
 
 // LoginResponse representa a resposta de login
 type LoginResponse struct {
-	Token        string    `json:"token"`
-	RefreshToken string    `json:"refresh_token"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	User         UserInfo  `json:"user"`
+	Token                 string    `json:"token"`
+	RefreshToken          string    `json:"refresh_token"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	User                  UserInfo  `json:"user"`
+}
+
+// RefreshToken é o registro de um refresh token emitido. Só o hash é guardado. Tokens gerados
+// a partir do mesmo login formam uma família: cada uso rotaciona o token, e reapresentar um
+// token já rotacionado indica vazamento e revoga a família inteira.
+type RefreshToken struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	FamilyID     uuid.UUID
+	TokenHash    string
+	Device       string
+	ExpiresAt    time.Time
+	RotatedAt    *time.Time
+	ReplacedByID *uuid.UUID
+	RevokedAt    *time.Time
+	CreatedAt    time.Time
+}
+
+// IsExpired indica se o token passou da validade
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// UserToken é um token de uso único e com prazo, enviado por e-mail para redefinir a senha ou
+// confirmar o e-mail. Assim como o refresh token, só o hash é guardado.
+type UserToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// IsUsable indica se o token ainda pode ser usado
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// HashToken calcula o hash guardado no banco para refresh tokens e tokens de uso único. O
+// token é aleatório e longo, então um SHA-256 basta e permite a busca direta pelo hash.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 // UserInfo representa as informações do usuário no token
 type UserInfo struct {
-	ID       uuid.UUID `json:"id"`
-	Email    string    `json:"email"`
-	Username string    `json:"username"`
-	UserType string    `json:"user_type"`
+	ID            uuid.UUID `json:"id"`
+	Email         string    `json:"email"`
+	Username      string    `json:"username"`
+	UserType      string    `json:"user_type"`
+	EmailVerified bool      `json:"email_verified"`
 }
 
 // Claims representa as claims do JWT
 type Claims struct {
+	// TokenID é o jti, que identifica o token na lista de revogação
+	TokenID  uuid.UUID `json:"jti"`
 	UserID   uuid.UUID `json:"user_id"`
 	Email    string    `json:"email"`
 	Username string    `json:"username"`
@@ -42,12 +127,91 @@ type Claims struct {
 type TokenService interface {
 	GenerateToken(userInfo UserInfo) (string, error)
 	ValidateToken(token string) (*Claims, error)
+	// GenerateRefreshToken gera o valor opaco do refresh token; a validação é feita pelo hash guardado
 	GenerateRefreshToken(userID uuid.UUID) (string, error)
-	ValidateRefreshToken(refreshToken string) (uuid.UUID, error)
 }
 
 // AuthRepository define a interface para repositório de autenticação
 type AuthRepository interface {
 	FindUserByEmail(email string) (*UserInfo, error)
+	FindUserByID(id uuid.UUID) (*UserInfo, error)
 	ValidatePassword(email, password string) error
+	// UpdatePassword grava o hash bcrypt da nova senha
+	UpdatePassword(userID uuid.UUID, passwordHash string) error
+	MarkEmailVerified(userID uuid.UUID, verifiedAt time.Time) error
+}
+
+// UserTokenRepository define a persistência dos tokens de uso único
+type UserTokenRepository interface {
+	Create(token *UserToken) error
+	// FindByHash retorna nil, nil quando nenhum token da finalidade tem o hash
+	FindByHash(tokenHash string, purpose string) (*UserToken, error)
+	// MarkUsed consome o token se ele ainda não foi usado; retorna false quando outro uso chegou antes
+	MarkUsed(id uuid.UUID, usedAt time.Time) (bool, error)
+	// InvalidateForUser consome os tokens pendentes da finalidade, para que só o último enviado valha
+	InvalidateForUser(userID uuid.UUID, purpose string, usedAt time.Time) error
+}
+
+// RefreshTokenRepository define a persistência dos refresh tokens
+type RefreshTokenRepository interface {
+	Create(token *RefreshToken) error
+	// FindByHash retorna nil, nil quando nenhum token tem o hash
+	FindByHash(tokenHash string) (*RefreshToken, error)
+	// MarkRotated marca o token como usado, se ainda estiver ativo. Retorna false quando outro
+	// uso chegou antes, o que também é tratado como reuso.
+	MarkRotated(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error)
+	// RevokeFamily revoga todos os tokens ainda não revogados da família
+	RevokeFamily(familyID uuid.UUID, revokedAt time.Time) error
+	// RevokeAllForUser revoga todos os tokens ainda não revogados do usuário
+	RevokeAllForUser(userID uuid.UUID, revokedAt time.Time) error
+}
+
+// Repositories agrupa os repositórios de autenticação que participam de uma unidade de trabalho
+type Repositories struct {
+	Auth          AuthRepository
+	UserTokens    UserTokenRepository
+	RefreshTokens RefreshTokenRepository
+}
+
+// UnitOfWork executa várias operações dos repositórios de autenticação em uma única transação
+type UnitOfWork interface {
+	// Execute executa fn dentro de uma transação, fazendo rollback se fn retornar erro
+	Execute(fn func(repos Repositories) error) error
+}
+
+// TokenRevocationStore guarda os tokens de acesso revogados antes de expirar. Cada entrada vale
+// até a expiração dos tokens que cobre e depois pode ser removida.
+type TokenRevocationStore interface {
+	// RevokeToken revoga um único token de acesso pelo jti
+	RevokeToken(tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error
+	// RevokeUserTokens revoga todos os tokens do usuário emitidos até issuedBefore. Como o iat tem
+	// precisão de segundos, tokens emitidos no mesmo segundo também são revogados.
+	RevokeUserTokens(userID uuid.UUID, issuedBefore time.Time) error
+	IsRevoked(claims *Claims) (bool, error)
+	// PruneExpired remove as entradas que já não cobrem nenhum token válido
+	PruneExpired(now time.Time) (int64, error)
+}
+
+// JSONWebKey é a chave pública de assinatura no formato JWK (RFC 7517)
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// N e E são o módulo e o expoente de uma chave RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv e X são a curva e a chave pública de uma chave OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet é o documento publicado em /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeySetProvider expõe as chaves públicas que validam os tokens emitidos
+type KeySetProvider interface {
+	PublicKeySet() JSONWebKeySet
 }
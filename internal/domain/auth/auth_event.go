@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventLoginSucceeded  = "login_succeeded"
+	EventLoginFailed     = "login_failed"
+	EventLoginThrottled  = "login_throttled"
+	EventAccountLocked   = "account_locked"
+	EventAccountUnlocked = "account_unlocked"
+)
+
+const (
+	DefaultAuthEventLimit = 100
+	MaxAuthEventLimit     = 500
+)
+
+// IsValidAuthEventType indica se o tipo é um dos eventos registrados
+func IsValidAuthEventType(eventType string) bool {
+	switch eventType {
+	case EventLoginSucceeded, EventLoginFailed, EventLoginThrottled, EventAccountLocked, EventAccountUnlocked:
+		return true
+	}
+	return false
+}
+
+// AuthEvent registra o resultado de uma tentativa de login ou de uma ação sobre o bloqueio da
+// conta, para auditoria. UserID fica vazio quando o e-mail não corresponde a nenhum usuário.
+type AuthEvent struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      string     `json:"type"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	Email     string     `json:"email"`
+	IP        string     `json:"ip"`
+	UserAgent string     `json:"user_agent"`
+	Detail    string     `json:"detail"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AuthEventFilter seleciona os eventos mais recentes; campos vazios não filtram
+type AuthEventFilter struct {
+	Email  string
+	IP     string
+	Type   string
+	UserID *uuid.UUID
+	Limit  int
+}
+
+// AuthEventRepository guarda o histórico de eventos de autenticação
+type AuthEventRepository interface {
+	Record(event *AuthEvent) error
+	// Find retorna os eventos do filtro, do mais recente para o mais antigo
+	Find(filter AuthEventFilter) ([]AuthEvent, error)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// AttemptScopeEmail conta as falhas de login por e-mail e é o que bloqueia a conta
+	AttemptScopeEmail = "email"
+	// AttemptScopeIP conta as falhas de login por IP, pegando quem testa vários e-mails
+	AttemptScopeIP = "ip"
+)
+
+var (
+	ErrTooManyLoginAttempts = errors.New("too many login attempts")
+	ErrAccountLocked        = errors.New("account temporarily locked")
+)
+
+// LoginBlockedError recusa a tentativa antes de conferir a senha e informa quando tentar de novo
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// LoginAttempt acumula as falhas recentes de login de um e-mail ou IP
+type LoginAttempt struct {
+	Scope         string
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginThrottlePolicy define o backoff e o bloqueio. Depois das tentativas livres, cada falha
+// dobra a espera antes da próxima tentativa, até MaxDelay; ao atingir LockoutThreshold falhas
+// o e-mail fica bloqueado por LockoutDuration. Falhas mais antigas que FailureWindow deixam
+// de contar. Valores zerados desligam a regra correspondente.
+type LoginThrottlePolicy struct {
+	FreeAttempts     int
+	IPFreeAttempts   int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	FailureWindow    time.Duration
+}
+
+// DefaultLoginThrottlePolicy é a política usada pela aplicação. O IP tolera mais falhas porque
+// vários usuários podem sair pelo mesmo endereço.
+func DefaultLoginThrottlePolicy() LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		FreeAttempts:     3,
+		IPFreeAttempts:   20,
+		BaseDelay:        time.Second,
+		MaxDelay:         5 * time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  15 * time.Minute,
+		FailureWindow:    15 * time.Minute,
+	}
+}
+
+// Backoff é a espera depois de failures falhas seguidas, dado o número de tentativas livres
+func (p LoginThrottlePolicy) Backoff(failures, freeAttempts int) time.Duration {
+	excess := failures - freeAttempts
+	if excess <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < excess; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// RetryAfter diz quanto falta para a chave aceitar uma nova tentativa e se o motivo é o bloqueio da conta
+func (p LoginThrottlePolicy) RetryAfter(attempt *LoginAttempt, now time.Time) (time.Duration, bool) {
+	if attempt == nil {
+		return 0, false
+	}
+	if attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil) {
+		return attempt.LockedUntil.Sub(now), true
+	}
+	if p.FailureWindow > 0 && !attempt.LastFailureAt.After(now.Add(-p.FailureWindow)) {
+		return 0, false
+	}
+
+	freeAttempts := p.FreeAttempts
+	if attempt.Scope == AttemptScopeIP {
+		freeAttempts = p.IPFreeAttempts
+	}
+	delay := p.Backoff(attempt.Failures, freeAttempts)
+	if delay == 0 {
+		return 0, false
+	}
+	wait := attempt.LastFailureAt.Add(delay).Sub(now)
+	if wait <= 0 {
+		return 0, false
+	}
+	return wait, false
+}
+
+// ShouldLock indica se a falha registrada deve bloquear a conta
+func (p LoginThrottlePolicy) ShouldLock(attempt *LoginAttempt) bool {
+	return p.LockoutThreshold > 0 && attempt.Scope == AttemptScopeEmail && attempt.Failures >= p.LockoutThreshold
+}
+
+// NormalizeEmail gera a chave de contagem por e-mail, para que variações de caixa contem juntas
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// LoginAttemptRepository guarda os contadores de falha por e-mail e por IP
+type LoginAttemptRepository interface {
+	// Find retorna nil, nil quando a chave não tem falhas registradas
+	Find(scope, key string) (*LoginAttempt, error)
+	// RegisterFailure soma uma falha de forma atômica, recomeçando a contagem quando a última
+	// falha é anterior a windowStart, e retorna o contador atualizado
+	RegisterFailure(scope, key string, failedAt, windowStart time.Time) (*LoginAttempt, error)
+	Lock(scope, key string, until time.Time) error
+	// Reset apaga as falhas e o bloqueio da chave
+	Reset(scope, key string) error
+}
@@ -4,15 +4,21 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 )
 
+// Input representa uma peça, insumo ou serviço. Quantity é o saldo físico (on-hand),
+// ReservedQuantity a parte reservada para orders ainda não aprovadas e
+// AvailableQuantity o saldo livre para novas reservas ou baixas.
 type Input struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Quantity    int       `json:"quantity"`
-	InputType   string    `json:"input_type"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                uuid.UUID   `json:"id"`
+	Name              string      `json:"name"`
+	Description       string      `json:"description"`
+	Price             money.Money `json:"price"`
+	Quantity          int         `json:"quantity"`
+	ReservedQuantity  int         `json:"reserved_quantity"`
+	AvailableQuantity int         `json:"available_quantity"`
+	InputType         string      `json:"input_type"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
 }
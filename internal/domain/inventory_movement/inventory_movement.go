@@ -0,0 +1,46 @@
+package inventory_movement
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de movimentação de estoque
+const (
+	MovementTypeOrderConsumption = "order_consumption"
+	MovementTypeOrderReturn      = "order_return"
+	MovementTypeManualRestock    = "manual_restock"
+	MovementTypeAdjustment       = "adjustment"
+	MovementTypeStocktake        = "stocktake"
+)
+
+// InventoryMovement registra uma alteração no estoque de um input
+type InventoryMovement struct {
+	ID           uuid.UUID  `json:"id"`
+	InputID      uuid.UUID  `json:"input_id"`
+	OrderID      *uuid.UUID `json:"order_id,omitempty"`
+	UserID       *uuid.UUID `json:"user_id,omitempty"`
+	MovementType string     `json:"movement_type"`
+	Delta        int        `json:"delta"`
+	BalanceAfter int        `json:"balance_after"`
+	Reason       string     `json:"reason,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// MovementContext descreve a origem de uma alteração de estoque
+type MovementContext struct {
+	MovementType string
+	OrderID      *uuid.UUID
+	UserID       *uuid.UUID
+	Reason       string
+}
+
+// IsValidMovementType verifica se o tipo de movimentação existe
+func IsValidMovementType(movementType string) bool {
+	switch movementType {
+	case MovementTypeOrderConsumption, MovementTypeOrderReturn, MovementTypeManualRestock, MovementTypeAdjustment, MovementTypeStocktake:
+		return true
+	}
+	return false
+}
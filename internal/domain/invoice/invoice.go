@@ -0,0 +1,117 @@
+package invoice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+const (
+	// DocumentTypeInvoice identifica a sequência de numeração das notas de serviço
+	DocumentTypeInvoice = "invoice"
+	// DocumentTypeCreditNote identifica a sequência de numeração das notas de crédito
+	DocumentTypeCreditNote = "credit_note"
+
+	// DefaultWorkshopCode é a oficina usada quando WORKSHOP_CODE não está configurado
+	DefaultWorkshopCode = "MAIN"
+)
+
+// documentPrefixes compõe o número exibido de cada tipo de documento
+var documentPrefixes = map[string]string{
+	DocumentTypeInvoice:    "INV",
+	DocumentTypeCreditNote: "CN",
+}
+
+// FormatNumber monta o número exibido do documento, por exemplo MAIN-INV-000042
+func FormatNumber(workshopCode string, documentType string, number int64) string {
+	return fmt.Sprintf("%s-%s-%06d", workshopCode, documentPrefixes[documentType], number)
+}
+
+// CustomerSnapshot é a cópia dos dados do cliente no momento da emissão
+type CustomerSnapshot struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	DocumentNumber string    `json:"document_number"`
+	CustomerType   string    `json:"customer_type"`
+}
+
+// VehicleSnapshot é a cópia dos dados do veículo no momento da emissão
+type VehicleSnapshot struct {
+	ID                          uuid.UUID `json:"id"`
+	Model                       string    `json:"model"`
+	Brand                       string    `json:"brand"`
+	ReleaseYear                 int       `json:"release_year"`
+	VehicleIdentificationNumber string    `json:"vehicle_identification_number"`
+	NumberPlate                 string    `json:"number_plate"`
+	Color                       string    `json:"color"`
+}
+
+// Invoice é a nota de serviço emitida quando a order é concluída. A numeração é sequencial e
+// sem lacunas por oficina, e as linhas, o cliente e o veículo são copiados na emissão. A nota
+// nunca é alterada: correções são feitas por notas de crédito.
+type Invoice struct {
+	ID           uuid.UUID `json:"id"`
+	WorkshopCode string    `json:"workshop_code"`
+	Number       int64     `json:"number"`
+	// FormattedNumber é o número exibido, com a oficina e o tipo do documento
+	FormattedNumber string     `json:"formatted_number"`
+	OrderID         uuid.UUID  `json:"order_id"`
+	QuoteID         *uuid.UUID `json:"quote_id,omitempty"`
+
+	Customer CustomerSnapshot `json:"customer"`
+	Vehicle  VehicleSnapshot  `json:"vehicle"`
+	Items    []InvoiceItem    `json:"items"`
+
+	PartsSubtotal  money.Money `json:"parts_subtotal"`
+	LaborSubtotal  money.Money `json:"labor_subtotal"`
+	DiscountTotal  money.Money `json:"discount_total"`
+	SurchargeTotal money.Money `json:"surcharge_total"`
+	TaxTotal       money.Money `json:"tax_total"`
+	TotalPrice     money.Money `json:"total_price"`
+
+	CreditNotes     []CreditNote `json:"credit_notes"`
+	CreatedByUserID *uuid.UUID   `json:"created_by_user_id,omitempty"`
+	IssuedAt        time.Time    `json:"issued_at"`
+}
+
+// InvoiceItem é uma linha da nota: peça, mão de obra, desconto ou acréscimo
+type InvoiceItem struct {
+	ID          uuid.UUID   `json:"id"`
+	InvoiceID   uuid.UUID   `json:"invoice_id"`
+	ItemType    string      `json:"item_type"`
+	ReferenceID uuid.UUID   `json:"reference_id"`
+	Description string      `json:"description"`
+	Quantity    float64     `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"`
+	TotalPrice  money.Money `json:"total_price"`
+}
+
+// CreditNote estorna total ou parcialmente o valor de uma nota já emitida. Tem numeração
+// própria, também sequencial e sem lacunas por oficina.
+type CreditNote struct {
+	ID              uuid.UUID   `json:"id"`
+	InvoiceID       uuid.UUID   `json:"invoice_id"`
+	WorkshopCode    string      `json:"workshop_code"`
+	Number          int64       `json:"number"`
+	FormattedNumber string      `json:"formatted_number"`
+	Reason          string      `json:"reason"`
+	Amount          money.Money `json:"amount"`
+	CreatedByUserID *uuid.UUID  `json:"created_by_user_id,omitempty"`
+	IssuedAt        time.Time   `json:"issued_at"`
+}
+
+// CreditedTotal soma o valor das notas de crédito já emitidas para a nota
+func (i *Invoice) CreditedTotal() money.Money {
+	total := money.Zero
+	for _, creditNote := range i.CreditNotes {
+		total = total.Add(creditNote.Amount)
+	}
+	return total
+}
+
+// CreditableAmount é o valor que ainda pode ser estornado por notas de crédito
+func (i *Invoice) CreditableAmount() money.Money {
+	return i.TotalPrice.Sub(i.CreditedTotal())
+}
@@ -0,0 +1,20 @@
+package labor_service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+// LaborService representa uma operação de mão de obra do catálogo da oficina, com as horas
+// padrão para executá-la e o valor cobrado por hora
+type LaborService struct {
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	DefaultHours float64     `json:"default_hours"`
+	HourlyRate   money.Money `json:"hourly_rate"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,13 @@
+package mail
+
+// Message é um e-mail em texto simples
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer envia e-mails; a implementação é escolhida pela configuração (SMTP, arquivo ou log)
+type Mailer interface {
+	Send(message Message) error
+}
@@ -0,0 +1,269 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency é a moeda em que a oficina opera. Todos os valores são em reais.
+const Currency = "BRL"
+
+// Money é um valor monetário em centavos inteiros. A aritmética é feita em centavos, sem a perda
+// de precisão do float64, e o valor é gravado como DECIMAL(10,2) e serializado no JSON como um
+// número com duas casas decimais.
+type Money struct {
+	cents int64
+}
+
+// Zero é o valor monetário nulo
+var Zero = Money{}
+
+// FromCents cria um valor a partir de centavos
+func FromCents(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// FromFloat converte um float64 para centavos, arredondando para o centavo mais próximo.
+// Deve ser usado apenas na fronteira com valores que ainda chegam como float.
+func FromFloat(value float64) Money {
+	return Money{cents: int64(math.Round(value * 100))}
+}
+
+// Parse lê um valor decimal como "123.45", "-0.5" ou "10". Valores com mais de duas casas
+// decimais significativas são rejeitados em vez de arredondados.
+func Parse(value string) (Money, error) {
+	text := strings.TrimSpace(value)
+	if text == "" {
+		return Zero, errors.New("invalid money value")
+	}
+
+	negative := false
+	switch text[0] {
+	case '-':
+		negative = true
+		text = text[1:]
+	case '+':
+		text = text[1:]
+	}
+
+	integerPart, fractionPart, _ := strings.Cut(text, ".")
+	if integerPart == "" && fractionPart == "" {
+		return Zero, errors.New("invalid money value")
+	}
+	if !isDigits(integerPart) || !isDigits(fractionPart) {
+		return Zero, errors.New("invalid money value")
+	}
+
+	fractionPart = strings.TrimRight(fractionPart, "0")
+	if len(fractionPart) > 2 {
+		return Zero, errors.New("money value has more than two decimal places")
+	}
+	fractionPart += strings.Repeat("0", 2-len(fractionPart))
+
+	if integerPart == "" {
+		integerPart = "0"
+	}
+	cents, err := strconv.ParseInt(integerPart+fractionPart, 10, 64)
+	if err != nil {
+		return Zero, errors.New("invalid money value")
+	}
+	if negative {
+		cents = -cents
+	}
+	return Money{cents: cents}, nil
+}
+
+// MustParse é como Parse, mas entra em pânico se o valor for inválido. Útil em constantes e testes.
+func MustParse(value string) Money {
+	m, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Cents retorna o valor em centavos
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Currency retorna o código ISO 4217 da moeda do valor
+func (m Money) Currency() string {
+	return Currency
+}
+
+// Add soma dois valores
+func (m Money) Add(other Money) Money {
+	return Money{cents: m.cents + other.cents}
+}
+
+// Sub subtrai um valor
+func (m Money) Sub(other Money) Money {
+	return Money{cents: m.cents - other.cents}
+}
+
+// Neg inverte o sinal do valor
+func (m Money) Neg() Money {
+	return Money{cents: -m.cents}
+}
+
+// Mul multiplica o valor por uma quantidade inteira, sem arredondamento
+func (m Money) Mul(quantity int64) Money {
+	return Money{cents: m.cents * quantity}
+}
+
+// MulFloat multiplica o valor por um fator fracionário, como horas trabalhadas, arredondando
+// para o centavo mais próximo (metade para longe do zero)
+func (m Money) MulFloat(factor float64) Money {
+	return Money{cents: int64(math.Round(float64(m.cents) * factor))}
+}
+
+// Percent retorna o percentual informado do valor, arredondado para o centavo mais próximo
+func (m Money) Percent(rate float64) Money {
+	return m.MulFloat(rate / 100)
+}
+
+// Allocate retorna a fração part/total do valor, arredondada para o centavo mais próximo.
+// Usado para ratear um valor entre categorias proporcionalmente a cada uma.
+func (m Money) Allocate(part, total Money) Money {
+	if total.cents == 0 {
+		return Zero
+	}
+	return Money{cents: int64(math.Round(float64(m.cents) * float64(part.cents) / float64(total.cents)))}
+}
+
+// Ratio retorna a razão entre dois valores, ou zero se o divisor for zero
+func (m Money) Ratio(other Money) float64 {
+	if other.cents == 0 {
+		return 0
+	}
+	return float64(m.cents) / float64(other.cents)
+}
+
+// IsZero indica se o valor é zero
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// IsPositive indica se o valor é maior que zero
+func (m Money) IsPositive() bool {
+	return m.cents > 0
+}
+
+// IsNegative indica se o valor é menor que zero
+func (m Money) IsNegative() bool {
+	return m.cents < 0
+}
+
+// Equal compara dois valores
+func (m Money) Equal(other Money) bool {
+	return m.cents == other.cents
+}
+
+// GreaterThan indica se o valor é maior que o outro
+func (m Money) GreaterThan(other Money) bool {
+	return m.cents > other.cents
+}
+
+// LessThan indica se o valor é menor que o outro
+func (m Money) LessThan(other Money) bool {
+	return m.cents < other.cents
+}
+
+// Max retorna o maior entre dois valores
+func Max(a, b Money) Money {
+	if a.cents > b.cents {
+		return a
+	}
+	return b
+}
+
+// String formata o valor com duas casas decimais, por exemplo "1234.50"
+func (m Money) String() string {
+	cents := m.cents
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON serializa o valor como um número JSON com duas casas decimais
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON aceita um número JSON ou uma string com o valor decimal. O texto é lido
+// diretamente, sem passar por float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	if strings.ContainsAny(text, "eE") {
+		return errors.New("invalid money value")
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value grava o valor como texto decimal, convertido pelo banco para DECIMAL sem perda
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan lê um DECIMAL do banco. O driver entrega o valor como texto, convertido sem passar por float64.
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Zero
+		return nil
+	case []byte:
+		return m.scanText(string(v))
+	case string:
+		return m.scanText(v)
+	case int64:
+		*m = FromCents(v * 100)
+		return nil
+	case float64:
+		*m = FromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into money", value)
+	}
+}
+
+func (m *Money) scanText(text string) error {
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// GormDataType informa ao GORM o tipo da coluna usada para valores monetários
+func (Money) GormDataType() string {
+	return "decimal(10,2)"
+}
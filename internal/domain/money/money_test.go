@@ -0,0 +1,142 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse_ValidValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0", 0},
+		{"0.01", 1},
+		{"0.1", 10},
+		{"10", 1000},
+		{"10.10", 1010},
+		{"19.99", 1999},
+		{"-2.50", -250},
+		{".5", 50},
+		{"1.230", 123},
+		{"99999999.99", 9999999999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if m.Cents() != tt.expected {
+				t.Errorf("Expected %d cents, got %d", tt.expected, m.Cents())
+			}
+		})
+	}
+}
+
+func TestParse_InvalidValues(t *testing.T) {
+	for _, input := range []string{"", "-", ".", "abc", "1.2.3", "1,50", "0.001", "1e3"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := Parse(input); err == nil {
+				t.Errorf("Expected error for %q", input)
+			}
+		})
+	}
+}
+
+func TestMoney_RoundTripsExactly(t *testing.T) {
+	for _, value := range []string{"0.00", "0.01", "0.10", "0.29", "1.15", "10.10", "19.99", "-7.05", "99999999.99"} {
+		t.Run(value, func(t *testing.T) {
+			original := MustParse(value)
+
+			// Banco: Value grava texto decimal e Scan lê o texto devolvido pelo driver
+			stored, err := original.Value()
+			if err != nil {
+				t.Fatalf("Expected no error on Value, got %v", err)
+			}
+			if stored != value {
+				t.Errorf("Expected stored value %s, got %v", value, stored)
+			}
+			var scanned Money
+			if err := scanned.Scan([]byte(stored.(string))); err != nil {
+				t.Fatalf("Expected no error on Scan, got %v", err)
+			}
+			if !scanned.Equal(original) {
+				t.Errorf("Expected scanned %s, got %s", original, scanned)
+			}
+
+			// JSON: serializa como número e lê de volta sem passar por float64
+			encoded, err := json.Marshal(original)
+			if err != nil {
+				t.Fatalf("Expected no error on Marshal, got %v", err)
+			}
+			if string(encoded) != value {
+				t.Errorf("Expected JSON %s, got %s", value, encoded)
+			}
+			var decoded Money
+			if err := json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("Expected no error on Unmarshal, got %v", err)
+			}
+			if !decoded.Equal(original) {
+				t.Errorf("Expected decoded %s, got %s", original, decoded)
+			}
+		})
+	}
+}
+
+func TestMoney_UnmarshalJSON_AcceptsStringsAndRejectsExtraPrecision(t *testing.T) {
+	var payload struct {
+		Price Money `json:"price"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":"15.50"}`), &payload); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if payload.Price.Cents() != 1550 {
+		t.Errorf("Expected 1550 cents, got %d", payload.Price.Cents())
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":15.555}`), &payload); err == nil {
+		t.Error("Expected error for a price with three decimal places")
+	}
+}
+
+func TestMoney_Scan_LegacyDriverTypes(t *testing.T) {
+	var m Money
+
+	if err := m.Scan(float64(0.29)); err != nil || m.Cents() != 29 {
+		t.Errorf("Expected 29 cents from float64, got %d (%v)", m.Cents(), err)
+	}
+	if err := m.Scan(int64(12)); err != nil || m.Cents() != 1200 {
+		t.Errorf("Expected 1200 cents from int64, got %d (%v)", m.Cents(), err)
+	}
+	if err := m.Scan(nil); err != nil || !m.IsZero() {
+		t.Errorf("Expected zero from nil, got %s (%v)", m, err)
+	}
+	if err := m.Scan(true); err == nil {
+		t.Error("Expected error scanning a bool")
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	// 0.1 + 0.2 em float64 não é 0.3; em centavos é exato
+	if !MustParse("0.10").Add(MustParse("0.20")).Equal(MustParse("0.30")) {
+		t.Error("Expected 0.10 + 0.20 to equal 0.30")
+	}
+	if got := MustParse("15.50").Mul(3); !got.Equal(MustParse("46.50")) {
+		t.Errorf("Expected 46.50, got %s", got)
+	}
+	if got := MustParse("80.00").MulFloat(1.25); !got.Equal(MustParse("100.00")) {
+		t.Errorf("Expected 100.00, got %s", got)
+	}
+	if got := MustParse("0.05").Percent(50); !got.Equal(MustParse("0.03")) {
+		t.Errorf("Expected half-up rounding to 0.03, got %s", got)
+	}
+	if got := MustParse("10.00").Allocate(MustParse("1.00"), MustParse("3.00")); !got.Equal(MustParse("3.33")) {
+		t.Errorf("Expected 3.33, got %s", got)
+	}
+	if got := MustParse("-0.05").String(); got != "-0.05" {
+		t.Errorf("Expected -0.05, got %s", got)
+	}
+}
@@ -27,6 +27,9 @@ type Order struct {
 	CustomerID uuid.UUID `json:"customer_id"`
 	VehicleID  uuid.UUID `json:"vehicle_id"`
 	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	// ApprovedQuoteID é a versão do orçamento aprovada. Fica vazio até a aprovação e volta a
+	// ficar vazio quando a order retorna para aprovação.
+	ApprovedQuoteID *uuid.UUID `json:"approved_quote_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,136 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
+)
+
+// ErrTransitionNotAllowedForUser indica que o tipo de usuário não pode realizar a transição
+var ErrTransitionNotAllowedForUser = errors.New("user not allowed to perform this status transition")
+
+// StatusTransition define um próximo status permitido e quais tipos de usuário podem acioná-lo
+type StatusTransition struct {
+	To           string
+	AllowedUsers []string
+}
+
+// InvalidTransitionError indica uma transição de status que não existe na máquina de estados
+type InvalidTransitionError struct {
+	From    string
+	To      string
+	Allowed []string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return "invalid status transition"
+}
+
+var (
+	staffUsers = []string{user.UserTypeMechanic, user.UserTypeAdmin}
+	adminOnly  = []string{user.UserTypeAdmin}
+	allUsers   = []string{user.UserTypeMechanic, user.UserTypeAdmin, user.UserTypeVehicleOwner}
+)
+
+// validStatuses mantém a ordem natural do fluxo de uma order
+var validStatuses = []string{
+	StatusReceived,
+	StatusUndergoingDiagnosis,
+	StatusAwaitingApproval,
+	StatusInProgress,
+	StatusCompleted,
+	StatusDelivered,
+	StatusCanceled,
+}
+
+// reservationStatuses são os status anteriores à aprovação do orçamento, em que as peças
+// adicionadas à order ficam apenas reservadas no estoque
+var reservationStatuses = map[string]bool{
+	StatusReceived:            true,
+	StatusUndergoingDiagnosis: true,
+	StatusAwaitingApproval:    true,
+}
+
+// statusTransitions é a tabela de transições da máquina de estados da order
+var statusTransitions = map[string][]StatusTransition{
+	StatusReceived: {
+		{To: StatusUndergoingDiagnosis, AllowedUsers: staffUsers},
+		{To: StatusCanceled, AllowedUsers: staffUsers},
+	},
+	StatusUndergoingDiagnosis: {
+		{To: StatusAwaitingApproval, AllowedUsers: staffUsers},
+		{To: StatusCanceled, AllowedUsers: staffUsers},
+	},
+	StatusAwaitingApproval: {
+		// Cliente aprova o orçamento (admin pode registrar a aprovação em nome dele)
+		{To: StatusInProgress, AllowedUsers: []string{user.UserTypeVehicleOwner, user.UserTypeAdmin}},
+		// Mecânico revisa o diagnóstico antes da aprovação
+		{To: StatusUndergoingDiagnosis, AllowedUsers: staffUsers},
+		{To: StatusCanceled, AllowedUsers: allUsers},
+	},
+	StatusInProgress: {
+		{To: StatusCompleted, AllowedUsers: staffUsers},
+		// Peças ou mão de obra alteradas depois da aprovação exigem uma nova aprovação do cliente
+		{To: StatusAwaitingApproval, AllowedUsers: staffUsers},
+		{To: StatusCanceled, AllowedUsers: adminOnly},
+	},
+	StatusCompleted: {
+		{To: StatusDelivered, AllowedUsers: staffUsers},
+	},
+	StatusDelivered: {},
+	StatusCanceled:  {},
+}
+
+// ValidStatuses retorna a lista de status válidos
+func ValidStatuses() []string {
+	statuses := make([]string, len(validStatuses))
+	copy(statuses, validStatuses)
+	return statuses
+}
+
+// IsValidStatus verifica se o status existe na máquina de estados
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// IsFinalStatus verifica se o status não permite novas transições
+func IsFinalStatus(status string) bool {
+	transitions, ok := statusTransitions[status]
+	return ok && len(transitions) == 0
+}
+
+// ReservesStock indica se as peças de uma order no status informado devem ser reservadas em vez de baixadas
+func ReservesStock(status string) bool {
+	return reservationStatuses[status]
+}
+
+// AllowedNextStatuses retorna os próximos status possíveis a partir do status atual
+func AllowedNextStatuses(from string) []string {
+	statuses := []string{}
+	for _, transition := range statusTransitions[from] {
+		statuses = append(statuses, transition.To)
+	}
+	return statuses
+}
+
+// ValidateTransition valida se a transição existe e se o tipo de usuário pode realizá-la
+func ValidateTransition(from, to, userType string) error {
+	for _, transition := range statusTransitions[from] {
+		if transition.To != to {
+			continue
+		}
+		for _, allowed := range transition.AllowedUsers {
+			if allowed == userType {
+				return nil
+			}
+		}
+		return ErrTransitionNotAllowedForUser
+	}
+
+	return &InvalidTransitionError{
+		From:    from,
+		To:      to,
+		Allowed: AllowedNextStatuses(from),
+	}
+}
@@ -0,0 +1,64 @@
+package order_adjustment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+const (
+	// KindDiscount reduz o valor da linha ou da order
+	KindDiscount = "discount"
+	// KindSurcharge acrescenta um valor à linha ou à order
+	KindSurcharge = "surcharge"
+
+	// ValueTypePercentage indica que o valor é um percentual da base do ajuste
+	ValueTypePercentage = "percentage"
+	// ValueTypeFixed indica que o valor é um montante fixo em reais, gravado em centavos
+	ValueTypeFixed = "fixed"
+
+	// StatusApproved indica que o ajuste já entra no total da order
+	StatusApproved = "approved"
+	// StatusPendingApproval indica um desconto acima do limite do mecânico, aguardando um admin
+	StatusPendingApproval = "pending_approval"
+)
+
+// OrderAdjustment é um desconto ou acréscimo aplicado a uma linha da order (peça ou mão de obra)
+// ou à order inteira, quando LineID é nulo.
+type OrderAdjustment struct {
+	ID      uuid.UUID `json:"id"`
+	OrderID uuid.UUID `json:"order_id"`
+	// LineID é o id do item (order input) ou da linha de mão de obra (order service) ajustado
+	LineID    *uuid.UUID `json:"line_id,omitempty"`
+	Kind      string     `json:"kind"`
+	ValueType string     `json:"value_type"`
+	// Percentage é preenchido apenas nos ajustes percentuais e Amount apenas nos de valor fixo
+	Percentage *float64     `json:"percentage,omitempty"`
+	Amount     *money.Money `json:"amount,omitempty"`
+	// Currency é a moeda de Amount, vazia nos ajustes percentuais
+	Currency string `json:"currency,omitempty"`
+	Reason   string `json:"reason"`
+	Status   string `json:"status"`
+
+	CreatedByUserID  uuid.UUID  `json:"created_by_user_id"`
+	ApprovedByUserID *uuid.UUID `json:"approved_by_user_id,omitempty"`
+	ApprovedAt       *time.Time `json:"approved_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// IsApproved indica se o ajuste entra no cálculo do total
+func (a *OrderAdjustment) IsApproved() bool {
+	return a.Status == StatusApproved
+}
+
+// IsValidKind verifica se o tipo do ajuste é conhecido
+func IsValidKind(kind string) bool {
+	return kind == KindDiscount || kind == KindSurcharge
+}
+
+// IsValidValueType verifica se a forma de cálculo do ajuste é conhecida
+func IsValidValueType(valueType string) bool {
+	return valueType == ValueTypePercentage || valueType == ValueTypeFixed
+}
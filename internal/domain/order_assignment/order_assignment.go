@@ -0,0 +1,25 @@
+package order_assignment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderAssignment registra um mecânico designado para trabalhar em uma order. A designação
+// nunca é apagada: ao retirar o mecânico, UnassignedAt é preenchido e o registro fica no histórico.
+type OrderAssignment struct {
+	ID               uuid.UUID `json:"id"`
+	OrderID          uuid.UUID `json:"order_id"`
+	MechanicID       uuid.UUID `json:"mechanic_id"`
+	AssignedByUserID uuid.UUID `json:"assigned_by_user_id"`
+	AssignedAt       time.Time `json:"assigned_at"`
+
+	UnassignedByUserID *uuid.UUID `json:"unassigned_by_user_id,omitempty"`
+	UnassignedAt       *time.Time `json:"unassigned_at,omitempty"`
+}
+
+// IsActive indica se o mecânico continua designado para a order
+func (a *OrderAssignment) IsActive() bool {
+	return a.UnassignedAt == nil
+}
@@ -4,15 +4,61 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+const (
+	// StockStatusReserved indica que a quantidade do item está apenas reservada no estoque
+	StockStatusReserved = "reserved"
+	// StockStatusConsumed indica que a quantidade do item já foi baixada do estoque
+	StockStatusConsumed = "consumed"
+	// StockStatusReleased indica que a reserva do item foi liberada sem consumo
+	StockStatusReleased = "released"
+	// StockStatusReturned indica que a quantidade baixada voltou ao estoque no cancelamento da order
+	StockStatusReturned = "returned"
 )
 
 type OrderInput struct {
-	ID         uuid.UUID `json:"id"`
-	OrderID    uuid.UUID `json:"order_id"`
-	InputID    uuid.UUID `json:"input_id"`
-	Quantity   int       `json:"quantity"`
-	UnitPrice  float64   `json:"unit_price"`
-	TotalPrice float64   `json:"total_price"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uuid.UUID   `json:"id"`
+	OrderID    uuid.UUID   `json:"order_id"`
+	InputID    uuid.UUID   `json:"input_id"`
+	Quantity   int         `json:"quantity"`
+	UnitPrice  money.Money `json:"unit_price"`
+	TotalPrice money.Money `json:"total_price"`
+	// StockStatus indica a situação do item no estoque: reserved, consumed, released ou returned
+	StockStatus string `json:"stock_status"`
+	// UsedOrDamaged marca peças já aplicadas ou danificadas, que não voltam ao estoque no cancelamento
+	UsedOrDamaged bool `json:"used_or_damaged"`
+	// VoidedAt é preenchido quando o item é anulado pelo cancelamento da order
+	VoidedAt  *time.Time `json:"voided_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// RestockItem descreve a quantidade de um item da order afetada pelo cancelamento
+type RestockItem struct {
+	OrderInputID uuid.UUID `json:"order_input_id"`
+	InputID      uuid.UUID `json:"input_id"`
+	Quantity     int       `json:"quantity"`
+}
+
+// RestockSummary resume o que aconteceu com o estoque de cada item quando a order foi cancelada
+type RestockSummary struct {
+	// Restocked são as peças já baixadas que voltaram ao saldo físico
+	Restocked []RestockItem `json:"restocked"`
+	// Released são as peças reservadas cuja reserva foi liberada
+	Released []RestockItem `json:"released"`
+	// Retained são as peças marcadas como usadas ou danificadas, que não voltam ao estoque
+	Retained []RestockItem `json:"retained"`
+	// VoidedItems é a quantidade de itens da order anulados
+	VoidedItems int `json:"voided_items"`
+}
+
+// NewRestockSummary cria um resumo vazio, serializado com listas vazias em vez de null
+func NewRestockSummary() *RestockSummary {
+	return &RestockSummary{
+		Restocked: []RestockItem{},
+		Released:  []RestockItem{},
+		Retained:  []RestockItem{},
+	}
 }
@@ -0,0 +1,32 @@
+package order_service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+// OrderService é uma linha de mão de obra da order. O valor da hora é copiado do catálogo
+// no momento em que o serviço é adicionado, para que mudanças no catálogo não alterem orders abertas.
+type OrderService struct {
+	ID             uuid.UUID  `json:"id"`
+	OrderID        uuid.UUID  `json:"order_id"`
+	LaborServiceID uuid.UUID  `json:"labor_service_id"`
+	MechanicID     *uuid.UUID `json:"mechanic_id,omitempty"`
+	EstimatedHours float64    `json:"estimated_hours"`
+	// ActualHours é preenchido pelo mecânico ao concluir o serviço
+	ActualHours *float64    `json:"actual_hours,omitempty"`
+	HourlyRate  money.Money `json:"hourly_rate"`
+	TotalPrice  money.Money `json:"total_price"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// BilledHours retorna as horas cobradas: as horas reais quando informadas, senão as estimadas
+func BilledHours(estimatedHours float64, actualHours *float64) float64 {
+	if actualHours != nil {
+		return *actualHours
+	}
+	return estimatedHours
+}
@@ -6,11 +6,46 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// SourceAPI indica uma mudança feita pela equipe da oficina através da API
+	SourceAPI = "api"
+	// SourceAutomation indica uma mudança feita pelo sistema, sem ação direta de um usuário
+	SourceAutomation = "automation"
+	// SourceCustomer indica uma mudança feita pelo cliente, como a aprovação ou rejeição do orçamento
+	SourceCustomer = "customer"
+)
+
+// IsValidSource verifica se a origem da mudança de status é conhecida
+func IsValidSource(source string) bool {
+	return source == SourceAPI || source == SourceAutomation || source == SourceCustomer
+}
+
 type OrderStatusHistory struct {
-	ID        uuid.UUID  `json:"id"`
-	OrderID   uuid.UUID  `json:"order_id"`
-	Status    string     `json:"status"`
-	StartedAt time.Time  `json:"started_at"`
-	EndedAt   *time.Time `json:"ended_at,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
+	ID              uuid.UUID  `json:"id"`
+	OrderID         uuid.UUID  `json:"order_id"`
+	Status          string     `json:"status"`
+	ChangedByUserID *uuid.UUID `json:"changed_by_user_id,omitempty"`
+	Note            string     `json:"note,omitempty"`
+	Source          string     `json:"source"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// StatusChange descreve quem realizou uma mudança de status, o comentário associado e a origem
+type StatusChange struct {
+	ChangedByUserID *uuid.UUID
+	Note            string
+	// Source é a origem da mudança. Vazio equivale a SourceAPI.
+	Source string
+	// OverrideBalance libera a entrega de uma order com saldo em aberto. Só é aceito de um admin.
+	OverrideBalance bool
+}
+
+// ResolvedSource retorna a origem da mudança, usando SourceAPI quando não informada
+func (c StatusChange) ResolvedSource() string {
+	if c.Source == "" {
+		return SourceAPI
+	}
+	return c.Source
 }
@@ -0,0 +1,91 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+const (
+	MethodCash     = "cash"
+	MethodCard     = "card"
+	MethodPix      = "pix"
+	MethodBankSlip = "bank_slip"
+
+	// BilledFromInvoice indica que o valor cobrado vem da nota emitida, já descontadas as notas de crédito
+	BilledFromInvoice = "invoice"
+	// BilledFromQuote indica que o valor cobrado vem do orçamento aprovado, antes da emissão da nota
+	BilledFromQuote = "quote"
+	// BilledFromNone indica que a order ainda não tem valor a cobrar
+	BilledFromNone = "none"
+)
+
+// methodsRequiringReference são as formas de pagamento rastreáveis, em que o número da
+// transação, do comprovante ou do boleto é obrigatório
+var methodsRequiringReference = map[string]bool{
+	MethodCard:     true,
+	MethodPix:      true,
+	MethodBankSlip: true,
+}
+
+// Payment é um pagamento, total ou parcial, recebido por uma order
+type Payment struct {
+	ID      uuid.UUID   `json:"id"`
+	OrderID uuid.UUID   `json:"order_id"`
+	Method  string      `json:"method"`
+	Amount  money.Money `json:"amount"`
+	// Reference é o número da transação, do comprovante PIX ou do boleto
+	Reference        string     `json:"reference,omitempty"`
+	ReceivedByUserID *uuid.UUID `json:"received_by_user_id,omitempty"`
+	PaidAt           time.Time  `json:"paid_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// Balance resume o que foi cobrado e pago em uma order
+type Balance struct {
+	BilledFrom   string      `json:"billed_from"`
+	AmountBilled money.Money `json:"amount_billed"`
+	AmountPaid   money.Money `json:"amount_paid"`
+	AmountDue    money.Money `json:"amount_due"`
+}
+
+// NewBalance calcula o saldo devedor. Pagamentos acima do valor cobrado, possíveis quando uma
+// nota de crédito é emitida depois do pagamento, não geram saldo negativo.
+func NewBalance(billedFrom string, billed money.Money, payments []Payment) Balance {
+	paid := money.Zero
+	for _, payment := range payments {
+		paid = paid.Add(payment.Amount)
+	}
+	return Balance{
+		BilledFrom:   billedFrom,
+		AmountBilled: billed,
+		AmountPaid:   paid,
+		AmountDue:    money.Max(billed.Sub(paid), money.Zero),
+	}
+}
+
+// IsSettled indica se não há saldo em aberto
+func (b Balance) IsSettled() bool {
+	return !b.AmountDue.IsPositive()
+}
+
+// ValidMethods retorna as formas de pagamento aceitas
+func ValidMethods() []string {
+	return []string{MethodCash, MethodCard, MethodPix, MethodBankSlip}
+}
+
+// IsValidMethod verifica se a forma de pagamento é aceita
+func IsValidMethod(method string) bool {
+	for _, valid := range ValidMethods() {
+		if method == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresReference indica se a forma de pagamento exige o número de referência
+func RequiresReference(method string) bool {
+	return methodsRequiringReference[method]
+}
@@ -0,0 +1,224 @@
+package pricing
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	adjustment "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+)
+
+const (
+	// CategoryPart agrupa peças e insumos, tributados pelo ICMS
+	CategoryPart = "part"
+	// CategoryLabor agrupa a mão de obra, tributada pelo ISS
+	CategoryLabor = "labor"
+
+	// DefaultMechanicDiscountCapPercent é o desconto máximo que um mecânico concede sem aprovação
+	// enquanto o admin não configura outro limite
+	DefaultMechanicDiscountCapPercent = 10
+)
+
+// TaxRate é a alíquota aplicada a uma categoria de item, por exemplo ISS sobre serviços
+type TaxRate struct {
+	Category string  `json:"category"`
+	Name     string  `json:"name"`
+	Rate     float64 `json:"rate"`
+}
+
+// Settings reúne a configuração de preços mantida pelos admins
+type Settings struct {
+	// MechanicDiscountCapPercent é o desconto total, em percentual do subtotal, que um mecânico
+	// pode conceder sem aprovação de um admin
+	MechanicDiscountCapPercent float64   `json:"mechanic_discount_cap_percent"`
+	TaxRates                   []TaxRate `json:"tax_rates"`
+}
+
+// IsValidCategory verifica se a categoria de item é conhecida
+func IsValidCategory(category string) bool {
+	return category == CategoryPart || category == CategoryLabor
+}
+
+// Line é uma linha cobrada da order: um item de peça ou uma linha de mão de obra
+type Line struct {
+	ID       uuid.UUID
+	Category string
+	Amount   money.Money
+}
+
+// AppliedAdjustment é o valor efetivo de um ajuste aprovado
+type AppliedAdjustment struct {
+	ID     uuid.UUID   `json:"id"`
+	Kind   string      `json:"kind"`
+	Amount money.Money `json:"amount"`
+}
+
+// TaxLine é o imposto calculado para uma categoria
+type TaxLine struct {
+	Category string      `json:"category"`
+	Name     string      `json:"name"`
+	Rate     float64     `json:"rate"`
+	Base     money.Money `json:"base"`
+	Amount   money.Money `json:"amount"`
+}
+
+// Breakdown é a composição do total da order
+type Breakdown struct {
+	PartsSubtotal  money.Money         `json:"parts_subtotal"`
+	LaborSubtotal  money.Money         `json:"labor_subtotal"`
+	Subtotal       money.Money         `json:"subtotal"`
+	DiscountTotal  money.Money         `json:"discount_total"`
+	SurchargeTotal money.Money         `json:"surcharge_total"`
+	Adjustments    []AppliedAdjustment `json:"adjustments"`
+	Taxes          []TaxLine           `json:"taxes"`
+	TaxTotal       money.Money         `json:"tax_total"`
+	TotalPrice     money.Money         `json:"total_price"`
+}
+
+// DiscountPercent retorna o desconto total como percentual do subtotal
+func (b *Breakdown) DiscountPercent() float64 {
+	if !b.Subtotal.IsPositive() {
+		return 0
+	}
+	return b.DiscountTotal.Ratio(b.Subtotal) * 100
+}
+
+// categories define a ordem fixa de rateio, para que o centavo de arredondamento caia sempre
+// na mesma categoria
+var categories = []string{CategoryPart, CategoryLabor}
+
+// Calculate compõe o total da order. Os ajustes de linha incidem sobre o valor da linha, os
+// ajustes da order sobre o subtotal já ajustado e são rateados entre as categorias pelo valor de
+// cada uma. Os impostos são calculados sobre o valor líquido de cada categoria e somados ao total.
+// Ajustes pendentes de aprovação são ignorados e nenhum desconto deixa a base negativa.
+func Calculate(lines []Line, adjustments []adjustment.OrderAdjustment, rates []TaxRate) Breakdown {
+	breakdown := Breakdown{Adjustments: []AppliedAdjustment{}, Taxes: []TaxLine{}}
+
+	lineAmount := make(map[uuid.UUID]money.Money, len(lines))
+	net := make(map[uuid.UUID]money.Money, len(lines))
+	for _, line := range lines {
+		lineAmount[line.ID] = line.Amount
+		net[line.ID] = line.Amount
+		switch line.Category {
+		case CategoryPart:
+			breakdown.PartsSubtotal = breakdown.PartsSubtotal.Add(line.Amount)
+		case CategoryLabor:
+			breakdown.LaborSubtotal = breakdown.LaborSubtotal.Add(line.Amount)
+		}
+	}
+
+	// Ajustes de linha
+	for _, adj := range adjustments {
+		if !adj.IsApproved() || adj.LineID == nil {
+			continue
+		}
+		current, ok := net[*adj.LineID]
+		if !ok {
+			continue
+		}
+		amount := resolveAmount(adj, lineAmount[*adj.LineID], current)
+		net[*adj.LineID] = current.Add(signed(adj.Kind, amount))
+		breakdown.apply(adj, amount)
+	}
+
+	categoryNet := map[string]money.Money{}
+	for _, line := range lines {
+		categoryNet[line.Category] = categoryNet[line.Category].Add(net[line.ID])
+	}
+	orderBase := sumValues(categoryNet)
+
+	// Ajustes da order. Percentuais incidem todos sobre a mesma base, sem efeito cascata.
+	var untaxed money.Money
+	for _, adj := range adjustments {
+		if !adj.IsApproved() || adj.LineID != nil {
+			continue
+		}
+		current := sumValues(categoryNet)
+		amount := resolveAmount(adj, orderBase, current)
+		breakdown.apply(adj, amount)
+
+		// Sem base para ratear, o acréscimo entra no total sem tributação
+		if !current.IsPositive() {
+			untaxed = untaxed.Add(signed(adj.Kind, amount))
+			continue
+		}
+		prorate(categoryNet, signed(adj.Kind, amount), current)
+	}
+
+	for _, rate := range rates {
+		base := categoryNet[rate.Category]
+		if !base.IsPositive() {
+			continue
+		}
+		tax := TaxLine{
+			Category: rate.Category,
+			Name:     rate.Name,
+			Rate:     rate.Rate,
+			Base:     base,
+			Amount:   base.Percent(rate.Rate),
+		}
+		breakdown.Taxes = append(breakdown.Taxes, tax)
+		breakdown.TaxTotal = breakdown.TaxTotal.Add(tax.Amount)
+	}
+
+	breakdown.Subtotal = breakdown.PartsSubtotal.Add(breakdown.LaborSubtotal)
+	breakdown.TotalPrice = sumValues(categoryNet).Add(untaxed).Add(breakdown.TaxTotal)
+	return breakdown
+}
+
+func (b *Breakdown) apply(adj adjustment.OrderAdjustment, amount money.Money) {
+	b.Adjustments = append(b.Adjustments, AppliedAdjustment{ID: adj.ID, Kind: adj.Kind, Amount: amount})
+	if adj.Kind == adjustment.KindDiscount {
+		b.DiscountTotal = b.DiscountTotal.Add(amount)
+	} else {
+		b.SurchargeTotal = b.SurchargeTotal.Add(amount)
+	}
+}
+
+// prorate distribui o valor entre as categorias proporcionalmente ao valor de cada uma. A última
+// categoria com valor recebe a diferença de arredondamento, para que a soma seja exata.
+func prorate(categoryNet map[string]money.Money, amount money.Money, total money.Money) {
+	remaining := amount
+	last := ""
+	for _, category := range categories {
+		value := categoryNet[category]
+		if value.IsZero() {
+			continue
+		}
+		share := amount.Allocate(value, total)
+		categoryNet[category] = value.Add(share)
+		remaining = remaining.Sub(share)
+		last = category
+	}
+	if last != "" {
+		categoryNet[last] = categoryNet[last].Add(remaining)
+	}
+}
+
+// resolveAmount calcula o valor do ajuste sobre a base, limitando descontos ao valor ainda disponível
+func resolveAmount(adj adjustment.OrderAdjustment, base money.Money, available money.Money) money.Money {
+	amount := money.Zero
+	switch {
+	case adj.ValueType == adjustment.ValueTypePercentage && adj.Percentage != nil:
+		amount = base.Percent(*adj.Percentage)
+	case adj.ValueType == adjustment.ValueTypeFixed && adj.Amount != nil:
+		amount = *adj.Amount
+	}
+	if adj.Kind == adjustment.KindDiscount && amount.GreaterThan(available) {
+		amount = money.Max(available, money.Zero)
+	}
+	return amount
+}
+
+func signed(kind string, amount money.Money) money.Money {
+	if kind == adjustment.KindDiscount {
+		return amount.Neg()
+	}
+	return amount
+}
+
+func sumValues(values map[string]money.Money) money.Money {
+	var total money.Money
+	for _, value := range values {
+		total = total.Add(value)
+	}
+	return total
+}
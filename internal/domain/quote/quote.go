@@ -0,0 +1,119 @@
+package quote
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+)
+
+const (
+	// ItemTypePart identifica uma peça ou insumo copiado dos itens da order
+	ItemTypePart = "part"
+	// ItemTypeLabor identifica uma linha de mão de obra copiada dos serviços da order
+	ItemTypeLabor = "labor"
+	// ItemTypeDiscount identifica um desconto aprovado da order, com o valor já calculado
+	ItemTypeDiscount = "discount"
+	// ItemTypeSurcharge identifica um acréscimo aprovado da order, com o valor já calculado
+	ItemTypeSurcharge = "surcharge"
+
+	// DefaultValidityDays é a validade do orçamento quando não informada
+	DefaultValidityDays = 15
+	// MaxValidityDays limita a validade que pode ser concedida em um orçamento
+	MaxValidityDays = 90
+)
+
+// Quote é uma versão imutável do orçamento de uma order. Cada revisão recebe o próximo
+// número de versão e guarda uma cópia das peças e da mão de obra no momento da geração.
+type Quote struct {
+	ID              uuid.UUID   `json:"id"`
+	OrderID         uuid.UUID   `json:"order_id"`
+	Version         int         `json:"version"`
+	ValidUntil      time.Time   `json:"valid_until"`
+	PartsSubtotal   money.Money `json:"parts_subtotal"`
+	LaborSubtotal   money.Money `json:"labor_subtotal"`
+	DiscountTotal   money.Money `json:"discount_total"`
+	SurchargeTotal  money.Money `json:"surcharge_total"`
+	TaxTotal        money.Money `json:"tax_total"`
+	TotalPrice      money.Money `json:"total_price"`
+	CreatedByUserID *uuid.UUID  `json:"created_by_user_id,omitempty"`
+	Items           []QuoteItem `json:"items"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// QuoteItem é uma linha do orçamento. Para peças a quantidade é em unidades, para mão de obra
+// em horas estimadas. Descontos e acréscimos têm quantidade 1 e o valor calculado como preço.
+type QuoteItem struct {
+	ID          uuid.UUID   `json:"id"`
+	QuoteID     uuid.UUID   `json:"quote_id"`
+	ItemType    string      `json:"item_type"`
+	ReferenceID uuid.UUID   `json:"reference_id"`
+	Description string      `json:"description"`
+	Quantity    float64     `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"`
+	TotalPrice  money.Money `json:"total_price"`
+}
+
+// IsExpired indica se a validade do orçamento já passou
+func (q *Quote) IsExpired(now time.Time) bool {
+	return now.After(q.ValidUntil)
+}
+
+// ApplyBreakdown copia para o orçamento os subtotais, ajustes, impostos e o total calculados
+func (q *Quote) ApplyBreakdown(breakdown pricing.Breakdown) {
+	q.PartsSubtotal = breakdown.PartsSubtotal
+	q.LaborSubtotal = breakdown.LaborSubtotal
+	q.DiscountTotal = breakdown.DiscountTotal
+	q.SurchargeTotal = breakdown.SurchargeTotal
+	q.TaxTotal = breakdown.TaxTotal
+	q.TotalPrice = breakdown.TotalPrice
+}
+
+// SameItems compara o conteúdo de dois conjuntos de linhas sem considerar a ordem, os ids das
+// linhas nem as descrições. É usado para saber se a order mudou depois que o orçamento foi gerado.
+func SameItems(a, b []QuoteItem) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keysA, keysB := itemKeys(a), itemKeys(b)
+	for i := range keysA {
+		if keysA[i] != keysB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type itemKey struct {
+	itemType    string
+	referenceID uuid.UUID
+	quantity    float64
+	unitPrice   money.Money
+}
+
+func itemKeys(items []QuoteItem) []itemKey {
+	keys := make([]itemKey, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, itemKey{
+			itemType:    item.ItemType,
+			referenceID: item.ReferenceID,
+			quantity:    item.Quantity,
+			unitPrice:   item.UnitPrice,
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].itemType != keys[j].itemType {
+			return keys[i].itemType < keys[j].itemType
+		}
+		if keys[i].referenceID != keys[j].referenceID {
+			return keys[i].referenceID.String() < keys[j].referenceID.String()
+		}
+		if keys[i].quantity != keys[j].quantity {
+			return keys[i].quantity < keys[j].quantity
+		}
+		return keys[i].unitPrice.LessThan(keys[j].unitPrice)
+	})
+	return keys
+}
@@ -0,0 +1,43 @@
+package report
+
+import "time"
+
+const (
+	// MaxRangeDays limita o intervalo dos relatórios para manter as agregações rápidas
+	MaxRangeDays = 366
+	// DefaultRangeDays é o intervalo usado quando o período não é informado
+	DefaultRangeDays = 30
+)
+
+// StatusDuration resume o tempo gasto em um status pelas etapas encerradas no período, em segundos
+type StatusDuration struct {
+	Status        string  `json:"status"`
+	Count         int64   `json:"count"`
+	MeanSeconds   float64 `json:"mean_seconds"`
+	MedianSeconds float64 `json:"median_seconds"`
+	P90Seconds    float64 `json:"p90_seconds"`
+}
+
+// DailyThroughput conta as orders abertas e entregues em um dia
+type DailyThroughput struct {
+	Day       time.Time `json:"day"`
+	Opened    int64     `json:"opened"`
+	Delivered int64     `json:"delivered"`
+}
+
+// StatusCount é a quantidade de orders paradas em um status no momento da consulta
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+// StatusDurationReport é o relatório operacional de tempo por status e vazão da oficina.
+// O período é [From, To], com To inclusivo; o trabalho em andamento reflete o momento da consulta.
+type StatusDurationReport struct {
+	From            time.Time         `json:"from"`
+	To              time.Time         `json:"to"`
+	StatusDurations []StatusDuration  `json:"status_durations"`
+	Throughput      []DailyThroughput `json:"throughput"`
+	WorkInProgress  []StatusCount     `json:"work_in_progress"`
+	GeneratedAt     time.Time         `json:"generated_at"`
+}
@@ -13,8 +13,10 @@ type User struct {
 	Username   string     `json:"username"`
 	UserType   string     `json:"user_type"` // admin, mechanic, vehicle_owner
 	CustomerID *uuid.UUID `json:"customer_id,omitempty"`
-	CreatedAt  time.Time  `json:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at"`
+	// EmailVerifiedAt é preenchido quando o usuário confirma o e-mail pelo link enviado no cadastro
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // Constantes para os tipos de usuário
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AuthEventRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewAuthEventRepository(db *gorm.DB, logger *zap.Logger) *AuthEventRepository {
+	return &AuthEventRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AuthEventRepository) Record(event *domain.AuthEvent) error {
+	model := &models.AuthEvent{
+		ID:        event.ID,
+		Type:      event.Type,
+		UserID:    event.UserID,
+		Email:     event.Email,
+		IP:        event.IP,
+		UserAgent: event.UserAgent,
+		Detail:    event.Detail,
+	}
+	if err := r.db.Create(model).Error; err != nil {
+		r.logger.Error("Error recording auth event", zap.Error(err), zap.String("type", event.Type))
+		return err
+	}
+	event.ID = model.ID
+	event.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *AuthEventRepository) Find(filter domain.AuthEventFilter) ([]domain.AuthEvent, error) {
+	query := r.db.Model(&models.AuthEvent{})
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+
+	var rows []models.AuthEvent
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(filter.Limit).Find(&rows).Error; err != nil {
+		r.logger.Error("Error finding auth events", zap.Error(err))
+		return nil, err
+	}
+
+	events := make([]domain.AuthEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, domain.AuthEvent{
+			ID:        row.ID,
+			Type:      row.Type,
+			UserID:    row.UserID,
+			Email:     row.Email,
+			IP:        row.IP,
+			UserAgent: row.UserAgent,
+			Detail:    row.Detail,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return events, nil
+}
@@ -2,6 +2,9 @@ package auth
 
 import (
 	"errors"
+	"time"
+
+	"github.com/google/uuid"
 
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
@@ -34,10 +37,29 @@ func (r *AuthRepository) FindUserByEmail(email string) (*domain.UserInfo, error)
 	r.logger.Info("User found", zap.String("email", user.Email), zap.String("username", user.Username))
 
 	return &domain.UserInfo{
-		ID:       user.ID,
-		Email:    user.Email,
-		Username: user.Username,
-		UserType: user.UserType,
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		UserType:      user.UserType,
+		EmailVerified: user.EmailVerifiedAt != nil,
+	}, nil
+}
+
+func (r *AuthRepository) FindUserByID(id uuid.UUID) (*domain.UserInfo, error) {
+	r.logger.Info("Finding user by ID", zap.String("userID", id.String()))
+
+	var user models.User
+	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+		r.logger.Error("User not found", zap.Error(err), zap.String("userID", id.String()))
+		return nil, err
+	}
+
+	return &domain.UserInfo{
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		UserType:      user.UserType,
+		EmailVerified: user.EmailVerifiedAt != nil,
 	}, nil
 }
 
@@ -60,3 +82,31 @@ func (r *AuthRepository) ValidatePassword(email, password string) error {
 	r.logger.Info("Password validated successfully", zap.String("email", email))
 	return nil
 }
+
+func (r *AuthRepository) UpdatePassword(userID uuid.UUID, passwordHash string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Update("password", passwordHash)
+	if result.Error != nil {
+		r.logger.Error("Error updating password", zap.Error(result.Error), zap.String("userID", userID.String()))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.Info("Password updated", zap.String("userID", userID.String()))
+	return nil
+}
+
+func (r *AuthRepository) MarkEmailVerified(userID uuid.UUID, verifiedAt time.Time) error {
+	// Mantém a data da primeira confirmação
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND email_verified_at IS NULL", userID).
+		Update("email_verified_at", verifiedAt)
+	if result.Error != nil {
+		r.logger.Error("Error marking e-mail as verified", zap.Error(result.Error), zap.String("userID", userID.String()))
+		return result.Error
+	}
+
+	r.logger.Info("E-mail verified", zap.String("userID", userID.String()))
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,36 +13,46 @@ import (
 )
 
 type JWTService struct {
-	secretKey     []byte
-	refreshSecret []byte
-	logger        *zap.Logger
+	keys   *keyRing
+	logger *zap.Logger
 }
 
-func NewJWTService(logger *zap.Logger) *JWTService {
-	secretKey := os.Getenv("JWT_SECRET_KEY")
-	if secretKey == "" {
-		secretKey = "your-secret-key-change-in-production"
+// NewJWTService carrega as chaves de assinatura. Sem nenhuma chave, só sobe em desenvolvimento,
+// com uma chave temporária; em qualquer outro ambiente retorna ErrNoSigningKey.
+func NewJWTService(config KeyConfig, logger *zap.Logger) (*JWTService, error) {
+	keys, err := loadKeyRing(config)
+	if err != nil {
+		return nil, err
 	}
 
-	refreshSecret := os.Getenv("JWT_REFRESH_SECRET")
-	if refreshSecret == "" {
-		refreshSecret = "your-refresh-secret-key-change-in-production"
+	if config.KeysDir == "" {
+		logger.Warn("No JWT signing keys configured, using an ephemeral development key")
 	}
+	logger.Info("JWT signing keys loaded",
+		zap.Int("keys", len(keys.keys)),
+		zap.String("signingKID", keys.signer(time.Now()).kid),
+		zap.Int("scheduledRotations", len(keys.rotation)))
 
 	return &JWTService{
-		secretKey:     []byte(secretKey),
-		refreshSecret: []byte(refreshSecret),
-		logger:        logger,
-	}
+		keys:   keys,
+		logger: logger,
+	}, nil
+}
+
+// PublicKeySet retorna as chaves públicas de todas as chaves carregadas, inclusive as agendadas
+// para assinar no futuro, para que outros serviços já as conheçam antes da troca
+func (j *JWTService) PublicKeySet() domain.JSONWebKeySet {
+	return j.keys.publicKeySet()
 }
 
 func (j *JWTService) GenerateToken(userInfo domain.UserInfo) (string, error) {
 	j.logger.Info("Generating JWT token", zap.String("email", userInfo.Email))
 
 	now := time.Now()
-	exp := now.Add(24 * time.Hour)
+	exp := now.Add(domain.AccessTokenTTL)
 
 	claims := jwt.MapClaims{
+		"jti":       uuid.New().String(),
 		"user_id":   userInfo.ID.String(),
 		"email":     userInfo.Email,
 		"username":  userInfo.Username,
@@ -52,8 +61,10 @@ func (j *JWTService) GenerateToken(userInfo domain.UserInfo) (string, error) {
 		"iat":       now.Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secretKey)
+	key := j.keys.signer(now)
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	tokenString, err := token.SignedString(key.private)
 	if err != nil {
 		j.logger.Error("Error signing token", zap.Error(err))
 		return "", err
@@ -67,11 +78,17 @@ func (j *JWTService) ValidateToken(tokenString string) (*domain.Claims, error) {
 	j.logger.Info("Validating JWT token")
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := j.keys.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %q", kid)
+		}
+		// O algoritmo tem que ser o da chave, nunca o escolhido pelo token
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return j.secretKey, nil
-	})
+		return key.private.Public(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 
 	if err != nil {
 		j.logger.Error("Error parsing token", zap.Error(err))
@@ -95,7 +112,16 @@ func (j *JWTService) ValidateToken(tokenString string) (*domain.Claims, error) {
 		return nil, err
 	}
 
+	// Sem jti o token não pode ser revogado, então não é aceito
+	jti, _ := claims["jti"].(string)
+	tokenID, err := uuid.Parse(jti)
+	if err != nil {
+		j.logger.Error("Invalid token ID in token", zap.Error(err))
+		return nil, fmt.Errorf("invalid token id")
+	}
+
 	domainClaims := &domain.Claims{
+		TokenID:  tokenID,
 		UserID:   userID,
 		Email:    claims["email"].(string),
 		Username: claims["username"].(string),
@@ -111,7 +137,7 @@ func (j *JWTService) ValidateToken(tokenString string) (*domain.Claims, error) {
 func (j *JWTService) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 	j.logger.Info("Generating refresh token", zap.String("userID", userID.String()))
 
-	// Gera um refresh token simples (em produção, use uma abordagem mais segura)
+	// O token é só um valor aleatório; o vínculo com o usuário fica no registro guardado pelo hash
 	refreshToken := make([]byte, 32)
 	_, err := rand.Read(refreshToken)
 	if err != nil {
@@ -119,24 +145,7 @@ func (j *JWTService) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 		return "", err
 	}
 
-	tokenString := base64.URLEncoding.EncodeToString(refreshToken)
+	tokenString := base64.RawURLEncoding.EncodeToString(refreshToken)
 	j.logger.Info("Refresh token generated successfully")
 	return tokenString, nil
 }
-
-func (j *JWTService) ValidateRefreshToken(refreshToken string) (uuid.UUID, error) {
-	j.logger.Info("Validating refresh token")
-
-	// Em uma implementação real, você validaria o refresh token contra o banco de dados
-	// Por simplicidade, aqui apenas decodificamos
-	_, err := base64.URLEncoding.DecodeString(refreshToken)
-	if err != nil {
-		j.logger.Error("Invalid refresh token", zap.Error(err))
-		return uuid.Nil, fmt.Errorf("invalid refresh token")
-	}
-
-	// Em uma implementação real, você extrairia o userID do refresh token
-	// Por simplicidade, retornamos um UUID vazio
-	j.logger.Info("Refresh token validated successfully")
-	return uuid.Nil, nil
-}
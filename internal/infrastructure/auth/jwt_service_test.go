@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"go.uber.org/zap"
+)
+
+// writeKeys grava uma chave Ed25519 e uma RSA no diretório, com os kids "ed" e "rsa"
+func writeKeys(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for kid, key := range map[string]interface{}{"ed": edKey, "rsa": rsaKey} {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		if err := os.WriteFile(filepath.Join(dir, kid+".pem"), content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestJWTService_SignsWithScheduledKeyAndValidatesAll(t *testing.T) {
+	// Arrange
+	dir := writeKeys(t)
+	config := KeyConfig{
+		KeysDir:    dir,
+		SigningKID: "rsa",
+		Rotation:   map[string]time.Time{"ed": time.Now().Add(-time.Minute)},
+	}
+	service, err := NewJWTService(config, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	user := domain.UserInfo{ID: uuid.New(), Email: "joao@example.com", Username: "joao", UserType: "admin"}
+
+	// Act
+	token, err := service.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	claims, err := service.ValidateToken(token)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected token to be valid, got %v", err)
+	}
+	if claims.UserID != user.ID || claims.TokenID == uuid.Nil {
+		t.Errorf("Expected claims for user %s with a jti, got %+v", user.ID, claims)
+	}
+	// A rotação agendada já venceu, então a chave Ed25519 assina
+	if service.keys.signer(time.Now()).kid != "ed" {
+		t.Error("Expected the scheduled key to be signing")
+	}
+
+	// Tokens da chave anterior continuam válidos
+	service.keys.rotation = nil
+	previous, _ := service.GenerateToken(user)
+	if _, err := service.ValidateToken(previous); err != nil {
+		t.Errorf("Expected token signed by the previous key to be valid, got %v", err)
+	}
+
+	set := service.PublicKeySet()
+	if len(set.Keys) != 2 || set.Keys[0].Kid != "ed" || set.Keys[0].Kty != "OKP" || set.Keys[1].Kty != "RSA" || set.Keys[1].N == "" {
+		t.Errorf("Expected both public keys in the JWKS, got %+v", set)
+	}
+}
+
+func TestJWTService_RejectsTokensFromUnknownKeys(t *testing.T) {
+	// Arrange
+	issuer, err := NewJWTService(KeyConfig{AllowEphemeral: true}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	verifier, err := NewJWTService(KeyConfig{KeysDir: writeKeys(t), SigningKID: "ed"}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	token, _ := issuer.GenerateToken(domain.UserInfo{ID: uuid.New()})
+
+	// Act
+	_, err = verifier.ValidateToken(token)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "unknown signing key") {
+		t.Errorf("Expected token from an unknown key to be rejected, got %v", err)
+	}
+}
+
+func TestNewJWTService_RequiresKeyOutsideDevelopment(t *testing.T) {
+	if _, err := NewJWTService(KeyConfig{}, zap.NewNop()); !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("Expected ErrNoSigningKey, got %v", err)
+	}
+	if _, err := NewJWTService(KeyConfig{KeysDir: writeKeys(t)}, zap.NewNop()); err == nil {
+		t.Error("Expected JWT_SIGNING_KID to be required with more than one key")
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewLoginAttemptRepository(db *gorm.DB, logger *zap.Logger) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *LoginAttemptRepository) Find(scope, key string) (*domain.LoginAttempt, error) {
+	var model models.LoginAttempt
+	err := r.db.Where("scope = ? AND attempt_key = ?", scope, key).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Error finding login attempts", zap.Error(err), zap.String("scope", scope))
+		return nil, err
+	}
+	return toDomainLoginAttempt(model), nil
+}
+
+// RegisterFailure faz o upsert em um único comando, para que falhas simultâneas não se percam
+func (r *LoginAttemptRepository) RegisterFailure(scope, key string, failedAt, windowStart time.Time) (*domain.LoginAttempt, error) {
+	var model models.LoginAttempt
+	result := r.db.Raw(`
+		INSERT INTO login_attempts (scope, attempt_key, failures, last_failure_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (scope, attempt_key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING scope, attempt_key, failures, last_failure_at, locked_until`,
+		scope, key, failedAt, windowStart).Scan(&model)
+	if result.Error != nil {
+		r.logger.Error("Error registering login failure", zap.Error(result.Error), zap.String("scope", scope))
+		return nil, result.Error
+	}
+	return toDomainLoginAttempt(model), nil
+}
+
+func (r *LoginAttemptRepository) Lock(scope, key string, until time.Time) error {
+	result := r.db.Model(&models.LoginAttempt{}).
+		Where("scope = ? AND attempt_key = ?", scope, key).
+		Update("locked_until", until)
+	if result.Error != nil {
+		r.logger.Error("Error locking login", zap.Error(result.Error), zap.String("scope", scope))
+		return result.Error
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) Reset(scope, key string) error {
+	if err := r.db.Where("scope = ? AND attempt_key = ?", scope, key).Delete(&models.LoginAttempt{}).Error; err != nil {
+		r.logger.Error("Error resetting login attempts", zap.Error(err), zap.String("scope", scope))
+		return err
+	}
+	return nil
+}
+
+func toDomainLoginAttempt(model models.LoginAttempt) *domain.LoginAttempt {
+	return &domain.LoginAttempt{
+		Scope:         model.Scope,
+		Key:           model.AttemptKey,
+		Failures:      model.Failures,
+		LastFailureAt: model.LastFailureAt,
+		LockedUntil:   model.LockedUntil,
+	}
+}
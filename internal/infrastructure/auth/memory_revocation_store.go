@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// MemoryRevocationStore implementa TokenRevocationStore em memória, para testes e execuções
+// locais sem banco. As revogações se perdem quando o processo reinicia.
+type MemoryRevocationStore struct {
+	mu sync.RWMutex
+	// tokens mapeia o jti revogado para a expiração do token
+	tokens map[uuid.UUID]time.Time
+	// users mapeia o usuário para o instante até o qual os tokens emitidos foram revogados
+	users map[uuid.UUID]time.Time
+}
+
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		tokens: map[uuid.UUID]time.Time{},
+		users:  map[uuid.UUID]time.Time{},
+	}
+}
+
+func (s *MemoryRevocationStore) RevokeToken(tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[tokenID] = expiresAt
+	return nil
+}
+
+func (s *MemoryRevocationStore) RevokeUserTokens(userID uuid.UUID, issuedBefore time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.users[userID]; !ok || issuedBefore.After(current) {
+		s.users[userID] = issuedBefore
+	}
+	return nil
+}
+
+func (s *MemoryRevocationStore) IsRevoked(claims *domain.Claims) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now()
+	if expiresAt, ok := s.tokens[claims.TokenID]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+	if revokedBefore, ok := s.users[claims.UserID]; ok && !time.Unix(claims.Iat, 0).After(revokedBefore) {
+		return now.Before(revokedBefore.Add(domain.AccessTokenTTL)), nil
+	}
+	return false, nil
+}
+
+func (s *MemoryRevocationStore) PruneExpired(now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pruned int64
+	for tokenID, expiresAt := range s.tokens {
+		if !now.Before(expiresAt) {
+			delete(s.tokens, tokenID)
+			pruned++
+		}
+	}
+	for userID, revokedBefore := range s.users {
+		if !now.Before(revokedBefore.Add(domain.AccessTokenTTL)) {
+			delete(s.users, userID)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewRefreshTokenRepository(db *gorm.DB, logger *zap.Logger) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *RefreshTokenRepository) Create(token *domain.RefreshToken) error {
+	model := &models.RefreshToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		Device:    token.Device,
+		ExpiresAt: token.ExpiresAt,
+	}
+	if err := r.db.Create(model).Error; err != nil {
+		r.logger.Error("Error creating refresh token", zap.Error(err), zap.String("userID", token.UserID.String()))
+		return err
+	}
+	token.ID = model.ID
+	token.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *RefreshTokenRepository) FindByHash(tokenHash string) (*domain.RefreshToken, error) {
+	var model models.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Error finding refresh token", zap.Error(err))
+		return nil, err
+	}
+
+	return &domain.RefreshToken{
+		ID:           model.ID,
+		UserID:       model.UserID,
+		FamilyID:     model.FamilyID,
+		TokenHash:    model.TokenHash,
+		Device:       model.Device,
+		ExpiresAt:    model.ExpiresAt,
+		RotatedAt:    model.RotatedAt,
+		ReplacedByID: model.ReplacedByID,
+		RevokedAt:    model.RevokedAt,
+		CreatedAt:    model.CreatedAt,
+	}, nil
+}
+
+// MarkRotated usa uma atualização condicional para que dois usos simultâneos do mesmo token
+// não rotacionem ambos
+func (r *RefreshTokenRepository) MarkRotated(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"rotated_at": rotatedAt, "replaced_by_id": replacedByID})
+	if result.Error != nil {
+		r.logger.Error("Error rotating refresh token", zap.Error(result.Error), zap.String("tokenID", id.String()))
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(familyID uuid.UUID, revokedAt time.Time) error {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		r.logger.Error("Error revoking refresh token family", zap.Error(result.Error), zap.String("familyID", familyID.String()))
+		return result.Error
+	}
+	r.logger.Info("Refresh token family revoked",
+		zap.String("familyID", familyID.String()),
+		zap.Int64("revokedTokens", result.RowsAffected))
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeAllForUser(userID uuid.UUID, revokedAt time.Time) error {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		r.logger.Error("Error revoking user refresh tokens", zap.Error(result.Error), zap.String("userID", userID.String()))
+		return result.Error
+	}
+	r.logger.Info("User refresh tokens revoked",
+		zap.String("userID", userID.String()),
+		zap.Int64("revokedTokens", result.RowsAffected))
+	return nil
+}
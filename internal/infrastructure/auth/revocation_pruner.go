@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"go.uber.org/zap"
+)
+
+// DefaultPruneInterval é o intervalo entre as limpezas da lista de revogação
+const DefaultPruneInterval = time.Hour
+
+// StartRevocationPruner remove periodicamente as revogações expiradas até o contexto ser cancelado
+func StartRevocationPruner(ctx context.Context, store domain.TokenRevocationStore, interval time.Duration, logger *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				pruned, err := store.PruneExpired(now)
+				if err != nil {
+					logger.Error("Error pruning token revocations", zap.Error(err))
+					continue
+				}
+				logger.Info("Token revocations pruned", zap.Int64("pruned", pruned))
+			}
+		}
+	}()
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevocationStore implementa TokenRevocationStore no Postgres
+type RevocationStore struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewRevocationStore(db *gorm.DB, logger *zap.Logger) *RevocationStore {
+	return &RevocationStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (s *RevocationStore) RevokeToken(tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error {
+	revocation := &models.TokenRevocation{TokenID: &tokenID, UserID: userID, ExpiresAt: expiresAt}
+	// Revogar o mesmo token duas vezes não é erro
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(revocation).Error; err != nil {
+		s.logger.Error("Error revoking token", zap.Error(err), zap.String("tokenID", tokenID.String()))
+		return err
+	}
+	return nil
+}
+
+func (s *RevocationStore) RevokeUserTokens(userID uuid.UUID, issuedBefore time.Time) error {
+	revocation := &models.TokenRevocation{
+		UserID:        userID,
+		RevokedBefore: &issuedBefore,
+		// Depois disso, todo token emitido até issuedBefore já expirou sozinho
+		ExpiresAt: issuedBefore.Add(domain.AccessTokenTTL),
+	}
+	if err := s.db.Create(revocation).Error; err != nil {
+		s.logger.Error("Error revoking user tokens", zap.Error(err), zap.String("userID", userID.String()))
+		return err
+	}
+	return nil
+}
+
+// IsRevoked consulta em uma única query a revogação pelo jti e a revogação de todas as sessões
+func (s *RevocationStore) IsRevoked(claims *domain.Claims) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.TokenRevocation{}).
+		Where("token_id = ? OR (user_id = ? AND revoked_before >= ?)", claims.TokenID, claims.UserID, time.Unix(claims.Iat, 0)).
+		Where("expires_at > ?", time.Now()).
+		Count(&count).Error
+	if err != nil {
+		s.logger.Error("Error checking token revocation", zap.Error(err))
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *RevocationStore) PruneExpired(now time.Time) (int64, error) {
+	result := s.db.Where("expires_at <= ?", now).Delete(&models.TokenRevocation{})
+	if result.Error != nil {
+		s.logger.Error("Error pruning token revocations", zap.Error(result.Error))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// minRSAKeyBits é o menor tamanho aceito para chaves RSA
+const minRSAKeyBits = 2048
+
+var ErrNoSigningKey = errors.New("no JWT signing key configured")
+
+// KeyConfig descreve onde estão as chaves de assinatura e quando cada uma passa a assinar.
+// Cada arquivo <kid>.pem do diretório é uma chave privada PKCS#8 (RSA ou Ed25519) ou PKCS#1 (RSA).
+type KeyConfig struct {
+	KeysDir string
+	// SigningKID é a chave que assina enquanto nenhuma entrada de Rotation estiver vigente
+	SigningKID string
+	// Rotation agenda a troca da chave de assinatura: a partir de cada instante, o kid indicado assina
+	Rotation map[string]time.Time
+	// AllowEphemeral gera uma chave temporária quando nenhuma é configurada; só em desenvolvimento
+	AllowEphemeral bool
+}
+
+// KeyConfigFromEnv lê JWT_KEYS_DIR, JWT_SIGNING_KID e JWT_KEY_ROTATION, no formato
+// "kid=2026-11-01T00:00:00Z,kid2=2026-12-01T00:00:00Z"
+func KeyConfigFromEnv() (KeyConfig, error) {
+	config := KeyConfig{
+		KeysDir:        os.Getenv("JWT_KEYS_DIR"),
+		SigningKID:     os.Getenv("JWT_SIGNING_KID"),
+		Rotation:       map[string]time.Time{},
+		AllowEphemeral: os.Getenv("ENVIRONMENT_LEVEL") == "development",
+	}
+
+	for _, entry := range strings.Split(os.Getenv("JWT_KEY_ROTATION"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return config, fmt.Errorf("invalid JWT_KEY_ROTATION entry %q", entry)
+		}
+		at, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+		if err != nil {
+			return config, fmt.Errorf("invalid JWT_KEY_ROTATION time for %q: %w", kid, err)
+		}
+		config.Rotation[strings.TrimSpace(kid)] = at
+	}
+	return config, nil
+}
+
+// signingKey é uma chave privada com o algoritmo usado para assiná-la
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+}
+
+func (k *signingKey) publicJWK() domain.JSONWebKey {
+	jwk := domain.JSONWebKey{Kid: k.kid, Use: "sig", Alg: k.method.Alg()}
+	switch public := k.private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// keyRing reúne as chaves que validam tokens e o agendamento de qual delas assina
+type keyRing struct {
+	keys       map[string]*signingKey
+	signingKID string
+	// rotation fica ordenada pelo instante em que cada kid passa a assinar
+	rotation []scheduledKey
+}
+
+type scheduledKey struct {
+	kid string
+	at  time.Time
+}
+
+// signer retorna a chave que assina no instante informado
+func (r *keyRing) signer(now time.Time) *signingKey {
+	kid := r.signingKID
+	for _, scheduled := range r.rotation {
+		if scheduled.at.After(now) {
+			break
+		}
+		kid = scheduled.kid
+	}
+	return r.keys[kid]
+}
+
+func (r *keyRing) publicKeySet() domain.JSONWebKeySet {
+	set := domain.JSONWebKeySet{Keys: make([]domain.JSONWebKey, 0, len(r.keys))}
+	for _, key := range r.keys {
+		set.Keys = append(set.Keys, key.publicJWK())
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+// loadKeyRing lê as chaves do diretório e valida o agendamento de rotação
+func loadKeyRing(config KeyConfig) (*keyRing, error) {
+	ring := &keyRing{keys: map[string]*signingKey{}, signingKID: config.SigningKID}
+
+	if config.KeysDir != "" {
+		paths, err := filepath.Glob(filepath.Join(config.KeysDir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+			key, err := loadSigningKey(kid, path)
+			if err != nil {
+				return nil, err
+			}
+			ring.keys[kid] = key
+		}
+	}
+
+	if len(ring.keys) == 0 {
+		if !config.AllowEphemeral {
+			return nil, ErrNoSigningKey
+		}
+		key, err := ephemeralSigningKey()
+		if err != nil {
+			return nil, err
+		}
+		ring.keys[key.kid] = key
+	}
+
+	if ring.signingKID == "" {
+		if len(ring.keys) > 1 {
+			return nil, errors.New("JWT_SIGNING_KID is required when more than one key is configured")
+		}
+		for kid := range ring.keys {
+			ring.signingKID = kid
+		}
+	}
+	if _, ok := ring.keys[ring.signingKID]; !ok {
+		return nil, fmt.Errorf("signing key %q not found", ring.signingKID)
+	}
+
+	for kid, at := range config.Rotation {
+		if _, ok := ring.keys[kid]; !ok {
+			return nil, fmt.Errorf("scheduled signing key %q not found", kid)
+		}
+		ring.rotation = append(ring.rotation, scheduledKey{kid: kid, at: at})
+	}
+	sort.Slice(ring.rotation, func(i, j int) bool { return ring.rotation[i].at.Before(ring.rotation[j].at) })
+
+	return ring, nil
+}
+
+func loadSigningKey(kid string, path string) (*signingKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("key %q is not PEM encoded", kid)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %q has unsupported PEM type %q", kid, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", kid, err)
+	}
+	return newSigningKey(kid, parsed)
+}
+
+func newSigningKey(kid string, private interface{}) (*signingKey, error) {
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key %q must have at least %d bits", kid, minRSAKeyBits)
+		}
+		return &signingKey{kid: kid, method: jwt.SigningMethodRS256, private: key}, nil
+	case ed25519.PrivateKey:
+		return &signingKey{kid: kid, method: jwt.SigningMethodEdDSA, private: key}, nil
+	default:
+		return nil, fmt.Errorf("key %q must be RSA or Ed25519", kid)
+	}
+}
+
+// ephemeralSigningKey gera uma chave Ed25519 que só vale enquanto o processo roda
+func ephemeralSigningKey() (*signingKey, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKey("dev-"+uuid.New().String(), private)
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// GormUnitOfWork implementa a unidade de trabalho de autenticação usando transações do GORM
+type GormUnitOfWork struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewGormUnitOfWork(db *gorm.DB, logger *zap.Logger) *GormUnitOfWork {
+	return &GormUnitOfWork{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Execute cria os repositórios ligados à transação e faz rollback se fn retornar erro
+func (u *GormUnitOfWork) Execute(fn func(repos domain.Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(domain.Repositories{
+			Auth:          NewAuthRepository(tx, u.logger),
+			UserTokens:    NewUserTokenRepository(tx, u.logger),
+			RefreshTokens: NewRefreshTokenRepository(tx, u.logger),
+		})
+	})
+}
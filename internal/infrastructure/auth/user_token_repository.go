@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewUserTokenRepository(db *gorm.DB, logger *zap.Logger) *UserTokenRepository {
+	return &UserTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *UserTokenRepository) Create(token *domain.UserToken) error {
+	model := &models.UserToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Purpose:   token.Purpose,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	}
+	if err := r.db.Create(model).Error; err != nil {
+		r.logger.Error("Error creating user token", zap.Error(err), zap.String("userID", token.UserID.String()))
+		return err
+	}
+	token.ID = model.ID
+	token.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *UserTokenRepository) FindByHash(tokenHash string, purpose string) (*domain.UserToken, error) {
+	var model models.UserToken
+	err := r.db.Where("token_hash = ? AND purpose = ?", tokenHash, purpose).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Error finding user token", zap.Error(err))
+		return nil, err
+	}
+
+	return &domain.UserToken{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		Purpose:   model.Purpose,
+		TokenHash: model.TokenHash,
+		ExpiresAt: model.ExpiresAt,
+		UsedAt:    model.UsedAt,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+// MarkUsed usa uma atualização condicional para que o token só seja consumido uma vez
+func (r *UserTokenRepository) MarkUsed(id uuid.UUID, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		r.logger.Error("Error consuming user token", zap.Error(result.Error), zap.String("tokenID", id.String()))
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *UserTokenRepository) InvalidateForUser(userID uuid.UUID, purpose string, usedAt time.Time) error {
+	result := r.db.Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		r.logger.Error("Error invalidating user tokens", zap.Error(result.Error), zap.String("userID", userID.String()))
+		return result.Error
+	}
+	return nil
+}
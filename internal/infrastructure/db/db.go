@@ -47,7 +47,7 @@ func InitDB(logger *zap.Logger) {
 	logger.Info("Successfully connected to database")
 
 	logger.Info("Running auto-migration")
-	err = db.AutoMigrate(&models.User{}, &models.Customer{}, &models.Vehicle{}, &models.Input{}, &models.Order{}, &models.OrderInput{}, &models.OrderStatusHistory{})
+	err = db.AutoMigrate(&models.User{}, &models.Customer{}, &models.Vehicle{}, &models.Input{}, &models.Order{}, &models.OrderInput{}, &models.OrderStatusHistory{}, &models.InventoryMovement{}, &models.LaborService{}, &models.OrderService{}, &models.Quote{}, &models.QuoteItem{}, &models.OrderAdjustment{}, &models.TaxRate{}, &models.PricingSettings{}, &models.DocumentSequence{}, &models.Invoice{}, &models.InvoiceItem{}, &models.CreditNote{}, &models.Payment{}, &models.OrderAssignment{}, &models.RefreshToken{}, &models.TokenRevocation{}, &models.UserToken{}, &models.LoginAttempt{}, &models.AuthEvent{})
 	if err != nil {
 		logger.Error("Failed to run auto-migration", zap.Error(err))
 		return
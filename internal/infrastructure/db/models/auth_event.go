@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthEvent é o registro de auditoria de login e bloqueio. Não referencia users para que o
+// histórico sobreviva à exclusão do usuário.
+type AuthEvent struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Type      string     `json:"type" gorm:"not null;index:idx_auth_events_type_created_at,priority:1"`
+	UserID    *uuid.UUID `json:"user_id" gorm:"type:uuid;index:idx_auth_events_user_id_created_at,priority:1"`
+	Email     string     `json:"email" gorm:"index:idx_auth_events_email_created_at,priority:1"`
+	IP        string     `json:"ip" gorm:"index:idx_auth_events_ip_created_at,priority:1"`
+	UserAgent string     `json:"user_agent"`
+	Detail    string     `json:"detail"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime;index:idx_auth_events_type_created_at,priority:2;index:idx_auth_events_user_id_created_at,priority:2;index:idx_auth_events_email_created_at,priority:2;index:idx_auth_events_ip_created_at,priority:2"`
+}
+
+func (ae *AuthEvent) TableName() string {
+	return "auth_events"
+}
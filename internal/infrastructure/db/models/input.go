@@ -4,17 +4,20 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 )
 
 type Input struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	Name        string    `json:"name" gorm:"not null;unique"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price" gorm:"not null"`
-	Quantity    int       `json:"quantity" gorm:"not null"`
-	InputType   string    `json:"input_type" gorm:"not null"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name        string      `json:"name" gorm:"not null;unique"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" gorm:"type:decimal(10,2);not null"`
+	Quantity    int         `json:"quantity" gorm:"not null"`
+	// ReservedQuantity só é alterado pelas operações atômicas de reserva do repositório
+	ReservedQuantity int       `json:"reserved_quantity" gorm:"not null;default:0"`
+	InputType        string    `json:"input_type" gorm:"not null"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (i *Input) TableName() string {
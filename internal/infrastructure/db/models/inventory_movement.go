@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InventoryMovement struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	InputID      uuid.UUID  `json:"input_id" gorm:"type:uuid;not null;index"`
+	OrderID      *uuid.UUID `json:"order_id" gorm:"type:uuid"`
+	UserID       *uuid.UUID `json:"user_id" gorm:"type:uuid"`
+	MovementType string     `json:"movement_type" gorm:"not null"`
+	Delta        int        `json:"delta" gorm:"not null"`
+	BalanceAfter int        `json:"balance_after" gorm:"not null"`
+	Reason       string     `json:"reason"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (im *InventoryMovement) TableName() string {
+	return "inventory_movements"
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+type Invoice struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	WorkshopCode string     `json:"workshop_code" gorm:"not null;uniqueIndex:idx_invoices_workshop_number"`
+	Number       int64      `json:"number" gorm:"not null;uniqueIndex:idx_invoices_workshop_number"`
+	OrderID      uuid.UUID  `json:"order_id" gorm:"type:uuid;not null;uniqueIndex"`
+	QuoteID      *uuid.UUID `json:"quote_id" gorm:"type:uuid"`
+
+	CustomerID             uuid.UUID `json:"customer_id" gorm:"type:uuid;not null"`
+	CustomerName           string    `json:"customer_name" gorm:"not null"`
+	CustomerDocumentNumber string    `json:"customer_document_number" gorm:"not null"`
+	CustomerType           string    `json:"customer_type" gorm:"not null"`
+
+	VehicleID                   uuid.UUID `json:"vehicle_id" gorm:"type:uuid;not null"`
+	VehicleModel                string    `json:"vehicle_model" gorm:"not null"`
+	VehicleBrand                string    `json:"vehicle_brand" gorm:"not null"`
+	VehicleReleaseYear          int       `json:"vehicle_release_year" gorm:"not null"`
+	VehicleIdentificationNumber string    `json:"vehicle_identification_number" gorm:"not null"`
+	VehicleNumberPlate          string    `json:"vehicle_number_plate" gorm:"not null"`
+	VehicleColor                string    `json:"vehicle_color"`
+
+	PartsSubtotal  money.Money `json:"parts_subtotal" gorm:"type:decimal(10,2);not null"`
+	LaborSubtotal  money.Money `json:"labor_subtotal" gorm:"type:decimal(10,2);not null"`
+	DiscountTotal  money.Money `json:"discount_total" gorm:"type:decimal(10,2);not null;default:0"`
+	SurchargeTotal money.Money `json:"surcharge_total" gorm:"type:decimal(10,2);not null;default:0"`
+	TaxTotal       money.Money `json:"tax_total" gorm:"type:decimal(10,2);not null;default:0"`
+	TotalPrice     money.Money `json:"total_price" gorm:"type:decimal(10,2);not null"`
+
+	Items           []InvoiceItem `json:"items" gorm:"foreignKey:InvoiceID"`
+	CreditNotes     []CreditNote  `json:"credit_notes" gorm:"foreignKey:InvoiceID"`
+	CreatedByUserID *uuid.UUID    `json:"created_by_user_id" gorm:"type:uuid"`
+	IssuedAt        time.Time     `json:"issued_at" gorm:"not null"`
+}
+
+func (i *Invoice) TableName() string {
+	return "invoices"
+}
+
+type InvoiceItem struct {
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	InvoiceID   uuid.UUID   `json:"invoice_id" gorm:"type:uuid;not null;index"`
+	ItemType    string      `json:"item_type" gorm:"not null"`
+	ReferenceID uuid.UUID   `json:"reference_id" gorm:"type:uuid;not null"`
+	Description string      `json:"description"`
+	Quantity    float64     `json:"quantity" gorm:"not null"`
+	UnitPrice   money.Money `json:"unit_price" gorm:"type:decimal(10,2);not null"`
+	TotalPrice  money.Money `json:"total_price" gorm:"type:decimal(10,2);not null"`
+}
+
+func (i *InvoiceItem) TableName() string {
+	return "invoice_items"
+}
+
+type CreditNote struct {
+	ID              uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	InvoiceID       uuid.UUID   `json:"invoice_id" gorm:"type:uuid;not null;index"`
+	WorkshopCode    string      `json:"workshop_code" gorm:"not null;uniqueIndex:idx_credit_notes_workshop_number"`
+	Number          int64       `json:"number" gorm:"not null;uniqueIndex:idx_credit_notes_workshop_number"`
+	Reason          string      `json:"reason" gorm:"not null"`
+	Amount          money.Money `json:"amount" gorm:"type:decimal(10,2);not null"`
+	CreatedByUserID *uuid.UUID  `json:"created_by_user_id" gorm:"type:uuid"`
+	IssuedAt        time.Time   `json:"issued_at" gorm:"not null"`
+}
+
+func (c *CreditNote) TableName() string {
+	return "credit_notes"
+}
+
+// DocumentSequence guarda o último número emitido de cada tipo de documento por oficina.
+// A linha é bloqueada durante a emissão, o que garante a numeração sem lacunas.
+type DocumentSequence struct {
+	WorkshopCode string `json:"workshop_code" gorm:"primaryKey"`
+	DocumentType string `json:"document_type" gorm:"primaryKey"`
+	LastNumber   int64  `json:"last_number" gorm:"not null;default:0"`
+}
+
+func (d *DocumentSequence) TableName() string {
+	return "document_sequences"
+}
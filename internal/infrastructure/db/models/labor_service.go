@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+type LaborService struct {
+	ID           uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name         string      `json:"name" gorm:"not null;unique"`
+	Description  string      `json:"description"`
+	DefaultHours float64     `json:"default_hours" gorm:"not null"`
+	HourlyRate   money.Money `json:"hourly_rate" gorm:"type:decimal(10,2);not null"`
+	CreatedAt    time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (l *LaborService) TableName() string {
+	return "labor_services"
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// LoginAttempt é o contador de falhas de login de um e-mail ou IP
+type LoginAttempt struct {
+	Scope         string     `json:"scope" gorm:"primaryKey;check:scope IN ('email', 'ip')"`
+	AttemptKey    string     `json:"attempt_key" gorm:"primaryKey"`
+	Failures      int        `json:"failures" gorm:"not null;default:0"`
+	LastFailureAt time.Time  `json:"last_failure_at" gorm:"not null"`
+	LockedUntil   *time.Time `json:"locked_until"`
+}
+
+func (la *LoginAttempt) TableName() string {
+	return "login_attempts"
+}
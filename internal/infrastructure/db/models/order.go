@@ -11,8 +11,10 @@ type Order struct {
 	CustomerID uuid.UUID `json:"customer_id" gorm:"type:uuid;not null"`
 	VehicleID  uuid.UUID `json:"vehicle_id" gorm:"type:uuid;not null"`
 	Status     string    `json:"status" gorm:"not null"`
-	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	// ApprovedQuoteID aponta para a versão do orçamento aprovada pelo cliente
+	ApprovedQuoteID *uuid.UUID `json:"approved_quote_id" gorm:"type:uuid"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (o *Order) TableName() string {
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+type OrderAdjustment struct {
+	ID               uuid.UUID    `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	OrderID          uuid.UUID    `json:"order_id" gorm:"type:uuid;not null;index"`
+	LineID           *uuid.UUID   `json:"line_id" gorm:"type:uuid"`
+	Kind             string       `json:"kind" gorm:"not null"`
+	ValueType        string       `json:"value_type" gorm:"not null"`
+	Percentage       *float64     `json:"percentage" gorm:"type:decimal(5,2)"`
+	Amount           *money.Money `json:"amount"`
+	Reason           string       `json:"reason" gorm:"not null"`
+	Status           string       `json:"status" gorm:"not null"`
+	CreatedByUserID  uuid.UUID    `json:"created_by_user_id" gorm:"type:uuid;not null"`
+	ApprovedByUserID *uuid.UUID   `json:"approved_by_user_id" gorm:"type:uuid"`
+	ApprovedAt       *time.Time   `json:"approved_at"`
+	CreatedAt        time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (oa *OrderAdjustment) TableName() string {
+	return "order_adjustments"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderAssignment struct {
+	ID                 uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	OrderID            uuid.UUID  `json:"order_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_order_assignments_active,where:unassigned_at IS NULL"`
+	MechanicID         uuid.UUID  `json:"mechanic_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_order_assignments_active"`
+	AssignedByUserID   uuid.UUID  `json:"assigned_by_user_id" gorm:"type:uuid;not null"`
+	AssignedAt         time.Time  `json:"assigned_at" gorm:"not null"`
+	UnassignedByUserID *uuid.UUID `json:"unassigned_by_user_id" gorm:"type:uuid"`
+	UnassignedAt       *time.Time `json:"unassigned_at"`
+}
+
+func (oa *OrderAssignment) TableName() string {
+	return "order_assignments"
+}
@@ -4,17 +4,21 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 )
 
 type OrderInput struct {
-	ID         uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	OrderID    uuid.UUID `json:"order_id" gorm:"type:uuid;not null"`
-	InputID    uuid.UUID `json:"input_id" gorm:"type:uuid;not null"`
-	Quantity   int       `json:"quantity" gorm:"not null"`
-	UnitPrice  float64   `json:"unit_price" gorm:"not null"`
-	TotalPrice float64   `json:"total_price" gorm:"not null"`
-	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID            uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	OrderID       uuid.UUID   `json:"order_id" gorm:"type:uuid;not null"`
+	InputID       uuid.UUID   `json:"input_id" gorm:"type:uuid;not null"`
+	Quantity      int         `json:"quantity" gorm:"not null"`
+	UnitPrice     money.Money `json:"unit_price" gorm:"type:decimal(10,2);not null"`
+	TotalPrice    money.Money `json:"total_price" gorm:"type:decimal(10,2);not null"`
+	StockStatus   string      `json:"stock_status" gorm:"not null;default:consumed"`
+	UsedOrDamaged bool        `json:"used_or_damaged" gorm:"not null;default:false"`
+	VoidedAt      *time.Time  `json:"voided_at"`
+	CreatedAt     time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (oi *OrderInput) TableName() string {
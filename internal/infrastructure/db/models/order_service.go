@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+type OrderService struct {
+	ID             uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	OrderID        uuid.UUID   `json:"order_id" gorm:"type:uuid;not null"`
+	LaborServiceID uuid.UUID   `json:"labor_service_id" gorm:"type:uuid;not null"`
+	MechanicID     *uuid.UUID  `json:"mechanic_id" gorm:"type:uuid"`
+	EstimatedHours float64     `json:"estimated_hours" gorm:"not null"`
+	ActualHours    *float64    `json:"actual_hours"`
+	HourlyRate     money.Money `json:"hourly_rate" gorm:"type:decimal(10,2);not null"`
+	TotalPrice     money.Money `json:"total_price" gorm:"type:decimal(10,2);not null"`
+	CreatedAt      time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (o *OrderService) TableName() string {
+	return "order_services"
+}
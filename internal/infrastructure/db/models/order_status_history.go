@@ -7,12 +7,15 @@ import (
 )
 
 type OrderStatusHistory struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	OrderID   uuid.UUID  `json:"order_id" gorm:"type:uuid;not null"`
-	Status    string     `json:"status" gorm:"not null"`
-	StartedAt time.Time  `json:"started_at" gorm:"not null"`
-	EndedAt   *time.Time `json:"ended_at"`
-	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	OrderID         uuid.UUID  `json:"order_id" gorm:"type:uuid;not null"`
+	Status          string     `json:"status" gorm:"not null;index:idx_order_status_history_status_ended_at,priority:1;index:idx_order_status_history_status_started_at,priority:1"`
+	ChangedByUserID *uuid.UUID `json:"changed_by_user_id" gorm:"type:uuid"`
+	Note            string     `json:"note"`
+	Source          string     `json:"source" gorm:"not null;default:api;check:source IN ('api', 'automation', 'customer')"`
+	StartedAt       time.Time  `json:"started_at" gorm:"not null;index:idx_order_status_history_status_started_at,priority:2"`
+	EndedAt         *time.Time `json:"ended_at" gorm:"index:idx_order_status_history_status_ended_at,priority:2"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
 }
 
 func (osh *OrderStatusHistory) TableName() string {
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+type Payment struct {
+	ID               uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	OrderID          uuid.UUID   `json:"order_id" gorm:"type:uuid;not null;index"`
+	Method           string      `json:"method" gorm:"not null"`
+	Amount           money.Money `json:"amount" gorm:"type:decimal(10,2);not null"`
+	Reference        string      `json:"reference"`
+	ReceivedByUserID *uuid.UUID  `json:"received_by_user_id" gorm:"type:uuid"`
+	PaidAt           time.Time   `json:"paid_at" gorm:"not null"`
+	CreatedAt        time.Time   `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (p *Payment) TableName() string {
+	return "payments"
+}
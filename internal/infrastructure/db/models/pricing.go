@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+type TaxRate struct {
+	Category  string    `json:"category" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Rate      float64   `json:"rate" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (t *TaxRate) TableName() string {
+	return "tax_rates"
+}
+
+// PricingSettings tem uma única linha, identificada por PricingSettingsID
+type PricingSettings struct {
+	ID                         int       `json:"id" gorm:"primaryKey"`
+	MechanicDiscountCapPercent float64   `json:"mechanic_discount_cap_percent" gorm:"not null"`
+	UpdatedAt                  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// PricingSettingsID é o id da única linha de configuração de preços
+const PricingSettingsID = 1
+
+func (p *PricingSettings) TableName() string {
+	return "pricing_settings"
+}
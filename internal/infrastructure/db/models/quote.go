@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+type Quote struct {
+	ID              uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	OrderID         uuid.UUID   `json:"order_id" gorm:"type:uuid;not null;uniqueIndex:idx_quotes_order_version"`
+	Version         int         `json:"version" gorm:"not null;uniqueIndex:idx_quotes_order_version"`
+	ValidUntil      time.Time   `json:"valid_until" gorm:"not null"`
+	PartsSubtotal   money.Money `json:"parts_subtotal" gorm:"type:decimal(10,2);not null"`
+	LaborSubtotal   money.Money `json:"labor_subtotal" gorm:"type:decimal(10,2);not null"`
+	DiscountTotal   money.Money `json:"discount_total" gorm:"type:decimal(10,2);not null;default:0"`
+	SurchargeTotal  money.Money `json:"surcharge_total" gorm:"type:decimal(10,2);not null;default:0"`
+	TaxTotal        money.Money `json:"tax_total" gorm:"type:decimal(10,2);not null;default:0"`
+	TotalPrice      money.Money `json:"total_price" gorm:"type:decimal(10,2);not null"`
+	CreatedByUserID *uuid.UUID  `json:"created_by_user_id" gorm:"type:uuid"`
+	Items           []QuoteItem `json:"items" gorm:"foreignKey:QuoteID"`
+	CreatedAt       time.Time   `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (q *Quote) TableName() string {
+	return "quotes"
+}
+
+type QuoteItem struct {
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	QuoteID     uuid.UUID   `json:"quote_id" gorm:"type:uuid;not null;index"`
+	ItemType    string      `json:"item_type" gorm:"not null"`
+	ReferenceID uuid.UUID   `json:"reference_id" gorm:"type:uuid;not null"`
+	Description string      `json:"description"`
+	Quantity    float64     `json:"quantity" gorm:"not null"`
+	UnitPrice   money.Money `json:"unit_price" gorm:"type:decimal(10,2);not null"`
+	TotalPrice  money.Money `json:"total_price" gorm:"type:decimal(10,2);not null"`
+}
+
+func (q *QuoteItem) TableName() string {
+	return "quote_items"
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenRevocation revoga um token de acesso pelo jti (TokenID) ou, no logout de todas as
+// sessões, todos os tokens do usuário emitidos até RevokedBefore
+type TokenRevocation struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TokenID       *uuid.UUID `json:"token_id" gorm:"type:uuid;uniqueIndex"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	RevokedBefore *time.Time `json:"revoked_before"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (tr *TokenRevocation) TableName() string {
+	return "token_revocations"
+}
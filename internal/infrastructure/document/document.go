@@ -0,0 +1,125 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+)
+
+const (
+	// FormatHTML gera uma página HTML pronta para impressão
+	FormatHTML = "html"
+	// FormatPDF gera um PDF, montado localmente sem serviços externos
+	FormatPDF = "pdf"
+)
+
+// ErrInvalidFormat indica um formato de saída não suportado
+var ErrInvalidFormat = errors.New("invalid document format")
+
+// Workshop identifica a oficina no cabeçalho dos documentos
+type Workshop struct {
+	Name           string
+	DocumentNumber string
+	Address        string
+	Phone          string
+}
+
+// Lines retorna as linhas preenchidas do cabeçalho da oficina, na ordem de impressão
+func (w Workshop) Lines() []string {
+	lines := []string{}
+	for _, line := range []string{w.Name, w.DocumentNumber, w.Address, w.Phone} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Document é a estrutura genérica de um documento impresso (nota, nota de crédito, orçamento):
+// cabeçalho, blocos de campos, uma tabela de linhas e os totais
+type Document struct {
+	Workshop Workshop
+	Title    string
+	Number   string
+	IssuedAt time.Time
+	Sections []Section
+	Table    Table
+	Totals   []Field
+	Notes    []string
+}
+
+// Section é um bloco de campos, como os dados do cliente ou do veículo
+type Section struct {
+	Title  string
+	Fields []Field
+}
+
+// Field é um par rótulo e valor já formatado
+type Field struct {
+	Label string
+	Value string
+}
+
+// Table é a tabela de linhas do documento. A primeira coluna é a descrição e as demais
+// são alinhadas à direita.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// IsValidFormat verifica se o formato de saída é suportado
+func IsValidFormat(format string) bool {
+	return format == FormatHTML || format == FormatPDF
+}
+
+// ContentType retorna o Content-Type HTTP do formato
+func ContentType(format string) string {
+	if format == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/html; charset=utf-8"
+}
+
+// Render gera o documento no formato informado
+func Render(doc Document, format string) ([]byte, error) {
+	switch format {
+	case FormatHTML:
+		return RenderHTML(doc)
+	case FormatPDF:
+		return RenderPDF(doc)
+	}
+	return nil, ErrInvalidFormat
+}
+
+// FormatMoney formata um valor no padrão brasileiro, por exemplo R$ 1.234,56
+func FormatMoney(value money.Money) string {
+	sign := ""
+	if value.IsNegative() {
+		sign = "-"
+		value = value.Neg()
+	}
+	units, cents := value.Cents()/100, value.Cents()%100
+
+	digits := strconv.FormatInt(units, 10)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return fmt.Sprintf("%sR$ %s,%02d", sign, grouped.String(), cents)
+}
+
+// FormatQuantity formata quantidades inteiras sem casas decimais e horas com até duas casas
+func FormatQuantity(quantity float64) string {
+	formatted := strconv.FormatFloat(quantity, 'f', -1, 64)
+	if strings.Contains(formatted, ".") {
+		formatted = strconv.FormatFloat(quantity, 'f', 2, 64)
+	}
+	return strings.Replace(formatted, ".", ",", 1)
+}
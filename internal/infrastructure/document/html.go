@@ -0,0 +1,64 @@
+package document
+
+import (
+	"bytes"
+	"html/template"
+)
+
+var htmlTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} {{.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 12px; margin: 32px; color: #222; }
+header.workshop { border-bottom: 2px solid #222; padding-bottom: 8px; margin-bottom: 16px; }
+header.workshop div:first-child { font-size: 16px; font-weight: bold; }
+h1 { font-size: 20px; margin-bottom: 4px; }
+h2 { font-size: 14px; margin: 16px 0 4px; }
+.meta { color: #555; margin-bottom: 16px; }
+dl { display: grid; grid-template-columns: max-content auto; gap: 2px 12px; margin: 0; }
+dt { font-weight: bold; }
+dd { margin: 0; }
+table { border-collapse: collapse; width: 100%; margin-top: 16px; }
+th, td { border-bottom: 1px solid #ccc; padding: 4px 6px; }
+th { text-align: left; background: #f2f2f2; }
+td.num, th.num { text-align: right; }
+.totals { margin-top: 16px; margin-left: auto; width: 40%; }
+.totals td { border: none; }
+.totals tr:last-child td { font-weight: bold; border-top: 1px solid #222; }
+.notes { margin-top: 24px; color: #555; }
+</style>
+</head>
+<body>
+{{with .Workshop.Lines}}<header class="workshop">{{range .}}<div>{{.}}</div>{{end}}</header>{{end}}
+<h1>{{.Title}} {{.Number}}</h1>
+<div class="meta">Emitido em {{.IssuedAt.Format "02/01/2006 15:04"}}</div>
+{{range .Sections}}
+<h2>{{.Title}}</h2>
+<dl>{{range .Fields}}<dt>{{.Label}}</dt><dd>{{.Value}}</dd>{{end}}</dl>
+{{end}}
+{{if .Table.Headers}}
+<table>
+<thead><tr>{{range $i, $h := .Table.Headers}}<th{{if $i}} class="num"{{end}}>{{$h}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Table.Rows}}<tr>{{range $i, $c := .}}<td{{if $i}} class="num"{{end}}>{{$c}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}
+{{if .Totals}}<table class="totals">
+{{range .Totals}}<tr><td>{{.Label}}</td><td class="num">{{.Value}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Notes}}<div class="notes">{{range .Notes}}<p>{{.}}</p>{{end}}</div>{{end}}
+</body>
+</html>
+`))
+
+// RenderHTML gera o documento como uma página HTML autocontida
+func RenderHTML(doc Document) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := htmlTemplate.Execute(&buffer, doc); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,42 @@
+package document
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderHTML_EscapesCustomerFields(t *testing.T) {
+	// Arrange
+	doc := Document{
+		Workshop: Workshop{Name: "Oficina Central"},
+		Title:    "Orçamento",
+		Number:   "1",
+		IssuedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		Sections: []Section{{Title: "Cliente", Fields: []Field{{Label: "Nome", Value: "<script>alert(1)</script>"}}}},
+		Table: Table{
+			Headers: []string{"Descrição", "Total"},
+			Rows:    [][]string{{`Pastilha"><img src=x onerror=alert(1)>`, "R$ 91,00"}},
+		},
+		Notes: []string{"Cliente pediu <b>urgência</b> & retorno"},
+	}
+
+	// Act
+	content, err := RenderHTML(doc)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	html := string(content)
+	for _, raw := range []string{"<script>", "<img", "<b>"} {
+		if strings.Contains(html, raw) {
+			t.Errorf("Expected %q to be escaped", raw)
+		}
+	}
+	for _, escaped := range []string{"&lt;script&gt;", "&lt;img src=x", "&lt;b&gt;urgência&lt;/b&gt; &amp; retorno"} {
+		if !strings.Contains(html, escaped) {
+			t.Errorf("Expected HTML to contain %q", escaped)
+		}
+	}
+}
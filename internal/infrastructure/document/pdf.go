@@ -0,0 +1,247 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout de página A4 em pontos
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	marginLeft   = 50
+	marginTop    = 50
+	marginBottom = 50
+
+	fontRegular = "F1"
+	fontBold    = "F2"
+	fontMono    = "F3"
+
+	// A tabela usa Courier, em que cada caractere ocupa 0,6 do tamanho da fonte
+	tableFontSize  = 9
+	tableLineChars = (pageWidth - 2*marginLeft) * 10 / (6 * tableFontSize)
+)
+
+// pdfLine é uma linha de texto posicionada pelo paginador
+type pdfLine struct {
+	font string
+	size float64
+	text string
+	// gap é o espaço extra antes da linha, usado para separar blocos
+	gap float64
+}
+
+// RenderPDF gera o documento como PDF 1.4 usando apenas as fontes padrão do leitor, sem
+// dependências externas. O texto é codificado em WinAnsi, que cobre a acentuação do português.
+func RenderPDF(doc Document) ([]byte, error) {
+	lines := layoutLines(doc)
+	pages := paginate(lines)
+	return writePDF(pages), nil
+}
+
+// layoutLines transforma o documento em linhas de texto na ordem de impressão
+func layoutLines(doc Document) []pdfLine {
+	lines := []pdfLine{}
+	for i, line := range doc.Workshop.Lines() {
+		if i == 0 {
+			lines = append(lines, pdfLine{font: fontBold, size: 12, text: line})
+			continue
+		}
+		lines = append(lines, pdfLine{font: fontRegular, size: 9, text: line})
+	}
+
+	title := pdfLine{font: fontBold, size: 16, text: strings.TrimSpace(doc.Title + " " + doc.Number)}
+	if len(lines) > 0 {
+		title.gap = 16
+	}
+	lines = append(lines, title,
+		pdfLine{font: fontRegular, size: 10, text: "Emitido em " + doc.IssuedAt.Format("02/01/2006 15:04"), gap: 4})
+
+	for _, section := range doc.Sections {
+		lines = append(lines, pdfLine{font: fontBold, size: 12, text: section.Title, gap: 12})
+		for _, field := range section.Fields {
+			lines = append(lines, pdfLine{font: fontRegular, size: 10, text: field.Label + ": " + field.Value})
+		}
+	}
+
+	if len(doc.Table.Headers) > 0 {
+		rows := formatTable(doc.Table)
+		lines = append(lines, pdfLine{font: fontMono, size: tableFontSize, text: rows[0], gap: 12})
+		lines = append(lines, pdfLine{font: fontMono, size: tableFontSize, text: strings.Repeat("-", utf8.RuneCountInString(rows[0]))})
+		for _, row := range rows[1:] {
+			lines = append(lines, pdfLine{font: fontMono, size: tableFontSize, text: row})
+		}
+	}
+
+	if len(doc.Totals) > 0 {
+		labelWidth, valueWidth := 0, 0
+		for _, total := range doc.Totals {
+			labelWidth = max(labelWidth, utf8.RuneCountInString(total.Label))
+			valueWidth = max(valueWidth, utf8.RuneCountInString(total.Value))
+		}
+		indent := max(tableLineChars-labelWidth-valueWidth-2, 0)
+		for i, total := range doc.Totals {
+			line := pdfLine{
+				font: fontMono,
+				size: tableFontSize,
+				text: strings.Repeat(" ", indent) + padRight(total.Label, labelWidth) + "  " + padLeft(total.Value, valueWidth),
+			}
+			if i == 0 {
+				line.gap = 8
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	for i, note := range doc.Notes {
+		line := pdfLine{font: fontRegular, size: 9, text: note}
+		if i == 0 {
+			line.gap = 16
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formatTable alinha as colunas em largura fixa. A descrição ocupa o espaço que sobra e é
+// truncada quando não cabe na linha.
+func formatTable(table Table) []string {
+	widths := make([]int, len(table.Headers))
+	for i, header := range table.Headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	for _, row := range table.Rows {
+		for i := 1; i < len(widths) && i < len(row); i++ {
+			widths[i] = max(widths[i], utf8.RuneCountInString(row[i]))
+		}
+	}
+	fixed := 0
+	for _, width := range widths[1:] {
+		fixed += width + 2
+	}
+	widths[0] = max(tableLineChars-fixed, 8)
+
+	format := func(cells []string) string {
+		var b strings.Builder
+		for i, width := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			if i == 0 {
+				b.WriteString(padRight(truncate(cell, width), width))
+				continue
+			}
+			b.WriteString("  ")
+			b.WriteString(padLeft(cell, width))
+		}
+		return b.String()
+	}
+
+	rows := []string{format(table.Headers)}
+	for _, row := range table.Rows {
+		rows = append(rows, format(row))
+	}
+	return rows
+}
+
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "."
+}
+
+func padRight(text string, width int) string {
+	return text + strings.Repeat(" ", max(width-utf8.RuneCountInString(text), 0))
+}
+
+func padLeft(text string, width int) string {
+	return strings.Repeat(" ", max(width-utf8.RuneCountInString(text), 0)) + text
+}
+
+// paginate distribui as linhas em páginas e gera o content stream de cada uma
+func paginate(lines []pdfLine) [][]byte {
+	var pages [][]byte
+	var content bytes.Buffer
+	y := float64(pageHeight - marginTop)
+
+	for _, line := range lines {
+		height := line.size*1.4 + line.gap
+		if y-height < marginBottom && content.Len() > 0 {
+			pages = append(pages, content.Bytes())
+			content = bytes.Buffer{}
+			y = float64(pageHeight - marginTop)
+			height = line.size * 1.4
+		}
+		y -= height
+		fmt.Fprintf(&content, "BT /%s %.1f Tf %d %.1f Td (%s) Tj ET\n", line.font, line.size, marginLeft, y, escapePDFText(line.text))
+	}
+	return append(pages, content.Bytes())
+}
+
+// escapePDFText converte o texto para WinAnsi e escapa os caracteres especiais de strings PDF
+func escapePDFText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			// Latin-1 coincide com WinAnsi nesta faixa; escrito em octal para manter o byte único
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// writePDF monta o arquivo: catálogo, árvore de páginas, fontes, páginas e tabela xref
+func writePDF(pages [][]byte) []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	addObject := func(body string) int {
+		offsets = append(offsets, out.Len())
+		id := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", id, body)
+		return id
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Os ids 1 a 5 são fixos; cada página ocupa dois objetos (página e conteúdo) a partir do 6
+	pageIDs := make([]string, len(pages))
+	for i := range pages {
+		pageIDs[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	addObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageIDs, " "), len(pages)))
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range pages {
+		contentID := 7 + 2*i
+		addObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s 3 0 R /%s 4 0 R /%s 5 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, fontMono, contentID))
+		addObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
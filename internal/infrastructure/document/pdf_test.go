@@ -0,0 +1,59 @@
+package document
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestEscapePDFText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"plain ascii", "Pastilha de freio", "Pastilha de freio"},
+		{"parentheses", "Filtro (ar)", `Filtro \(ar\)`},
+		{"backslash", `C:\oficina`, `C:\\oficina`},
+		{"latin-1 accents", "Revisão", `Revis\343o`},
+		{"outside winansi", "Total €", "Total ?"},
+		{"control characters", "linha\nquebrada", "linha?quebrada"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			escaped := escapePDFText(tt.text)
+
+			// Assert
+			if escaped != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, escaped)
+			}
+		})
+	}
+}
+
+func TestRenderPDF_EscapesCustomerFields(t *testing.T) {
+	// Arrange
+	// Um nome que fecha a string PDF não pode injetar operadores na página
+	doc := Document{
+		Title:    "Recibo",
+		Number:   "1",
+		IssuedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		Sections: []Section{{Title: "Cliente", Fields: []Field{{Label: "Nome", Value: "Maria) Tj (Souza"}}}},
+	}
+
+	// Act
+	content, err := RenderPDF(doc)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Contains(content, []byte(`Maria\) Tj \(Souza`)) {
+		t.Error("Expected parentheses in the customer name to be escaped")
+	}
+	if bytes.Contains(content, []byte("Maria) Tj (Souza")) {
+		t.Error("Expected no unescaped customer text in the page content")
+	}
+}
@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/mail"
+	"go.uber.org/zap"
+)
+
+// LogMailer não envia nada: registra o e-mail no log e, com um diretório configurado, grava
+// cada mensagem em um arquivo .eml. Serve para desenvolvimento local.
+type LogMailer struct {
+	outputDir string
+	from      string
+	logger    *zap.Logger
+}
+
+func NewLogMailer(outputDir string, from string, logger *zap.Logger) *LogMailer {
+	return &LogMailer{
+		outputDir: outputDir,
+		from:      from,
+		logger:    logger,
+	}
+}
+
+func (m *LogMailer) Send(message domain.Message) error {
+	m.logger.Info("E-mail not sent, logging instead",
+		zap.String("to", message.To),
+		zap.String("subject", message.Subject),
+		zap.String("body", message.Body))
+
+	if m.outputDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(m.outputDir, 0o755); err != nil {
+		m.logger.Error("Error creating mail output directory", zap.Error(err))
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405"), uuid.New().String())
+	if err := os.WriteFile(filepath.Join(m.outputDir, name), formatMessage(m.from, message), 0o600); err != nil {
+		m.logger.Error("Error writing e-mail file", zap.Error(err))
+		return err
+	}
+	return nil
+}
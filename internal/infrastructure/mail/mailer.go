@@ -0,0 +1,32 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/mail"
+	"go.uber.org/zap"
+)
+
+// NewMailerFromEnv escolhe a implementação por MAILER: "smtp" usa SMTP_HOST, SMTP_PORT,
+// SMTP_USERNAME e SMTP_PASSWORD; qualquer outro valor usa o LogMailer, gravando em MAIL_OUTPUT_DIR
+func NewMailerFromEnv(logger *zap.Logger) (domain.Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	if os.Getenv("MAILER") != "smtp" {
+		return NewLogMailer(os.Getenv("MAIL_OUTPUT_DIR"), from, logger), nil
+	}
+
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil, fmt.Errorf("SMTP_HOST is required when MAILER is smtp")
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return NewSMTPMailer(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from, logger), nil
+}
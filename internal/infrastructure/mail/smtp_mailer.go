@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/mail"
+	"go.uber.org/zap"
+)
+
+// SMTPMailer envia e-mails por um servidor SMTP, com STARTTLS quando o servidor oferece
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	logger   *zap.Logger
+}
+
+func NewSMTPMailer(host string, port string, username string, password string, from string, logger *zap.Logger) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		logger:   logger,
+	}
+}
+
+func (m *SMTPMailer) Send(message domain.Message) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if err := smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, m.from, []string{message.To}, formatMessage(m.from, message)); err != nil {
+		m.logger.Error("Error sending e-mail", zap.Error(err), zap.String("to", message.To))
+		return err
+	}
+
+	m.logger.Info("E-mail sent", zap.String("to", message.To), zap.String("subject", message.Subject))
+	return nil
+}
+
+// formatMessage monta o e-mail no formato RFC 5322, com os cabeçalhos sem quebras de linha
+func formatMessage(from string, message domain.Message) []byte {
+	clean := strings.NewReplacer("\r", "", "\n", "")
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "From: %s\r\n", clean.Replace(from))
+	fmt.Fprintf(&builder, "To: %s\r\n", clean.Replace(message.To))
+	fmt.Fprintf(&builder, "Subject: %s\r\n", clean.Replace(message.Subject))
+	fmt.Fprintf(&builder, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(builder.String())
+}
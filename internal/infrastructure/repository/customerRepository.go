@@ -10,11 +10,27 @@ import (
 type CustomerRepository interface {
 	Create(customer *models.Customer) error
 	FindByID(id uuid.UUID) (*models.Customer, error)
-	FindAll() ([]models.Customer, error)
+	FindAll(spec QuerySpec) (*Page[models.Customer], error)
 	Update(customer *models.Customer) error
 	Delete(id uuid.UUID) error
 }
 
+// customerQueryFields define os campos aceitos na listagem de customers
+var customerQueryFields = QueryFields{
+	Table: "customers",
+	Sortable: map[string]string{
+		"name":       "name",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Filterable: map[string]string{
+		"name":            "name",
+		"document_number": "document_number",
+		"customer_type":   "customer_type",
+	},
+	DefaultSort: "created_at",
+}
+
 // CustomerRepositoryAdapter implementa CustomerRepository usando GORM
 type CustomerRepositoryAdapter struct {
 	db *gorm.DB
@@ -43,14 +59,10 @@ func (c *CustomerRepositoryAdapter) FindByID(id uuid.UUID) (*models.Customer, er
 	return &customer, nil
 }
 
-// FindAll implementa a busca de todos os customers
-func (c *CustomerRepositoryAdapter) FindAll() ([]models.Customer, error) {
-	var customers []models.Customer
-	result := c.db.Find(&customers)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	return customers, nil
+// FindAll implementa a listagem paginada de customers
+func (c *CustomerRepositoryAdapter) FindAll(spec QuerySpec) (*Page[models.Customer], error) {
+	return findPage(c.db.Model(&models.Customer{}), spec, customerQueryFields,
+		func(customer *models.Customer) uuid.UUID { return customer.ID })
 }
 
 // Update implementa a atualização de um customer
@@ -1,19 +1,58 @@
 package repository
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrInsufficientQuantity indica que o estoque não comporta a baixa solicitada
+var ErrInsufficientQuantity = errors.New("insufficient quantity")
+
+// ErrInsufficientReservation indica que não há quantidade reservada suficiente para liberar ou consumir
+var ErrInsufficientReservation = errors.New("insufficient reserved quantity")
+
 // InputRepository define a interface para operações de input no banco
 type InputRepository interface {
 	Create(input *models.Input) error
 	FindByID(id uuid.UUID) (*models.Input, error)
-	FindAll() ([]models.Input, error)
+	// FindByIDForUpdate busca o input bloqueando a linha até o fim da transação, para que o saldo
+	// lido não seja alterado por reservas e baixas concorrentes antes da gravação
+	FindByIDForUpdate(id uuid.UUID) (*models.Input, error)
+	FindAll(spec QuerySpec) (*Page[models.Input], error)
 	FindByName(name string) (*models.Input, error)
 	Update(input *models.Input) error
 	Delete(id uuid.UUID) error
+	// DecreaseQuantity baixa o estoque de forma atômica, sem permitir quantidade negativa
+	DecreaseQuantity(id uuid.UUID, quantity int) (*models.Input, error)
+	// IncreaseQuantity repõe o estoque de forma atômica
+	IncreaseQuantity(id uuid.UUID, quantity int) (*models.Input, error)
+	// ReserveQuantity reserva parte do saldo disponível de forma atômica
+	ReserveQuantity(id uuid.UUID, quantity int) (*models.Input, error)
+	// ReleaseReservation libera uma reserva sem alterar o saldo físico
+	ReleaseReservation(id uuid.UUID, quantity int) (*models.Input, error)
+	// ConsumeReservation converte uma reserva em baixa do saldo físico
+	ConsumeReservation(id uuid.UUID, quantity int) (*models.Input, error)
+}
+
+// inputQueryFields define os campos aceitos na listagem de inputs
+var inputQueryFields = QueryFields{
+	Table: "inputs",
+	Sortable: map[string]string{
+		"name":       "name",
+		"price":      "price",
+		"quantity":   "quantity",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Filterable: map[string]string{
+		"name":       "name",
+		"input_type": "input_type",
+	},
+	DefaultSort: "created_at",
 }
 
 // InputRepositoryAdapter implementa InputRepository usando GORM
@@ -44,14 +83,20 @@ func (i *InputRepositoryAdapter) FindByID(id uuid.UUID) (*models.Input, error) {
 	return &input, nil
 }
 
-// FindAll implementa a busca de todos os inputs
-func (i *InputRepositoryAdapter) FindAll() ([]models.Input, error) {
-	var inputs []models.Input
-	result := i.db.Find(&inputs)
+// FindByIDForUpdate implementa a busca de input com SELECT ... FOR UPDATE
+func (i *InputRepositoryAdapter) FindByIDForUpdate(id uuid.UUID) (*models.Input, error) {
+	var input models.Input
+	result := i.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&input)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	return inputs, nil
+	return &input, nil
+}
+
+// FindAll implementa a listagem paginada de inputs
+func (i *InputRepositoryAdapter) FindAll(spec QuerySpec) (*Page[models.Input], error) {
+	return findPage(i.db.Model(&models.Input{}), spec, inputQueryFields,
+		func(input *models.Input) uuid.UUID { return input.ID })
 }
 
 // FindByName implementa a busca de input por nome
@@ -64,10 +109,22 @@ func (i *InputRepositoryAdapter) FindByName(name string) (*models.Input, error)
 	return &input, nil
 }
 
-// Update implementa a atualização de um input
+// Update implementa a atualização de um input. Todos os campos são gravados, inclusive valores
+// zero como quantidade 0, e o modelo recebe a linha gravada. A quantidade reservada é omitida
+// para não sobrescrever reservas feitas concorrentemente pelas operações atômicas.
 func (i *InputRepositoryAdapter) Update(input *models.Input) error {
-	result := i.db.Model(input).Updates(input)
-	return result.Error
+	result := i.db.Model(input).
+		Clauses(clause.Returning{}).
+		Select("*").
+		Omit("id", "reserved_quantity", "created_at").
+		Updates(input)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 // Delete implementa a exclusão de um input
@@ -75,3 +132,88 @@ func (i *InputRepositoryAdapter) Delete(id uuid.UUID) error {
 	result := i.db.Where("id = ?", id).Delete(&models.Input{})
 	return result.Error
 }
+
+// DecreaseQuantity implementa a baixa atômica de estoque. O UPDATE condicional é avaliado
+// sob o lock da linha, então baixas concorrentes nunca deixam a quantidade negativa nem
+// consomem a parte do saldo reservada para outras orders.
+func (i *InputRepositoryAdapter) DecreaseQuantity(id uuid.UUID, quantity int) (*models.Input, error) {
+	var input models.Input
+	result := i.db.Model(&input).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND quantity - reserved_quantity >= ?", id, quantity).
+		Update("quantity", gorm.Expr("quantity - ?", quantity))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInsufficientQuantity
+	}
+	return &input, nil
+}
+
+// IncreaseQuantity implementa a reposição atômica de estoque
+func (i *InputRepositoryAdapter) IncreaseQuantity(id uuid.UUID, quantity int) (*models.Input, error) {
+	var input models.Input
+	result := i.db.Model(&input).
+		Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Update("quantity", gorm.Expr("quantity + ?", quantity))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &input, nil
+}
+
+// ReserveQuantity implementa a reserva atômica de estoque, limitada ao saldo disponível
+func (i *InputRepositoryAdapter) ReserveQuantity(id uuid.UUID, quantity int) (*models.Input, error) {
+	var input models.Input
+	result := i.db.Model(&input).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND quantity - reserved_quantity >= ?", id, quantity).
+		Update("reserved_quantity", gorm.Expr("reserved_quantity + ?", quantity))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInsufficientQuantity
+	}
+	return &input, nil
+}
+
+// ReleaseReservation implementa a liberação atômica de uma reserva
+func (i *InputRepositoryAdapter) ReleaseReservation(id uuid.UUID, quantity int) (*models.Input, error) {
+	var input models.Input
+	result := i.db.Model(&input).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND reserved_quantity >= ?", id, quantity).
+		Update("reserved_quantity", gorm.Expr("reserved_quantity - ?", quantity))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInsufficientReservation
+	}
+	return &input, nil
+}
+
+// ConsumeReservation implementa a conversão atômica de uma reserva em baixa de estoque
+func (i *InputRepositoryAdapter) ConsumeReservation(id uuid.UUID, quantity int) (*models.Input, error) {
+	var input models.Input
+	result := i.db.Model(&input).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND reserved_quantity >= ? AND quantity >= ?", id, quantity, quantity).
+		Updates(map[string]interface{}{
+			"quantity":          gorm.Expr("quantity - ?", quantity),
+			"reserved_quantity": gorm.Expr("reserved_quantity - ?", quantity),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInsufficientReservation
+	}
+	return &input, nil
+}
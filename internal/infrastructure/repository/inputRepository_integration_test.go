@@ -0,0 +1,371 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	inputDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/input"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openIntegrationDB conecta no Postgres do docker compose usando as mesmas variáveis da aplicação
+func openIntegrationDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dbHost := os.Getenv("DATABASE_HOST")
+	dbUser := os.Getenv("DATABASE_USER")
+	dbPassword := os.Getenv("DATABASE_PASSWORD")
+	dbName := os.Getenv("DATABASE_NAME")
+	dbPort := os.Getenv("DATABASE_PORT")
+	if dbHost == "" || dbUser == "" || dbPassword == "" || dbName == "" || dbPort == "" {
+		t.Skip("DATABASE_* environment variables not set, skipping integration test")
+	}
+
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+		dbHost, dbUser, dbPassword, dbName, dbPort)
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&models.Input{}, &models.InventoryMovement{}); err != nil {
+		t.Fatalf("Failed to migrate inputs: %v", err)
+	}
+
+	return db
+}
+
+func TestInputRepository_DecreaseQuantity_ConcurrentDecreasesNeverGoNegative(t *testing.T) {
+	// Arrange
+	db := openIntegrationDB(t)
+
+	const initialQuantity = 10
+	const workers = 50
+
+	stock := &models.Input{
+		ID:        uuid.New(),
+		Name:      "Concurrent stock " + uuid.NewString(),
+		Price:     money.MustParse("10.00"),
+		Quantity:  initialQuantity,
+		InputType: "material",
+	}
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("Failed to create input: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("input_id = ?", stock.ID).Delete(&models.InventoryMovement{})
+		db.Where("id = ?", stock.ID).Delete(&models.Input{})
+	})
+
+	loggerMock := &mocks.LoggerMock{}
+	useCase := &input.DecreaseQuantityInput{
+		InputRepository: repository.NewInputRepositoryAdapter(db),
+		Logger:          loggerMock,
+		UnitOfWork:      repository.NewGormUnitOfWork(db),
+
+		RecordInventoryMovement: &input.RecordInventoryMovement{
+			InventoryMovementRepository: repository.NewInventoryMovementRepositoryAdapter(db),
+			Logger:                      loggerMock,
+		},
+	}
+	movement := inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment}
+
+	// Act
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	start := make(chan struct{})
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := useCase.Process(stock.ID, 1, movement); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// Assert
+	var final models.Input
+	if err := db.Where("id = ?", stock.ID).First(&final).Error; err != nil {
+		t.Fatalf("Failed to reload input: %v", err)
+	}
+
+	if final.Quantity < 0 {
+		t.Fatalf("Expected stock never to go negative, got %d", final.Quantity)
+	}
+	if succeeded != initialQuantity {
+		t.Errorf("Expected exactly %d successful decreases, got %d", initialQuantity, succeeded)
+	}
+	if final.Quantity != initialQuantity-succeeded {
+		t.Errorf("Expected final quantity %d, got %d", initialQuantity-succeeded, final.Quantity)
+	}
+
+	// Cada baixa bem-sucedida gera exatamente uma movimentação no ledger
+	var movements int64
+	db.Model(&models.InventoryMovement{}).Where("input_id = ?", stock.ID).Count(&movements)
+	if int(movements) != succeeded {
+		t.Errorf("Expected %d inventory movements, got %d", succeeded, movements)
+	}
+}
+
+func TestInputRepository_ReserveQuantity_ConcurrentReservationsNeverExceedAvailable(t *testing.T) {
+	// Arrange
+	db := openIntegrationDB(t)
+
+	const initialQuantity = 10
+	const alreadyReserved = 4
+	const workers = 30
+
+	stock := &models.Input{
+		ID:               uuid.New(),
+		Name:             "Concurrent reservation " + uuid.NewString(),
+		Price:            money.MustParse("10.00"),
+		Quantity:         initialQuantity,
+		ReservedQuantity: alreadyReserved,
+		InputType:        "material",
+	}
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("Failed to create input: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("id = ?", stock.ID).Delete(&models.Input{})
+	})
+
+	repo := repository.NewInputRepositoryAdapter(db)
+
+	// Act
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	start := make(chan struct{})
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if _, err := repo.ReserveQuantity(stock.ID, 1); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// Assert
+	var final models.Input
+	if err := db.Where("id = ?", stock.ID).First(&final).Error; err != nil {
+		t.Fatalf("Failed to reload input: %v", err)
+	}
+
+	if succeeded != initialQuantity-alreadyReserved {
+		t.Errorf("Expected exactly %d successful reservations, got %d", initialQuantity-alreadyReserved, succeeded)
+	}
+	if final.ReservedQuantity != initialQuantity {
+		t.Errorf("Expected reserved quantity %d, got %d", initialQuantity, final.ReservedQuantity)
+	}
+	if final.Quantity != initialQuantity {
+		t.Errorf("Expected on-hand quantity to stay %d, got %d", initialQuantity, final.Quantity)
+	}
+}
+
+func TestInputRepository_Price_ExistingDecimalValuesRoundTripExactly(t *testing.T) {
+	// Arrange
+	db := openIntegrationDB(t)
+	repo := repository.NewInputRepositoryAdapter(db)
+
+	// Valores gravados direto no banco, como os dados existentes antes do tipo Money.
+	// Vários deles não têm representação exata em float64.
+	prices := []string{"0.01", "0.10", "0.29", "1.15", "19.99", "1234.56", "99999999.99"}
+	inputType := "money-" + uuid.NewString()
+	ids := make([]uuid.UUID, len(prices))
+	for i, price := range prices {
+		ids[i] = uuid.New()
+		err := db.Exec(
+			"INSERT INTO inputs (id, name, description, price, quantity, input_type) VALUES (?, ?, '', ?::numeric, 1, ?)",
+			ids[i], fmt.Sprintf("Money %s %d", inputType, i), price, inputType).Error
+		if err != nil {
+			t.Fatalf("Failed to insert input: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Where("input_type = ?", inputType).Delete(&models.Input{})
+	})
+
+	for i, price := range prices {
+		// Act
+		loaded, err := repo.FindByID(ids[i])
+		if err != nil {
+			t.Fatalf("Failed to load input: %v", err)
+		}
+		if loaded.Price.String() != price {
+			t.Errorf("Expected loaded price %s, got %s", price, loaded.Price)
+		}
+
+		// Regrava sem alterar e confere o texto da coluna no banco
+		if err := repo.Update(loaded); err != nil {
+			t.Fatalf("Failed to update input: %v", err)
+		}
+		var stored string
+		if err := db.Raw("SELECT price::text FROM inputs WHERE id = ?", ids[i]).Scan(&stored).Error; err != nil {
+			t.Fatalf("Failed to read stored price: %v", err)
+		}
+
+		// Assert
+		if stored != price {
+			t.Errorf("Expected stored price %s after round trip, got %s", price, stored)
+		}
+	}
+
+	// A soma feita em Money bate com a soma feita pelo banco
+	var dbTotal string
+	if err := db.Raw("SELECT SUM(price)::text FROM inputs WHERE input_type = ?", inputType).Scan(&dbTotal).Error; err != nil {
+		t.Fatalf("Failed to sum prices: %v", err)
+	}
+	total := money.Zero
+	for _, price := range prices {
+		total = total.Add(money.MustParse(price))
+	}
+	if total.String() != dbTotal {
+		t.Errorf("Expected total %s to match database sum, got %s", dbTotal, total)
+	}
+}
+
+func TestInputRepository_Update_ZeroQuantityUpdatesStockAndLedger(t *testing.T) {
+	// Arrange
+	db := openIntegrationDB(t)
+
+	stock := &models.Input{
+		ID:        uuid.New(),
+		Name:      "Zero stock " + uuid.NewString(),
+		Price:     money.MustParse("10.00"),
+		Quantity:  7,
+		InputType: "material",
+	}
+	if err := db.Create(stock).Error; err != nil {
+		t.Fatalf("Failed to create input: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("input_id = ?", stock.ID).Delete(&models.InventoryMovement{})
+		db.Where("id = ?", stock.ID).Delete(&models.Input{})
+	})
+
+	loggerMock := &mocks.LoggerMock{}
+	useCase := &input.UpdateByIdInput{
+		InputRepository: repository.NewInputRepositoryAdapter(db),
+		Logger:          loggerMock,
+		UnitOfWork:      repository.NewGormUnitOfWork(db),
+
+		RecordInventoryMovement: &input.RecordInventoryMovement{
+			InventoryMovementRepository: repository.NewInventoryMovementRepositoryAdapter(db),
+			Logger:                      loggerMock,
+		},
+	}
+
+	// Act
+	err := useCase.Process(stock.ID, &inputDomain.Input{Name: stock.Name, Price: stock.Price, Quantity: 0, InputType: "material"}, uuid.New())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var final models.Input
+	if err := db.Where("id = ?", stock.ID).First(&final).Error; err != nil {
+		t.Fatalf("Failed to reload input: %v", err)
+	}
+	if final.Quantity != 0 {
+		t.Errorf("Expected quantity 0 to be written, got %d", final.Quantity)
+	}
+	var movement models.InventoryMovement
+	if err := db.Where("input_id = ?", stock.ID).First(&movement).Error; err != nil {
+		t.Fatalf("Failed to load movement: %v", err)
+	}
+	if movement.Delta != -7 || movement.BalanceAfter != final.Quantity {
+		t.Errorf("Expected ledger to match the stock, got delta %d balance %d", movement.Delta, movement.BalanceAfter)
+	}
+}
+
+func TestInputRepository_FindAll_CursorPagesCoverEveryRow(t *testing.T) {
+	// Arrange
+	db := openIntegrationDB(t)
+	repo := repository.NewInputRepositoryAdapter(db)
+
+	const rows = 5
+	inputType := "paging-" + uuid.NewString()
+	for i := 0; i < rows; i++ {
+		item := &models.Input{
+			ID:        uuid.New(),
+			Name:      fmt.Sprintf("Paging %s %d", inputType, i),
+			Price:     money.FromCents(int64(i%2+1) * 100),
+			Quantity:  1,
+			InputType: inputType,
+		}
+		if err := db.Create(item).Error; err != nil {
+			t.Fatalf("Failed to create input: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Where("input_type = ?", inputType).Delete(&models.Input{})
+	})
+
+	// Act
+	// Ordena por preço, que se repete entre os registros, para exercitar o desempate pelo id
+	spec := repository.QuerySpec{
+		Limit:   2,
+		SortBy:  "price",
+		Filters: map[string]string{"input_type": inputType},
+	}
+	seen := map[uuid.UUID]bool{}
+	pages := 0
+	for {
+		page, err := repo.FindAll(spec)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		pages++
+		if page.Total != rows {
+			t.Errorf("Expected total %d, got %d", rows, page.Total)
+		}
+		for _, item := range page.Items {
+			if seen[item.ID] {
+				t.Errorf("Input %s returned in more than one page", item.ID)
+			}
+			seen[item.ID] = true
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		spec.Cursor = page.NextCursor
+	}
+
+	// Assert
+	if len(seen) != rows {
+		t.Errorf("Expected %d inputs across pages, got %d", rows, len(seen))
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+}
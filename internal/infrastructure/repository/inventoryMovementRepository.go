@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// InventoryMovementRepository define a interface para operações de inventory_movements no banco
+type InventoryMovementRepository interface {
+	Create(movement *models.InventoryMovement) error
+	FindByInputID(inputID uuid.UUID) ([]models.InventoryMovement, error)
+}
+
+// InventoryMovementRepositoryAdapter implementa InventoryMovementRepository usando GORM
+type InventoryMovementRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewInventoryMovementRepositoryAdapter cria uma nova instância do adaptador
+func NewInventoryMovementRepositoryAdapter(db *gorm.DB) InventoryMovementRepository {
+	return &InventoryMovementRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa a criação de uma movimentação de estoque
+func (im *InventoryMovementRepositoryAdapter) Create(movement *models.InventoryMovement) error {
+	result := im.db.Create(movement)
+	return result.Error
+}
+
+// FindByInputID implementa a busca das movimentações de um input, da mais recente para a mais antiga
+func (im *InventoryMovementRepositoryAdapter) FindByInputID(inputID uuid.UUID) ([]models.InventoryMovement, error) {
+	var movements []models.InventoryMovement
+	result := im.db.Where("input_id = ?", inputID).Order("created_at DESC").Find(&movements)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return movements, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InvoiceRepository define a interface para operações de notas e notas de crédito no banco.
+// Os documentos são imutáveis: não existem operações de update ou delete.
+type InvoiceRepository interface {
+	// Create grava a nota junto com suas linhas
+	Create(invoice *models.Invoice) error
+	FindByID(id uuid.UUID) (*models.Invoice, error)
+	// FindByOrderID retorna a nota da order, ou nil quando a order ainda não foi faturada
+	FindByOrderID(orderID uuid.UUID) (*models.Invoice, error)
+	CreateCreditNote(creditNote *models.CreditNote) error
+	// NextNumber reserva o próximo número do tipo de documento da oficina. Deve ser chamado
+	// dentro da transação que grava o documento: a linha da sequência fica bloqueada até o commit
+	// e um rollback devolve o número, mantendo a numeração sem lacunas.
+	NextNumber(workshopCode string, documentType string) (int64, error)
+}
+
+// InvoiceRepositoryAdapter implementa InvoiceRepository usando GORM
+type InvoiceRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepositoryAdapter cria uma nova instância do adaptador
+func NewInvoiceRepositoryAdapter(db *gorm.DB) InvoiceRepository {
+	return &InvoiceRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa a criação da nota. O GORM grava as linhas associadas na mesma operação.
+func (i *InvoiceRepositoryAdapter) Create(invoice *models.Invoice) error {
+	result := i.db.Omit("CreditNotes").Create(invoice)
+	return result.Error
+}
+
+// FindByID implementa a busca de nota por ID
+func (i *InvoiceRepositoryAdapter) FindByID(id uuid.UUID) (*models.Invoice, error) {
+	var invoice models.Invoice
+	result := i.withDetails().Where("id = ?", id).First(&invoice)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &invoice, nil
+}
+
+// FindByOrderID implementa a busca da nota de uma order
+func (i *InvoiceRepositoryAdapter) FindByOrderID(orderID uuid.UUID) (*models.Invoice, error) {
+	var invoice models.Invoice
+	result := i.withDetails().Where("order_id = ?", orderID).First(&invoice)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &invoice, nil
+}
+
+// CreateCreditNote implementa a criação de uma nota de crédito
+func (i *InvoiceRepositoryAdapter) CreateCreditNote(creditNote *models.CreditNote) error {
+	result := i.db.Create(creditNote)
+	return result.Error
+}
+
+// NextNumber implementa a reserva do próximo número. O UPDATE bloqueia a linha da sequência,
+// serializando emissões concorrentes da mesma oficina e tipo de documento.
+func (i *InvoiceRepositoryAdapter) NextNumber(workshopCode string, documentType string) (int64, error) {
+	sequence := models.DocumentSequence{WorkshopCode: workshopCode, DocumentType: documentType}
+	if err := i.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+		return 0, err
+	}
+
+	var number int64
+	result := i.db.Raw(
+		"UPDATE document_sequences SET last_number = last_number + 1 WHERE workshop_code = ? AND document_type = ? RETURNING last_number",
+		workshopCode, documentType).Scan(&number)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return number, nil
+}
+
+// withDetails carrega as linhas em uma ordem estável e as notas de crédito pela numeração
+func (i *InvoiceRepositoryAdapter) withDetails() *gorm.DB {
+	return i.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("item_type ASC").Order("description ASC")
+		}).
+		Preload("CreditNotes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number ASC")
+		})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// LaborServiceRepository define a interface para operações do catálogo de serviços no banco
+type LaborServiceRepository interface {
+	Create(laborService *models.LaborService) error
+	FindByID(id uuid.UUID) (*models.LaborService, error)
+	FindByName(name string) (*models.LaborService, error)
+	FindAll(spec QuerySpec) (*Page[models.LaborService], error)
+	Update(laborService *models.LaborService) error
+	Delete(id uuid.UUID) error
+}
+
+// laborServiceQueryFields define os campos aceitos na listagem do catálogo de serviços
+var laborServiceQueryFields = QueryFields{
+	Table: "labor_services",
+	Sortable: map[string]string{
+		"name":        "name",
+		"hourly_rate": "hourly_rate",
+		"created_at":  "created_at",
+		"updated_at":  "updated_at",
+	},
+	Filterable: map[string]string{
+		"name": "name",
+	},
+	DefaultSort: "name",
+}
+
+// LaborServiceRepositoryAdapter implementa LaborServiceRepository usando GORM
+type LaborServiceRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewLaborServiceRepositoryAdapter cria uma nova instância do adaptador
+func NewLaborServiceRepositoryAdapter(db *gorm.DB) LaborServiceRepository {
+	return &LaborServiceRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa a criação de um serviço do catálogo
+func (l *LaborServiceRepositoryAdapter) Create(laborService *models.LaborService) error {
+	result := l.db.Create(laborService)
+	return result.Error
+}
+
+// FindByID implementa a busca de serviço do catálogo por ID
+func (l *LaborServiceRepositoryAdapter) FindByID(id uuid.UUID) (*models.LaborService, error) {
+	var laborService models.LaborService
+	result := l.db.Where("id = ?", id).First(&laborService)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &laborService, nil
+}
+
+// FindByName implementa a busca de serviço do catálogo por nome
+func (l *LaborServiceRepositoryAdapter) FindByName(name string) (*models.LaborService, error) {
+	var laborService models.LaborService
+	result := l.db.Where("name = ?", name).First(&laborService)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &laborService, nil
+}
+
+// FindAll implementa a listagem paginada do catálogo de serviços
+func (l *LaborServiceRepositoryAdapter) FindAll(spec QuerySpec) (*Page[models.LaborService], error) {
+	return findPage(l.db.Model(&models.LaborService{}), spec, laborServiceQueryFields,
+		func(laborService *models.LaborService) uuid.UUID { return laborService.ID })
+}
+
+// Update implementa a atualização de um serviço do catálogo
+func (l *LaborServiceRepositoryAdapter) Update(laborService *models.LaborService) error {
+	result := l.db.Model(laborService).Updates(laborService)
+	return result.Error
+}
+
+// Delete implementa a remoção de um serviço do catálogo
+func (l *LaborServiceRepositoryAdapter) Delete(id uuid.UUID) error {
+	result := l.db.Where("id = ?", id).Delete(&models.LaborService{})
+	return result.Error
+}
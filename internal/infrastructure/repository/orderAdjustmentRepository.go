@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// OrderAdjustmentRepository define a interface para operações dos descontos e acréscimos da order no banco
+type OrderAdjustmentRepository interface {
+	Create(adjustment *models.OrderAdjustment) error
+	FindByID(id uuid.UUID) (*models.OrderAdjustment, error)
+	FindByOrderID(orderID uuid.UUID) ([]models.OrderAdjustment, error)
+	Update(adjustment *models.OrderAdjustment) error
+	Delete(id uuid.UUID) error
+}
+
+// OrderAdjustmentRepositoryAdapter implementa OrderAdjustmentRepository usando GORM
+type OrderAdjustmentRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewOrderAdjustmentRepositoryAdapter cria uma nova instância do adaptador
+func NewOrderAdjustmentRepositoryAdapter(db *gorm.DB) OrderAdjustmentRepository {
+	return &OrderAdjustmentRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa a criação de um ajuste
+func (oa *OrderAdjustmentRepositoryAdapter) Create(adjustment *models.OrderAdjustment) error {
+	result := oa.db.Create(adjustment)
+	return result.Error
+}
+
+// FindByID implementa a busca de ajuste por ID
+func (oa *OrderAdjustmentRepositoryAdapter) FindByID(id uuid.UUID) (*models.OrderAdjustment, error) {
+	var adjustment models.OrderAdjustment
+	result := oa.db.Where("id = ?", id).First(&adjustment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &adjustment, nil
+}
+
+// FindByOrderID implementa a busca dos ajustes de uma order, na ordem em que foram criados
+func (oa *OrderAdjustmentRepositoryAdapter) FindByOrderID(orderID uuid.UUID) ([]models.OrderAdjustment, error) {
+	var adjustments []models.OrderAdjustment
+	result := oa.db.Where("order_id = ?", orderID).Order("created_at ASC").Find(&adjustments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return adjustments, nil
+}
+
+// Update implementa a atualização de um ajuste
+func (oa *OrderAdjustmentRepositoryAdapter) Update(adjustment *models.OrderAdjustment) error {
+	result := oa.db.Model(adjustment).Updates(adjustment)
+	return result.Error
+}
+
+// Delete implementa a remoção de um ajuste
+func (oa *OrderAdjustmentRepositoryAdapter) Delete(id uuid.UUID) error {
+	result := oa.db.Where("id = ?", id).Delete(&models.OrderAdjustment{})
+	return result.Error
+}
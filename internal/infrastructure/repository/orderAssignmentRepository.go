@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// OrderAssignmentRepository define a interface para operações de designação de mecânicos no banco.
+// As designações não são removidas, apenas encerradas.
+type OrderAssignmentRepository interface {
+	Create(assignment *models.OrderAssignment) error
+	// FindByOrderID retorna o histórico de designações da order, da mais antiga para a mais recente
+	FindByOrderID(orderID uuid.UUID) ([]models.OrderAssignment, error)
+	// FindActive retorna a designação ativa do mecânico na order, ou nil quando não existe
+	FindActive(orderID uuid.UUID, mechanicID uuid.UUID) (*models.OrderAssignment, error)
+	Update(assignment *models.OrderAssignment) error
+}
+
+// OrderAssignmentRepositoryAdapter implementa OrderAssignmentRepository usando GORM
+type OrderAssignmentRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewOrderAssignmentRepositoryAdapter cria uma nova instância do adaptador
+func NewOrderAssignmentRepositoryAdapter(db *gorm.DB) OrderAssignmentRepository {
+	return &OrderAssignmentRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa a criação de uma designação
+func (oa *OrderAssignmentRepositoryAdapter) Create(assignment *models.OrderAssignment) error {
+	result := oa.db.Create(assignment)
+	return result.Error
+}
+
+// FindByOrderID implementa a busca do histórico de designações de uma order
+func (oa *OrderAssignmentRepositoryAdapter) FindByOrderID(orderID uuid.UUID) ([]models.OrderAssignment, error) {
+	var assignments []models.OrderAssignment
+	result := oa.db.Where("order_id = ?", orderID).Order("assigned_at ASC").Find(&assignments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return assignments, nil
+}
+
+// FindActive implementa a busca da designação ativa de um mecânico em uma order
+func (oa *OrderAssignmentRepositoryAdapter) FindActive(orderID uuid.UUID, mechanicID uuid.UUID) (*models.OrderAssignment, error) {
+	var assignment models.OrderAssignment
+	result := oa.db.Where("order_id = ? AND mechanic_id = ? AND unassigned_at IS NULL", orderID, mechanicID).First(&assignment)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &assignment, nil
+}
+
+// Update implementa a atualização de uma designação
+func (oa *OrderAssignmentRepositoryAdapter) Update(assignment *models.OrderAssignment) error {
+	result := oa.db.Save(assignment)
+	return result.Error
+}
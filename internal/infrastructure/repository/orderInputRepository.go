@@ -13,6 +13,8 @@ type OrderInputRepository interface {
 	FindByOrderID(orderID uuid.UUID) ([]models.OrderInput, error)
 	FindByOrderIDAndInputID(orderID uuid.UUID, inputID uuid.UUID) (*models.OrderInput, error)
 	Update(orderInput *models.OrderInput) error
+	// SetUsedOrDamaged grava a marcação de peça usada ou danificada, inclusive quando ela é removida
+	SetUsedOrDamaged(id uuid.UUID, usedOrDamaged bool) error
 	Delete(id uuid.UUID) error
 	DeleteByOrderIDAndInputID(orderID uuid.UUID, inputID uuid.UUID) error
 }
@@ -71,6 +73,12 @@ func (oi *OrderInputRepositoryAdapter) Update(orderInput *models.OrderInput) err
 	return result.Error
 }
 
+// SetUsedOrDamaged implementa a atualização da marcação de peça usada ou danificada
+func (oi *OrderInputRepositoryAdapter) SetUsedOrDamaged(id uuid.UUID, usedOrDamaged bool) error {
+	result := oi.db.Model(&models.OrderInput{}).Where("id = ?", id).Update("used_or_damaged", usedOrDamaged)
+	return result.Error
+}
+
 // Delete implementa a exclusão de um order_input
 func (oi *OrderInputRepositoryAdapter) Delete(id uuid.UUID) error {
 	result := oi.db.Where("id = ?", id).Delete(&models.OrderInput{})
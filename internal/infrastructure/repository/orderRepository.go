@@ -1,17 +1,51 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// OrderFilter define os filtros específicos da listagem de orders.
+// Campos vazios ou nil não restringem o resultado.
+type OrderFilter struct {
+	Status      string
+	CustomerID  *uuid.UUID
+	VehicleID   *uuid.UUID
+	NumberPlate string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// MechanicID restringe às orders em que o mecânico está designado no momento
+	MechanicID *uuid.UUID
+	// ExcludeStatuses remove da listagem as orders nos status informados
+	ExcludeStatuses []string
+}
+
+// orderQueryFields define os campos aceitos na listagem de orders. Os filtros de order
+// são tipados e ficam em OrderFilter.
+var orderQueryFields = QueryFields{
+	Table: "orders",
+	Sortable: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	DefaultSort: "created_at",
+}
+
 // OrderRepository define a interface para operações de order no banco
 type OrderRepository interface {
 	Create(order *models.Order) error
 	FindByID(id uuid.UUID) (*models.Order, error)
-	FindAll() ([]models.Order, error)
+	// FindByIDForUpdate busca a order bloqueando a linha até o fim da transação, serializando
+	// operações concorrentes sobre a mesma order
+	FindByIDForUpdate(id uuid.UUID) (*models.Order, error)
+	FindAll(filter OrderFilter, spec QuerySpec) (*Page[models.Order], error)
 	Update(order *models.Order) error
+	// SetApprovedQuote grava ou limpa (quoteID nil) a versão do orçamento aprovada
+	SetApprovedQuote(orderID uuid.UUID, quoteID *uuid.UUID) error
 	Delete(id uuid.UUID) error
 }
 
@@ -43,14 +77,49 @@ func (o *OrderRepositoryAdapter) FindByID(id uuid.UUID) (*models.Order, error) {
 	return &order, nil
 }
 
-// FindAll implementa a busca de todos os orders
-func (o *OrderRepositoryAdapter) FindAll() ([]models.Order, error) {
-	var orders []models.Order
-	result := o.db.Find(&orders)
+// FindByIDForUpdate implementa a busca de order com SELECT ... FOR UPDATE
+func (o *OrderRepositoryAdapter) FindByIDForUpdate(id uuid.UUID) (*models.Order, error) {
+	var order models.Order
+	result := o.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&order)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	return orders, nil
+	return &order, nil
+}
+
+// FindAll implementa a listagem filtrada e paginada de orders
+func (o *OrderRepositoryAdapter) FindAll(filter OrderFilter, spec QuerySpec) (*Page[models.Order], error) {
+	query := o.db.Model(&models.Order{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	if filter.VehicleID != nil {
+		query = query.Where("vehicle_id = ?", *filter.VehicleID)
+	}
+	if filter.NumberPlate != "" {
+		query = query.Where("vehicle_id IN (?)",
+			o.db.Model(&models.Vehicle{}).Select("id").Where("UPPER(number_plate) = UPPER(?)", filter.NumberPlate))
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	if filter.MechanicID != nil {
+		query = query.Where("id IN (?)",
+			o.db.Model(&models.OrderAssignment{}).Select("order_id").Where("mechanic_id = ? AND unassigned_at IS NULL", *filter.MechanicID))
+	}
+	if len(filter.ExcludeStatuses) > 0 {
+		query = query.Where("status NOT IN ?", filter.ExcludeStatuses)
+	}
+
+	return findPage(query, spec, orderQueryFields,
+		func(order *models.Order) uuid.UUID { return order.ID })
 }
 
 // Update implementa a atualização de um order
@@ -59,6 +128,13 @@ func (o *OrderRepositoryAdapter) Update(order *models.Order) error {
 	return result.Error
 }
 
+// SetApprovedQuote implementa a gravação da versão aprovada. Usa um update direto da coluna
+// porque Updates ignora campos nil e não conseguiria limpar a aprovação.
+func (o *OrderRepositoryAdapter) SetApprovedQuote(orderID uuid.UUID, quoteID *uuid.UUID) error {
+	result := o.db.Model(&models.Order{}).Where("id = ?", orderID).Update("approved_quote_id", quoteID)
+	return result.Error
+}
+
 // Delete implementa a exclusão de um order
 func (o *OrderRepositoryAdapter) Delete(id uuid.UUID) error {
 	result := o.db.Where("id = ?", id).Delete(&models.Order{})
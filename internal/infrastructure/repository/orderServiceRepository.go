@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// OrderServiceRepository define a interface para operações das linhas de mão de obra da order no banco
+type OrderServiceRepository interface {
+	Create(orderService *models.OrderService) error
+	FindByID(id uuid.UUID) (*models.OrderService, error)
+	FindByOrderID(orderID uuid.UUID) ([]models.OrderService, error)
+	Update(orderService *models.OrderService) error
+	Delete(id uuid.UUID) error
+}
+
+// OrderServiceRepositoryAdapter implementa OrderServiceRepository usando GORM
+type OrderServiceRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewOrderServiceRepositoryAdapter cria uma nova instância do adaptador
+func NewOrderServiceRepositoryAdapter(db *gorm.DB) OrderServiceRepository {
+	return &OrderServiceRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa a criação de uma linha de mão de obra
+func (os *OrderServiceRepositoryAdapter) Create(orderService *models.OrderService) error {
+	result := os.db.Create(orderService)
+	return result.Error
+}
+
+// FindByID implementa a busca de linha de mão de obra por ID
+func (os *OrderServiceRepositoryAdapter) FindByID(id uuid.UUID) (*models.OrderService, error) {
+	var orderService models.OrderService
+	result := os.db.Where("id = ?", id).First(&orderService)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &orderService, nil
+}
+
+// FindByOrderID implementa a busca das linhas de mão de obra de uma order
+func (os *OrderServiceRepositoryAdapter) FindByOrderID(orderID uuid.UUID) ([]models.OrderService, error) {
+	var orderServices []models.OrderService
+	result := os.db.Where("order_id = ?", orderID).Order("created_at ASC").Find(&orderServices)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return orderServices, nil
+}
+
+// Update implementa a atualização de uma linha de mão de obra
+func (os *OrderServiceRepositoryAdapter) Update(orderService *models.OrderService) error {
+	result := os.db.Model(orderService).Updates(orderService)
+	return result.Error
+}
+
+// Delete implementa a remoção de uma linha de mão de obra
+func (os *OrderServiceRepositoryAdapter) Delete(id uuid.UUID) error {
+	result := os.db.Where("id = ?", id).Delete(&models.OrderService{})
+	return result.Error
+}
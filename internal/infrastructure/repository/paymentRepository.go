@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// PaymentRepository define a interface para operações de pagamentos no banco.
+// Pagamentos registrados não são alterados nem removidos.
+type PaymentRepository interface {
+	Create(payment *models.Payment) error
+	// FindByOrderID retorna os pagamentos da order pela data de pagamento
+	FindByOrderID(orderID uuid.UUID) ([]models.Payment, error)
+}
+
+// PaymentRepositoryAdapter implementa PaymentRepository usando GORM
+type PaymentRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewPaymentRepositoryAdapter cria uma nova instância do adaptador
+func NewPaymentRepositoryAdapter(db *gorm.DB) PaymentRepository {
+	return &PaymentRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa o registro de um pagamento
+func (p *PaymentRepositoryAdapter) Create(payment *models.Payment) error {
+	result := p.db.Create(payment)
+	return result.Error
+}
+
+// FindByOrderID implementa a busca dos pagamentos de uma order
+func (p *PaymentRepositoryAdapter) FindByOrderID(orderID uuid.UUID) ([]models.Payment, error) {
+	var payments []models.Payment
+	result := p.db.Where("order_id = ?", orderID).Order("paid_at ASC").Order("created_at ASC").Find(&payments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return payments, nil
+}
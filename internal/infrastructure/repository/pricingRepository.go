@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// PricingRepository define a interface para a configuração de preços: alíquotas por categoria
+// e limite de desconto dos mecânicos
+type PricingRepository interface {
+	FindTaxRates() ([]models.TaxRate, error)
+	SaveTaxRates(rates []models.TaxRate) error
+	FindSettings() (*models.PricingSettings, error)
+	SaveSettings(settings *models.PricingSettings) error
+}
+
+// PricingRepositoryAdapter implementa PricingRepository usando GORM
+type PricingRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewPricingRepositoryAdapter cria uma nova instância do adaptador
+func NewPricingRepositoryAdapter(db *gorm.DB) PricingRepository {
+	return &PricingRepositoryAdapter{
+		db: db,
+	}
+}
+
+// FindTaxRates implementa a busca das alíquotas configuradas
+func (p *PricingRepositoryAdapter) FindTaxRates() ([]models.TaxRate, error) {
+	var rates []models.TaxRate
+	result := p.db.Order("category ASC").Find(&rates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rates, nil
+}
+
+// SaveTaxRates substitui as alíquotas configuradas. Categorias ausentes deixam de ser tributadas.
+func (p *PricingRepositoryAdapter) SaveTaxRates(rates []models.TaxRate) error {
+	if err := p.db.Where("1 = 1").Delete(&models.TaxRate{}).Error; err != nil {
+		return err
+	}
+	if len(rates) == 0 {
+		return nil
+	}
+	return p.db.Create(&rates).Error
+}
+
+// FindSettings implementa a busca da configuração de preços. Retorna nil quando
+// nenhuma configuração foi salva.
+func (p *PricingRepositoryAdapter) FindSettings() (*models.PricingSettings, error) {
+	var settings models.PricingSettings
+	result := p.db.Where("id = ?", models.PricingSettingsID).First(&settings)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &settings, nil
+}
+
+// SaveSettings implementa a gravação da configuração de preços
+func (p *PricingRepositoryAdapter) SaveSettings(settings *models.PricingSettings) error {
+	settings.ID = models.PricingSettingsID
+	result := p.db.Save(settings)
+	return result.Error
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Limites de paginação das listagens
+const (
+	DefaultQueryLimit = 20
+	MaxQueryLimit     = 100
+)
+
+var (
+	ErrInvalidLimit       = errors.New("invalid limit")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrInvalidSortField   = errors.New("invalid sort field")
+	ErrInvalidFilterField = errors.New("invalid filter field")
+)
+
+// QuerySpec descreve a paginação, a ordenação e os filtros de uma listagem.
+// O cursor é opaco para o cliente e aponta para o último registro da página anterior.
+type QuerySpec struct {
+	Limit    int
+	Cursor   string
+	SortBy   string
+	SortDesc bool
+	// Filters mapeia o nome público do campo para o valor que deve ser igual
+	Filters map[string]string
+}
+
+// Validate valida o limite da página, aplicando o valor padrão quando não informado
+func (s *QuerySpec) Validate() error {
+	if s.Limit == 0 {
+		s.Limit = DefaultQueryLimit
+	}
+	if s.Limit < 0 || s.Limit > MaxQueryLimit {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+// QueryFields define, para cada listagem, quais campos podem ser usados na ordenação
+// e nos filtros e a coluna correspondente de cada um
+type QueryFields struct {
+	Table       string
+	Sortable    map[string]string
+	Filterable  map[string]string
+	DefaultSort string
+}
+
+// Page é o envelope de uma página de resultados
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int64  `json:"total"`
+}
+
+// MapPage converte os itens da página mantendo os metadados de paginação
+func MapPage[T any, R any](page *Page[T], mapper func(*T) R) *Page[R] {
+	mapped := &Page[R]{
+		Items:      make([]R, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+	for i := range page.Items {
+		mapped.Items = append(mapped.Items, mapper(&page.Items[i]))
+	}
+	return mapped
+}
+
+// EncodeCursor gera o cursor opaco a partir do id do último registro da página
+func EncodeCursor(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// DecodeCursor recupera o id do registro apontado pelo cursor
+func DecodeCursor(cursor string) (uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return uuid.Nil, ErrInvalidCursor
+	}
+	id, err := uuid.FromBytes(raw)
+	if err != nil {
+		return uuid.Nil, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// findPage executa a listagem paginada por keyset. A posição do cursor é buscada no próprio
+// banco, o que mantém a comparação com o tipo da coluna ordenada, e o id desempata registros
+// com o mesmo valor de ordenação.
+func findPage[T any](query *gorm.DB, spec QuerySpec, fields QueryFields, idOf func(*T) uuid.UUID) (*Page[T], error) {
+	sortBy := spec.SortBy
+	if sortBy == "" {
+		sortBy = fields.DefaultSort
+	}
+	sortColumn, ok := fields.Sortable[sortBy]
+	if !ok {
+		return nil, ErrInvalidSortField
+	}
+
+	for field, value := range spec.Filters {
+		column, ok := fields.Filterable[field]
+		if !ok {
+			return nil, ErrInvalidFilterField
+		}
+		query = query.Where(column+" = ?", value)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	direction, comparison := "ASC", ">"
+	if spec.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if spec.Cursor != "" {
+		cursorID, err := DecodeCursor(spec.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(
+			"("+sortColumn+", id) "+comparison+" (SELECT "+sortColumn+", id FROM "+fields.Table+" WHERE id = ?)",
+			cursorID)
+	}
+
+	var items []T
+	// Busca um registro a mais para saber se existe uma próxima página
+	result := query.
+		Order(sortColumn + " " + direction).
+		Order("id " + direction).
+		Limit(spec.Limit + 1).
+		Find(&items)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	page := &Page[T]{Items: items, Total: total}
+	if len(items) > spec.Limit {
+		page.Items = items[:spec.Limit]
+		page.NextCursor = EncodeCursor(idOf(&page.Items[spec.Limit-1]))
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page, nil
+}
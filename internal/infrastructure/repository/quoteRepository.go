@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"gorm.io/gorm"
+)
+
+// QuoteRepository define a interface para operações de orçamentos no banco.
+// Orçamentos são imutáveis: não existem operações de update ou delete.
+type QuoteRepository interface {
+	// Create grava o orçamento junto com suas linhas
+	Create(quote *models.Quote) error
+	FindByID(id uuid.UUID) (*models.Quote, error)
+	// FindByOrderID retorna todas as versões do orçamento da order, da mais antiga para a mais recente
+	FindByOrderID(orderID uuid.UUID) ([]models.Quote, error)
+	FindByOrderIDAndVersion(orderID uuid.UUID, version int) (*models.Quote, error)
+	// FindLatestByOrderID retorna a versão mais recente, ou nil quando a order ainda não tem orçamento
+	FindLatestByOrderID(orderID uuid.UUID) (*models.Quote, error)
+}
+
+// QuoteRepositoryAdapter implementa QuoteRepository usando GORM
+type QuoteRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewQuoteRepositoryAdapter cria uma nova instância do adaptador
+func NewQuoteRepositoryAdapter(db *gorm.DB) QuoteRepository {
+	return &QuoteRepositoryAdapter{
+		db: db,
+	}
+}
+
+// Create implementa a criação do orçamento. O GORM grava as linhas associadas na mesma operação.
+func (q *QuoteRepositoryAdapter) Create(quote *models.Quote) error {
+	result := q.db.Create(quote)
+	return result.Error
+}
+
+// FindByID implementa a busca de orçamento por ID
+func (q *QuoteRepositoryAdapter) FindByID(id uuid.UUID) (*models.Quote, error) {
+	var quote models.Quote
+	result := q.withItems().Where("id = ?", id).First(&quote)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &quote, nil
+}
+
+// FindByOrderID implementa a busca das versões do orçamento de uma order
+func (q *QuoteRepositoryAdapter) FindByOrderID(orderID uuid.UUID) ([]models.Quote, error) {
+	var quotes []models.Quote
+	result := q.withItems().Where("order_id = ?", orderID).Order("version ASC").Find(&quotes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return quotes, nil
+}
+
+// FindByOrderIDAndVersion implementa a busca de uma versão específica do orçamento
+func (q *QuoteRepositoryAdapter) FindByOrderIDAndVersion(orderID uuid.UUID, version int) (*models.Quote, error) {
+	var quote models.Quote
+	result := q.withItems().Where("order_id = ? AND version = ?", orderID, version).First(&quote)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &quote, nil
+}
+
+// FindLatestByOrderID implementa a busca da versão mais recente do orçamento
+func (q *QuoteRepositoryAdapter) FindLatestByOrderID(orderID uuid.UUID) (*models.Quote, error) {
+	var quote models.Quote
+	result := q.withItems().Where("order_id = ?", orderID).Order("version DESC").First(&quote)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &quote, nil
+}
+
+// withItems carrega as linhas do orçamento em uma ordem estável
+func (q *QuoteRepositoryAdapter) withItems() *gorm.DB {
+	return q.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("item_type ASC").Order("description ASC")
+	})
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatusDurationRow é a agregação do tempo gasto em um status, em segundos
+type StatusDurationRow struct {
+	Status        string
+	Count         int64
+	MeanSeconds   float64
+	MedianSeconds float64
+	P90Seconds    float64
+}
+
+// DailyThroughputRow é a quantidade de orders abertas e entregues em um dia
+type DailyThroughputRow struct {
+	Day       time.Time
+	Opened    int64
+	Delivered int64
+}
+
+// StatusCountRow é a quantidade de orders em um status
+type StatusCountRow struct {
+	Status string
+	Count  int64
+}
+
+// ReportRepository define as agregações dos relatórios operacionais, feitas inteiramente no banco
+type ReportRepository interface {
+	// StatusDurations agrega as etapas encerradas em [from, to), ignorando os status informados
+	StatusDurations(from time.Time, to time.Time, excludeStatuses []string) ([]StatusDurationRow, error)
+	// DailyThroughput conta por dia as orders criadas e as que entraram no status de entrega em [from, to)
+	DailyThroughput(from time.Time, to time.Time, deliveredStatus string) ([]DailyThroughputRow, error)
+	// WorkInProgress conta as orders pela etapa ainda aberta no histórico
+	WorkInProgress() ([]StatusCountRow, error)
+}
+
+// ReportRepositoryAdapter implementa ReportRepository usando GORM
+type ReportRepositoryAdapter struct {
+	db *gorm.DB
+}
+
+// NewReportRepositoryAdapter cria uma nova instância do adaptador
+func NewReportRepositoryAdapter(db *gorm.DB) ReportRepository {
+	return &ReportRepositoryAdapter{
+		db: db,
+	}
+}
+
+// StatusDurations calcula média, mediana e p90 com percentile_cont, sem trazer as etapas para a aplicação
+func (r *ReportRepositoryAdapter) StatusDurations(from time.Time, to time.Time, excludeStatuses []string) ([]StatusDurationRow, error) {
+	var rows []StatusDurationRow
+	query := r.db.Table("order_status_history").
+		Select(`status,
+			COUNT(*) AS count,
+			AVG(EXTRACT(EPOCH FROM ended_at - started_at)) AS mean_seconds,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM ended_at - started_at)) AS median_seconds,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM ended_at - started_at)) AS p90_seconds`).
+		Where("ended_at IS NOT NULL AND ended_at >= ? AND ended_at < ?", from, to)
+	if len(excludeStatuses) > 0 {
+		query = query.Where("status NOT IN ?", excludeStatuses)
+	}
+	result := query.Group("status").Order("status").Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rows, nil
+}
+
+// DailyThroughput junta as aberturas e as entregas em uma única passada agrupada por dia
+func (r *ReportRepositoryAdapter) DailyThroughput(from time.Time, to time.Time, deliveredStatus string) ([]DailyThroughputRow, error) {
+	var rows []DailyThroughputRow
+	result := r.db.Raw(`
+		SELECT day, SUM(opened) AS opened, SUM(delivered) AS delivered
+		FROM (
+			SELECT DATE(created_at) AS day, 1 AS opened, 0 AS delivered
+			FROM orders
+			WHERE created_at >= ? AND created_at < ?
+			UNION ALL
+			SELECT DATE(started_at) AS day, 0 AS opened, 1 AS delivered
+			FROM order_status_history
+			WHERE status = ? AND started_at >= ? AND started_at < ?
+		) AS events
+		GROUP BY day
+		ORDER BY day`,
+		from, to, deliveredStatus, from, to).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rows, nil
+}
+
+// WorkInProgress usa a etapa aberta do histórico; status finais são gravados já encerrados
+func (r *ReportRepositoryAdapter) WorkInProgress() ([]StatusCountRow, error) {
+	var rows []StatusCountRow
+	result := r.db.Table("order_status_history").
+		Select("status, COUNT(*) AS count").
+		Where("ended_at IS NULL").
+		Group("status").
+		Order("status").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rows, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// Repositories agrupa os repositórios que participam de uma unidade de trabalho
+type Repositories struct {
+	Orders             OrderRepository
+	Inputs             InputRepository
+	OrderInputs        OrderInputRepository
+	OrderStatusHistory OrderStatusHistoryRepository
+	Customers          CustomerRepository
+	Vehicles           VehicleRepository
+	Users              UserRepository
+	InventoryMovements InventoryMovementRepository
+	LaborServices      LaborServiceRepository
+	OrderServices      OrderServiceRepository
+	Quotes             QuoteRepository
+	OrderAdjustments   OrderAdjustmentRepository
+	Pricing            PricingRepository
+	Invoices           InvoiceRepository
+	Payments           PaymentRepository
+	OrderAssignments   OrderAssignmentRepository
+	// UnitOfWork permite que usecases aninhados reutilizem a transação corrente
+	UnitOfWork UnitOfWork
+}
+
+// UnitOfWork define a interface para executar várias operações de repositório em uma única transação
+type UnitOfWork interface {
+	// Execute executa fn dentro de uma transação, fazendo rollback se fn retornar erro
+	Execute(fn func(repos Repositories) error) error
+}
+
+// GormUnitOfWork implementa UnitOfWork usando transações do GORM
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewGormUnitOfWork cria uma nova instância da unidade de trabalho
+func NewGormUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &GormUnitOfWork{
+		db: db,
+	}
+}
+
+// NewRepositories cria os repositórios ligados à conexão (ou transação) informada
+func NewRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Orders:             NewOrderRepositoryAdapter(db),
+		Inputs:             NewInputRepositoryAdapter(db),
+		OrderInputs:        NewOrderInputRepositoryAdapter(db),
+		OrderStatusHistory: NewOrderStatusHistoryRepositoryAdapter(db),
+		Customers:          NewCustomerRepositoryAdapter(db),
+		Vehicles:           NewVehicleRepositoryAdapter(db),
+		Users:              NewUserRepositoryAdapter(db),
+		InventoryMovements: NewInventoryMovementRepositoryAdapter(db),
+		LaborServices:      NewLaborServiceRepositoryAdapter(db),
+		OrderServices:      NewOrderServiceRepositoryAdapter(db),
+		Quotes:             NewQuoteRepositoryAdapter(db),
+		OrderAdjustments:   NewOrderAdjustmentRepositoryAdapter(db),
+		Pricing:            NewPricingRepositoryAdapter(db),
+		Invoices:           NewInvoiceRepositoryAdapter(db),
+		Payments:           NewPaymentRepositoryAdapter(db),
+		OrderAssignments:   NewOrderAssignmentRepositoryAdapter(db),
+		UnitOfWork:         NewGormUnitOfWork(db),
+	}
+}
+
+// Execute implementa a execução transacional. Quando chamado dentro de uma transação
+// já aberta, o GORM utiliza um savepoint, mantendo tudo no mesmo commit.
+func (u *GormUnitOfWork) Execute(fn func(repos Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
@@ -10,12 +10,31 @@ import (
 type VehicleRepository interface {
 	Create(vehicle *models.Vehicle) error
 	FindByID(id uuid.UUID) (*models.Vehicle, error)
-	FindByCustomerID(customerID uuid.UUID) ([]models.Vehicle, error)
+	FindByCustomerID(customerID uuid.UUID, spec QuerySpec) (*Page[models.Vehicle], error)
 	FindByNumberPlate(numberPlate string) (*models.Vehicle, error)
 	Update(vehicle *models.Vehicle) error
 	Delete(id uuid.UUID) error
 }
 
+// vehicleQueryFields define os campos aceitos na listagem de vehicles
+var vehicleQueryFields = QueryFields{
+	Table: "vehicles",
+	Sortable: map[string]string{
+		"brand":        "brand",
+		"model":        "model",
+		"release_year": "release_year",
+		"created_at":   "created_at",
+		"updated_at":   "updated_at",
+	},
+	Filterable: map[string]string{
+		"brand":        "brand",
+		"model":        "model",
+		"color":        "color",
+		"number_plate": "number_plate",
+	},
+	DefaultSort: "created_at",
+}
+
 // VehicleRepositoryAdapter implementa VehicleRepository usando GORM
 type VehicleRepositoryAdapter struct {
 	db *gorm.DB
@@ -44,14 +63,11 @@ func (v *VehicleRepositoryAdapter) FindByID(id uuid.UUID) (*models.Vehicle, erro
 	return &vehicle, nil
 }
 
-// FindByCustomerID implementa a busca de vehicles por customer ID
-func (v *VehicleRepositoryAdapter) FindByCustomerID(customerID uuid.UUID) ([]models.Vehicle, error) {
-	var vehicles []models.Vehicle
-	result := v.db.Where("customer_id = ?", customerID).Find(&vehicles)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	return vehicles, nil
+// FindByCustomerID implementa a listagem paginada de vehicles por customer ID
+func (v *VehicleRepositoryAdapter) FindByCustomerID(customerID uuid.UUID, spec QuerySpec) (*Page[models.Vehicle], error) {
+	query := v.db.Model(&models.Vehicle{}).Where("customer_id = ?", customerID)
+	return findPage(query, spec, vehicleQueryFields,
+		func(vehicle *models.Vehicle) uuid.UUID { return vehicle.ID })
 }
 
 // FindByNumberPlate implementa a busca de vehicle por placa
@@ -3,21 +3,113 @@ package controller
 import (
 	"encoding/json"
 	"errors"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/auth"
 	"go.uber.org/zap"
 )
 
 type AuthController struct {
-	Logger       *zap.Logger
-	LoginUseCase *auth.LoginUseCase
+	Logger                       *zap.Logger
+	LoginUseCase                 *auth.LoginUseCase
+	RefreshTokenUseCase          *auth.RefreshTokenUseCase
+	LogoutUseCase                *auth.LogoutUseCase
+	LogoutAllUseCase             *auth.LogoutAllUseCase
+	KeySet                       domain.KeySetProvider
+	RequestPasswordResetUseCase  *auth.RequestPasswordResetUseCase
+	ResetPasswordUseCase         *auth.ResetPasswordUseCase
+	SendEmailVerificationUseCase *auth.SendEmailVerificationUseCase
+	VerifyEmailUseCase           *auth.VerifyEmailUseCase
+	UnlockAccountUseCase         *auth.UnlockAccountUseCase
+	ListAuthEventsUseCase        *auth.ListAuthEventsUseCase
+	// TrustProxyHeaders faz o IP do cliente vir do X-Forwarded-For; só deve ser ligado atrás de um proxy que o preencha
+	TrustProxyHeaders bool
 }
 
 type LoginDTO struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Device é opcional; sem ele, o User-Agent identifica o cliente do refresh token
+	Device string `json:"device"`
+}
+
+type RefreshTokenDTO struct {
+	RefreshToken string `json:"refresh_token"`
+	Device       string `json:"device"`
+}
+
+func (dto *RefreshTokenDTO) Validate() error {
+	if dto.RefreshToken == "" {
+		return errors.New("refresh_token is required")
+	}
+	return nil
+}
+
+// LogoutDTO é opcional; com o refresh_token, a sessão inteira é encerrada
+type LogoutDTO struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ForgotPasswordDTO struct {
+	Email string `json:"email"`
+}
+
+func (dto *ForgotPasswordDTO) Validate() error {
+	if !emailRegex.MatchString(dto.Email) {
+		return errors.New("invalid email format")
+	}
+	return nil
+}
+
+type ResetPasswordDTO struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (dto *ResetPasswordDTO) Validate() error {
+	if dto.Token == "" {
+		return errors.New("token is required")
+	}
+	if !passwordRegex.MatchString(dto.Password) {
+		return errors.New("password must be at least 6 characters long")
+	}
+	return nil
+}
+
+type VerifyEmailDTO struct {
+	Token string `json:"token"`
+}
+
+// requestDevice usa o device informado ou, na falta dele, o User-Agent da requisição
+func requestDevice(r *http.Request, device string) string {
+	if device != "" {
+		return device
+	}
+	return r.UserAgent()
+}
+
+// clientIP retorna o IP usado no controle de tentativas. Com TrustProxyHeaders, usa o último
+// endereço do X-Forwarded-For, o único acrescentado pelo proxy e não pelo cliente.
+func (ac *AuthController) clientIP(r *http.Request) string {
+	if ac.TrustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			return strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
 
 func (dto *LoginDTO) Validate() error {
@@ -55,15 +147,32 @@ func (ac *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 	ac.Logger.Info("Validation passed")
 
 	request := domain.LoginRequest{
-		Email:    dto.Email,
-		Password: dto.Password,
+		Email:     dto.Email,
+		Password:  dto.Password,
+		Device:    requestDevice(r, dto.Device),
+		IP:        ac.clientIP(r),
+		UserAgent: r.UserAgent(),
 	}
 
 	ac.Logger.Info("Calling LoginUseCase.Execute...")
 	response, err := ac.LoginUseCase.Execute(request)
 	if err != nil {
 		ac.Logger.Error("Login failed", zap.Error(err))
-		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+
+		var blocked *domain.LoginBlockedError
+		switch {
+		case errors.As(err, &blocked):
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+			if errors.Is(err, domain.ErrAccountLocked) {
+				http.Error(w, "Account temporarily locked", http.StatusTooManyRequests)
+			} else {
+				http.Error(w, "Too many login attempts", http.StatusTooManyRequests)
+			}
+		case err.Error() == "invalid credentials":
+			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+		default:
+			http.Error(w, "Error logging in", http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -73,3 +182,287 @@ func (ac *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+func (ac *AuthController) Refresh(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH REFRESH ENDPOINT CALLED ===")
+
+	var dto RefreshTokenDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		ac.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		ac.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := ac.RefreshTokenUseCase.Execute(domain.RefreshRequest{
+		RefreshToken: dto.RefreshToken,
+		Device:       requestDevice(r, dto.Device),
+	})
+	if err != nil {
+		ac.Logger.Error("Refresh failed", zap.Error(err))
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidRefreshToken), errors.Is(err, domain.ErrRefreshTokenReuse):
+			http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+		default:
+			http.Error(w, "Error refreshing token", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (ac *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH LOGOUT ENDPOINT CALLED ===")
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ac.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var dto LogoutDTO
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			ac.Logger.Error("Error decoding JSON", zap.Error(err))
+			http.Error(w, "Invalid data", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := ac.LogoutUseCase.Execute(claims, dto.RefreshToken); err != nil {
+		ac.Logger.Error("Logout failed", zap.Error(err))
+		http.Error(w, "Error logging out", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ac *AuthController) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH LOGOUT ALL ENDPOINT CALLED ===")
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ac.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := ac.LogoutAllUseCase.Execute(claims); err != nil {
+		ac.Logger.Error("Logout from all sessions failed", zap.Error(err))
+		http.Error(w, "Error logging out", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// JWKS publica as chaves públicas para que outros serviços validem os tokens sem segredo compartilhado
+func (ac *AuthController) JWKS(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH JWKS ENDPOINT CALLED ===")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ac.KeySet.PublicKeySet())
+}
+
+// ForgotPassword responde sempre 202, exista ou não uma conta com o e-mail
+func (ac *AuthController) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH FORGOT PASSWORD ENDPOINT CALLED ===")
+
+	var dto ForgotPasswordDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		ac.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := ac.RequestPasswordResetUseCase.Execute(dto.Email); err != nil {
+		ac.Logger.Error("Error requesting password reset", zap.Error(err))
+		http.Error(w, "Error requesting password reset", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"message": "If the email is registered, a reset link has been sent"})
+}
+
+func (ac *AuthController) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH RESET PASSWORD ENDPOINT CALLED ===")
+
+	var dto ResetPasswordDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		ac.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := ac.ResetPasswordUseCase.Execute(dto.Token, dto.Password); err != nil {
+		ac.Logger.Error("Error resetting password", zap.Error(err))
+
+		if errors.Is(err, domain.ErrInvalidUserToken) {
+			http.Error(w, "Invalid or expired token", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Error resetting password", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ac *AuthController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH VERIFY EMAIL ENDPOINT CALLED ===")
+
+	var dto VerifyEmailDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		ac.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := ac.VerifyEmailUseCase.Execute(dto.Token); err != nil {
+		ac.Logger.Error("Error verifying email", zap.Error(err))
+
+		if errors.Is(err, domain.ErrInvalidUserToken) {
+			http.Error(w, "Invalid or expired token", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Error verifying email", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendEmailVerification envia um novo link de confirmação para o usuário autenticado
+func (ac *AuthController) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH RESEND EMAIL VERIFICATION ENDPOINT CALLED ===")
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ac.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := ac.SendEmailVerificationUseCase.Execute(claims.UserID); err != nil {
+		ac.Logger.Error("Error sending email verification", zap.Error(err))
+
+		switch err.Error() {
+		case "user not found":
+			http.Error(w, "User not found", http.StatusNotFound)
+		case "email already verified":
+			http.Error(w, "Email already verified", http.StatusConflict)
+		default:
+			http.Error(w, "Error sending email verification", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// UnlockAccount remove o bloqueio por excesso de tentativas de login de um usuário
+func (ac *AuthController) UnlockAccount(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH UNLOCK ACCOUNT ENDPOINT CALLED ===")
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ac.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		ac.Logger.Error("Error parsing UUID", zap.Error(err))
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := ac.UnlockAccountUseCase.Execute(id, claims); err != nil {
+		ac.Logger.Error("Error unlocking account", zap.Error(err))
+
+		if err.Error() == "user not found" {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error unlocking account", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AuthEvents lista os eventos de autenticação mais recentes, filtrando por email, ip, type e user_id
+func (ac *AuthController) AuthEvents(w http.ResponseWriter, r *http.Request) {
+	ac.Logger.Info("=== AUTH EVENTS ENDPOINT CALLED ===")
+
+	query := r.URL.Query()
+	filter := domain.AuthEventFilter{
+		Email: query.Get("email"),
+		IP:    query.Get("ip"),
+		Type:  query.Get("type"),
+	}
+
+	if value := query.Get("user_id"); value != "" {
+		userID, err := uuid.Parse(value)
+		if err != nil {
+			http.Error(w, "Invalid user_id", http.StatusBadRequest)
+			return
+		}
+		filter.UserID = &userID
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	events, err := ac.ListAuthEventsUseCase.Execute(filter)
+	if err != nil {
+		ac.Logger.Error("Error listing auth events", zap.Error(err))
+
+		switch err.Error() {
+		case "invalid event type":
+			http.Error(w, "Invalid event type", http.StatusBadRequest)
+		case "invalid limit":
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+		default:
+			http.Error(w, "Error listing auth events", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(events)
+}
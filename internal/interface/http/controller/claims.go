@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// claimsFromRequest recupera as claims colocadas no contexto pelo AuthMiddleware
+func claimsFromRequest(r *http.Request) (*auth.Claims, bool) {
+	claims, ok := r.Context().Value("claims").(*auth.Claims)
+	return claims, ok
+}
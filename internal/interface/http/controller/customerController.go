@@ -101,15 +101,27 @@ func (cc *CustomerController) Create(w http.ResponseWriter, r *http.Request) {
 func (cc *CustomerController) FindAll(w http.ResponseWriter, r *http.Request) {
 	cc.Logger.Info("=== CUSTOMER FIND ALL ENDPOINT CALLED ===")
 
+	spec, err := parseQuerySpec(r, "name", "document_number", "customer_type")
+	if err != nil {
+		cc.Logger.Error("Error parsing query", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	cc.Logger.Info("Calling FindAllCustomer.Process...")
-	customers, err := cc.FindAllCustomer.Process()
+	customers, err := cc.FindAllCustomer.Process(spec)
 	if err != nil {
 		cc.Logger.Error("Error finding all customers", zap.Error(err))
+		if writeQueryError(w, err) {
+			return
+		}
 		http.Error(w, "Error retrieving customers", http.StatusInternalServerError)
 		return
 	}
 
-	cc.Logger.Info("Successfully retrieved customers", zap.Int("count", len(customers)))
+	cc.Logger.Info("Successfully retrieved customers",
+		zap.Int("count", len(customers.Items)),
+		zap.Int64("total", customers.Total))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
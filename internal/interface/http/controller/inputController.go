@@ -9,6 +9,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/input"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -31,21 +33,24 @@ type InputController struct {
 	FindAllInputs   *input.FindAllInputs
 	UpdateByIdInput *input.UpdateByIdInput
 	DeleteByIdInput *input.DeleteByIdInput
+
+	FindInputMovements *input.FindInputMovements
+	AdjustInputStock   *input.AdjustInputStock
 }
 
 type InputDTO struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int     `json:"quantity"`
-	InputType   string  `json:"input_type"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Quantity    int         `json:"quantity"`
+	InputType   string      `json:"input_type"`
 }
 
 func (dto *InputDTO) Validate() error {
 	if !inputNameRegex.MatchString(dto.Name) {
 		return errors.New("name must contain only letters, numbers, spaces, hyphens and underscores, between 2 and 50 characters")
 	}
-	if dto.Price <= 0 {
+	if !dto.Price.IsPositive() {
 		return errors.New("price must be greater than zero")
 	}
 	if !inputTypeRegex.MatchString(dto.InputType) {
@@ -70,6 +75,29 @@ func (dto *InputDTO) Validate() error {
 	return nil
 }
 
+// StockMovementDTO representa uma movimentação manual de estoque.
+// Para "stocktake" a quantidade é o saldo contado; para "adjustment" pode ser negativa.
+type StockMovementDTO struct {
+	MovementType string `json:"movement_type"`
+	Quantity     int    `json:"quantity"`
+	Reason       string `json:"reason"`
+}
+
+func (dto *StockMovementDTO) Validate() error {
+	switch dto.MovementType {
+	case inventoryMovement.MovementTypeManualRestock, inventoryMovement.MovementTypeAdjustment, inventoryMovement.MovementTypeStocktake:
+	default:
+		return errors.New("movement_type must be 'manual_restock', 'adjustment' or 'stocktake'")
+	}
+	if dto.MovementType == inventoryMovement.MovementTypeAdjustment && dto.Reason == "" {
+		return errors.New("reason is required for adjustment")
+	}
+	if len(dto.Reason) > 500 {
+		return errors.New("reason must be less than 500 characters")
+	}
+	return nil
+}
+
 func (ic *InputController) Create(w http.ResponseWriter, r *http.Request) {
 	ic.Logger.Info("=== INPUT CREATE ENDPOINT CALLED ===")
 
@@ -82,7 +110,7 @@ func (ic *InputController) Create(w http.ResponseWriter, r *http.Request) {
 
 	ic.Logger.Info("Received input creation request",
 		zap.String("name", dto.Name),
-		zap.Float64("price", dto.Price),
+		zap.Stringer("price", dto.Price),
 		zap.Int("quantity", dto.Quantity),
 		zap.String("description", dto.Description),
 		zap.String("inputType", dto.InputType))
@@ -117,12 +145,19 @@ func (ic *InputController) Create(w http.ResponseWriter, r *http.Request) {
 	ic.Logger.Info("Entity created",
 		zap.String("id", entity.ID.String()),
 		zap.String("name", entity.Name),
-		zap.Float64("price", entity.Price),
+		zap.Stringer("price", entity.Price),
 		zap.Int("quantity", entity.Quantity),
 		zap.String("inputType", entity.InputType))
 
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ic.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	ic.Logger.Info("Calling CreateInput.Process...")
-	err := ic.CreateInput.Process(entity)
+	err := ic.CreateInput.Process(entity, claims.UserID)
 	if err != nil {
 		ic.Logger.Error("Error creating input", zap.Error(err))
 
@@ -172,7 +207,7 @@ func (ic *InputController) FindById(w http.ResponseWriter, r *http.Request) {
 		zap.String("id", input.ID.String()),
 		zap.String("name", input.Name),
 		zap.String("inputType", input.InputType),
-		zap.Float64("price", input.Price),
+		zap.Stringer("price", input.Price),
 		zap.Int("quantity", input.Quantity))
 
 	w.Header().Set("Content-Type", "application/json")
@@ -183,15 +218,27 @@ func (ic *InputController) FindById(w http.ResponseWriter, r *http.Request) {
 func (ic *InputController) FindAll(w http.ResponseWriter, r *http.Request) {
 	ic.Logger.Info("=== INPUT FIND ALL ENDPOINT CALLED ===")
 
+	spec, err := parseQuerySpec(r, "name", "input_type")
+	if err != nil {
+		ic.Logger.Error("Error parsing query", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ic.Logger.Info("Calling FindAllInputs.Process...")
-	inputs, err := ic.FindAllInputs.Process()
+	inputs, err := ic.FindAllInputs.Process(spec)
 	if err != nil {
 		ic.Logger.Error("Error finding all inputs", zap.Error(err))
+		if writeQueryError(w, err) {
+			return
+		}
 		http.Error(w, "Error finding inputs", http.StatusInternalServerError)
 		return
 	}
 
-	ic.Logger.Info("Successfully found inputs", zap.Int("count", len(inputs)))
+	ic.Logger.Info("Successfully found inputs",
+		zap.Int("count", len(inputs.Items)),
+		zap.Int64("total", inputs.Total))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -222,7 +269,7 @@ func (ic *InputController) UpdateById(w http.ResponseWriter, r *http.Request) {
 		zap.String("id", id.String()),
 		zap.String("name", dto.Name),
 		zap.String("inputType", dto.InputType),
-		zap.Float64("price", dto.Price),
+		zap.Stringer("price", dto.Price),
 		zap.Int("quantity", dto.Quantity),
 		zap.String("description", dto.Description))
 
@@ -257,11 +304,18 @@ func (ic *InputController) UpdateById(w http.ResponseWriter, r *http.Request) {
 		zap.String("id", entity.ID.String()),
 		zap.String("name", entity.Name),
 		zap.String("inputType", entity.InputType),
-		zap.Float64("price", entity.Price),
+		zap.Stringer("price", entity.Price),
 		zap.Int("quantity", entity.Quantity))
 
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ic.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	ic.Logger.Info("Calling UpdateByIdInput.Process...")
-	err = ic.UpdateByIdInput.Process(id, entity)
+	err = ic.UpdateByIdInput.Process(id, entity, claims.UserID)
 	if err != nil {
 		ic.Logger.Error("Error updating input", zap.Error(err))
 
@@ -277,6 +331,12 @@ func (ic *InputController) UpdateById(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// Tratamento específico para saldo abaixo das reservas
+		if err.Error() == "quantity cannot be lower than reserved quantity" {
+			http.Error(w, "Quantity cannot be lower than reserved quantity", http.StatusConflict)
+			return
+		}
+
 		http.Error(w, "Error updating input", http.StatusInternalServerError)
 		return
 	}
@@ -328,3 +388,100 @@ func (ic *InputController) DeleteById(w http.ResponseWriter, r *http.Request) {
 		"id":      id.String(),
 	})
 }
+
+func (ic *InputController) FindMovements(w http.ResponseWriter, r *http.Request) {
+	ic.Logger.Info("=== INPUT FIND MOVEMENTS ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		ic.Logger.Error("Error parsing UUID", zap.Error(err))
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	ic.Logger.Info("Calling FindInputMovements.Process...")
+	movements, err := ic.FindInputMovements.Process(id)
+	if err != nil {
+		ic.Logger.Error("Error finding input movements", zap.Error(err), zap.String("id", id.String()))
+
+		if err.Error() == "input not found" {
+			http.Error(w, "Input not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, "Error finding input movements", http.StatusInternalServerError)
+		return
+	}
+
+	ic.Logger.Info("Successfully found input movements", zap.Int("count", len(movements)))
+
+	if movements == nil {
+		movements = []*inventoryMovement.InventoryMovement{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(movements)
+}
+
+func (ic *InputController) RecordMovement(w http.ResponseWriter, r *http.Request) {
+	ic.Logger.Info("=== INPUT RECORD MOVEMENT ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		ic.Logger.Error("Error parsing UUID", zap.Error(err))
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto StockMovementDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		ic.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		ic.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ic.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ic.Logger.Info("Calling AdjustInputStock.Process...",
+		zap.String("id", id.String()),
+		zap.String("movementType", dto.MovementType),
+		zap.Int("quantity", dto.Quantity))
+	err = ic.AdjustInputStock.Process(id, dto.MovementType, dto.Quantity, claims.UserID, dto.Reason)
+	if err != nil {
+		ic.Logger.Error("Error recording stock movement", zap.Error(err))
+
+		switch err.Error() {
+		case "input not found":
+			http.Error(w, "Input not found", http.StatusNotFound)
+		case "insufficient quantity", "service inputs do not track stock":
+			http.Error(w, err.Error(), http.StatusConflict)
+		case "restock quantity must be greater than zero", "adjustment quantity must not be zero", "counted quantity must not be negative":
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Error recording stock movement", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ic.Logger.Info("Stock movement recorded successfully", zap.String("id", id.String()))
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Stock movement recorded successfully",
+		"id":      id.String(),
+	})
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/document"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_access"
+	"go.uber.org/zap"
+)
+
+type InvoiceController struct {
+	Logger                 *zap.Logger
+	FindInvoiceByIdUC      *invoice.FindInvoiceById
+	FindInvoiceByOrderIdUC *invoice.FindInvoiceByOrderId
+	RenderInvoiceUC        *invoice.RenderInvoice
+	IssueCreditNoteUC      *invoice.IssueCreditNote
+	ValidateOrderAccessUC  *order_access.ValidateOrderAccess
+}
+
+// IssueCreditNoteDTO emite uma nota de crédito. Sem amount, o saldo restante da nota é estornado.
+type IssueCreditNoteDTO struct {
+	Reason string       `json:"reason"`
+	Amount *money.Money `json:"amount"`
+}
+
+// FindById retorna a nota em JSON ou, com ?format=html|pdf, o documento pronto para impressão
+func (ic *InvoiceController) FindById(w http.ResponseWriter, r *http.Request) {
+	ic.Logger.Info("=== INVOICE FIND BY ID ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	invoiceID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		ic.Logger.Error("Error parsing invoice ID", zap.Error(err))
+		http.Error(w, "Invalid invoice ID format", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ic.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// O dono de veículo só consulta as notas das orders do seu customer
+	if err := ic.ValidateOrderAccessUC.ProcessInvoice(invoiceID, claims.UserID, claims.UserType); err != nil {
+		ic.Logger.Error("Error validating invoice access", zap.Error(err))
+		writeOrderAccessError(w, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" || format == "json" {
+		found, err := ic.FindInvoiceByIdUC.Process(invoiceID)
+		if err != nil {
+			ic.Logger.Error("Error finding invoice", zap.Error(err))
+			http.Error(w, "Invoice not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(found)
+		return
+	}
+
+	content, contentType, err := ic.RenderInvoiceUC.Process(invoiceID, format)
+	if err != nil {
+		ic.Logger.Error("Error rendering invoice", zap.Error(err))
+
+		switch err.Error() {
+		case "invoice not found":
+			http.Error(w, "Invoice not found", http.StatusNotFound)
+		case document.ErrInvalidFormat.Error():
+			http.Error(w, "Invalid format, use json, html or pdf", http.StatusBadRequest)
+		default:
+			http.Error(w, "Error rendering invoice", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+func (ic *InvoiceController) FindByOrderId(w http.ResponseWriter, r *http.Request) {
+	ic.Logger.Info("=== INVOICE FIND BY ORDER ID ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		ic.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ic.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := ic.ValidateOrderAccessUC.Process(orderID, claims.UserID, claims.UserType); err != nil {
+		ic.Logger.Error("Error validating order access", zap.Error(err))
+		writeOrderAccessError(w, err)
+		return
+	}
+
+	found, err := ic.FindInvoiceByOrderIdUC.Process(orderID)
+	if err != nil {
+		ic.Logger.Error("Error finding invoice", zap.Error(err))
+
+		if err.Error() == "invoice not found" {
+			http.Error(w, "Invoice not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, "Error finding invoice", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(found)
+}
+
+func (ic *InvoiceController) IssueCreditNote(w http.ResponseWriter, r *http.Request) {
+	ic.Logger.Info("=== INVOICE ISSUE CREDIT NOTE ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	invoiceID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		ic.Logger.Error("Error parsing invoice ID", zap.Error(err))
+		http.Error(w, "Invalid invoice ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto IssueCreditNoteDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		ic.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		ic.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	creditNote, err := ic.IssueCreditNoteUC.Process(invoiceID, dto.Amount, dto.Reason, &claims.UserID)
+	if err != nil {
+		ic.Logger.Error("Error issuing credit note", zap.Error(err))
+
+		switch err.Error() {
+		case "invoice not found":
+			http.Error(w, "Invoice not found", http.StatusNotFound)
+		case "credit note reason is required":
+			http.Error(w, "reason is required", http.StatusBadRequest)
+		case "credit note amount must be greater than zero":
+			http.Error(w, "amount must be greater than zero", http.StatusBadRequest)
+		case "invoice is fully credited":
+			http.Error(w, "Invoice is fully credited", http.StatusConflict)
+		case "credit note exceeds invoice balance":
+			http.Error(w, "Credit note exceeds invoice balance", http.StatusConflict)
+		default:
+			http.Error(w, "Error issuing credit note", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ic.Logger.Info("Credit note issued successfully",
+		zap.String("invoiceID", invoiceID.String()),
+		zap.String("creditNoteNumber", creditNote.FormattedNumber))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(creditNote)
+}
@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/labor_service"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type LaborServiceController struct {
+	Logger                 *zap.Logger
+	CreateLaborService     *labor_service.CreateLaborService
+	FindByIdLaborService   *labor_service.FindByIdLaborService
+	FindAllLaborServices   *labor_service.FindAllLaborServices
+	UpdateByIdLaborService *labor_service.UpdateByIdLaborService
+	DeleteByIdLaborService *labor_service.DeleteByIdLaborService
+}
+
+type LaborServiceDTO struct {
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	DefaultHours float64     `json:"default_hours"`
+	HourlyRate   money.Money `json:"hourly_rate"`
+}
+
+func (dto *LaborServiceDTO) Validate() error {
+	if !inputNameRegex.MatchString(dto.Name) {
+		return errors.New("name must contain only letters, numbers, spaces, hyphens and underscores, between 2 and 50 characters")
+	}
+	if dto.DefaultHours <= 0 {
+		return errors.New("default_hours must be greater than zero")
+	}
+	if !dto.HourlyRate.IsPositive() {
+		return errors.New("hourly_rate must be greater than zero")
+	}
+	if len(dto.Description) > 500 {
+		return errors.New("description must be less than 500 characters")
+	}
+	return nil
+}
+
+func (lc *LaborServiceController) Create(w http.ResponseWriter, r *http.Request) {
+	lc.Logger.Info("=== LABOR SERVICE CREATE ENDPOINT CALLED ===")
+
+	var dto LaborServiceDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		lc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		lc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entity := &domain.LaborService{
+		ID:           uuid.New(),
+		Name:         dto.Name,
+		Description:  dto.Description,
+		DefaultHours: dto.DefaultHours,
+		HourlyRate:   dto.HourlyRate,
+	}
+
+	lc.Logger.Info("Calling CreateLaborService.Process...", zap.String("name", entity.Name))
+	err := lc.CreateLaborService.Process(entity)
+	if err != nil {
+		lc.Logger.Error("Error creating labor service", zap.Error(err))
+
+		if err.Error() == "labor service name already exists" {
+			http.Error(w, "Labor service name already exists", http.StatusConflict)
+			return
+		}
+
+		http.Error(w, "Error creating labor service", http.StatusInternalServerError)
+		return
+	}
+
+	lc.Logger.Info("Labor service created successfully", zap.String("id", entity.ID.String()))
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Labor service created successfully",
+		"id":      entity.ID.String(),
+	})
+}
+
+func (lc *LaborServiceController) FindById(w http.ResponseWriter, r *http.Request) {
+	lc.Logger.Info("=== LABOR SERVICE FIND BY ID ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		lc.Logger.Error("Error parsing UUID", zap.Error(err))
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	laborService, err := lc.FindByIdLaborService.Process(id)
+	if err != nil {
+		lc.Logger.Error("Error finding labor service by ID", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Labor service not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(laborService)
+}
+
+func (lc *LaborServiceController) FindAll(w http.ResponseWriter, r *http.Request) {
+	lc.Logger.Info("=== LABOR SERVICE FIND ALL ENDPOINT CALLED ===")
+
+	spec, err := parseQuerySpec(r, "name")
+	if err != nil {
+		lc.Logger.Error("Error parsing query", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	laborServices, err := lc.FindAllLaborServices.Process(spec)
+	if err != nil {
+		lc.Logger.Error("Error finding all labor services", zap.Error(err))
+		if writeQueryError(w, err) {
+			return
+		}
+		http.Error(w, "Error finding labor services", http.StatusInternalServerError)
+		return
+	}
+
+	lc.Logger.Info("Successfully found labor services",
+		zap.Int("count", len(laborServices.Items)),
+		zap.Int64("total", laborServices.Total))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(laborServices)
+}
+
+func (lc *LaborServiceController) UpdateById(w http.ResponseWriter, r *http.Request) {
+	lc.Logger.Info("=== LABOR SERVICE UPDATE BY ID ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		lc.Logger.Error("Error parsing UUID", zap.Error(err))
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto LaborServiceDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		lc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		lc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entity := &domain.LaborService{
+		ID:           id,
+		Name:         dto.Name,
+		Description:  dto.Description,
+		DefaultHours: dto.DefaultHours,
+		HourlyRate:   dto.HourlyRate,
+	}
+
+	err = lc.UpdateByIdLaborService.Process(id, entity)
+	if err != nil {
+		lc.Logger.Error("Error updating labor service", zap.Error(err))
+
+		if err.Error() == "labor service name already exists" {
+			http.Error(w, "Labor service name already exists", http.StatusConflict)
+			return
+		}
+
+		if err.Error() == "record not found" {
+			http.Error(w, "Labor service not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, "Error updating labor service", http.StatusInternalServerError)
+		return
+	}
+
+	lc.Logger.Info("Labor service updated successfully", zap.String("id", id.String()))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Labor service updated successfully",
+		"id":      id.String(),
+	})
+}
+
+func (lc *LaborServiceController) DeleteById(w http.ResponseWriter, r *http.Request) {
+	lc.Logger.Info("=== LABOR SERVICE DELETE BY ID ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		lc.Logger.Error("Error parsing UUID", zap.Error(err))
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	err = lc.DeleteByIdLaborService.Process(id)
+	if err != nil {
+		lc.Logger.Error("Error deleting labor service by ID", zap.Error(err), zap.String("id", id.String()))
+
+		if err == gorm.ErrRecordNotFound {
+			http.Error(w, "Labor service not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, "Error deleting labor service", http.StatusInternalServerError)
+		return
+	}
+
+	lc.Logger.Info("Successfully deleted labor service", zap.String("id", id.String()))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Labor service deleted successfully",
+		"id":      id.String(),
+	})
+}
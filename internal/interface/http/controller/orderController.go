@@ -3,14 +3,30 @@ package controller
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	adjustmentDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	statusHistory "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
+	paymentDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/document"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_access"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_adjustment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_assignment"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
 	"go.uber.org/zap"
 )
 
@@ -34,7 +50,36 @@ type OrderController struct {
 	AddInputToOrderUC       *order_input.AddInputToOrder
 	RemoveInputFromOrderUC  *order_input.RemoveInputFromOrder
 	FindOrderOverviewByIdUC *order.FindOrderOverviewById
+	FindAllOrdersUC         *order.FindAllOrders
 	UpdateOrderStatusUC     *order.UpdateOrderStatus
+	ApproveOrderUC          *order.ApproveOrder
+	RejectOrderUC           *order.RejectOrder
+
+	FlagOrderInputConditionUC *order_input.FlagOrderInputCondition
+
+	AddServiceToOrderUC      *order_service.AddServiceToOrder
+	UpdateOrderServiceUC     *order_service.UpdateOrderService
+	RemoveServiceFromOrderUC *order_service.RemoveServiceFromOrder
+
+	GenerateQuoteUC       *quote.GenerateQuote
+	FindQuotesByOrderIdUC *quote.FindQuotesByOrderId
+	FindQuoteByVersionUC  *quote.FindQuoteByVersion
+
+	AddOrderAdjustmentUC     *order_adjustment.AddOrderAdjustment
+	ApproveOrderAdjustmentUC *order_adjustment.ApproveOrderAdjustment
+	RemoveOrderAdjustmentUC  *order_adjustment.RemoveOrderAdjustment
+
+	RecordPaymentUC         *payment.RecordPayment
+	FindPaymentsByOrderIdUC *payment.FindPaymentsByOrderId
+
+	RenderOrderDocumentUC *order.RenderOrderDocument
+
+	AssignMechanicUC       *order_assignment.AssignMechanic
+	UnassignMechanicUC     *order_assignment.UnassignMechanic
+	FindOrderAssignmentsUC *order_assignment.FindOrderAssignments
+	FindMechanicOrdersUC   *order_assignment.FindMechanicOrders
+
+	ValidateOrderAccessUC *order_access.ValidateOrderAccess
 }
 
 type OrderDTO struct {
@@ -82,17 +127,185 @@ func (dto *RemoveInputFromOrderDTO) Validate() error {
 	return nil
 }
 
+type FlagOrderInputConditionDTO struct {
+	InputID       string `json:"input_id"`
+	UsedOrDamaged *bool  `json:"used_or_damaged"`
+}
+
+func (dto *FlagOrderInputConditionDTO) Validate() error {
+	if dto.InputID == "" {
+		return errors.New("input_id is required")
+	}
+	if dto.UsedOrDamaged == nil {
+		return errors.New("used_or_damaged is required")
+	}
+	return nil
+}
+
+// AddServiceToOrderDTO adiciona uma linha de mão de obra. Sem estimated_hours, são usadas
+// as horas padrão do catálogo.
+type AddServiceToOrderDTO struct {
+	LaborServiceID string   `json:"labor_service_id"`
+	MechanicID     *string  `json:"mechanic_id"`
+	EstimatedHours *float64 `json:"estimated_hours"`
+}
+
+func (dto *AddServiceToOrderDTO) Validate() error {
+	if dto.LaborServiceID == "" {
+		return errors.New("labor_service_id is required")
+	}
+	if dto.EstimatedHours != nil && *dto.EstimatedHours <= 0 {
+		return errors.New("estimated_hours must be greater than zero")
+	}
+	return nil
+}
+
+// UpdateOrderServiceDTO altera apenas os campos informados da linha de mão de obra
+type UpdateOrderServiceDTO struct {
+	MechanicID     *string  `json:"mechanic_id"`
+	EstimatedHours *float64 `json:"estimated_hours"`
+	ActualHours    *float64 `json:"actual_hours"`
+}
+
+func (dto *UpdateOrderServiceDTO) Validate() error {
+	if dto.MechanicID == nil && dto.EstimatedHours == nil && dto.ActualHours == nil {
+		return errors.New("at least one field must be informed")
+	}
+	if dto.EstimatedHours != nil && *dto.EstimatedHours <= 0 {
+		return errors.New("estimated_hours must be greater than zero")
+	}
+	if dto.ActualHours != nil && *dto.ActualHours < 0 {
+		return errors.New("actual_hours cannot be negative")
+	}
+	return nil
+}
+
+// AddOrderAdjustmentDTO registra um desconto ou acréscimo. Sem line_id, o ajuste vale para a
+// order inteira; com line_id, para o item ou a linha de mão de obra informada. Ajustes
+// percentuais informam percentage e os de valor fixo, amount em reais.
+type AddOrderAdjustmentDTO struct {
+	LineID     *string      `json:"line_id"`
+	Kind       string       `json:"kind"`
+	ValueType  string       `json:"value_type"`
+	Percentage *float64     `json:"percentage"`
+	Amount     *money.Money `json:"amount"`
+	Reason     string       `json:"reason"`
+}
+
+func (dto *AddOrderAdjustmentDTO) Validate() error {
+	if dto.Kind == "" {
+		return errors.New("kind is required")
+	}
+	if dto.ValueType == "" {
+		return errors.New("value_type is required")
+	}
+	if dto.Percentage == nil && dto.Amount == nil {
+		return errors.New("percentage or amount is required")
+	}
+	if dto.Percentage != nil && dto.Amount != nil {
+		return errors.New("use either percentage or amount, not both")
+	}
+	if strings.TrimSpace(dto.Reason) == "" {
+		return errors.New("reason is required")
+	}
+	if len(dto.Reason) > 500 {
+		return errors.New("reason must be less than 500 characters")
+	}
+	return nil
+}
+
 type UpdateOrderStatusDTO struct {
 	Status string `json:"status"`
+	// Note é o motivo ou comentário da mudança, gravado no histórico de status
+	Note string `json:"note"`
+	// OverrideBalance libera a entrega com saldo em aberto. Aceito apenas de admins.
+	OverrideBalance bool `json:"override_balance"`
 }
 
 func (dto *UpdateOrderStatusDTO) Validate() error {
 	if dto.Status == "" {
 		return errors.New("status is required")
 	}
+	if len(dto.Note) > 500 {
+		return errors.New("note must be less than 500 characters")
+	}
+	return nil
+}
+
+type OrderDecisionDTO struct {
+	Comment string `json:"comment"`
+	// QuoteVersion é a versão do orçamento aprovada. Obrigatória apenas na aprovação.
+	QuoteVersion int `json:"quote_version"`
+}
+
+func (dto *OrderDecisionDTO) Validate() error {
+	if len(dto.Comment) > 500 {
+		return errors.New("comment must be less than 500 characters")
+	}
+	return nil
+}
+
+type AssignMechanicDTO struct {
+	MechanicID string `json:"mechanic_id"`
+}
+
+func (dto *AssignMechanicDTO) Validate() error {
+	if dto.MechanicID == "" {
+		return errors.New("mechanic_id is required")
+	}
+	return nil
+}
+
+// RecordPaymentDTO registra um pagamento. Sem paid_at, vale o momento do registro.
+type RecordPaymentDTO struct {
+	Method    string      `json:"method"`
+	Amount    money.Money `json:"amount"`
+	Reference string      `json:"reference"`
+	PaidAt    *time.Time  `json:"paid_at"`
+}
+
+func (dto *RecordPaymentDTO) Validate() error {
+	if dto.Method == "" {
+		return errors.New("method is required")
+	}
+	if !dto.Amount.IsPositive() {
+		return errors.New("amount must be greater than zero")
+	}
+	if len(dto.Reference) > 100 {
+		return errors.New("reference must be less than 100 characters")
+	}
 	return nil
 }
 
+// GenerateQuoteDTO gera uma nova versão do orçamento. Sem validity_days, vale a validade padrão.
+type GenerateQuoteDTO struct {
+	ValidityDays int `json:"validity_days"`
+}
+
+// writeStatusTransitionError escreve a resposta para erros da máquina de estados da order
+func (oc *OrderController) writeStatusTransitionError(w http.ResponseWriter, err error) bool {
+	// Tratamento específico para transição não prevista na máquina de estados
+	var transitionErr *domain.InvalidTransitionError
+	if errors.As(err, &transitionErr) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":            "Invalid status transition",
+			"current_status":   transitionErr.From,
+			"requested_status": transitionErr.To,
+			"allowed_statuses": transitionErr.Allowed,
+		})
+		return true
+	}
+
+	// Tratamento específico para usuário sem permissão para a transição
+	if errors.Is(err, domain.ErrTransitionNotAllowedForUser) {
+		http.Error(w, "User not allowed to perform this status transition", http.StatusForbidden)
+		return true
+	}
+
+	return false
+}
+
 func (oc *OrderController) Create(w http.ResponseWriter, r *http.Request) {
 	oc.Logger.Info("=== ORDER CREATE ENDPOINT CALLED ===")
 
@@ -229,8 +442,15 @@ func (oc *OrderController) AddInputToOrder(w http.ResponseWriter, r *http.Reques
 	}
 	oc.Logger.Info("Input ID parsed successfully", zap.String("inputID", inputID.String()))
 
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	oc.Logger.Info("Calling AddInputToOrder.Process...")
-	err = oc.AddInputToOrderUC.Process(orderID, inputID, dto.Quantity)
+	err = oc.AddInputToOrderUC.Process(orderID, inputID, dto.Quantity, claims.UserID)
 	if err != nil {
 		oc.Logger.Error("Error adding input to order", zap.Error(err))
 
@@ -247,11 +467,18 @@ func (oc *OrderController) AddInputToOrder(w http.ResponseWriter, r *http.Reques
 		}
 
 		// Tratamento específico para quantidade insuficiente
-		if err.Error() == "insufficient input quantity" {
+		// A reserva ou baixa atômica também falha quando outra order consumiu o saldo disponível
+		if err.Error() == "insufficient input quantity" || err.Error() == "insufficient quantity" {
 			http.Error(w, "Insufficient input quantity", http.StatusBadRequest)
 			return
 		}
 
+		// Tratamento específico para order finalizada
+		if err.Error() == "order is finalized" {
+			http.Error(w, "Order is finalized", http.StatusConflict)
+			return
+		}
+
 		// Tratamento específico para quantidade inválida
 		if err.Error() == "quantity must be greater than zero" {
 			http.Error(w, "Quantity must be greater than zero", http.StatusBadRequest)
@@ -323,8 +550,15 @@ func (oc *OrderController) RemoveInputFromOrder(w http.ResponseWriter, r *http.R
 	}
 	oc.Logger.Info("Input ID parsed successfully", zap.String("inputID", inputID.String()))
 
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	oc.Logger.Info("Calling RemoveInputFromOrder.Process...")
-	err = oc.RemoveInputFromOrderUC.Process(orderID, inputID, dto.Quantity)
+	err = oc.RemoveInputFromOrderUC.Process(orderID, inputID, dto.Quantity, claims.UserID)
 	if err != nil {
 		oc.Logger.Error("Error removing input from order", zap.Error(err))
 
@@ -364,6 +598,12 @@ func (oc *OrderController) RemoveInputFromOrder(w http.ResponseWriter, r *http.R
 			return
 		}
 
+		// Tratamento específico para order finalizada
+		if err.Error() == "order is finalized" {
+			http.Error(w, "Order is finalized", http.StatusConflict)
+			return
+		}
+
 		http.Error(w, "Error removing input from order", http.StatusInternalServerError)
 		return
 	}
@@ -396,6 +636,11 @@ func (oc *OrderController) FindOrderOverviewById(w http.ResponseWriter, r *http.
 	}
 	oc.Logger.Info("Order ID parsed successfully", zap.String("orderID", orderID.String()))
 
+	// O overview traz valores pagos e em aberto; o cliente só vê as próprias orders
+	if !oc.authorizeOrderAccess(w, r, orderID) {
+		return
+	}
+
 	oc.Logger.Info("Calling FindOrderOverviewById.Process...")
 	result, err := oc.FindOrderOverviewByIdUC.Process(orderID)
 	if err != nil {
@@ -425,6 +670,171 @@ func (oc *OrderController) FindOrderOverviewById(w http.ResponseWriter, r *http.
 	json.NewEncoder(w).Encode(result)
 }
 
+// parseOrderFilter converte os filtros específicos da listagem de orders da query string
+func (oc *OrderController) parseOrderFilter(r *http.Request) (repository.OrderFilter, error) {
+	query := r.URL.Query()
+	filter := repository.OrderFilter{
+		Status:      query.Get("status"),
+		NumberPlate: query.Get("plate"),
+	}
+
+	if value := query.Get("customer_id"); value != "" {
+		customerID, err := uuid.Parse(value)
+		if err != nil {
+			return filter, errors.New("Invalid customer ID format")
+		}
+		filter.CustomerID = &customerID
+	}
+
+	if value := query.Get("vehicle_id"); value != "" {
+		vehicleID, err := uuid.Parse(value)
+		if err != nil {
+			return filter, errors.New("Invalid vehicle ID format")
+		}
+		filter.VehicleID = &vehicleID
+	}
+
+	if value := query.Get("mechanic_id"); value != "" {
+		mechanicID, err := uuid.Parse(value)
+		if err != nil {
+			return filter, errors.New("Invalid mechanic ID format")
+		}
+		filter.MechanicID = &mechanicID
+	}
+
+	if value := query.Get("created_from"); value != "" {
+		createdFrom, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, errors.New("Invalid created_from format, expected RFC3339")
+		}
+		filter.CreatedFrom = &createdFrom
+	}
+
+	if value := query.Get("created_to"); value != "" {
+		createdTo, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, errors.New("Invalid created_to format, expected RFC3339")
+		}
+		filter.CreatedTo = &createdTo
+	}
+
+	return filter, nil
+}
+
+func (oc *OrderController) FindAll(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("Received find all orders request", zap.String("query", r.URL.RawQuery))
+
+	filter, err := oc.parseOrderFilter(r)
+	if err != nil {
+		oc.Logger.Error("Error parsing order filter", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	spec, err := parseQuerySpec(r)
+	if err != nil {
+		oc.Logger.Error("Error parsing query", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	result, err := oc.FindAllOrdersUC.Process(filter, spec, claims.UserID, claims.UserType)
+	if err != nil {
+		oc.Logger.Error("Error finding orders", zap.Error(err))
+		if writeQueryError(w, err) {
+			return
+		}
+
+		switch err.Error() {
+		case "invalid status", "invalid created at range":
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case "user not found", "user has no customer":
+			http.Error(w, "User has no customer", http.StatusForbidden)
+		default:
+			http.Error(w, "Error finding orders", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	oc.Logger.Info("Orders found successfully",
+		zap.Int("count", len(result.Items)),
+		zap.Int64("total", result.Total))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+func (oc *OrderController) FlagOrderInputCondition(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER FLAG INPUT CONDITION ENDPOINT CALLED ===")
+
+	// Extrai o order ID da URL
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto FlagOrderInputConditionDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	inputID, err := uuid.Parse(dto.InputID)
+	if err != nil {
+		oc.Logger.Error("Error parsing input ID", zap.Error(err))
+		http.Error(w, "Invalid input ID format", http.StatusBadRequest)
+		return
+	}
+
+	err = oc.FlagOrderInputConditionUC.Process(orderID, inputID, *dto.UsedOrDamaged)
+	if err != nil {
+		oc.Logger.Error("Error flagging order input condition", zap.Error(err))
+
+		switch err.Error() {
+		case "order not found":
+			http.Error(w, "Order not found", http.StatusNotFound)
+		case "order input not found":
+			http.Error(w, "Order input not found", http.StatusNotFound)
+		case "order is finalized":
+			http.Error(w, "Order is finalized", http.StatusConflict)
+		case "only consumed order inputs can be flagged":
+			http.Error(w, "Only consumed order inputs can be flagged", http.StatusConflict)
+		default:
+			http.Error(w, "Error flagging order input condition", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	oc.Logger.Info("Order input condition updated successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("inputID", inputID.String()))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":         "Order input condition updated successfully",
+		"order_id":        orderID.String(),
+		"input_id":        inputID.String(),
+		"used_or_damaged": *dto.UsedOrDamaged,
+	})
+}
+
 func (oc *OrderController) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
 	oc.Logger.Info("=== ORDER UPDATE STATUS ENDPOINT CALLED ===")
 
@@ -461,11 +871,27 @@ func (oc *OrderController) UpdateOrderStatus(w http.ResponseWriter, r *http.Requ
 
 	oc.Logger.Info("Validation passed")
 
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	oc.Logger.Info("Calling UpdateOrderStatus.Process...")
-	err = oc.UpdateOrderStatusUC.Process(orderID, dto.Status)
+	restockSummary, err := oc.UpdateOrderStatusUC.Process(orderID, dto.Status, claims.UserType, statusHistory.StatusChange{
+		ChangedByUserID: &claims.UserID,
+		Note:            strings.TrimSpace(dto.Note),
+		Source:          statusHistory.SourceAPI,
+		OverrideBalance: dto.OverrideBalance,
+	})
 	if err != nil {
 		oc.Logger.Error("Error updating order status", zap.Error(err))
 
+		if oc.writeStatusTransitionError(w, err) {
+			return
+		}
+
 		// Tratamento específico para order não encontrado
 		if err.Error() == "order not found" {
 			http.Error(w, "Order not found", http.StatusNotFound)
@@ -478,6 +904,22 @@ func (oc *OrderController) UpdateOrderStatus(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
+		// Tratamento específico para order alterada depois da aprovação do orçamento
+		if err.Error() == "order changed after approval" {
+			http.Error(w, "Order changed after quote approval, a new quote must be approved", http.StatusConflict)
+			return
+		}
+
+		// Tratamento específico para entrega com saldo em aberto
+		if err.Error() == "order has outstanding balance" {
+			http.Error(w, "Order has an outstanding balance, record the payment or ask an admin to override", http.StatusConflict)
+			return
+		}
+		if err.Error() == "balance override requires admin" {
+			http.Error(w, "Only admins can override the outstanding balance", http.StatusForbidden)
+			return
+		}
+
 		http.Error(w, "Error updating order status", http.StatusInternalServerError)
 		return
 	}
@@ -486,10 +928,1034 @@ func (oc *OrderController) UpdateOrderStatus(w http.ResponseWriter, r *http.Requ
 		zap.String("orderID", orderID.String()),
 		zap.String("newStatus", dto.Status))
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
+	response := map[string]interface{}{
 		"message":  "Order status updated successfully",
 		"order_id": orderID.String(),
 		"status":   dto.Status,
+	}
+	// No cancelamento a resposta informa o que voltou ao estoque
+	if restockSummary != nil {
+		response["restock_summary"] = restockSummary
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// decodeOrderDecision decodifica o corpo opcional de aprovação/rejeição
+func (oc *OrderController) decodeOrderDecision(r *http.Request) (*OrderDecisionDTO, error) {
+	var dto OrderDecisionDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return &dto, nil
+}
+
+// writeOrderDecisionError escreve a resposta de erro da aprovação/rejeição
+func (oc *OrderController) writeOrderDecisionError(w http.ResponseWriter, err error) {
+	if oc.writeStatusTransitionError(w, err) {
+		return
+	}
+
+	switch err.Error() {
+	case "order not found":
+		http.Error(w, "Order not found", http.StatusNotFound)
+	case "user not found":
+		http.Error(w, "User not found", http.StatusNotFound)
+	case "order does not belong to user customer":
+		http.Error(w, "Order does not belong to user customer", http.StatusForbidden)
+	case "order is not awaiting approval":
+		http.Error(w, "Order is not awaiting approval", http.StatusConflict)
+	case "quote not found":
+		http.Error(w, "Quote not found", http.StatusNotFound)
+	case "quote is outdated":
+		http.Error(w, "Quote is outdated, a newer version must be approved", http.StatusConflict)
+	case "quote has expired":
+		http.Error(w, "Quote has expired", http.StatusConflict)
+	default:
+		http.Error(w, "Error processing order decision", http.StatusInternalServerError)
+	}
+}
+
+func (oc *OrderController) Approve(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER APPROVE ENDPOINT CALLED ===")
+
+	// Extrai o order ID da URL
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	dto, err := oc.decodeOrderDecision(r)
+	if err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// A aprovação sempre se refere a uma versão específica do orçamento
+	if dto.QuoteVersion <= 0 {
+		oc.Logger.Error("Quote version not informed")
+		http.Error(w, "quote_version is required", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	oc.Logger.Info("Calling ApproveOrder.Process...",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", claims.UserID.String()),
+		zap.Int("quoteVersion", dto.QuoteVersion))
+	err = oc.ApproveOrderUC.Process(orderID, claims.UserID, dto.QuoteVersion, dto.Comment)
+	if err != nil {
+		oc.Logger.Error("Error approving order", zap.Error(err))
+		oc.writeOrderDecisionError(w, err)
+		return
+	}
+
+	oc.Logger.Info("Order approved successfully", zap.String("orderID", orderID.String()))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":       "Order approved successfully",
+		"order_id":      orderID.String(),
+		"status":        domain.StatusInProgress,
+		"quote_version": dto.QuoteVersion,
 	})
 }
+
+func (oc *OrderController) Reject(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER REJECT ENDPOINT CALLED ===")
+
+	// Extrai o order ID da URL
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	dto, err := oc.decodeOrderDecision(r)
+	if err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	oc.Logger.Info("Calling RejectOrder.Process...",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", claims.UserID.String()))
+	restockSummary, err := oc.RejectOrderUC.Process(orderID, claims.UserID, dto.Comment)
+	if err != nil {
+		oc.Logger.Error("Error rejecting order", zap.Error(err))
+		oc.writeOrderDecisionError(w, err)
+		return
+	}
+
+	oc.Logger.Info("Order rejected successfully", zap.String("orderID", orderID.String()))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":         "Order rejected successfully",
+		"order_id":        orderID.String(),
+		"status":          domain.StatusCanceled,
+		"restock_summary": restockSummary,
+	})
+}
+
+// parseOptionalUUID converte um id opcional do body
+func parseOptionalUUID(value *string) (*uuid.UUID, error) {
+	if value == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// writeOrderServiceError traduz os erros das linhas de mão de obra para o status HTTP
+func (oc *OrderController) writeOrderServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "order not found":
+		http.Error(w, "Order not found", http.StatusNotFound)
+	case "labor service not found":
+		http.Error(w, "Labor service not found", http.StatusNotFound)
+	case "order service not found":
+		http.Error(w, "Order service not found", http.StatusNotFound)
+	case "mechanic not found":
+		http.Error(w, "Mechanic not found", http.StatusNotFound)
+	case "order is finalized":
+		http.Error(w, "Order is finalized", http.StatusConflict)
+	case "user is not a mechanic", "estimated hours must be greater than zero", "actual hours cannot be negative":
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func (oc *OrderController) AddServiceToOrder(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER ADD SERVICE ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto AddServiceToOrderDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	laborServiceID, err := uuid.Parse(dto.LaborServiceID)
+	if err != nil {
+		oc.Logger.Error("Error parsing labor service ID", zap.Error(err))
+		http.Error(w, "Invalid labor service ID format", http.StatusBadRequest)
+		return
+	}
+
+	mechanicID, err := parseOptionalUUID(dto.MechanicID)
+	if err != nil {
+		oc.Logger.Error("Error parsing mechanic ID", zap.Error(err))
+		http.Error(w, "Invalid mechanic ID format", http.StatusBadRequest)
+		return
+	}
+
+	estimatedHours := 0.0
+	if dto.EstimatedHours != nil {
+		estimatedHours = *dto.EstimatedHours
+	}
+
+	oc.Logger.Info("Calling AddServiceToOrder.Process...",
+		zap.String("orderID", orderID.String()),
+		zap.String("laborServiceID", laborServiceID.String()))
+	orderService, err := oc.AddServiceToOrderUC.Process(orderID, laborServiceID, mechanicID, estimatedHours)
+	if err != nil {
+		oc.Logger.Error("Error adding service to order", zap.Error(err))
+		oc.writeOrderServiceError(w, err, "Error adding service to order")
+		return
+	}
+
+	oc.Logger.Info("Service added to order successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("orderServiceID", orderService.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(orderService)
+}
+
+func (oc *OrderController) UpdateOrderService(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER UPDATE SERVICE ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	orderServiceID, err := uuid.Parse(vars["orderServiceId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order service ID", zap.Error(err))
+		http.Error(w, "Invalid order service ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto UpdateOrderServiceDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mechanicID, err := parseOptionalUUID(dto.MechanicID)
+	if err != nil {
+		oc.Logger.Error("Error parsing mechanic ID", zap.Error(err))
+		http.Error(w, "Invalid mechanic ID format", http.StatusBadRequest)
+		return
+	}
+
+	changes := order_service.OrderServiceChanges{
+		MechanicID:     mechanicID,
+		EstimatedHours: dto.EstimatedHours,
+		ActualHours:    dto.ActualHours,
+	}
+
+	orderService, err := oc.UpdateOrderServiceUC.Process(orderID, orderServiceID, changes)
+	if err != nil {
+		oc.Logger.Error("Error updating order service", zap.Error(err))
+		oc.writeOrderServiceError(w, err, "Error updating order service")
+		return
+	}
+
+	oc.Logger.Info("Order service updated successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("orderServiceID", orderServiceID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(orderService)
+}
+
+func (oc *OrderController) RemoveServiceFromOrder(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER REMOVE SERVICE ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	orderServiceID, err := uuid.Parse(vars["orderServiceId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order service ID", zap.Error(err))
+		http.Error(w, "Invalid order service ID format", http.StatusBadRequest)
+		return
+	}
+
+	err = oc.RemoveServiceFromOrderUC.Process(orderID, orderServiceID)
+	if err != nil {
+		oc.Logger.Error("Error removing service from order", zap.Error(err))
+		oc.writeOrderServiceError(w, err, "Error removing service from order")
+		return
+	}
+
+	oc.Logger.Info("Service removed from order successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("orderServiceID", orderServiceID.String()))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message":          "Service removed from order successfully",
+		"order_id":         orderID.String(),
+		"order_service_id": orderServiceID.String(),
+	})
+}
+
+func (oc *OrderController) GenerateQuote(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER GENERATE QUOTE ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	// O corpo é opcional
+	var dto GenerateQuoteDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil && !errors.Is(err, io.EOF) {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	quote, err := oc.GenerateQuoteUC.Process(orderID, claims.UserID, dto.ValidityDays)
+	if err != nil {
+		oc.Logger.Error("Error generating quote", zap.Error(err))
+
+		switch err.Error() {
+		case "order not found":
+			http.Error(w, "Order not found", http.StatusNotFound)
+		case "order is finalized":
+			http.Error(w, "Order is finalized", http.StatusConflict)
+		case "order has no items to quote":
+			http.Error(w, "Order has no items to quote", http.StatusConflict)
+		case "invalid validity days":
+			http.Error(w, "validity_days must be between 1 and 90", http.StatusBadRequest)
+		default:
+			http.Error(w, "Error generating quote", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	oc.Logger.Info("Quote generated successfully",
+		zap.String("orderID", orderID.String()),
+		zap.Int("version", quote.Version))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(quote)
+}
+
+func (oc *OrderController) FindQuotes(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER FIND QUOTES ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	if !oc.authorizeOrderAccess(w, r, orderID) {
+		return
+	}
+
+	quotes, err := oc.FindQuotesByOrderIdUC.Process(orderID)
+	if err != nil {
+		oc.Logger.Error("Error finding quotes", zap.Error(err))
+
+		if err.Error() == "order not found" {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, "Error finding quotes", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quotes)
+}
+
+func (oc *OrderController) FindQuoteByVersion(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER FIND QUOTE BY VERSION ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil || version <= 0 {
+		oc.Logger.Error("Error parsing quote version", zap.String("version", vars["version"]))
+		http.Error(w, "Invalid quote version", http.StatusBadRequest)
+		return
+	}
+
+	if !oc.authorizeOrderAccess(w, r, orderID) {
+		return
+	}
+
+	quote, err := oc.FindQuoteByVersionUC.Process(orderID, version)
+	if err != nil {
+		oc.Logger.Error("Error finding quote", zap.Error(err))
+		http.Error(w, "Quote not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quote)
+}
+
+// authorizeOrderAccess restringe o dono de veículo às orders do seu customer e escreve a resposta
+// de erro quando o acesso é negado
+func (oc *OrderController) authorizeOrderAccess(w http.ResponseWriter, r *http.Request, orderID uuid.UUID) bool {
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	err := oc.ValidateOrderAccessUC.Process(orderID, claims.UserID, claims.UserType)
+	if err != nil {
+		oc.Logger.Error("Error validating order access", zap.Error(err))
+		writeOrderAccessError(w, err)
+		return false
+	}
+	return true
+}
+
+// writeOrderAccessError traduz os erros da validação de acesso à order para o status HTTP
+func writeOrderAccessError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "order not found":
+		http.Error(w, "Order not found", http.StatusNotFound)
+	case "invoice not found":
+		http.Error(w, "Invoice not found", http.StatusNotFound)
+	case "user not found":
+		http.Error(w, "User not found", http.StatusNotFound)
+	case "order does not belong to user customer":
+		http.Error(w, "Order does not belong to user customer", http.StatusForbidden)
+	default:
+		http.Error(w, "Error validating order access", http.StatusInternalServerError)
+	}
+}
+
+// writeOrderAdjustmentError traduz os erros dos descontos e acréscimos para o status HTTP
+func (oc *OrderController) writeOrderAdjustmentError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "order not found":
+		http.Error(w, "Order not found", http.StatusNotFound)
+	case "order line not found":
+		http.Error(w, "Order line not found", http.StatusNotFound)
+	case "order adjustment not found":
+		http.Error(w, "Order adjustment not found", http.StatusNotFound)
+	case "order is finalized":
+		http.Error(w, "Order is finalized", http.StatusConflict)
+	case "order adjustment is not pending approval":
+		http.Error(w, "Order adjustment is not pending approval", http.StatusConflict)
+	case "invalid adjustment kind", "invalid adjustment value type", "adjustment value does not match value type",
+		"adjustment value must be greater than zero",
+		"adjustment percentage cannot exceed 100", "adjustment reason is required":
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func (oc *OrderController) AddOrderAdjustment(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER ADD ADJUSTMENT ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto AddOrderAdjustmentDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lineID, err := parseOptionalUUID(dto.LineID)
+	if err != nil {
+		oc.Logger.Error("Error parsing line ID", zap.Error(err))
+		http.Error(w, "Invalid line ID format", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entity := &adjustmentDomain.OrderAdjustment{
+		ID:              uuid.New(),
+		OrderID:         orderID,
+		LineID:          lineID,
+		Kind:            dto.Kind,
+		ValueType:       dto.ValueType,
+		Percentage:      dto.Percentage,
+		Amount:          dto.Amount,
+		Reason:          strings.TrimSpace(dto.Reason),
+		CreatedByUserID: claims.UserID,
+	}
+
+	oc.Logger.Info("Calling AddOrderAdjustment.Process...",
+		zap.String("orderID", orderID.String()),
+		zap.String("kind", entity.Kind),
+		zap.String("userType", claims.UserType))
+	adjustment, err := oc.AddOrderAdjustmentUC.Process(entity, claims.UserType)
+	if err != nil {
+		oc.Logger.Error("Error adding adjustment to order", zap.Error(err))
+		oc.writeOrderAdjustmentError(w, err, "Error adding adjustment to order")
+		return
+	}
+
+	oc.Logger.Info("Adjustment added to order successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("adjustmentID", adjustment.ID.String()),
+		zap.String("status", adjustment.Status))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(adjustment)
+}
+
+func (oc *OrderController) ApproveOrderAdjustment(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER APPROVE ADJUSTMENT ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	adjustmentID, err := uuid.Parse(vars["adjustmentId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing adjustment ID", zap.Error(err))
+		http.Error(w, "Invalid adjustment ID format", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	adjustment, err := oc.ApproveOrderAdjustmentUC.Process(orderID, adjustmentID, claims.UserID)
+	if err != nil {
+		oc.Logger.Error("Error approving order adjustment", zap.Error(err))
+		oc.writeOrderAdjustmentError(w, err, "Error approving order adjustment")
+		return
+	}
+
+	oc.Logger.Info("Order adjustment approved successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("adjustmentID", adjustmentID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(adjustment)
+}
+
+func (oc *OrderController) RemoveOrderAdjustment(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER REMOVE ADJUSTMENT ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	adjustmentID, err := uuid.Parse(vars["adjustmentId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing adjustment ID", zap.Error(err))
+		http.Error(w, "Invalid adjustment ID format", http.StatusBadRequest)
+		return
+	}
+
+	err = oc.RemoveOrderAdjustmentUC.Process(orderID, adjustmentID)
+	if err != nil {
+		oc.Logger.Error("Error removing order adjustment", zap.Error(err))
+		oc.writeOrderAdjustmentError(w, err, "Error removing order adjustment")
+		return
+	}
+
+	oc.Logger.Info("Order adjustment removed successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("adjustmentID", adjustmentID.String()))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message":       "Order adjustment removed successfully",
+		"order_id":      orderID.String(),
+		"adjustment_id": adjustmentID.String(),
+	})
+}
+
+func (oc *OrderController) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER RECORD PAYMENT ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto RecordPaymentDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entity := &paymentDomain.Payment{
+		OrderID:          orderID,
+		Method:           dto.Method,
+		Amount:           dto.Amount,
+		Reference:        dto.Reference,
+		ReceivedByUserID: &claims.UserID,
+	}
+	if dto.PaidAt != nil {
+		entity.PaidAt = *dto.PaidAt
+	}
+
+	recorded, err := oc.RecordPaymentUC.Process(entity)
+	if err != nil {
+		oc.Logger.Error("Error recording payment", zap.Error(err))
+
+		switch err.Error() {
+		case "order not found":
+			http.Error(w, "Order not found", http.StatusNotFound)
+		case "invalid payment method":
+			http.Error(w, "Invalid payment method, use cash, card, pix or bank_slip", http.StatusBadRequest)
+		case "payment amount must be greater than zero", "payment reference is required", "payment date cannot be in the future":
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case "order is canceled":
+			http.Error(w, "Order is canceled", http.StatusConflict)
+		case "order has no amount due":
+			http.Error(w, "Order has no amount due", http.StatusConflict)
+		case "payment exceeds amount due":
+			http.Error(w, "Payment exceeds amount due", http.StatusConflict)
+		default:
+			http.Error(w, "Error recording payment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	oc.Logger.Info("Payment recorded successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("paymentID", recorded.ID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(recorded)
+}
+
+func (oc *OrderController) FindPayments(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER FIND PAYMENTS ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	if !oc.authorizeOrderAccess(w, r, orderID) {
+		return
+	}
+
+	payments, err := oc.FindPaymentsByOrderIdUC.Process(orderID)
+	if err != nil {
+		oc.Logger.Error("Error finding payments", zap.Error(err))
+
+		if err.Error() == "order not found" {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, "Error finding payments", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(payments)
+}
+
+// Document gera a ordem de serviço, o orçamento ou o comprovante de entrega da order em HTML ou
+// PDF. Sem format, o documento sai em PDF; no orçamento, ?version escolhe a versão impressa.
+func (oc *OrderController) Document(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER DOCUMENT ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	// A ownership é validada antes de gerar o documento
+	if !oc.authorizeOrderAccess(w, r, orderID) {
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = document.FormatPDF
+	}
+
+	version := 0
+	if value := query.Get("version"); value != "" {
+		version, err = strconv.Atoi(value)
+		if err != nil || version <= 0 {
+			oc.Logger.Error("Error parsing quote version", zap.String("version", value))
+			http.Error(w, "Invalid quote version", http.StatusBadRequest)
+			return
+		}
+	}
+
+	content, contentType, err := oc.RenderOrderDocumentUC.Process(orderID, query.Get("type"), format, version)
+	if err != nil {
+		oc.Logger.Error("Error rendering order document", zap.Error(err))
+
+		switch err.Error() {
+		case "invalid document type":
+			http.Error(w, "Invalid document type, use work_order, quote or receipt", http.StatusBadRequest)
+		case document.ErrInvalidFormat.Error():
+			http.Error(w, "Invalid format, use html or pdf", http.StatusBadRequest)
+		case "order not found":
+			http.Error(w, "Order not found", http.StatusNotFound)
+		case "quote not found":
+			http.Error(w, "Quote not found", http.StatusNotFound)
+		case "order is not delivered":
+			http.Error(w, "Receipt is only available after delivery", http.StatusConflict)
+		default:
+			http.Error(w, "Error rendering order document", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// writeOrderAssignmentError traduz os erros da designação de mecânicos para o status HTTP
+func (oc *OrderController) writeOrderAssignmentError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "order not found":
+		http.Error(w, "Order not found", http.StatusNotFound)
+	case "mechanic not found":
+		http.Error(w, "Mechanic not found", http.StatusNotFound)
+	case "mechanic not assigned":
+		http.Error(w, "Mechanic not assigned to order", http.StatusNotFound)
+	case "user is not a mechanic":
+		http.Error(w, "User is not a mechanic", http.StatusBadRequest)
+	case "order is finalized":
+		http.Error(w, "Order is finalized", http.StatusConflict)
+	case "mechanic already assigned":
+		http.Error(w, "Mechanic already assigned to order", http.StatusConflict)
+	default:
+		http.Error(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func (oc *OrderController) AssignMechanic(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER ASSIGN MECHANIC ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	var dto AssignMechanicDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		oc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		oc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mechanicID, err := uuid.Parse(dto.MechanicID)
+	if err != nil {
+		oc.Logger.Error("Error parsing mechanic ID", zap.Error(err))
+		http.Error(w, "Invalid mechanic ID format", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	assignment, err := oc.AssignMechanicUC.Process(orderID, mechanicID, claims.UserID)
+	if err != nil {
+		oc.Logger.Error("Error assigning mechanic", zap.Error(err))
+		oc.writeOrderAssignmentError(w, err, "Error assigning mechanic")
+		return
+	}
+
+	oc.Logger.Info("Mechanic assigned successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("mechanicID", mechanicID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(assignment)
+}
+
+func (oc *OrderController) UnassignMechanic(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER UNASSIGN MECHANIC ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	mechanicID, err := uuid.Parse(vars["mechanicId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing mechanic ID", zap.Error(err))
+		http.Error(w, "Invalid mechanic ID format", http.StatusBadRequest)
+		return
+	}
+
+	claims, ok := claimsFromRequest(r)
+	if !ok {
+		oc.Logger.Error("Claims not found in context")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	assignment, err := oc.UnassignMechanicUC.Process(orderID, mechanicID, claims.UserID)
+	if err != nil {
+		oc.Logger.Error("Error unassigning mechanic", zap.Error(err))
+		oc.writeOrderAssignmentError(w, err, "Error unassigning mechanic")
+		return
+	}
+
+	oc.Logger.Info("Mechanic unassigned successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("mechanicID", mechanicID.String()))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(assignment)
+}
+
+// FindAssignments retorna o histórico de mecânicos designados para a order
+func (oc *OrderController) FindAssignments(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== ORDER FIND ASSIGNMENTS ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	orderID, err := uuid.Parse(vars["orderId"])
+	if err != nil {
+		oc.Logger.Error("Error parsing order ID", zap.Error(err))
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	assignments, err := oc.FindOrderAssignmentsUC.Process(orderID)
+	if err != nil {
+		oc.Logger.Error("Error finding order assignments", zap.Error(err))
+		oc.writeOrderAssignmentError(w, err, "Error finding order assignments")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(assignments)
+}
+
+// MechanicOrders retorna a fila de orders do mecânico, paginada como a listagem de orders
+func (oc *OrderController) MechanicOrders(w http.ResponseWriter, r *http.Request) {
+	oc.Logger.Info("=== MECHANIC ORDERS ENDPOINT CALLED ===")
+
+	vars := mux.Vars(r)
+	mechanicID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		oc.Logger.Error("Error parsing mechanic ID", zap.Error(err))
+		http.Error(w, "Invalid mechanic ID format", http.StatusBadRequest)
+		return
+	}
+
+	spec, err := parseQuerySpec(r)
+	if err != nil {
+		oc.Logger.Error("Error parsing query", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := oc.FindMechanicOrdersUC.Process(mechanicID, r.URL.Query().Get("status"), spec)
+	if err != nil {
+		oc.Logger.Error("Error finding mechanic orders", zap.Error(err))
+		if writeQueryError(w, err) {
+			return
+		}
+
+		switch err.Error() {
+		case "invalid status":
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			oc.writeOrderAssignmentError(w, err, "Error finding mechanic orders")
+		}
+		return
+	}
+
+	oc.Logger.Info("Mechanic orders found successfully",
+		zap.String("mechanicID", mechanicID.String()),
+		zap.Int("count", len(result.Items)),
+		zap.Int64("total", result.Total))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/pricing"
+	"go.uber.org/zap"
+)
+
+type PricingController struct {
+	Logger                  *zap.Logger
+	FindPricingSettingsUC   *pricing.FindPricingSettings
+	UpdatePricingSettingsUC *pricing.UpdatePricingSettings
+}
+
+// PricingSettingsDTO substitui toda a configuração de preços
+type PricingSettingsDTO struct {
+	MechanicDiscountCapPercent *float64         `json:"mechanic_discount_cap_percent"`
+	TaxRates                   []domain.TaxRate `json:"tax_rates"`
+}
+
+func (dto *PricingSettingsDTO) Validate() error {
+	if dto.MechanicDiscountCapPercent == nil {
+		return errors.New("mechanic_discount_cap_percent is required")
+	}
+	if dto.TaxRates == nil {
+		return errors.New("tax_rates is required")
+	}
+	return nil
+}
+
+func (pc *PricingController) FindSettings(w http.ResponseWriter, r *http.Request) {
+	pc.Logger.Info("=== PRICING FIND SETTINGS ENDPOINT CALLED ===")
+
+	settings, err := pc.FindPricingSettingsUC.Process()
+	if err != nil {
+		pc.Logger.Error("Error finding pricing settings", zap.Error(err))
+		http.Error(w, "Error finding pricing settings", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
+
+func (pc *PricingController) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	pc.Logger.Info("=== PRICING UPDATE SETTINGS ENDPOINT CALLED ===")
+
+	var dto PricingSettingsDTO
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		pc.Logger.Error("Error decoding JSON", zap.Error(err))
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		pc.Logger.Error("Validation failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	settings := domain.Settings{
+		MechanicDiscountCapPercent: *dto.MechanicDiscountCapPercent,
+		TaxRates:                   dto.TaxRates,
+	}
+
+	err := pc.UpdatePricingSettingsUC.Process(settings)
+	if err != nil {
+		pc.Logger.Error("Error updating pricing settings", zap.Error(err))
+
+		switch err.Error() {
+		case "invalid discount cap", "invalid tax rate category", "duplicated tax rate category",
+			"tax rate name is required", "invalid tax rate":
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Error updating pricing settings", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	pc.Logger.Info("Pricing settings updated successfully")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+)
+
+// parseQuerySpec lê da query string a paginação e a ordenação comuns a todas as listagens
+// (limit, cursor, sort e order) e os filtros de igualdade aceitos pelo endpoint
+func parseQuerySpec(r *http.Request, filterFields ...string) (repository.QuerySpec, error) {
+	query := r.URL.Query()
+	spec := repository.QuerySpec{
+		Cursor:  query.Get("cursor"),
+		SortBy:  query.Get("sort"),
+		Filters: map[string]string{},
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return spec, errors.New("Invalid limit")
+		}
+		spec.Limit = limit
+	}
+
+	switch query.Get("order") {
+	case "", "desc":
+		spec.SortDesc = true
+	case "asc":
+		spec.SortDesc = false
+	default:
+		return spec, errors.New("Invalid order, expected asc or desc")
+	}
+
+	for _, field := range filterFields {
+		if value := query.Get(field); value != "" {
+			spec.Filters[field] = value
+		}
+	}
+
+	return spec, nil
+}
+
+// writeQueryError responde 400 para erros de paginação, ordenação ou filtro.
+// Retorna false quando o erro não é de query e deve ser tratado pelo chamador.
+func writeQueryError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, repository.ErrInvalidLimit):
+		http.Error(w, "Invalid limit", http.StatusBadRequest)
+	case errors.Is(err, repository.ErrInvalidCursor):
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+	case errors.Is(err, repository.ErrInvalidSortField):
+		http.Error(w, "Invalid sort field", http.StatusBadRequest)
+	case errors.Is(err, repository.ErrInvalidFilterField):
+		http.Error(w, "Invalid filter field", http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
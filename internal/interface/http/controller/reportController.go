@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/report"
+	"go.uber.org/zap"
+)
+
+type ReportController struct {
+	Logger                     *zap.Logger
+	FindStatusDurationReportUC *report.FindStatusDurationReport
+}
+
+// parseReportDate lê uma data no formato YYYY-MM-DD; parâmetro ausente resulta em nil
+func parseReportDate(r *http.Request, name string) (*time.Time, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, false
+	}
+	return &date, true
+}
+
+// StatusDurations retorna o relatório em JSON ou, com ?format=csv, a exportação em CSV
+func (rc *ReportController) StatusDurations(w http.ResponseWriter, r *http.Request) {
+	rc.Logger.Info("=== REPORT STATUS DURATIONS ENDPOINT CALLED ===")
+
+	from, ok := parseReportDate(r, "from")
+	if !ok {
+		http.Error(w, "Invalid from, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	to, ok := parseReportDate(r, "to")
+	if !ok {
+		http.Error(w, "Invalid to, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = report.FormatJSON
+	}
+	if format != report.FormatJSON && format != report.FormatCSV {
+		http.Error(w, "Invalid format, use json or csv", http.StatusBadRequest)
+		return
+	}
+
+	result, err := rc.FindStatusDurationReportUC.Process(from, to)
+	if err != nil {
+		rc.Logger.Error("Error generating status duration report", zap.Error(err))
+
+		switch err.Error() {
+		case "invalid date range":
+			http.Error(w, "from must not be after to", http.StatusBadRequest)
+		case "date range too long":
+			http.Error(w, "Date range too long", http.StatusBadRequest)
+		default:
+			http.Error(w, "Error generating report", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if format == report.FormatCSV {
+		content, err := report.RenderCSV(result)
+		if err != nil {
+			rc.Logger.Error("Error rendering report CSV", zap.Error(err))
+			http.Error(w, "Error generating report", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="status-durations.csv"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
@@ -194,17 +194,28 @@ func (vc *VehicleController) FindByCustomerId(w http.ResponseWriter, r *http.Req
 
 	vc.Logger.Info("Parsed customer ID", zap.String("customerID", customerID.String()))
 
+	spec, err := parseQuerySpec(r, "brand", "model", "color", "number_plate")
+	if err != nil {
+		vc.Logger.Error("Error parsing query", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	vc.Logger.Info("Calling FindByCustomerIdVehicle.Process...")
-	vehicles, err := vc.FindByCustomerIdVehicle.Process(customerID)
+	vehicles, err := vc.FindByCustomerIdVehicle.Process(customerID, spec)
 	if err != nil {
 		vc.Logger.Error("Error finding vehicles by customer ID", zap.Error(err), zap.String("customerID", customerID.String()))
+		if writeQueryError(w, err) {
+			return
+		}
 		http.Error(w, "Error finding vehicles", http.StatusInternalServerError)
 		return
 	}
 
 	vc.Logger.Info("Successfully found vehicles",
 		zap.String("customerID", customerID.String()),
-		zap.Int("count", len(vehicles)))
+		zap.Int("count", len(vehicles.Items)),
+		zap.Int64("total", vehicles.Total))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
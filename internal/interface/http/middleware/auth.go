@@ -10,14 +10,16 @@ import (
 )
 
 type AuthMiddleware struct {
-	tokenService domain.TokenService
-	logger       *zap.Logger
+	tokenService    domain.TokenService
+	revocationStore domain.TokenRevocationStore
+	logger          *zap.Logger
 }
 
-func NewAuthMiddleware(tokenService domain.TokenService, logger *zap.Logger) *AuthMiddleware {
+func NewAuthMiddleware(tokenService domain.TokenService, revocationStore domain.TokenRevocationStore, logger *zap.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
-		tokenService: tokenService,
-		logger:       logger,
+		tokenService:    tokenService,
+		revocationStore: revocationStore,
+		logger:          logger,
 	}
 }
 
@@ -56,6 +58,19 @@ func (am *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Verifica se o token foi revogado por logout
+		revoked, err := am.revocationStore.IsRevoked(claims)
+		if err != nil {
+			am.logger.Error("Error checking token revocation", zap.Error(err))
+			http.Error(w, "Error validating token", http.StatusInternalServerError)
+			return
+		}
+		if revoked {
+			am.logger.Error("Revoked token", zap.String("tokenID", claims.TokenID.String()))
+			http.Error(w, "Token revoked", http.StatusUnauthorized)
+			return
+		}
+
 		am.logger.Info("Token validated successfully", zap.String("email", claims.Email))
 
 		// Adiciona as claims ao contexto da requisição
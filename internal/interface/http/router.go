@@ -11,32 +11,40 @@ import (
 )
 
 type Router struct {
-	router             *mux.Router
-	logger             *zap.Logger
-	customerController *controller.CustomerController
-	userController     *controller.UserController
-	authController     *controller.AuthController
-	healthController   *controller.HealthController
-	vehicleController  *controller.VehicleController
-	inputController    *controller.InputController
-	orderController    *controller.OrderController
-	authMiddleware     *middleware.AuthMiddleware
-	authzMiddleware    *middleware.AuthorizationMiddleware
+	router                 *mux.Router
+	logger                 *zap.Logger
+	customerController     *controller.CustomerController
+	userController         *controller.UserController
+	authController         *controller.AuthController
+	healthController       *controller.HealthController
+	vehicleController      *controller.VehicleController
+	inputController        *controller.InputController
+	orderController        *controller.OrderController
+	laborServiceController *controller.LaborServiceController
+	pricingController      *controller.PricingController
+	invoiceController      *controller.InvoiceController
+	reportController       *controller.ReportController
+	authMiddleware         *middleware.AuthMiddleware
+	authzMiddleware        *middleware.AuthorizationMiddleware
 }
 
-func NewRouter(logger *zap.Logger, customerController *controller.CustomerController, userController *controller.UserController, authController *controller.AuthController, healthController *controller.HealthController, vehicleController *controller.VehicleController, inputController *controller.InputController, orderController *controller.OrderController, authMiddleware *middleware.AuthMiddleware, authzMiddleware *middleware.AuthorizationMiddleware) *Router {
+func NewRouter(logger *zap.Logger, customerController *controller.CustomerController, userController *controller.UserController, authController *controller.AuthController, healthController *controller.HealthController, vehicleController *controller.VehicleController, inputController *controller.InputController, orderController *controller.OrderController, laborServiceController *controller.LaborServiceController, pricingController *controller.PricingController, invoiceController *controller.InvoiceController, reportController *controller.ReportController, authMiddleware *middleware.AuthMiddleware, authzMiddleware *middleware.AuthorizationMiddleware) *Router {
 	return &Router{
-		router:             mux.NewRouter(),
-		logger:             logger,
-		customerController: customerController,
-		userController:     userController,
-		authController:     authController,
-		healthController:   healthController,
-		vehicleController:  vehicleController,
-		inputController:    inputController,
-		orderController:    orderController,
-		authMiddleware:     authMiddleware,
-		authzMiddleware:    authzMiddleware,
+		router:                 mux.NewRouter(),
+		logger:                 logger,
+		customerController:     customerController,
+		userController:         userController,
+		authController:         authController,
+		healthController:       healthController,
+		vehicleController:      vehicleController,
+		inputController:        inputController,
+		orderController:        orderController,
+		laborServiceController: laborServiceController,
+		pricingController:      pricingController,
+		invoiceController:      invoiceController,
+		reportController:       reportController,
+		authMiddleware:         authMiddleware,
+		authzMiddleware:        authzMiddleware,
 	}
 }
 
@@ -49,12 +57,42 @@ func (r *Router) SetupRouter(router *mux.Router) {
 	router.HandleFunc("/healthz", r.healthController.Healthz).Methods("GET")
 	r.logger.Info("Route registered: GET /healthz")
 
+	router.HandleFunc("/.well-known/jwks.json", r.authController.JWKS).Methods("GET")
+	r.logger.Info("Route registered: GET /.well-known/jwks.json")
+
 	router.HandleFunc("/auth/login", r.authController.Login).Methods("POST")
 	r.logger.Info("Route registered: POST /auth/login")
 
+	router.HandleFunc("/auth/refresh", r.authController.Refresh).Methods("POST")
+	r.logger.Info("Route registered: POST /auth/refresh")
+
+	router.HandleFunc("/auth/password/forgot", r.authController.ForgotPassword).Methods("POST")
+	r.logger.Info("Route registered: POST /auth/password/forgot")
+
+	router.HandleFunc("/auth/password/reset", r.authController.ResetPassword).Methods("POST")
+	r.logger.Info("Route registered: POST /auth/password/reset")
+
+	router.HandleFunc("/auth/email/verify", r.authController.VerifyEmail).Methods("POST")
+	r.logger.Info("Route registered: POST /auth/email/verify")
+
+	router.Handle("/auth/email/verify/resend", r.authMiddleware.Authenticate(http.HandlerFunc(r.authController.ResendEmailVerification))).Methods("POST")
+	r.logger.Info("Route registered: POST /auth/email/verify/resend (ALL AUTHENTICATED USERS)")
+
+	router.Handle("/auth/logout", r.authMiddleware.Authenticate(http.HandlerFunc(r.authController.Logout))).Methods("POST")
+	r.logger.Info("Route registered: POST /auth/logout (ALL AUTHENTICATED USERS)")
+
+	router.Handle("/auth/logout-all", r.authMiddleware.Authenticate(http.HandlerFunc(r.authController.LogoutAll))).Methods("POST")
+	r.logger.Info("Route registered: POST /auth/logout-all (ALL AUTHENTICATED USERS)")
+
 	router.HandleFunc("/user", r.userController.Create).Methods("POST")
 	r.logger.Info("Route registered: POST /user")
 
+	router.Handle("/user/{id}/unlock", r.authMiddleware.Authenticate(r.authzMiddleware.RequireAdmin(http.HandlerFunc(r.authController.UnlockAccount)))).Methods("POST")
+	r.logger.Info("Route registered: POST /user/{id}/unlock (ADMIN)")
+
+	router.Handle("/auth/events", r.authMiddleware.Authenticate(r.authzMiddleware.RequireAdmin(http.HandlerFunc(r.authController.AuthEvents)))).Methods("GET")
+	r.logger.Info("Route registered: GET /auth/events (ADMIN)")
+
 	// ===== ROTAS PARA MECHANIC E ADMIN =====
 	// Customer routes - mechanic e admin
 	router.Handle("/customer", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.customerController.Create)))).Methods("POST")
@@ -104,20 +142,130 @@ func (r *Router) SetupRouter(router *mux.Router) {
 	router.Handle("/input/{id}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.inputController.DeleteById)))).Methods("DELETE")
 	r.logger.Info("Route registered: DELETE /input/{id} (MECHANIC & ADMIN)")
 
+	router.Handle("/input/{id}/movements", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.inputController.FindMovements)))).Methods("GET")
+	r.logger.Info("Route registered: GET /input/{id}/movements (MECHANIC & ADMIN)")
+
+	router.Handle("/input/{id}/movements", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.inputController.RecordMovement)))).Methods("POST")
+	r.logger.Info("Route registered: POST /input/{id}/movements (MECHANIC & ADMIN)")
+
+	// Labor service routes - mechanic e admin
+	router.Handle("/labor-service", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.laborServiceController.Create)))).Methods("POST")
+	r.logger.Info("Route registered: POST /labor-service (MECHANIC & ADMIN)")
+
+	router.Handle("/labor-service", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.laborServiceController.FindAll)))).Methods("GET")
+	r.logger.Info("Route registered: GET /labor-service (MECHANIC & ADMIN)")
+
+	router.Handle("/labor-service/{id}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.laborServiceController.FindById)))).Methods("GET")
+	r.logger.Info("Route registered: GET /labor-service/{id} (MECHANIC & ADMIN)")
+
+	router.Handle("/labor-service/{id}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.laborServiceController.UpdateById)))).Methods("PUT")
+	r.logger.Info("Route registered: PUT /labor-service/{id} (MECHANIC & ADMIN)")
+
+	router.Handle("/labor-service/{id}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.laborServiceController.DeleteById)))).Methods("DELETE")
+	r.logger.Info("Route registered: DELETE /labor-service/{id} (MECHANIC & ADMIN)")
+
+	// Configuração de preços - mecânico e admin consultam, apenas admin altera
+	router.Handle("/pricing/settings", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.pricingController.FindSettings)))).Methods("GET")
+	r.logger.Info("Route registered: GET /pricing/settings (MECHANIC & ADMIN)")
+
+	router.Handle("/pricing/settings", r.authMiddleware.Authenticate(r.authzMiddleware.RequireAdmin(http.HandlerFunc(r.pricingController.UpdateSettings)))).Methods("PUT")
+	r.logger.Info("Route registered: PUT /pricing/settings (ADMIN)")
+
 	// Order routes - mechanic e admin
 	router.Handle("/order", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.Create)))).Methods("POST")
 	r.logger.Info("Route registered: POST /order (MECHANIC & ADMIN)")
 
+	router.Handle("/order", r.authMiddleware.Authenticate(http.HandlerFunc(r.orderController.FindAll))).Methods("GET")
+	r.logger.Info("Route registered: GET /order (ALL AUTHENTICATED USERS)")
+
 	router.Handle("/order/{orderId}/input", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.AddInputToOrder)))).Methods("POST")
 	r.logger.Info("Route registered: POST /order/{orderId}/input (MECHANIC & ADMIN)")
 
 	router.Handle("/order/{orderId}/input/remove", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.RemoveInputFromOrder)))).Methods("POST")
 	r.logger.Info("Route registered: POST /order/{orderId}/input/remove (MECHANIC & ADMIN)")
 
+	router.Handle("/order/{orderId}/input/condition", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.FlagOrderInputCondition)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/input/condition (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/service", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.AddServiceToOrder)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/service (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/service/{orderServiceId}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.UpdateOrderService)))).Methods("PUT")
+	r.logger.Info("Route registered: PUT /order/{orderId}/service/{orderServiceId} (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/service/{orderServiceId}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.RemoveServiceFromOrder)))).Methods("DELETE")
+	r.logger.Info("Route registered: DELETE /order/{orderId}/service/{orderServiceId} (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/adjustment", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.AddOrderAdjustment)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/adjustment (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/adjustment/{adjustmentId}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.RemoveOrderAdjustment)))).Methods("DELETE")
+	r.logger.Info("Route registered: DELETE /order/{orderId}/adjustment/{adjustmentId} (MECHANIC & ADMIN)")
+
+	// Descontos acima do limite do mecânico dependem de um admin
+	router.Handle("/order/{orderId}/adjustment/{adjustmentId}/approve", r.authMiddleware.Authenticate(r.authzMiddleware.RequireAdmin(http.HandlerFunc(r.orderController.ApproveOrderAdjustment)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/adjustment/{adjustmentId}/approve (ADMIN)")
+
 	router.Handle("/order/{orderId}/status", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.UpdateOrderStatus)))).Methods("PUT")
 	r.logger.Info("Route registered: PUT /order/{orderId}/status (MECHANIC & ADMIN)")
 
-	// Order overview - todos os tipos de usuário podem acessar
+	// ===== ROTAS PARA VEHICLE OWNER =====
+	// Aprovação do orçamento pelo cliente dono do veículo
+	router.Handle("/order/{orderId}/approve", r.authMiddleware.Authenticate(r.authzMiddleware.RequireVehicleOwner(http.HandlerFunc(r.orderController.Approve)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/approve (VEHICLE OWNER)")
+
+	router.Handle("/order/{orderId}/reject", r.authMiddleware.Authenticate(r.authzMiddleware.RequireVehicleOwner(http.HandlerFunc(r.orderController.Reject)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/reject (VEHICLE OWNER)")
+
+	// Orçamentos versionados - mecânico e admin geram, todos os usuários consultam
+	router.Handle("/order/{orderId}/quote", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.GenerateQuote)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/quote (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/quote", r.authMiddleware.Authenticate(http.HandlerFunc(r.orderController.FindQuotes))).Methods("GET")
+	r.logger.Info("Route registered: GET /order/{orderId}/quote (ALL AUTHENTICATED USERS)")
+
+	router.Handle("/order/{orderId}/quote/{version}", r.authMiddleware.Authenticate(http.HandlerFunc(r.orderController.FindQuoteByVersion))).Methods("GET")
+	r.logger.Info("Route registered: GET /order/{orderId}/quote/{version} (ALL AUTHENTICATED USERS)")
+
+	// Pagamentos - mecânico e admin registram, todos os usuários consultam o saldo
+	router.Handle("/order/{orderId}/payment", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.RecordPayment)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/payment (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/payment", r.authMiddleware.Authenticate(http.HandlerFunc(r.orderController.FindPayments))).Methods("GET")
+	r.logger.Info("Route registered: GET /order/{orderId}/payment (ALL AUTHENTICATED USERS)")
+
+	// Documentos imprimíveis da order - ordem de serviço, orçamento e comprovante de entrega
+	router.Handle("/order/{orderId}/document", r.authMiddleware.Authenticate(http.HandlerFunc(r.orderController.Document))).Methods("GET")
+	r.logger.Info("Route registered: GET /order/{orderId}/document (ALL AUTHENTICATED USERS)")
+
+	// Mecânicos designados - mecânico e admin designam, retiram e consultam a fila de trabalho
+	router.Handle("/order/{orderId}/mechanic", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.AssignMechanic)))).Methods("POST")
+	r.logger.Info("Route registered: POST /order/{orderId}/mechanic (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/mechanic", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.FindAssignments)))).Methods("GET")
+	r.logger.Info("Route registered: GET /order/{orderId}/mechanic (MECHANIC & ADMIN)")
+
+	router.Handle("/order/{orderId}/mechanic/{mechanicId}", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.UnassignMechanic)))).Methods("DELETE")
+	r.logger.Info("Route registered: DELETE /order/{orderId}/mechanic/{mechanicId} (MECHANIC & ADMIN)")
+
+	router.Handle("/mechanic/{id}/orders", r.authMiddleware.Authenticate(r.authzMiddleware.RequireMechanicOrAdmin(http.HandlerFunc(r.orderController.MechanicOrders)))).Methods("GET")
+	r.logger.Info("Route registered: GET /mechanic/{id}/orders (MECHANIC & ADMIN)")
+
+	// Notas de serviço - emitidas na conclusão da order, todos os usuários consultam e só o admin corrige
+	router.Handle("/order/{orderId}/invoice", r.authMiddleware.Authenticate(http.HandlerFunc(r.invoiceController.FindByOrderId))).Methods("GET")
+	r.logger.Info("Route registered: GET /order/{orderId}/invoice (ALL AUTHENTICATED USERS)")
+
+	router.Handle("/invoice/{id}", r.authMiddleware.Authenticate(http.HandlerFunc(r.invoiceController.FindById))).Methods("GET")
+	r.logger.Info("Route registered: GET /invoice/{id} (ALL AUTHENTICATED USERS)")
+
+	router.Handle("/invoice/{id}/credit-note", r.authMiddleware.Authenticate(r.authzMiddleware.RequireAdmin(http.HandlerFunc(r.invoiceController.IssueCreditNote)))).Methods("POST")
+	r.logger.Info("Route registered: POST /invoice/{id}/credit-note (ADMIN)")
+
+	// Relatórios operacionais - apenas admin
+	router.Handle("/reports/status-durations", r.authMiddleware.Authenticate(r.authzMiddleware.RequireAdmin(http.HandlerFunc(r.reportController.StatusDurations)))).Methods("GET")
+	r.logger.Info("Route registered: GET /reports/status-durations (ADMIN)")
+
+	// Order overview - todos os tipos de usuário podem acessar; o cliente só vê as próprias orders
 	router.Handle("/order/{orderId}/overview", r.authMiddleware.Authenticate(http.HandlerFunc(r.orderController.FindOrderOverviewById))).Methods("GET")
 	r.logger.Info("Route registered: GET /order/{orderId}/overview (ALL AUTHENTICATED USERS)")
 
@@ -20,6 +20,9 @@ func (InputPersistence) ToEntity(model *models.Input) *domain.Input {
 		InputType:   model.InputType,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
+
+		ReservedQuantity:  model.ReservedQuantity,
+		AvailableQuantity: model.Quantity - model.ReservedQuantity,
 	}
 }
 
@@ -36,5 +39,7 @@ func (InputPersistence) ToModel(entity *domain.Input) *models.Input {
 		InputType:   entity.InputType,
 		CreatedAt:   entity.CreatedAt,
 		UpdatedAt:   entity.UpdatedAt,
+
+		ReservedQuantity: entity.ReservedQuantity,
 	}
 }
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type InventoryMovementPersistence struct{}
+
+func (InventoryMovementPersistence) ToEntity(model *models.InventoryMovement) *domain.InventoryMovement {
+	if model == nil {
+		return nil
+	}
+	return &domain.InventoryMovement{
+		ID:           model.ID,
+		InputID:      model.InputID,
+		OrderID:      model.OrderID,
+		UserID:       model.UserID,
+		MovementType: model.MovementType,
+		Delta:        model.Delta,
+		BalanceAfter: model.BalanceAfter,
+		Reason:       model.Reason,
+		CreatedAt:    model.CreatedAt,
+	}
+}
+
+func (InventoryMovementPersistence) ToModel(entity *domain.InventoryMovement) *models.InventoryMovement {
+	if entity == nil {
+		return nil
+	}
+	return &models.InventoryMovement{
+		ID:           entity.ID,
+		InputID:      entity.InputID,
+		OrderID:      entity.OrderID,
+		UserID:       entity.UserID,
+		MovementType: entity.MovementType,
+		Delta:        entity.Delta,
+		BalanceAfter: entity.BalanceAfter,
+		Reason:       entity.Reason,
+		CreatedAt:    entity.CreatedAt,
+	}
+}
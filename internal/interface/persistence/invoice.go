@@ -0,0 +1,150 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type InvoicePersistence struct{}
+
+func (InvoicePersistence) ToEntity(model *models.Invoice) *domain.Invoice {
+	if model == nil {
+		return nil
+	}
+	items := make([]domain.InvoiceItem, 0, len(model.Items))
+	for _, item := range model.Items {
+		items = append(items, domain.InvoiceItem{
+			ID:          item.ID,
+			InvoiceID:   item.InvoiceID,
+			ItemType:    item.ItemType,
+			ReferenceID: item.ReferenceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+		})
+	}
+	creditNotes := make([]domain.CreditNote, 0, len(model.CreditNotes))
+	for i := range model.CreditNotes {
+		creditNotes = append(creditNotes, *CreditNotePersistence{}.ToEntity(&model.CreditNotes[i]))
+	}
+	return &domain.Invoice{
+		ID:              model.ID,
+		WorkshopCode:    model.WorkshopCode,
+		Number:          model.Number,
+		FormattedNumber: domain.FormatNumber(model.WorkshopCode, domain.DocumentTypeInvoice, model.Number),
+		OrderID:         model.OrderID,
+		QuoteID:         model.QuoteID,
+		Customer: domain.CustomerSnapshot{
+			ID:             model.CustomerID,
+			Name:           model.CustomerName,
+			DocumentNumber: model.CustomerDocumentNumber,
+			CustomerType:   model.CustomerType,
+		},
+		Vehicle: domain.VehicleSnapshot{
+			ID:                          model.VehicleID,
+			Model:                       model.VehicleModel,
+			Brand:                       model.VehicleBrand,
+			ReleaseYear:                 model.VehicleReleaseYear,
+			VehicleIdentificationNumber: model.VehicleIdentificationNumber,
+			NumberPlate:                 model.VehicleNumberPlate,
+			Color:                       model.VehicleColor,
+		},
+		Items:           items,
+		PartsSubtotal:   model.PartsSubtotal,
+		LaborSubtotal:   model.LaborSubtotal,
+		DiscountTotal:   model.DiscountTotal,
+		SurchargeTotal:  model.SurchargeTotal,
+		TaxTotal:        model.TaxTotal,
+		TotalPrice:      model.TotalPrice,
+		CreditNotes:     creditNotes,
+		CreatedByUserID: model.CreatedByUserID,
+		IssuedAt:        model.IssuedAt,
+	}
+}
+
+// ToModel converte a nota e suas linhas. As notas de crédito são gravadas separadamente.
+func (InvoicePersistence) ToModel(entity *domain.Invoice) *models.Invoice {
+	if entity == nil {
+		return nil
+	}
+	items := make([]models.InvoiceItem, 0, len(entity.Items))
+	for _, item := range entity.Items {
+		items = append(items, models.InvoiceItem{
+			ID:          item.ID,
+			InvoiceID:   entity.ID,
+			ItemType:    item.ItemType,
+			ReferenceID: item.ReferenceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+		})
+	}
+	return &models.Invoice{
+		ID:           entity.ID,
+		WorkshopCode: entity.WorkshopCode,
+		Number:       entity.Number,
+		OrderID:      entity.OrderID,
+		QuoteID:      entity.QuoteID,
+
+		CustomerID:             entity.Customer.ID,
+		CustomerName:           entity.Customer.Name,
+		CustomerDocumentNumber: entity.Customer.DocumentNumber,
+		CustomerType:           entity.Customer.CustomerType,
+
+		VehicleID:                   entity.Vehicle.ID,
+		VehicleModel:                entity.Vehicle.Model,
+		VehicleBrand:                entity.Vehicle.Brand,
+		VehicleReleaseYear:          entity.Vehicle.ReleaseYear,
+		VehicleIdentificationNumber: entity.Vehicle.VehicleIdentificationNumber,
+		VehicleNumberPlate:          entity.Vehicle.NumberPlate,
+		VehicleColor:                entity.Vehicle.Color,
+
+		PartsSubtotal:  entity.PartsSubtotal,
+		LaborSubtotal:  entity.LaborSubtotal,
+		DiscountTotal:  entity.DiscountTotal,
+		SurchargeTotal: entity.SurchargeTotal,
+		TaxTotal:       entity.TaxTotal,
+		TotalPrice:     entity.TotalPrice,
+
+		Items:           items,
+		CreatedByUserID: entity.CreatedByUserID,
+		IssuedAt:        entity.IssuedAt,
+	}
+}
+
+type CreditNotePersistence struct{}
+
+func (CreditNotePersistence) ToEntity(model *models.CreditNote) *domain.CreditNote {
+	if model == nil {
+		return nil
+	}
+	return &domain.CreditNote{
+		ID:              model.ID,
+		InvoiceID:       model.InvoiceID,
+		WorkshopCode:    model.WorkshopCode,
+		Number:          model.Number,
+		FormattedNumber: domain.FormatNumber(model.WorkshopCode, domain.DocumentTypeCreditNote, model.Number),
+		Reason:          model.Reason,
+		Amount:          model.Amount,
+		CreatedByUserID: model.CreatedByUserID,
+		IssuedAt:        model.IssuedAt,
+	}
+}
+
+func (CreditNotePersistence) ToModel(entity *domain.CreditNote) *models.CreditNote {
+	if entity == nil {
+		return nil
+	}
+	return &models.CreditNote{
+		ID:              entity.ID,
+		InvoiceID:       entity.InvoiceID,
+		WorkshopCode:    entity.WorkshopCode,
+		Number:          entity.Number,
+		Reason:          entity.Reason,
+		Amount:          entity.Amount,
+		CreatedByUserID: entity.CreatedByUserID,
+		IssuedAt:        entity.IssuedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type LaborServicePersistence struct{}
+
+func (LaborServicePersistence) ToEntity(model *models.LaborService) *domain.LaborService {
+	if model == nil {
+		return nil
+	}
+	return &domain.LaborService{
+		ID:           model.ID,
+		Name:         model.Name,
+		Description:  model.Description,
+		DefaultHours: model.DefaultHours,
+		HourlyRate:   model.HourlyRate,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
+
+func (LaborServicePersistence) ToModel(entity *domain.LaborService) *models.LaborService {
+	if entity == nil {
+		return nil
+	}
+	return &models.LaborService{
+		ID:           entity.ID,
+		Name:         entity.Name,
+		Description:  entity.Description,
+		DefaultHours: entity.DefaultHours,
+		HourlyRate:   entity.HourlyRate,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+}
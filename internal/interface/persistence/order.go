@@ -16,8 +16,10 @@ func (OrderPersistence) ToEntity(model *models.Order) *domain.Order {
 		CustomerID: model.CustomerID,
 		VehicleID:  model.VehicleID,
 		Status:     model.Status,
-		CreatedAt:  model.CreatedAt,
-		UpdatedAt:  model.UpdatedAt,
+
+		ApprovedQuoteID: model.ApprovedQuoteID,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
 	}
 }
 
@@ -30,7 +32,9 @@ func (OrderPersistence) ToModel(entity *domain.Order) *models.Order {
 		CustomerID: entity.CustomerID,
 		VehicleID:  entity.VehicleID,
 		Status:     entity.Status,
-		CreatedAt:  entity.CreatedAt,
-		UpdatedAt:  entity.UpdatedAt,
+
+		ApprovedQuoteID: entity.ApprovedQuoteID,
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
 	}
 }
@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type OrderAdjustmentPersistence struct{}
+
+func (OrderAdjustmentPersistence) ToEntity(model *models.OrderAdjustment) *domain.OrderAdjustment {
+	if model == nil {
+		return nil
+	}
+	currency := ""
+	if model.Amount != nil {
+		currency = money.Currency
+	}
+	return &domain.OrderAdjustment{
+		ID:               model.ID,
+		OrderID:          model.OrderID,
+		LineID:           model.LineID,
+		Kind:             model.Kind,
+		ValueType:        model.ValueType,
+		Percentage:       model.Percentage,
+		Amount:           model.Amount,
+		Currency:         currency,
+		Reason:           model.Reason,
+		Status:           model.Status,
+		CreatedByUserID:  model.CreatedByUserID,
+		ApprovedByUserID: model.ApprovedByUserID,
+		ApprovedAt:       model.ApprovedAt,
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+	}
+}
+
+func (OrderAdjustmentPersistence) ToModel(entity *domain.OrderAdjustment) *models.OrderAdjustment {
+	if entity == nil {
+		return nil
+	}
+	return &models.OrderAdjustment{
+		ID:               entity.ID,
+		OrderID:          entity.OrderID,
+		LineID:           entity.LineID,
+		Kind:             entity.Kind,
+		ValueType:        entity.ValueType,
+		Percentage:       entity.Percentage,
+		Amount:           entity.Amount,
+		Reason:           entity.Reason,
+		Status:           entity.Status,
+		CreatedByUserID:  entity.CreatedByUserID,
+		ApprovedByUserID: entity.ApprovedByUserID,
+		ApprovedAt:       entity.ApprovedAt,
+		CreatedAt:        entity.CreatedAt,
+		UpdatedAt:        entity.UpdatedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_assignment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type OrderAssignmentPersistence struct{}
+
+func (OrderAssignmentPersistence) ToEntity(model *models.OrderAssignment) *domain.OrderAssignment {
+	if model == nil {
+		return nil
+	}
+	return &domain.OrderAssignment{
+		ID:                 model.ID,
+		OrderID:            model.OrderID,
+		MechanicID:         model.MechanicID,
+		AssignedByUserID:   model.AssignedByUserID,
+		AssignedAt:         model.AssignedAt,
+		UnassignedByUserID: model.UnassignedByUserID,
+		UnassignedAt:       model.UnassignedAt,
+	}
+}
+
+func (OrderAssignmentPersistence) ToModel(entity *domain.OrderAssignment) *models.OrderAssignment {
+	if entity == nil {
+		return nil
+	}
+	return &models.OrderAssignment{
+		ID:                 entity.ID,
+		OrderID:            entity.OrderID,
+		MechanicID:         entity.MechanicID,
+		AssignedByUserID:   entity.AssignedByUserID,
+		AssignedAt:         entity.AssignedAt,
+		UnassignedByUserID: entity.UnassignedByUserID,
+		UnassignedAt:       entity.UnassignedAt,
+	}
+}
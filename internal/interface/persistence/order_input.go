@@ -12,14 +12,17 @@ func (OrderInputPersistence) ToEntity(model *models.OrderInput) *domain.OrderInp
 		return nil
 	}
 	return &domain.OrderInput{
-		ID:         model.ID,
-		OrderID:    model.OrderID,
-		InputID:    model.InputID,
-		Quantity:   model.Quantity,
-		UnitPrice:  model.UnitPrice,
-		TotalPrice: model.TotalPrice,
-		CreatedAt:  model.CreatedAt,
-		UpdatedAt:  model.UpdatedAt,
+		ID:            model.ID,
+		OrderID:       model.OrderID,
+		InputID:       model.InputID,
+		Quantity:      model.Quantity,
+		UnitPrice:     model.UnitPrice,
+		TotalPrice:    model.TotalPrice,
+		StockStatus:   model.StockStatus,
+		UsedOrDamaged: model.UsedOrDamaged,
+		VoidedAt:      model.VoidedAt,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
 	}
 }
 
@@ -28,13 +31,16 @@ func (OrderInputPersistence) ToModel(entity *domain.OrderInput) *models.OrderInp
 		return nil
 	}
 	return &models.OrderInput{
-		ID:         entity.ID,
-		OrderID:    entity.OrderID,
-		InputID:    entity.InputID,
-		Quantity:   entity.Quantity,
-		UnitPrice:  entity.UnitPrice,
-		TotalPrice: entity.TotalPrice,
-		CreatedAt:  entity.CreatedAt,
-		UpdatedAt:  entity.UpdatedAt,
+		ID:            entity.ID,
+		OrderID:       entity.OrderID,
+		InputID:       entity.InputID,
+		Quantity:      entity.Quantity,
+		UnitPrice:     entity.UnitPrice,
+		TotalPrice:    entity.TotalPrice,
+		StockStatus:   entity.StockStatus,
+		UsedOrDamaged: entity.UsedOrDamaged,
+		VoidedAt:      entity.VoidedAt,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
 	}
 }
@@ -0,0 +1,44 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type OrderServicePersistence struct{}
+
+func (OrderServicePersistence) ToEntity(model *models.OrderService) *domain.OrderService {
+	if model == nil {
+		return nil
+	}
+	return &domain.OrderService{
+		ID:             model.ID,
+		OrderID:        model.OrderID,
+		LaborServiceID: model.LaborServiceID,
+		MechanicID:     model.MechanicID,
+		EstimatedHours: model.EstimatedHours,
+		ActualHours:    model.ActualHours,
+		HourlyRate:     model.HourlyRate,
+		TotalPrice:     model.TotalPrice,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func (OrderServicePersistence) ToModel(entity *domain.OrderService) *models.OrderService {
+	if entity == nil {
+		return nil
+	}
+	return &models.OrderService{
+		ID:             entity.ID,
+		OrderID:        entity.OrderID,
+		LaborServiceID: entity.LaborServiceID,
+		MechanicID:     entity.MechanicID,
+		EstimatedHours: entity.EstimatedHours,
+		ActualHours:    entity.ActualHours,
+		HourlyRate:     entity.HourlyRate,
+		TotalPrice:     entity.TotalPrice,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+}
@@ -12,12 +12,15 @@ func (OrderStatusHistoryPersistence) ToEntity(model *models.OrderStatusHistory)
 		return nil
 	}
 	return &domain.OrderStatusHistory{
-		ID:        model.ID,
-		OrderID:   model.OrderID,
-		Status:    model.Status,
-		StartedAt: model.StartedAt,
-		EndedAt:   model.EndedAt,
-		CreatedAt: model.CreatedAt,
+		ID:              model.ID,
+		OrderID:         model.OrderID,
+		Status:          model.Status,
+		ChangedByUserID: model.ChangedByUserID,
+		Note:            model.Note,
+		Source:          model.Source,
+		StartedAt:       model.StartedAt,
+		EndedAt:         model.EndedAt,
+		CreatedAt:       model.CreatedAt,
 	}
 }
 
@@ -26,11 +29,14 @@ func (OrderStatusHistoryPersistence) ToModel(entity *domain.OrderStatusHistory)
 		return nil
 	}
 	return &models.OrderStatusHistory{
-		ID:        entity.ID,
-		OrderID:   entity.OrderID,
-		Status:    entity.Status,
-		StartedAt: entity.StartedAt,
-		EndedAt:   entity.EndedAt,
-		CreatedAt: entity.CreatedAt,
+		ID:              entity.ID,
+		OrderID:         entity.OrderID,
+		Status:          entity.Status,
+		ChangedByUserID: entity.ChangedByUserID,
+		Note:            entity.Note,
+		Source:          entity.Source,
+		StartedAt:       entity.StartedAt,
+		EndedAt:         entity.EndedAt,
+		CreatedAt:       entity.CreatedAt,
 	}
 }
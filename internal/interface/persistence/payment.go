@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type PaymentPersistence struct{}
+
+func (PaymentPersistence) ToEntity(model *models.Payment) *domain.Payment {
+	if model == nil {
+		return nil
+	}
+	return &domain.Payment{
+		ID:               model.ID,
+		OrderID:          model.OrderID,
+		Method:           model.Method,
+		Amount:           model.Amount,
+		Reference:        model.Reference,
+		ReceivedByUserID: model.ReceivedByUserID,
+		PaidAt:           model.PaidAt,
+		CreatedAt:        model.CreatedAt,
+	}
+}
+
+func (PaymentPersistence) ToModel(entity *domain.Payment) *models.Payment {
+	if entity == nil {
+		return nil
+	}
+	return &models.Payment{
+		ID:               entity.ID,
+		OrderID:          entity.OrderID,
+		Method:           entity.Method,
+		Amount:           entity.Amount,
+		Reference:        entity.Reference,
+		ReceivedByUserID: entity.ReceivedByUserID,
+		PaidAt:           entity.PaidAt,
+		CreatedAt:        entity.CreatedAt,
+	}
+}
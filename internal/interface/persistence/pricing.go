@@ -0,0 +1,30 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type TaxRatePersistence struct{}
+
+func (TaxRatePersistence) ToEntity(model *models.TaxRate) *domain.TaxRate {
+	if model == nil {
+		return nil
+	}
+	return &domain.TaxRate{
+		Category: model.Category,
+		Name:     model.Name,
+		Rate:     model.Rate,
+	}
+}
+
+func (TaxRatePersistence) ToModel(entity *domain.TaxRate) *models.TaxRate {
+	if entity == nil {
+		return nil
+	}
+	return &models.TaxRate{
+		Category: entity.Category,
+		Name:     entity.Name,
+		Rate:     entity.Rate,
+	}
+}
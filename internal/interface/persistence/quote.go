@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+type QuotePersistence struct{}
+
+func (QuotePersistence) ToEntity(model *models.Quote) *domain.Quote {
+	if model == nil {
+		return nil
+	}
+	items := make([]domain.QuoteItem, 0, len(model.Items))
+	for _, item := range model.Items {
+		items = append(items, domain.QuoteItem{
+			ID:          item.ID,
+			QuoteID:     item.QuoteID,
+			ItemType:    item.ItemType,
+			ReferenceID: item.ReferenceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+		})
+	}
+	return &domain.Quote{
+		ID:              model.ID,
+		OrderID:         model.OrderID,
+		Version:         model.Version,
+		ValidUntil:      model.ValidUntil,
+		PartsSubtotal:   model.PartsSubtotal,
+		LaborSubtotal:   model.LaborSubtotal,
+		DiscountTotal:   model.DiscountTotal,
+		SurchargeTotal:  model.SurchargeTotal,
+		TaxTotal:        model.TaxTotal,
+		TotalPrice:      model.TotalPrice,
+		CreatedByUserID: model.CreatedByUserID,
+		Items:           items,
+		CreatedAt:       model.CreatedAt,
+	}
+}
+
+func (QuotePersistence) ToModel(entity *domain.Quote) *models.Quote {
+	if entity == nil {
+		return nil
+	}
+	items := make([]models.QuoteItem, 0, len(entity.Items))
+	for _, item := range entity.Items {
+		items = append(items, models.QuoteItem{
+			ID:          item.ID,
+			QuoteID:     entity.ID,
+			ItemType:    item.ItemType,
+			ReferenceID: item.ReferenceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+		})
+	}
+	return &models.Quote{
+		ID:              entity.ID,
+		OrderID:         entity.OrderID,
+		Version:         entity.Version,
+		ValidUntil:      entity.ValidUntil,
+		PartsSubtotal:   entity.PartsSubtotal,
+		LaborSubtotal:   entity.LaborSubtotal,
+		DiscountTotal:   entity.DiscountTotal,
+		SurchargeTotal:  entity.SurchargeTotal,
+		TaxTotal:        entity.TaxTotal,
+		TotalPrice:      entity.TotalPrice,
+		CreatedByUserID: entity.CreatedByUserID,
+		Items:           items,
+		CreatedAt:       entity.CreatedAt,
+	}
+}
@@ -12,14 +12,15 @@ func (UserPersistence) ToEntity(model *models.User) *domain.User {
 		return nil
 	}
 	return &domain.User{
-		ID:         model.ID,
-		Email:      model.Email,
-		Password:   model.Password,
-		Username:   model.Username,
-		UserType:   model.UserType,
-		CustomerID: model.CustomerID,
-		CreatedAt:  model.CreatedAt,
-		UpdatedAt:  model.UpdatedAt,
+		ID:              model.ID,
+		Email:           model.Email,
+		Password:        model.Password,
+		Username:        model.Username,
+		UserType:        model.UserType,
+		CustomerID:      model.CustomerID,
+		EmailVerifiedAt: model.EmailVerifiedAt,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
 	}
 }
 
@@ -28,13 +29,14 @@ func (UserPersistence) ToModel(entity *domain.User) *models.User {
 		return nil
 	}
 	return &models.User{
-		ID:         entity.ID,
-		Email:      entity.Email,
-		Password:   entity.Password,
-		Username:   entity.Username,
-		UserType:   entity.UserType,
-		CustomerID: entity.CustomerID,
-		CreatedAt:  entity.CreatedAt,
-		UpdatedAt:  entity.UpdatedAt,
+		ID:              entity.ID,
+		Email:           entity.Email,
+		Password:        entity.Password,
+		Username:        entity.Username,
+		UserType:        entity.UserType,
+		CustomerID:      entity.CustomerID,
+		EmailVerifiedAt: entity.EmailVerifiedAt,
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
 	}
 }
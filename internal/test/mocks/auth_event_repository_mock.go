@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// AuthEventRepositoryMock implementa AuthEventRepository para testes
+type AuthEventRepositoryMock struct {
+	RecordFunc func(event *domain.AuthEvent) error
+	FindFunc   func(filter domain.AuthEventFilter) ([]domain.AuthEvent, error)
+}
+
+// Record chama a função mock
+func (m *AuthEventRepositoryMock) Record(event *domain.AuthEvent) error {
+	if m.RecordFunc != nil {
+		return m.RecordFunc(event)
+	}
+	return nil
+}
+
+// Find chama a função mock
+func (m *AuthEventRepositoryMock) Find(filter domain.AuthEventFilter) ([]domain.AuthEvent, error) {
+	if m.FindFunc != nil {
+		return m.FindFunc(filter)
+	}
+	return []domain.AuthEvent{}, nil
+}
@@ -1,13 +1,19 @@
 package mocks
 
 import (
+	"time"
+
+	"github.com/google/uuid"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
 )
 
 // AuthRepositoryMock implementa AuthRepository para testes
 type AuthRepositoryMock struct {
-	FindUserByEmailFunc  func(email string) (*domain.UserInfo, error)
-	ValidatePasswordFunc func(email, password string) error
+	FindUserByEmailFunc   func(email string) (*domain.UserInfo, error)
+	FindUserByIDFunc      func(id uuid.UUID) (*domain.UserInfo, error)
+	ValidatePasswordFunc  func(email, password string) error
+	UpdatePasswordFunc    func(userID uuid.UUID, passwordHash string) error
+	MarkEmailVerifiedFunc func(userID uuid.UUID, verifiedAt time.Time) error
 }
 
 // FindUserByEmail chama a função mock
@@ -18,6 +24,14 @@ func (m *AuthRepositoryMock) FindUserByEmail(email string) (*domain.UserInfo, er
 	return nil, nil
 }
 
+// FindUserByID chama a função mock
+func (m *AuthRepositoryMock) FindUserByID(id uuid.UUID) (*domain.UserInfo, error) {
+	if m.FindUserByIDFunc != nil {
+		return m.FindUserByIDFunc(id)
+	}
+	return nil, nil
+}
+
 // ValidatePassword chama a função mock
 func (m *AuthRepositoryMock) ValidatePassword(email, password string) error {
 	if m.ValidatePasswordFunc != nil {
@@ -25,3 +39,19 @@ func (m *AuthRepositoryMock) ValidatePassword(email, password string) error {
 	}
 	return nil
 }
+
+// UpdatePassword chama a função mock
+func (m *AuthRepositoryMock) UpdatePassword(userID uuid.UUID, passwordHash string) error {
+	if m.UpdatePasswordFunc != nil {
+		return m.UpdatePasswordFunc(userID, passwordHash)
+	}
+	return nil
+}
+
+// MarkEmailVerified chama a função mock
+func (m *AuthRepositoryMock) MarkEmailVerified(userID uuid.UUID, verifiedAt time.Time) error {
+	if m.MarkEmailVerifiedFunc != nil {
+		return m.MarkEmailVerifiedFunc(userID, verifiedAt)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// AuthUnitOfWorkMock implementa a UnitOfWork de autenticação para testes executando a função
+// diretamente com os repositórios mockados
+type AuthUnitOfWorkMock struct {
+	Repositories domain.Repositories
+	ExecuteFunc  func(fn func(repos domain.Repositories) error) error
+}
+
+// Execute chama a função mock
+func (m *AuthUnitOfWorkMock) Execute(fn func(repos domain.Repositories) error) error {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(fn)
+	}
+	return fn(m.Repositories)
+}
@@ -3,13 +3,14 @@ package mocks
 import (
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 )
 
 // CustomerRepositoryMock implementa CustomerRepository para testes
 type CustomerRepositoryMock struct {
 	CreateFunc   func(customer *models.Customer) error
 	FindByIDFunc func(id uuid.UUID) (*models.Customer, error)
-	FindAllFunc  func() ([]models.Customer, error)
+	FindAllFunc  func(spec repository.QuerySpec) (*repository.Page[models.Customer], error)
 	UpdateFunc   func(customer *models.Customer) error
 	DeleteFunc   func(id uuid.UUID) error
 }
@@ -31,11 +32,11 @@ func (m *CustomerRepositoryMock) FindByID(id uuid.UUID) (*models.Customer, error
 }
 
 // FindAll chama a função mock
-func (m *CustomerRepositoryMock) FindAll() ([]models.Customer, error) {
+func (m *CustomerRepositoryMock) FindAll(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
 	if m.FindAllFunc != nil {
-		return m.FindAllFunc()
+		return m.FindAllFunc(spec)
 	}
-	return nil, nil
+	return &repository.Page[models.Customer]{Items: []models.Customer{}}, nil
 }
 
 // Update chama a função mock
@@ -3,16 +3,26 @@ package mocks
 import (
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 )
 
 // InputRepositoryMock implementa InputRepository para testes
 type InputRepositoryMock struct {
-	CreateFunc     func(input *models.Input) error
-	FindByIDFunc   func(id uuid.UUID) (*models.Input, error)
-	FindAllFunc    func() ([]models.Input, error)
-	FindByNameFunc func(name string) (*models.Input, error)
-	UpdateFunc     func(input *models.Input) error
-	DeleteFunc     func(id uuid.UUID) error
+	CreateFunc   func(input *models.Input) error
+	FindByIDFunc func(id uuid.UUID) (*models.Input, error)
+	// FindByIDForUpdateFunc é opcional; sem ela o mock usa FindByIDFunc
+	FindByIDForUpdateFunc func(id uuid.UUID) (*models.Input, error)
+	FindAllFunc           func(spec repository.QuerySpec) (*repository.Page[models.Input], error)
+	FindByNameFunc        func(name string) (*models.Input, error)
+	UpdateFunc            func(input *models.Input) error
+	DeleteFunc            func(id uuid.UUID) error
+
+	DecreaseQuantityFunc func(id uuid.UUID, quantity int) (*models.Input, error)
+	IncreaseQuantityFunc func(id uuid.UUID, quantity int) (*models.Input, error)
+
+	ReserveQuantityFunc    func(id uuid.UUID, quantity int) (*models.Input, error)
+	ReleaseReservationFunc func(id uuid.UUID, quantity int) (*models.Input, error)
+	ConsumeReservationFunc func(id uuid.UUID, quantity int) (*models.Input, error)
 }
 
 // Create chama a função mock
@@ -31,12 +41,20 @@ func (m *InputRepositoryMock) FindByID(id uuid.UUID) (*models.Input, error) {
 	return nil, nil
 }
 
+// FindByIDForUpdate chama a função mock
+func (m *InputRepositoryMock) FindByIDForUpdate(id uuid.UUID) (*models.Input, error) {
+	if m.FindByIDForUpdateFunc != nil {
+		return m.FindByIDForUpdateFunc(id)
+	}
+	return m.FindByID(id)
+}
+
 // FindAll chama a função mock
-func (m *InputRepositoryMock) FindAll() ([]models.Input, error) {
+func (m *InputRepositoryMock) FindAll(spec repository.QuerySpec) (*repository.Page[models.Input], error) {
 	if m.FindAllFunc != nil {
-		return m.FindAllFunc()
+		return m.FindAllFunc(spec)
 	}
-	return nil, nil
+	return &repository.Page[models.Input]{Items: []models.Input{}}, nil
 }
 
 // FindByName chama a função mock
@@ -62,3 +80,43 @@ func (m *InputRepositoryMock) Delete(id uuid.UUID) error {
 	}
 	return nil
 }
+
+// DecreaseQuantity chama a função mock
+func (m *InputRepositoryMock) DecreaseQuantity(id uuid.UUID, quantity int) (*models.Input, error) {
+	if m.DecreaseQuantityFunc != nil {
+		return m.DecreaseQuantityFunc(id, quantity)
+	}
+	return nil, nil
+}
+
+// IncreaseQuantity chama a função mock
+func (m *InputRepositoryMock) IncreaseQuantity(id uuid.UUID, quantity int) (*models.Input, error) {
+	if m.IncreaseQuantityFunc != nil {
+		return m.IncreaseQuantityFunc(id, quantity)
+	}
+	return nil, nil
+}
+
+// ReserveQuantity chama a função mock
+func (m *InputRepositoryMock) ReserveQuantity(id uuid.UUID, quantity int) (*models.Input, error) {
+	if m.ReserveQuantityFunc != nil {
+		return m.ReserveQuantityFunc(id, quantity)
+	}
+	return nil, nil
+}
+
+// ReleaseReservation chama a função mock
+func (m *InputRepositoryMock) ReleaseReservation(id uuid.UUID, quantity int) (*models.Input, error) {
+	if m.ReleaseReservationFunc != nil {
+		return m.ReleaseReservationFunc(id, quantity)
+	}
+	return nil, nil
+}
+
+// ConsumeReservation chama a função mock
+func (m *InputRepositoryMock) ConsumeReservation(id uuid.UUID, quantity int) (*models.Input, error) {
+	if m.ConsumeReservationFunc != nil {
+		return m.ConsumeReservationFunc(id, quantity)
+	}
+	return nil, nil
+}
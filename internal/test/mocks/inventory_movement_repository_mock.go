@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// InventoryMovementRepositoryMock implementa InventoryMovementRepository para testes
+type InventoryMovementRepositoryMock struct {
+	CreateFunc        func(movement *models.InventoryMovement) error
+	FindByInputIDFunc func(inputID uuid.UUID) ([]models.InventoryMovement, error)
+}
+
+// Create chama a função mock
+func (m *InventoryMovementRepositoryMock) Create(movement *models.InventoryMovement) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(movement)
+	}
+	return nil
+}
+
+// FindByInputID chama a função mock
+func (m *InventoryMovementRepositoryMock) FindByInputID(inputID uuid.UUID) ([]models.InventoryMovement, error) {
+	if m.FindByInputIDFunc != nil {
+		return m.FindByInputIDFunc(inputID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// InvoiceRepositoryMock implementa InvoiceRepository para testes
+type InvoiceRepositoryMock struct {
+	CreateFunc           func(invoice *models.Invoice) error
+	FindByIDFunc         func(id uuid.UUID) (*models.Invoice, error)
+	FindByOrderIDFunc    func(orderID uuid.UUID) (*models.Invoice, error)
+	CreateCreditNoteFunc func(creditNote *models.CreditNote) error
+	NextNumberFunc       func(workshopCode string, documentType string) (int64, error)
+}
+
+// Create chama a função mock
+func (m *InvoiceRepositoryMock) Create(invoice *models.Invoice) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(invoice)
+	}
+	return nil
+}
+
+// FindByID chama a função mock
+func (m *InvoiceRepositoryMock) FindByID(id uuid.UUID) (*models.Invoice, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(id)
+	}
+	return nil, nil
+}
+
+// FindByOrderID chama a função mock
+func (m *InvoiceRepositoryMock) FindByOrderID(orderID uuid.UUID) (*models.Invoice, error) {
+	if m.FindByOrderIDFunc != nil {
+		return m.FindByOrderIDFunc(orderID)
+	}
+	return nil, nil
+}
+
+// CreateCreditNote chama a função mock
+func (m *InvoiceRepositoryMock) CreateCreditNote(creditNote *models.CreditNote) error {
+	if m.CreateCreditNoteFunc != nil {
+		return m.CreateCreditNoteFunc(creditNote)
+	}
+	return nil
+}
+
+// NextNumber chama a função mock
+func (m *InvoiceRepositoryMock) NextNumber(workshopCode string, documentType string) (int64, error) {
+	if m.NextNumberFunc != nil {
+		return m.NextNumberFunc(workshopCode, documentType)
+	}
+	return 0, nil
+}
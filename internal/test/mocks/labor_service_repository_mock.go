@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+)
+
+// LaborServiceRepositoryMock implementa LaborServiceRepository para testes
+type LaborServiceRepositoryMock struct {
+	CreateFunc     func(laborService *models.LaborService) error
+	FindByIDFunc   func(id uuid.UUID) (*models.LaborService, error)
+	FindByNameFunc func(name string) (*models.LaborService, error)
+	FindAllFunc    func(spec repository.QuerySpec) (*repository.Page[models.LaborService], error)
+	UpdateFunc     func(laborService *models.LaborService) error
+	DeleteFunc     func(id uuid.UUID) error
+}
+
+// Create chama a função mock
+func (m *LaborServiceRepositoryMock) Create(laborService *models.LaborService) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(laborService)
+	}
+	return nil
+}
+
+// FindByID chama a função mock
+func (m *LaborServiceRepositoryMock) FindByID(id uuid.UUID) (*models.LaborService, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(id)
+	}
+	return nil, nil
+}
+
+// FindByName chama a função mock
+func (m *LaborServiceRepositoryMock) FindByName(name string) (*models.LaborService, error) {
+	if m.FindByNameFunc != nil {
+		return m.FindByNameFunc(name)
+	}
+	return nil, nil
+}
+
+// FindAll chama a função mock
+func (m *LaborServiceRepositoryMock) FindAll(spec repository.QuerySpec) (*repository.Page[models.LaborService], error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(spec)
+	}
+	return &repository.Page[models.LaborService]{Items: []models.LaborService{}}, nil
+}
+
+// Update chama a função mock
+func (m *LaborServiceRepositoryMock) Update(laborService *models.LaborService) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(laborService)
+	}
+	return nil
+}
+
+// Delete chama a função mock
+func (m *LaborServiceRepositoryMock) Delete(id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
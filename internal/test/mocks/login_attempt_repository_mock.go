@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"time"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// LoginAttemptRepositoryMock implementa LoginAttemptRepository para testes
+type LoginAttemptRepositoryMock struct {
+	FindFunc            func(scope, key string) (*domain.LoginAttempt, error)
+	RegisterFailureFunc func(scope, key string, failedAt, windowStart time.Time) (*domain.LoginAttempt, error)
+	LockFunc            func(scope, key string, until time.Time) error
+	ResetFunc           func(scope, key string) error
+}
+
+// Find chama a função mock
+func (m *LoginAttemptRepositoryMock) Find(scope, key string) (*domain.LoginAttempt, error) {
+	if m.FindFunc != nil {
+		return m.FindFunc(scope, key)
+	}
+	return nil, nil
+}
+
+// RegisterFailure chama a função mock
+func (m *LoginAttemptRepositoryMock) RegisterFailure(scope, key string, failedAt, windowStart time.Time) (*domain.LoginAttempt, error) {
+	if m.RegisterFailureFunc != nil {
+		return m.RegisterFailureFunc(scope, key, failedAt, windowStart)
+	}
+	return &domain.LoginAttempt{Scope: scope, Key: key, Failures: 1, LastFailureAt: failedAt}, nil
+}
+
+// Lock chama a função mock
+func (m *LoginAttemptRepositoryMock) Lock(scope, key string, until time.Time) error {
+	if m.LockFunc != nil {
+		return m.LockFunc(scope, key, until)
+	}
+	return nil
+}
+
+// Reset chama a função mock
+func (m *LoginAttemptRepositoryMock) Reset(scope, key string) error {
+	if m.ResetFunc != nil {
+		return m.ResetFunc(scope, key)
+	}
+	return nil
+}
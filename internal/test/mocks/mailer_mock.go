@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/mail"
+)
+
+// MailerMock implementa Mailer para testes
+type MailerMock struct {
+	SendFunc func(message domain.Message) error
+}
+
+// Send chama a função mock
+func (m *MailerMock) Send(message domain.Message) error {
+	if m.SendFunc != nil {
+		return m.SendFunc(message)
+	}
+	return nil
+}
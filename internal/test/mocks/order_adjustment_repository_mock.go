@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// OrderAdjustmentRepositoryMock implementa OrderAdjustmentRepository para testes
+type OrderAdjustmentRepositoryMock struct {
+	CreateFunc        func(adjustment *models.OrderAdjustment) error
+	FindByIDFunc      func(id uuid.UUID) (*models.OrderAdjustment, error)
+	FindByOrderIDFunc func(orderID uuid.UUID) ([]models.OrderAdjustment, error)
+	UpdateFunc        func(adjustment *models.OrderAdjustment) error
+	DeleteFunc        func(id uuid.UUID) error
+}
+
+// Create chama a função mock
+func (m *OrderAdjustmentRepositoryMock) Create(adjustment *models.OrderAdjustment) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(adjustment)
+	}
+	return nil
+}
+
+// FindByID chama a função mock
+func (m *OrderAdjustmentRepositoryMock) FindByID(id uuid.UUID) (*models.OrderAdjustment, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(id)
+	}
+	return nil, nil
+}
+
+// FindByOrderID chama a função mock
+func (m *OrderAdjustmentRepositoryMock) FindByOrderID(orderID uuid.UUID) ([]models.OrderAdjustment, error) {
+	if m.FindByOrderIDFunc != nil {
+		return m.FindByOrderIDFunc(orderID)
+	}
+	return nil, nil
+}
+
+// Update chama a função mock
+func (m *OrderAdjustmentRepositoryMock) Update(adjustment *models.OrderAdjustment) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(adjustment)
+	}
+	return nil
+}
+
+// Delete chama a função mock
+func (m *OrderAdjustmentRepositoryMock) Delete(id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
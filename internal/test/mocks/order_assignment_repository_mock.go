@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// OrderAssignmentRepositoryMock implementa OrderAssignmentRepository para testes
+type OrderAssignmentRepositoryMock struct {
+	CreateFunc        func(assignment *models.OrderAssignment) error
+	FindByOrderIDFunc func(orderID uuid.UUID) ([]models.OrderAssignment, error)
+	FindActiveFunc    func(orderID uuid.UUID, mechanicID uuid.UUID) (*models.OrderAssignment, error)
+	UpdateFunc        func(assignment *models.OrderAssignment) error
+}
+
+// Create chama a função mock
+func (m *OrderAssignmentRepositoryMock) Create(assignment *models.OrderAssignment) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(assignment)
+	}
+	return nil
+}
+
+// FindByOrderID chama a função mock
+func (m *OrderAssignmentRepositoryMock) FindByOrderID(orderID uuid.UUID) ([]models.OrderAssignment, error) {
+	if m.FindByOrderIDFunc != nil {
+		return m.FindByOrderIDFunc(orderID)
+	}
+	return []models.OrderAssignment{}, nil
+}
+
+// FindActive chama a função mock
+func (m *OrderAssignmentRepositoryMock) FindActive(orderID uuid.UUID, mechanicID uuid.UUID) (*models.OrderAssignment, error) {
+	if m.FindActiveFunc != nil {
+		return m.FindActiveFunc(orderID, mechanicID)
+	}
+	return nil, nil
+}
+
+// Update chama a função mock
+func (m *OrderAssignmentRepositoryMock) Update(assignment *models.OrderAssignment) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(assignment)
+	}
+	return nil
+}
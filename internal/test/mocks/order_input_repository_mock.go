@@ -14,6 +14,8 @@ type OrderInputRepositoryMock struct {
 	UpdateFunc                    func(orderInput *models.OrderInput) error
 	DeleteFunc                    func(id uuid.UUID) error
 	DeleteByOrderIDAndInputIDFunc func(orderID uuid.UUID, inputID uuid.UUID) error
+
+	SetUsedOrDamagedFunc func(id uuid.UUID, usedOrDamaged bool) error
 }
 
 // Create chama a função mock
@@ -56,6 +58,14 @@ func (m *OrderInputRepositoryMock) Update(orderInput *models.OrderInput) error {
 	return nil
 }
 
+// SetUsedOrDamaged chama a função mock
+func (m *OrderInputRepositoryMock) SetUsedOrDamaged(id uuid.UUID, usedOrDamaged bool) error {
+	if m.SetUsedOrDamagedFunc != nil {
+		return m.SetUsedOrDamagedFunc(id, usedOrDamaged)
+	}
+	return nil
+}
+
 // Delete chama a função mock
 func (m *OrderInputRepositoryMock) Delete(id uuid.UUID) error {
 	if m.DeleteFunc != nil {
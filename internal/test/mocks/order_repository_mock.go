@@ -3,15 +3,19 @@ package mocks
 import (
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 )
 
 // OrderRepositoryMock implementa OrderRepository para testes
 type OrderRepositoryMock struct {
 	CreateFunc   func(order *models.Order) error
 	FindByIDFunc func(id uuid.UUID) (*models.Order, error)
-	FindAllFunc  func() ([]models.Order, error)
-	UpdateFunc   func(order *models.Order) error
-	DeleteFunc   func(id uuid.UUID) error
+	// FindByIDForUpdateFunc é opcional; sem ela o mock usa FindByIDFunc
+	FindByIDForUpdateFunc func(id uuid.UUID) (*models.Order, error)
+	FindAllFunc           func(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error)
+	UpdateFunc            func(order *models.Order) error
+	SetApprovedQuoteFunc  func(orderID uuid.UUID, quoteID *uuid.UUID) error
+	DeleteFunc            func(id uuid.UUID) error
 }
 
 // Create chama a função mock
@@ -30,12 +34,20 @@ func (m *OrderRepositoryMock) FindByID(id uuid.UUID) (*models.Order, error) {
 	return nil, nil
 }
 
+// FindByIDForUpdate chama a função mock
+func (m *OrderRepositoryMock) FindByIDForUpdate(id uuid.UUID) (*models.Order, error) {
+	if m.FindByIDForUpdateFunc != nil {
+		return m.FindByIDForUpdateFunc(id)
+	}
+	return m.FindByID(id)
+}
+
 // FindAll chama a função mock
-func (m *OrderRepositoryMock) FindAll() ([]models.Order, error) {
+func (m *OrderRepositoryMock) FindAll(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error) {
 	if m.FindAllFunc != nil {
-		return m.FindAllFunc()
+		return m.FindAllFunc(filter, spec)
 	}
-	return nil, nil
+	return &repository.Page[models.Order]{Items: []models.Order{}}, nil
 }
 
 // Update chama a função mock
@@ -46,6 +58,14 @@ func (m *OrderRepositoryMock) Update(order *models.Order) error {
 	return nil
 }
 
+// SetApprovedQuote chama a função mock
+func (m *OrderRepositoryMock) SetApprovedQuote(orderID uuid.UUID, quoteID *uuid.UUID) error {
+	if m.SetApprovedQuoteFunc != nil {
+		return m.SetApprovedQuoteFunc(orderID, quoteID)
+	}
+	return nil
+}
+
 // Delete chama a função mock
 func (m *OrderRepositoryMock) Delete(id uuid.UUID) error {
 	if m.DeleteFunc != nil {
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// OrderServiceRepositoryMock implementa OrderServiceRepository para testes
+type OrderServiceRepositoryMock struct {
+	CreateFunc        func(orderService *models.OrderService) error
+	FindByIDFunc      func(id uuid.UUID) (*models.OrderService, error)
+	FindByOrderIDFunc func(orderID uuid.UUID) ([]models.OrderService, error)
+	UpdateFunc        func(orderService *models.OrderService) error
+	DeleteFunc        func(id uuid.UUID) error
+}
+
+// Create chama a função mock
+func (m *OrderServiceRepositoryMock) Create(orderService *models.OrderService) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(orderService)
+	}
+	return nil
+}
+
+// FindByID chama a função mock
+func (m *OrderServiceRepositoryMock) FindByID(id uuid.UUID) (*models.OrderService, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(id)
+	}
+	return nil, nil
+}
+
+// FindByOrderID chama a função mock
+func (m *OrderServiceRepositoryMock) FindByOrderID(orderID uuid.UUID) ([]models.OrderService, error) {
+	if m.FindByOrderIDFunc != nil {
+		return m.FindByOrderIDFunc(orderID)
+	}
+	return nil, nil
+}
+
+// Update chama a função mock
+func (m *OrderServiceRepositoryMock) Update(orderService *models.OrderService) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(orderService)
+	}
+	return nil
+}
+
+// Delete chama a função mock
+func (m *OrderServiceRepositoryMock) Delete(id uuid.UUID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 )
 
@@ -10,11 +11,12 @@ type OrderStatusHistoryManagerMock struct {
 	IsFinalStatusFunc              func(status string) bool
 	FetchCurrentStatusFromDBFunc   func(orderID uuid.UUID) (*models.OrderStatusHistory, error)
 	FinalizeCurrentStatusFunc      func(orderID uuid.UUID) error
-	CreateNewStatusFunc            func(orderID uuid.UUID, status string) error
-	UpdateCurrentStatusToFinalFunc func(orderID uuid.UUID, finalStatus string) error
+	CreateNewStatusFunc            func(orderID uuid.UUID, status string, change domain.StatusChange) error
+	UpdateCurrentStatusToFinalFunc func(orderID uuid.UUID, finalStatus string, change domain.StatusChange) error
 	FetchOrderHistoryFromDBFunc    func(orderID uuid.UUID) ([]models.OrderStatusHistory, error)
 	StartNewStatusFunc             func(orderID uuid.UUID, status string) error
 	UpdateStatusFunc               func(orderID uuid.UUID, newStatus string) error
+	UpdateStatusWithChangeFunc     func(orderID uuid.UUID, newStatus string, change domain.StatusChange) error
 	GetOrderHistoryFunc            func(orderID uuid.UUID) ([]models.OrderStatusHistory, error)
 }
 
@@ -43,17 +45,17 @@ func (m *OrderStatusHistoryManagerMock) FinalizeCurrentStatus(orderID uuid.UUID)
 }
 
 // CreateNewStatus chama a função mock
-func (m *OrderStatusHistoryManagerMock) CreateNewStatus(orderID uuid.UUID, status string) error {
+func (m *OrderStatusHistoryManagerMock) CreateNewStatus(orderID uuid.UUID, status string, change domain.StatusChange) error {
 	if m.CreateNewStatusFunc != nil {
-		return m.CreateNewStatusFunc(orderID, status)
+		return m.CreateNewStatusFunc(orderID, status, change)
 	}
 	return nil
 }
 
 // UpdateCurrentStatusToFinal chama a função mock
-func (m *OrderStatusHistoryManagerMock) UpdateCurrentStatusToFinal(orderID uuid.UUID, finalStatus string) error {
+func (m *OrderStatusHistoryManagerMock) UpdateCurrentStatusToFinal(orderID uuid.UUID, finalStatus string, change domain.StatusChange) error {
 	if m.UpdateCurrentStatusToFinalFunc != nil {
-		return m.UpdateCurrentStatusToFinalFunc(orderID, finalStatus)
+		return m.UpdateCurrentStatusToFinalFunc(orderID, finalStatus, change)
 	}
 	return nil
 }
@@ -82,6 +84,14 @@ func (m *OrderStatusHistoryManagerMock) UpdateStatus(orderID uuid.UUID, newStatu
 	return nil
 }
 
+// UpdateStatusWithChange chama a função mock
+func (m *OrderStatusHistoryManagerMock) UpdateStatusWithChange(orderID uuid.UUID, newStatus string, change domain.StatusChange) error {
+	if m.UpdateStatusWithChangeFunc != nil {
+		return m.UpdateStatusWithChangeFunc(orderID, newStatus, change)
+	}
+	return nil
+}
+
 // GetOrderHistory chama a função mock
 func (m *OrderStatusHistoryManagerMock) GetOrderHistory(orderID uuid.UUID) ([]models.OrderStatusHistory, error) {
 	if m.GetOrderHistoryFunc != nil {
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// PaymentRepositoryMock implementa PaymentRepository para testes
+type PaymentRepositoryMock struct {
+	CreateFunc        func(payment *models.Payment) error
+	FindByOrderIDFunc func(orderID uuid.UUID) ([]models.Payment, error)
+}
+
+// Create chama a função mock
+func (m *PaymentRepositoryMock) Create(payment *models.Payment) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(payment)
+	}
+	return nil
+}
+
+// FindByOrderID chama a função mock
+func (m *PaymentRepositoryMock) FindByOrderID(orderID uuid.UUID) ([]models.Payment, error) {
+	if m.FindByOrderIDFunc != nil {
+		return m.FindByOrderIDFunc(orderID)
+	}
+	return []models.Payment{}, nil
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// PricingRepositoryMock implementa PricingRepository para testes
+type PricingRepositoryMock struct {
+	FindTaxRatesFunc func() ([]models.TaxRate, error)
+	SaveTaxRatesFunc func(rates []models.TaxRate) error
+	FindSettingsFunc func() (*models.PricingSettings, error)
+	SaveSettingsFunc func(settings *models.PricingSettings) error
+}
+
+// FindTaxRates chama a função mock
+func (m *PricingRepositoryMock) FindTaxRates() ([]models.TaxRate, error) {
+	if m.FindTaxRatesFunc != nil {
+		return m.FindTaxRatesFunc()
+	}
+	return nil, nil
+}
+
+// SaveTaxRates chama a função mock
+func (m *PricingRepositoryMock) SaveTaxRates(rates []models.TaxRate) error {
+	if m.SaveTaxRatesFunc != nil {
+		return m.SaveTaxRatesFunc(rates)
+	}
+	return nil
+}
+
+// FindSettings chama a função mock
+func (m *PricingRepositoryMock) FindSettings() (*models.PricingSettings, error) {
+	if m.FindSettingsFunc != nil {
+		return m.FindSettingsFunc()
+	}
+	return nil, nil
+}
+
+// SaveSettings chama a função mock
+func (m *PricingRepositoryMock) SaveSettings(settings *models.PricingSettings) error {
+	if m.SaveSettingsFunc != nil {
+		return m.SaveSettingsFunc(settings)
+	}
+	return nil
+}
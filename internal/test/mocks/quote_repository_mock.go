@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+)
+
+// QuoteRepositoryMock implementa QuoteRepository para testes
+type QuoteRepositoryMock struct {
+	CreateFunc                  func(quote *models.Quote) error
+	FindByIDFunc                func(id uuid.UUID) (*models.Quote, error)
+	FindByOrderIDFunc           func(orderID uuid.UUID) ([]models.Quote, error)
+	FindByOrderIDAndVersionFunc func(orderID uuid.UUID, version int) (*models.Quote, error)
+	FindLatestByOrderIDFunc     func(orderID uuid.UUID) (*models.Quote, error)
+}
+
+// Create chama a função mock
+func (m *QuoteRepositoryMock) Create(quote *models.Quote) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(quote)
+	}
+	return nil
+}
+
+// FindByID chama a função mock
+func (m *QuoteRepositoryMock) FindByID(id uuid.UUID) (*models.Quote, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(id)
+	}
+	return nil, nil
+}
+
+// FindByOrderID chama a função mock
+func (m *QuoteRepositoryMock) FindByOrderID(orderID uuid.UUID) ([]models.Quote, error) {
+	if m.FindByOrderIDFunc != nil {
+		return m.FindByOrderIDFunc(orderID)
+	}
+	return nil, nil
+}
+
+// FindByOrderIDAndVersion chama a função mock
+func (m *QuoteRepositoryMock) FindByOrderIDAndVersion(orderID uuid.UUID, version int) (*models.Quote, error) {
+	if m.FindByOrderIDAndVersionFunc != nil {
+		return m.FindByOrderIDAndVersionFunc(orderID, version)
+	}
+	return nil, nil
+}
+
+// FindLatestByOrderID chama a função mock
+func (m *QuoteRepositoryMock) FindLatestByOrderID(orderID uuid.UUID) (*models.Quote, error) {
+	if m.FindLatestByOrderIDFunc != nil {
+		return m.FindLatestByOrderIDFunc(orderID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// RefreshTokenRepositoryMock implementa RefreshTokenRepository para testes
+type RefreshTokenRepositoryMock struct {
+	CreateFunc           func(token *domain.RefreshToken) error
+	FindByHashFunc       func(tokenHash string) (*domain.RefreshToken, error)
+	MarkRotatedFunc      func(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error)
+	RevokeFamilyFunc     func(familyID uuid.UUID, revokedAt time.Time) error
+	RevokeAllForUserFunc func(userID uuid.UUID, revokedAt time.Time) error
+}
+
+// Create chama a função mock
+func (m *RefreshTokenRepositoryMock) Create(token *domain.RefreshToken) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(token)
+	}
+	return nil
+}
+
+// FindByHash chama a função mock
+func (m *RefreshTokenRepositoryMock) FindByHash(tokenHash string) (*domain.RefreshToken, error) {
+	if m.FindByHashFunc != nil {
+		return m.FindByHashFunc(tokenHash)
+	}
+	return nil, nil
+}
+
+// MarkRotated chama a função mock
+func (m *RefreshTokenRepositoryMock) MarkRotated(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error) {
+	if m.MarkRotatedFunc != nil {
+		return m.MarkRotatedFunc(id, replacedByID, rotatedAt)
+	}
+	return true, nil
+}
+
+// RevokeFamily chama a função mock
+func (m *RefreshTokenRepositoryMock) RevokeFamily(familyID uuid.UUID, revokedAt time.Time) error {
+	if m.RevokeFamilyFunc != nil {
+		return m.RevokeFamilyFunc(familyID, revokedAt)
+	}
+	return nil
+}
+
+// RevokeAllForUser chama a função mock
+func (m *RefreshTokenRepositoryMock) RevokeAllForUser(userID uuid.UUID, revokedAt time.Time) error {
+	if m.RevokeAllForUserFunc != nil {
+		return m.RevokeAllForUserFunc(userID, revokedAt)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+)
+
+// ReportRepositoryMock implementa ReportRepository para testes
+type ReportRepositoryMock struct {
+	StatusDurationsFunc func(from time.Time, to time.Time, excludeStatuses []string) ([]repository.StatusDurationRow, error)
+	DailyThroughputFunc func(from time.Time, to time.Time, deliveredStatus string) ([]repository.DailyThroughputRow, error)
+	WorkInProgressFunc  func() ([]repository.StatusCountRow, error)
+}
+
+// StatusDurations chama a função mock
+func (m *ReportRepositoryMock) StatusDurations(from time.Time, to time.Time, excludeStatuses []string) ([]repository.StatusDurationRow, error) {
+	if m.StatusDurationsFunc != nil {
+		return m.StatusDurationsFunc(from, to, excludeStatuses)
+	}
+	return []repository.StatusDurationRow{}, nil
+}
+
+// DailyThroughput chama a função mock
+func (m *ReportRepositoryMock) DailyThroughput(from time.Time, to time.Time, deliveredStatus string) ([]repository.DailyThroughputRow, error) {
+	if m.DailyThroughputFunc != nil {
+		return m.DailyThroughputFunc(from, to, deliveredStatus)
+	}
+	return []repository.DailyThroughputRow{}, nil
+}
+
+// WorkInProgress chama a função mock
+func (m *ReportRepositoryMock) WorkInProgress() ([]repository.StatusCountRow, error) {
+	if m.WorkInProgressFunc != nil {
+		return m.WorkInProgressFunc()
+	}
+	return []repository.StatusCountRow{}, nil
+}
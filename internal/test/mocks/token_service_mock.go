@@ -10,7 +10,6 @@ type TokenServiceMock struct {
 	GenerateTokenFunc        func(userInfo domain.UserInfo) (string, error)
 	ValidateTokenFunc        func(token string) (*domain.Claims, error)
 	GenerateRefreshTokenFunc func(userID uuid.UUID) (string, error)
-	ValidateRefreshTokenFunc func(refreshToken string) (uuid.UUID, error)
 }
 
 // GenerateToken chama a função mock
@@ -36,11 +35,3 @@ func (m *TokenServiceMock) GenerateRefreshToken(userID uuid.UUID) (string, error
 	}
 	return "", nil
 }
-
-// ValidateRefreshToken chama a função mock
-func (m *TokenServiceMock) ValidateRefreshToken(refreshToken string) (uuid.UUID, error) {
-	if m.ValidateRefreshTokenFunc != nil {
-		return m.ValidateRefreshTokenFunc(refreshToken)
-	}
-	return uuid.Nil, nil
-}
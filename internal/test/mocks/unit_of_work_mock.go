@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+)
+
+// UnitOfWorkMock implementa UnitOfWork para testes executando a função diretamente com os repositórios mockados
+type UnitOfWorkMock struct {
+	Repositories repository.Repositories
+	ExecuteFunc  func(fn func(repos repository.Repositories) error) error
+}
+
+// Execute chama a função mock
+func (m *UnitOfWorkMock) Execute(fn func(repos repository.Repositories) error) error {
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc(fn)
+	}
+
+	repos := m.Repositories
+	if repos.UnitOfWork == nil {
+		repos.UnitOfWork = m
+	}
+	return fn(repos)
+}
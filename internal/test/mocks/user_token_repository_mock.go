@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// UserTokenRepositoryMock implementa UserTokenRepository para testes
+type UserTokenRepositoryMock struct {
+	CreateFunc            func(token *domain.UserToken) error
+	FindByHashFunc        func(tokenHash string, purpose string) (*domain.UserToken, error)
+	MarkUsedFunc          func(id uuid.UUID, usedAt time.Time) (bool, error)
+	InvalidateForUserFunc func(userID uuid.UUID, purpose string, usedAt time.Time) error
+}
+
+// Create chama a função mock
+func (m *UserTokenRepositoryMock) Create(token *domain.UserToken) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(token)
+	}
+	return nil
+}
+
+// FindByHash chama a função mock
+func (m *UserTokenRepositoryMock) FindByHash(tokenHash string, purpose string) (*domain.UserToken, error) {
+	if m.FindByHashFunc != nil {
+		return m.FindByHashFunc(tokenHash, purpose)
+	}
+	return nil, nil
+}
+
+// MarkUsed chama a função mock
+func (m *UserTokenRepositoryMock) MarkUsed(id uuid.UUID, usedAt time.Time) (bool, error) {
+	if m.MarkUsedFunc != nil {
+		return m.MarkUsedFunc(id, usedAt)
+	}
+	return true, nil
+}
+
+// InvalidateForUser chama a função mock
+func (m *UserTokenRepositoryMock) InvalidateForUser(userID uuid.UUID, purpose string, usedAt time.Time) error {
+	if m.InvalidateForUserFunc != nil {
+		return m.InvalidateForUserFunc(userID, purpose, usedAt)
+	}
+	return nil
+}
@@ -3,13 +3,14 @@ package mocks
 import (
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 )
 
 // VehicleRepositoryMock implementa VehicleRepository para testes
 type VehicleRepositoryMock struct {
 	CreateFunc            func(vehicle *models.Vehicle) error
 	FindByIDFunc          func(id uuid.UUID) (*models.Vehicle, error)
-	FindByCustomerIDFunc  func(customerID uuid.UUID) ([]models.Vehicle, error)
+	FindByCustomerIDFunc  func(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error)
 	FindByNumberPlateFunc func(numberPlate string) (*models.Vehicle, error)
 	UpdateFunc            func(vehicle *models.Vehicle) error
 	DeleteFunc            func(id uuid.UUID) error
@@ -32,11 +33,11 @@ func (m *VehicleRepositoryMock) FindByID(id uuid.UUID) (*models.Vehicle, error)
 }
 
 // FindByCustomerID chama a função mock
-func (m *VehicleRepositoryMock) FindByCustomerID(customerID uuid.UUID) ([]models.Vehicle, error) {
+func (m *VehicleRepositoryMock) FindByCustomerID(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error) {
 	if m.FindByCustomerIDFunc != nil {
-		return m.FindByCustomerIDFunc(customerID)
+		return m.FindByCustomerIDFunc(customerID, spec)
 	}
-	return nil, nil
+	return &repository.Page[models.Vehicle]{Items: []models.Vehicle{}}, nil
 }
 
 // FindByNumberPlate chama a função mock
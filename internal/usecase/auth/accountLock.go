@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type UnlockAccountUseCase struct {
+	authRepository         domain.AuthRepository
+	loginAttemptRepository domain.LoginAttemptRepository
+	authEventRepository    domain.AuthEventRepository
+	logger                 logger.Logger
+}
+
+func NewUnlockAccountUseCase(authRepository domain.AuthRepository, loginAttemptRepository domain.LoginAttemptRepository, authEventRepository domain.AuthEventRepository, logger logger.Logger) *UnlockAccountUseCase {
+	return &UnlockAccountUseCase{
+		authRepository:         authRepository,
+		loginAttemptRepository: loginAttemptRepository,
+		authEventRepository:    authEventRepository,
+		logger:                 logger,
+	}
+}
+
+// Execute remove o bloqueio e as falhas acumuladas do e-mail do usuário. As falhas por IP
+// continuam valendo até expirar a janela.
+func (uc *UnlockAccountUseCase) Execute(userID uuid.UUID, admin *domain.Claims) error {
+	uc.logger.Info("Processing account unlock", zap.String("userID", userID.String()), zap.String("adminID", admin.UserID.String()))
+
+	userInfo, err := uc.authRepository.FindUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		uc.logger.Error("Error finding user", zap.Error(err))
+		return err
+	}
+
+	email := domain.NormalizeEmail(userInfo.Email)
+	if err := uc.loginAttemptRepository.Reset(domain.AttemptScopeEmail, email); err != nil {
+		uc.logger.Error("Error unlocking account", zap.Error(err))
+		return errors.New("error unlocking account")
+	}
+
+	event := &domain.AuthEvent{
+		ID:     uuid.New(),
+		Type:   domain.EventAccountUnlocked,
+		UserID: &userInfo.ID,
+		Email:  email,
+		Detail: "unlocked by admin " + admin.UserID.String(),
+	}
+	if err := uc.authEventRepository.Record(event); err != nil {
+		uc.logger.Error("Error recording auth event", zap.Error(err), zap.String("type", event.Type))
+	}
+
+	uc.logger.Info("Account unlocked", zap.String("userID", userID.String()))
+	return nil
+}
+
+type ListAuthEventsUseCase struct {
+	authEventRepository domain.AuthEventRepository
+	logger              logger.Logger
+}
+
+func NewListAuthEventsUseCase(authEventRepository domain.AuthEventRepository, logger logger.Logger) *ListAuthEventsUseCase {
+	return &ListAuthEventsUseCase{
+		authEventRepository: authEventRepository,
+		logger:              logger,
+	}
+}
+
+// Execute lista os eventos mais recentes do filtro, com limite padrão de DefaultAuthEventLimit
+func (uc *ListAuthEventsUseCase) Execute(filter domain.AuthEventFilter) ([]domain.AuthEvent, error) {
+	uc.logger.Info("Listing auth events", zap.String("email", filter.Email), zap.String("ip", filter.IP), zap.String("type", filter.Type))
+
+	if filter.Type != "" && !domain.IsValidAuthEventType(filter.Type) {
+		return nil, errors.New("invalid event type")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultAuthEventLimit
+	}
+	if filter.Limit < 0 || filter.Limit > domain.MaxAuthEventLimit {
+		return nil, errors.New("invalid limit")
+	}
+	filter.Email = domain.NormalizeEmail(filter.Email)
+	filter.IP = strings.TrimSpace(filter.IP)
+
+	events, err := uc.authEventRepository.Find(filter)
+	if err != nil {
+		uc.logger.Error("Error listing auth events", zap.Error(err))
+		return nil, err
+	}
+	return events, nil
+}
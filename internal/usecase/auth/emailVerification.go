@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	mailDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/mail"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+type SendEmailVerificationUseCase struct {
+	authRepository      domain.AuthRepository
+	userTokenRepository domain.UserTokenRepository
+	mailer              mailDomain.Mailer
+	appURL              string
+	logger              logger.Logger
+}
+
+func NewSendEmailVerificationUseCase(authRepository domain.AuthRepository, userTokenRepository domain.UserTokenRepository, mailer mailDomain.Mailer, appURL string, logger logger.Logger) *SendEmailVerificationUseCase {
+	return &SendEmailVerificationUseCase{
+		authRepository:      authRepository,
+		userTokenRepository: userTokenRepository,
+		mailer:              mailer,
+		appURL:              appURL,
+		logger:              logger,
+	}
+}
+
+// Execute envia o link de confirmação de e-mail; um novo envio invalida o link anterior
+func (uc *SendEmailVerificationUseCase) Execute(userID uuid.UUID) error {
+	uc.logger.Info("Processing e-mail verification", zap.String("userID", userID.String()))
+
+	userInfo, err := uc.authRepository.FindUserByID(userID)
+	if err != nil || userInfo == nil {
+		uc.logger.Error("User not found", zap.String("userID", userID.String()))
+		return errors.New("user not found")
+	}
+	if userInfo.EmailVerified {
+		return errors.New("email already verified")
+	}
+
+	token, err := issueUserToken(uc.userTokenRepository, userID, domain.PurposeEmailVerification, domain.EmailVerificationTokenTTL)
+	if err != nil {
+		uc.logger.Error("Error issuing e-mail verification token", zap.Error(err))
+		return errors.New("error sending email verification")
+	}
+
+	message := mailDomain.Message{
+		To:      userInfo.Email,
+		Subject: "Confirme o seu e-mail",
+		Body: fmt.Sprintf("Olá, %s.\n\nConfirme o seu e-mail pelo link abaixo, válido por %d horas:\n\n%s/verify-email?token=%s\n",
+			userInfo.Username, int(domain.EmailVerificationTokenTTL.Hours()), uc.appURL, url.QueryEscape(token)),
+	}
+	if err := uc.mailer.Send(message); err != nil {
+		uc.logger.Error("Error sending e-mail verification", zap.Error(err))
+		return errors.New("error sending email verification")
+	}
+
+	uc.logger.Info("E-mail verification sent", zap.String("userID", userID.String()))
+	return nil
+}
+
+type VerifyEmailUseCase struct {
+	authRepository      domain.AuthRepository
+	userTokenRepository domain.UserTokenRepository
+	logger              logger.Logger
+}
+
+func NewVerifyEmailUseCase(authRepository domain.AuthRepository, userTokenRepository domain.UserTokenRepository, logger logger.Logger) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		authRepository:      authRepository,
+		userTokenRepository: userTokenRepository,
+		logger:              logger,
+	}
+}
+
+// Execute confirma o e-mail do usuário dono do token
+func (uc *VerifyEmailUseCase) Execute(token string) error {
+	uc.logger.Info("Processing e-mail verification token")
+
+	record, err := consumeUserToken(uc.userTokenRepository, token, domain.PurposeEmailVerification)
+	if err != nil {
+		uc.logger.Error("Invalid e-mail verification token", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidUserToken) {
+			return err
+		}
+		return errors.New("error verifying email")
+	}
+
+	if err := uc.authRepository.MarkEmailVerified(record.UserID, time.Now()); err != nil {
+		uc.logger.Error("Error marking e-mail as verified", zap.Error(err))
+		return errors.New("error verifying email")
+	}
+
+	uc.logger.Info("E-mail verified", zap.String("userID", record.UserID.String()))
+	return nil
+}
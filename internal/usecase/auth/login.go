@@ -4,32 +4,55 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type LoginUseCase struct {
-	authRepository domain.AuthRepository
-	tokenService   domain.TokenService
-	logger         logger.Logger
+	authRepository         domain.AuthRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	tokenService           domain.TokenService
+	loginAttemptRepository domain.LoginAttemptRepository
+	authEventRepository    domain.AuthEventRepository
+	throttlePolicy         domain.LoginThrottlePolicy
+	logger                 logger.Logger
 }
 
-func NewLoginUseCase(authRepository domain.AuthRepository, tokenService domain.TokenService, logger logger.Logger) *LoginUseCase {
+func NewLoginUseCase(authRepository domain.AuthRepository, refreshTokenRepository domain.RefreshTokenRepository, tokenService domain.TokenService, loginAttemptRepository domain.LoginAttemptRepository, authEventRepository domain.AuthEventRepository, throttlePolicy domain.LoginThrottlePolicy, logger logger.Logger) *LoginUseCase {
 	return &LoginUseCase{
-		authRepository: authRepository,
-		tokenService:   tokenService,
-		logger:         logger,
+		authRepository:         authRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		tokenService:           tokenService,
+		loginAttemptRepository: loginAttemptRepository,
+		authEventRepository:    authEventRepository,
+		throttlePolicy:         throttlePolicy,
+		logger:                 logger,
 	}
 }
 
 func (uc *LoginUseCase) Execute(request domain.LoginRequest) (*domain.LoginResponse, error) {
-	uc.logger.Info("Processing login request", zap.String("email", request.Email))
+	uc.logger.Info("Processing login request", zap.String("email", request.Email), zap.String("ip", request.IP))
+
+	now := time.Now()
+	emailKey := domain.NormalizeEmail(request.Email)
+
+	// Recusa a tentativa durante o backoff ou o bloqueio, sem conferir a senha
+	if err := uc.checkThrottle(request, emailKey, now); err != nil {
+		return nil, err
+	}
 
 	// Busca o usuário por email
 	userInfo, err := uc.authRepository.FindUserByEmail(request.Email)
 	if err != nil {
 		uc.logger.Error("User not found", zap.Error(err), zap.String("email", request.Email))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			uc.registerFailure(request, emailKey, nil, "unknown email", now)
+		} else {
+			uc.recordEvent(request, domain.EventLoginFailed, nil, "error finding user")
+		}
 		return nil, errors.New("invalid credentials")
 	}
 
@@ -39,6 +62,7 @@ func (uc *LoginUseCase) Execute(request domain.LoginRequest) (*domain.LoginRespo
 	err = uc.authRepository.ValidatePassword(request.Email, request.Password)
 	if err != nil {
 		uc.logger.Error("Invalid password", zap.Error(err), zap.String("email", request.Email))
+		uc.registerFailure(request, emailKey, &userInfo.ID, "invalid password", now)
 		return nil, errors.New("invalid credentials")
 	}
 
@@ -48,25 +72,118 @@ func (uc *LoginUseCase) Execute(request domain.LoginRequest) (*domain.LoginRespo
 	token, err := uc.tokenService.GenerateToken(*userInfo)
 	if err != nil {
 		uc.logger.Error("Error generating token", zap.Error(err))
+		uc.recordEvent(request, domain.EventLoginFailed, &userInfo.ID, "error generating token")
 		return nil, errors.New("error generating token")
 	}
 
-	// Gera o refresh token
-	refreshToken, err := uc.tokenService.GenerateRefreshToken(userInfo.ID)
+	// Gera o refresh token, iniciando uma nova família de rotação
+	refreshToken, record, err := issueRefreshToken(uc.refreshTokenRepository, uc.tokenService, uuid.New(), userInfo.ID, uuid.New(), request.Device)
 	if err != nil {
 		uc.logger.Error("Error generating refresh token", zap.Error(err))
+		uc.recordEvent(request, domain.EventLoginFailed, &userInfo.ID, "error generating refresh token")
 		return nil, errors.New("error generating refresh token")
 	}
 
+	// O acerto zera as falhas do e-mail; as do IP só expiram com a janela, para que uma conta
+	// válida não sirva para liberar tentativas contra outras
+	if err := uc.loginAttemptRepository.Reset(domain.AttemptScopeEmail, emailKey); err != nil {
+		uc.logger.Error("Error resetting login attempts", zap.Error(err))
+	}
+	uc.recordEvent(request, domain.EventLoginSucceeded, &userInfo.ID, "")
+
 	// Calcula a data de expiração (24 horas)
-	expiresAt := time.Now().Add(24 * time.Hour)
+	expiresAt := time.Now().Add(domain.AccessTokenTTL)
 
 	uc.logger.Info("Login successful", zap.String("email", userInfo.Email))
 
 	return &domain.LoginResponse{
-		Token:        token,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
-		User:         *userInfo,
+		Token:                 token,
+		RefreshToken:          refreshToken,
+		ExpiresAt:             expiresAt,
+		RefreshTokenExpiresAt: record.ExpiresAt,
+		User:                  *userInfo,
 	}, nil
 }
+
+// attemptKeys lista as chaves contadas na tentativa; sem IP conhecido, só o e-mail conta
+func attemptKeys(request domain.LoginRequest, emailKey string) []domain.LoginAttempt {
+	keys := []domain.LoginAttempt{{Scope: domain.AttemptScopeEmail, Key: emailKey}}
+	if request.IP != "" {
+		keys = append(keys, domain.LoginAttempt{Scope: domain.AttemptScopeIP, Key: request.IP})
+	}
+	return keys
+}
+
+// checkThrottle retorna LoginBlockedError com a maior espera entre o e-mail e o IP
+func (uc *LoginUseCase) checkThrottle(request domain.LoginRequest, emailKey string, now time.Time) error {
+	var wait time.Duration
+	locked := false
+
+	for _, key := range attemptKeys(request, emailKey) {
+		attempt, err := uc.loginAttemptRepository.Find(key.Scope, key.Key)
+		if err != nil {
+			uc.logger.Error("Error checking login attempts", zap.Error(err), zap.String("scope", key.Scope))
+			return errors.New("error checking login attempts")
+		}
+
+		scopeWait, scopeLocked := uc.throttlePolicy.RetryAfter(attempt, now)
+		if scopeWait > wait {
+			wait = scopeWait
+		}
+		locked = locked || scopeLocked
+	}
+
+	if wait == 0 {
+		return nil
+	}
+
+	reason := domain.ErrTooManyLoginAttempts
+	if locked {
+		reason = domain.ErrAccountLocked
+	}
+	uc.logger.Info("Login attempt throttled", zap.String("email", request.Email), zap.String("ip", request.IP), zap.Duration("retryAfter", wait))
+	uc.recordEvent(request, domain.EventLoginThrottled, nil, reason.Error())
+	return &domain.LoginBlockedError{Err: reason, RetryAfter: wait}
+}
+
+// registerFailure conta a falha no e-mail e no IP e bloqueia a conta ao atingir o limite. Erros
+// aqui são só registrados: a resposta continua sendo "invalid credentials".
+func (uc *LoginUseCase) registerFailure(request domain.LoginRequest, emailKey string, userID *uuid.UUID, detail string, now time.Time) {
+	uc.recordEvent(request, domain.EventLoginFailed, userID, detail)
+
+	windowStart := now.Add(-uc.throttlePolicy.FailureWindow)
+	for _, key := range attemptKeys(request, emailKey) {
+		attempt, err := uc.loginAttemptRepository.RegisterFailure(key.Scope, key.Key, now, windowStart)
+		if err != nil {
+			uc.logger.Error("Error registering login failure", zap.Error(err), zap.String("scope", key.Scope))
+			continue
+		}
+		if !uc.throttlePolicy.ShouldLock(attempt) {
+			continue
+		}
+
+		lockedUntil := now.Add(uc.throttlePolicy.LockoutDuration)
+		if err := uc.loginAttemptRepository.Lock(key.Scope, key.Key, lockedUntil); err != nil {
+			uc.logger.Error("Error locking account", zap.Error(err))
+			continue
+		}
+		uc.logger.Info("Account locked", zap.String("email", request.Email), zap.Time("lockedUntil", lockedUntil))
+		uc.recordEvent(request, domain.EventAccountLocked, userID, "locked until "+lockedUntil.UTC().Format(time.RFC3339))
+	}
+}
+
+// recordEvent grava o evento de auditoria; uma falha na gravação não muda o resultado do login
+func (uc *LoginUseCase) recordEvent(request domain.LoginRequest, eventType string, userID *uuid.UUID, detail string) {
+	event := &domain.AuthEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		UserID:    userID,
+		Email:     domain.NormalizeEmail(request.Email),
+		IP:        request.IP,
+		UserAgent: request.UserAgent,
+		Detail:    detail,
+	}
+	if err := uc.authEventRepository.Record(event); err != nil {
+		uc.logger.Error("Error recording auth event", zap.Error(err), zap.String("type", eventType))
+	}
+}
@@ -0,0 +1,345 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func TestLoginThrottlePolicy_Backoff_DoublesUpToMaxDelay(t *testing.T) {
+	policy := domain.DefaultLoginThrottlePolicy()
+	cases := map[int]time.Duration{
+		3:  0,
+		4:  time.Second,
+		5:  2 * time.Second,
+		8:  16 * time.Second,
+		30: policy.MaxDelay,
+	}
+
+	for failures, expected := range cases {
+		if got := policy.Backoff(failures, policy.FreeAttempts); got != expected {
+			t.Errorf("Expected backoff %s after %d failures, got %s", expected, failures, got)
+		}
+	}
+}
+
+func TestLoginUseCase_Execute_RejectsAttemptDuringBackoff(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.ValidatePasswordFunc = func(email, password string) error {
+		t.Fatal("Expected password not to be checked during backoff")
+		return nil
+	}
+	lastFailureAt := time.Now()
+	attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+	attemptRepo.FindFunc = func(scope, key string) (*domain.LoginAttempt, error) {
+		if scope == domain.AttemptScopeEmail && key == "joao@example.com" {
+			return &domain.LoginAttempt{Scope: scope, Key: key, Failures: 6, LastFailureAt: lastFailureAt}, nil
+		}
+		return nil, nil
+	}
+	var events []domain.AuthEvent
+	eventRepo := &mocks.AuthEventRepositoryMock{RecordFunc: func(event *domain.AuthEvent) error {
+		events = append(events, *event)
+		return nil
+	}}
+	useCase := NewLoginUseCase(authRepoMock, &mocks.RefreshTokenRepositoryMock{}, &mocks.TokenServiceMock{}, attemptRepo, eventRepo, domain.DefaultLoginThrottlePolicy(), loggerMock)
+
+	// Act
+	_, err := useCase.Execute(domain.LoginRequest{Email: " Joao@Example.com", Password: "password123", IP: "10.0.0.1"})
+
+	// Assert
+	var blocked *domain.LoginBlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, domain.ErrTooManyLoginAttempts) {
+		t.Fatalf("Expected too many login attempts, got %v", err)
+	}
+	if blocked.RetryAfter <= 3*time.Second || blocked.RetryAfter > 4*time.Second {
+		t.Errorf("Expected to wait about 4s after 6 failures, got %s", blocked.RetryAfter)
+	}
+	if len(events) != 1 || events[0].Type != domain.EventLoginThrottled || events[0].IP != "10.0.0.1" {
+		t.Errorf("Expected a throttled event, got %+v", events)
+	}
+}
+
+func TestLoginUseCase_Execute_IPToleratesMoreFailuresThanEmail(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userID := uuid.New()
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByEmailFunc = func(email string) (*domain.UserInfo, error) {
+		return &domain.UserInfo{ID: userID, Email: email}, nil
+	}
+	authRepoMock.ValidatePasswordFunc = func(email, password string) error {
+		return nil
+	}
+	attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+	attemptRepo.FindFunc = func(scope, key string) (*domain.LoginAttempt, error) {
+		if scope == domain.AttemptScopeIP {
+			return &domain.LoginAttempt{Scope: scope, Key: key, Failures: 6, LastFailureAt: time.Now()}, nil
+		}
+		return nil, nil
+	}
+	var reset []string
+	attemptRepo.ResetFunc = func(scope, key string) error {
+		reset = append(reset, scope)
+		return nil
+	}
+	tokenServiceMock := &mocks.TokenServiceMock{}
+	tokenServiceMock.GenerateTokenFunc = func(userInfo domain.UserInfo) (string, error) {
+		return "mock-jwt-token", nil
+	}
+	tokenServiceMock.GenerateRefreshTokenFunc = func(userID uuid.UUID) (string, error) {
+		return "mock-refresh-token", nil
+	}
+	var events []domain.AuthEvent
+	eventRepo := &mocks.AuthEventRepositoryMock{RecordFunc: func(event *domain.AuthEvent) error {
+		events = append(events, *event)
+		return nil
+	}}
+	useCase := NewLoginUseCase(authRepoMock, &mocks.RefreshTokenRepositoryMock{}, tokenServiceMock, attemptRepo, eventRepo, domain.DefaultLoginThrottlePolicy(), loggerMock)
+
+	// Act
+	_, err := useCase.Execute(domain.LoginRequest{Email: "joao@example.com", Password: "password123", IP: "10.0.0.1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected login to succeed, got %v", err)
+	}
+	if len(reset) != 1 || reset[0] != domain.AttemptScopeEmail {
+		t.Errorf("Expected only the e-mail counter to be reset, got %v", reset)
+	}
+	if len(events) != 1 || events[0].Type != domain.EventLoginSucceeded || *events[0].UserID != userID {
+		t.Errorf("Expected a success event, got %+v", events)
+	}
+}
+
+func TestLoginUseCase_Execute_LocksAccountAtThreshold(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByEmailFunc = func(email string) (*domain.UserInfo, error) {
+		return &domain.UserInfo{ID: uuid.New(), Email: email}, nil
+	}
+	authRepoMock.ValidatePasswordFunc = func(email, password string) error {
+		return bcrypt.ErrMismatchedHashAndPassword
+	}
+	policy := domain.DefaultLoginThrottlePolicy()
+	attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+	var registered []string
+	attemptRepo.RegisterFailureFunc = func(scope, key string, failedAt, windowStart time.Time) (*domain.LoginAttempt, error) {
+		registered = append(registered, scope)
+		if failedAt.Sub(windowStart) != policy.FailureWindow {
+			t.Errorf("Expected window of %s, got %s", policy.FailureWindow, failedAt.Sub(windowStart))
+		}
+		return &domain.LoginAttempt{Scope: scope, Key: key, Failures: policy.LockoutThreshold, LastFailureAt: failedAt}, nil
+	}
+	var lockedScope string
+	var lockedUntil time.Time
+	attemptRepo.LockFunc = func(scope, key string, until time.Time) error {
+		lockedScope, lockedUntil = scope, until
+		return nil
+	}
+	var events []domain.AuthEvent
+	eventRepo := &mocks.AuthEventRepositoryMock{RecordFunc: func(event *domain.AuthEvent) error {
+		events = append(events, *event)
+		return nil
+	}}
+	useCase := NewLoginUseCase(authRepoMock, &mocks.RefreshTokenRepositoryMock{}, &mocks.TokenServiceMock{}, attemptRepo, eventRepo, domain.DefaultLoginThrottlePolicy(), loggerMock)
+
+	// Act
+	_, err := useCase.Execute(domain.LoginRequest{Email: "joao@example.com", Password: "wrong-password", IP: "10.0.0.1"})
+
+	// Assert
+	if err == nil || err.Error() != "invalid credentials" {
+		t.Fatalf("Expected invalid credentials, got %v", err)
+	}
+	if len(registered) != 2 {
+		t.Errorf("Expected failure to count for e-mail and IP, got %v", registered)
+	}
+	if lockedScope != domain.AttemptScopeEmail {
+		t.Errorf("Expected only the e-mail to be locked, got %q", lockedScope)
+	}
+	if remaining := time.Until(lockedUntil); remaining <= 0 || remaining > policy.LockoutDuration {
+		t.Errorf("Expected lock for %s, got %s", policy.LockoutDuration, remaining)
+	}
+	if len(events) != 2 || events[0].Type != domain.EventLoginFailed || events[1].Type != domain.EventAccountLocked {
+		t.Errorf("Expected failed and locked events, got %+v", events)
+	}
+}
+
+func TestLoginUseCase_Execute_RejectsLockedAccount(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+	attemptRepo.FindFunc = func(scope, key string) (*domain.LoginAttempt, error) {
+		if scope == domain.AttemptScopeEmail {
+			return &domain.LoginAttempt{Scope: scope, Key: key, Failures: 10, LastFailureAt: time.Now(), LockedUntil: &lockedUntil}, nil
+		}
+		return nil, nil
+	}
+	var events []domain.AuthEvent
+	eventRepo := &mocks.AuthEventRepositoryMock{RecordFunc: func(event *domain.AuthEvent) error {
+		events = append(events, *event)
+		return nil
+	}}
+	useCase := NewLoginUseCase(&mocks.AuthRepositoryMock{}, &mocks.RefreshTokenRepositoryMock{}, &mocks.TokenServiceMock{}, attemptRepo, eventRepo, domain.DefaultLoginThrottlePolicy(), loggerMock)
+
+	// Act
+	_, err := useCase.Execute(domain.LoginRequest{Email: "joao@example.com", Password: "password123"})
+
+	// Assert
+	var blocked *domain.LoginBlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, domain.ErrAccountLocked) {
+		t.Fatalf("Expected account locked, got %v", err)
+	}
+	if blocked.RetryAfter <= 9*time.Minute || blocked.RetryAfter > 10*time.Minute {
+		t.Errorf("Expected to wait until the lock expires, got %s", blocked.RetryAfter)
+	}
+}
+
+func TestLoginUseCase_Execute_CountsUnknownEmail(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByEmailFunc = func(email string) (*domain.UserInfo, error) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+	var registered []string
+	attemptRepo.RegisterFailureFunc = func(scope, key string, failedAt, windowStart time.Time) (*domain.LoginAttempt, error) {
+		registered = append(registered, scope+":"+key)
+		return &domain.LoginAttempt{Scope: scope, Key: key, Failures: 1, LastFailureAt: failedAt}, nil
+	}
+	var events []domain.AuthEvent
+	eventRepo := &mocks.AuthEventRepositoryMock{RecordFunc: func(event *domain.AuthEvent) error {
+		events = append(events, *event)
+		return nil
+	}}
+	useCase := NewLoginUseCase(authRepoMock, &mocks.RefreshTokenRepositoryMock{}, &mocks.TokenServiceMock{}, attemptRepo, eventRepo, domain.DefaultLoginThrottlePolicy(), loggerMock)
+
+	// Act
+	_, err := useCase.Execute(domain.LoginRequest{Email: "Ninguem@example.com", Password: "password123", IP: "10.0.0.1"})
+
+	// Assert
+	if err == nil || err.Error() != "invalid credentials" {
+		t.Fatalf("Expected invalid credentials, got %v", err)
+	}
+	if len(registered) != 2 || registered[0] != "email:ninguem@example.com" || registered[1] != "ip:10.0.0.1" {
+		t.Errorf("Expected unknown e-mail to count as a failure, got %v", registered)
+	}
+	if len(events) != 1 || events[0].UserID != nil || events[0].Detail != "unknown email" {
+		t.Errorf("Expected a failed event without user, got %+v", events)
+	}
+}
+
+func TestUnlockAccountUseCase_Execute_ResetsEmailAndRecordsEvent(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userID := uuid.New()
+	adminID := uuid.New()
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByIDFunc = func(id uuid.UUID) (*domain.UserInfo, error) {
+		if id != userID {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return &domain.UserInfo{ID: userID, Email: "Joao@Example.com"}, nil
+	}
+	attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+	var reset string
+	attemptRepo.ResetFunc = func(scope, key string) error {
+		reset = scope + ":" + key
+		return nil
+	}
+	var recorded *domain.AuthEvent
+	eventRepo := &mocks.AuthEventRepositoryMock{RecordFunc: func(event *domain.AuthEvent) error {
+		recorded = event
+		return nil
+	}}
+	useCase := NewUnlockAccountUseCase(authRepoMock, attemptRepo, eventRepo, loggerMock)
+	admin := &domain.Claims{UserID: adminID, UserType: "admin"}
+
+	// Act
+	err := useCase.Execute(userID, admin)
+	notFoundErr := useCase.Execute(uuid.New(), admin)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reset != "email:joao@example.com" {
+		t.Errorf("Expected e-mail counter to be reset, got %q", reset)
+	}
+	if recorded == nil || recorded.Type != domain.EventAccountUnlocked || *recorded.UserID != userID || recorded.Detail != "unlocked by admin "+adminID.String() {
+		t.Errorf("Expected an unlock event naming the admin, got %+v", recorded)
+	}
+	if notFoundErr == nil || notFoundErr.Error() != "user not found" {
+		t.Errorf("Expected user not found, got %v", notFoundErr)
+	}
+}
+
+func TestListAuthEventsUseCase_Execute_ValidatesFilter(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	var received domain.AuthEventFilter
+	eventRepo := &mocks.AuthEventRepositoryMock{FindFunc: func(filter domain.AuthEventFilter) ([]domain.AuthEvent, error) {
+		received = filter
+		return []domain.AuthEvent{}, nil
+	}}
+	useCase := NewListAuthEventsUseCase(eventRepo, loggerMock)
+
+	// Act
+	_, err := useCase.Execute(domain.AuthEventFilter{Email: "Joao@Example.com"})
+	_, typeErr := useCase.Execute(domain.AuthEventFilter{Type: "password_changed"})
+	_, limitErr := useCase.Execute(domain.AuthEventFilter{Limit: domain.MaxAuthEventLimit + 1})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if received.Email != "joao@example.com" || received.Limit != domain.DefaultAuthEventLimit {
+		t.Errorf("Expected normalized e-mail and default limit, got %+v", received)
+	}
+	if typeErr == nil || typeErr.Error() != "invalid event type" {
+		t.Errorf("Expected invalid event type, got %v", typeErr)
+	}
+	if limitErr == nil || limitErr.Error() != "invalid limit" {
+		t.Errorf("Expected invalid limit, got %v", limitErr)
+	}
+}
@@ -59,9 +59,12 @@ func TestLoginUseCase_Execute_Success(t *testing.T) {
 	}
 
 	useCase := &LoginUseCase{
-		authRepository: authRepoMock,
-		tokenService:   tokenServiceMock,
-		logger:         loggerMock,
+		authRepository:         authRepoMock,
+		refreshTokenRepository: &mocks.RefreshTokenRepositoryMock{},
+		loginAttemptRepository: &mocks.LoginAttemptRepositoryMock{},
+		authEventRepository:    &mocks.AuthEventRepositoryMock{},
+		tokenService:           tokenServiceMock,
+		logger:                 loggerMock,
 	}
 
 	request := domain.LoginRequest{
@@ -141,9 +144,12 @@ func TestLoginUseCase_Execute_UserNotFound(t *testing.T) {
 	}
 
 	useCase := &LoginUseCase{
-		authRepository: authRepoMock,
-		tokenService:   tokenServiceMock,
-		logger:         loggerMock,
+		authRepository:         authRepoMock,
+		refreshTokenRepository: &mocks.RefreshTokenRepositoryMock{},
+		loginAttemptRepository: &mocks.LoginAttemptRepositoryMock{},
+		authEventRepository:    &mocks.AuthEventRepositoryMock{},
+		tokenService:           tokenServiceMock,
+		logger:                 loggerMock,
 	}
 
 	request := domain.LoginRequest{
@@ -222,9 +228,12 @@ func TestLoginUseCase_Execute_InvalidPassword(t *testing.T) {
 	}
 
 	useCase := &LoginUseCase{
-		authRepository: authRepoMock,
-		tokenService:   tokenServiceMock,
-		logger:         loggerMock,
+		authRepository:         authRepoMock,
+		refreshTokenRepository: &mocks.RefreshTokenRepositoryMock{},
+		loginAttemptRepository: &mocks.LoginAttemptRepositoryMock{},
+		authEventRepository:    &mocks.AuthEventRepositoryMock{},
+		tokenService:           tokenServiceMock,
+		logger:                 loggerMock,
 	}
 
 	request := domain.LoginRequest{
@@ -320,9 +329,12 @@ func TestLoginUseCase_Execute_TokenGenerationError(t *testing.T) {
 	}
 
 	useCase := &LoginUseCase{
-		authRepository: authRepoMock,
-		tokenService:   tokenServiceMock,
-		logger:         loggerMock,
+		authRepository:         authRepoMock,
+		refreshTokenRepository: &mocks.RefreshTokenRepositoryMock{},
+		loginAttemptRepository: &mocks.LoginAttemptRepositoryMock{},
+		authEventRepository:    &mocks.AuthEventRepositoryMock{},
+		tokenService:           tokenServiceMock,
+		logger:                 loggerMock,
 	}
 
 	request := domain.LoginRequest{
@@ -423,9 +435,12 @@ func TestLoginUseCase_Execute_RefreshTokenGenerationError(t *testing.T) {
 	}
 
 	useCase := &LoginUseCase{
-		authRepository: authRepoMock,
-		tokenService:   tokenServiceMock,
-		logger:         loggerMock,
+		authRepository:         authRepoMock,
+		refreshTokenRepository: &mocks.RefreshTokenRepositoryMock{},
+		loginAttemptRepository: &mocks.LoginAttemptRepositoryMock{},
+		authEventRepository:    &mocks.AuthEventRepositoryMock{},
+		tokenService:           tokenServiceMock,
+		logger:                 loggerMock,
 	}
 
 	request := domain.LoginRequest{
@@ -521,9 +536,12 @@ func TestLoginUseCase_Execute_ExpiresAtCalculation(t *testing.T) {
 	}
 
 	useCase := &LoginUseCase{
-		authRepository: authRepoMock,
-		tokenService:   tokenServiceMock,
-		logger:         loggerMock,
+		authRepository:         authRepoMock,
+		refreshTokenRepository: &mocks.RefreshTokenRepositoryMock{},
+		loginAttemptRepository: &mocks.LoginAttemptRepositoryMock{},
+		authEventRepository:    &mocks.AuthEventRepositoryMock{},
+		tokenService:           tokenServiceMock,
+		logger:                 loggerMock,
 	}
 
 	request := domain.LoginRequest{
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+type LogoutUseCase struct {
+	refreshTokenRepository domain.RefreshTokenRepository
+	revocationStore        domain.TokenRevocationStore
+	logger                 logger.Logger
+}
+
+func NewLogoutUseCase(refreshTokenRepository domain.RefreshTokenRepository, revocationStore domain.TokenRevocationStore, logger logger.Logger) *LogoutUseCase {
+	return &LogoutUseCase{
+		refreshTokenRepository: refreshTokenRepository,
+		revocationStore:        revocationStore,
+		logger:                 logger,
+	}
+}
+
+// Execute revoga o token de acesso da requisição e, quando informado, o refresh token da mesma
+// sessão. Um refresh token de outro usuário é ignorado.
+func (uc *LogoutUseCase) Execute(claims *domain.Claims, refreshToken string) error {
+	uc.logger.Info("Processing logout", zap.String("userID", claims.UserID.String()))
+
+	if err := uc.revocationStore.RevokeToken(claims.TokenID, claims.UserID, time.Unix(claims.Exp, 0)); err != nil {
+		uc.logger.Error("Error revoking access token", zap.Error(err))
+		return errors.New("error revoking token")
+	}
+
+	if refreshToken != "" {
+		record, err := uc.refreshTokenRepository.FindByHash(domain.HashToken(refreshToken))
+		if err != nil {
+			uc.logger.Error("Error finding refresh token", zap.Error(err))
+			return errors.New("error revoking token")
+		}
+		if record != nil && record.UserID == claims.UserID {
+			if err := uc.refreshTokenRepository.RevokeFamily(record.FamilyID, time.Now()); err != nil {
+				uc.logger.Error("Error revoking refresh token", zap.Error(err))
+				return errors.New("error revoking token")
+			}
+		}
+	}
+
+	uc.logger.Info("Logout successful", zap.String("userID", claims.UserID.String()))
+	return nil
+}
+
+type LogoutAllUseCase struct {
+	refreshTokenRepository domain.RefreshTokenRepository
+	revocationStore        domain.TokenRevocationStore
+	logger                 logger.Logger
+}
+
+func NewLogoutAllUseCase(refreshTokenRepository domain.RefreshTokenRepository, revocationStore domain.TokenRevocationStore, logger logger.Logger) *LogoutAllUseCase {
+	return &LogoutAllUseCase{
+		refreshTokenRepository: refreshTokenRepository,
+		revocationStore:        revocationStore,
+		logger:                 logger,
+	}
+}
+
+// Execute encerra todas as sessões do usuário: revoga os tokens de acesso já emitidos, incluindo
+// o da própria requisição, e todos os refresh tokens
+func (uc *LogoutAllUseCase) Execute(claims *domain.Claims) error {
+	uc.logger.Info("Processing logout from all sessions", zap.String("userID", claims.UserID.String()))
+
+	now := time.Now()
+	if err := uc.revocationStore.RevokeUserTokens(claims.UserID, now); err != nil {
+		uc.logger.Error("Error revoking user access tokens", zap.Error(err))
+		return errors.New("error revoking token")
+	}
+	if err := uc.refreshTokenRepository.RevokeAllForUser(claims.UserID, now); err != nil {
+		uc.logger.Error("Error revoking user refresh tokens", zap.Error(err))
+		return errors.New("error revoking token")
+	}
+
+	uc.logger.Info("Logout from all sessions successful", zap.String("userID", claims.UserID.String()))
+	return nil
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	authInfra "github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestLogoutUseCase_Execute_RevokesAccessTokenAndSession(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	store := authInfra.NewMemoryRevocationStore()
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	userID := uuid.New()
+	familyID := uuid.New()
+	refreshRepoMock.FindByHashFunc = func(tokenHash string) (*domain.RefreshToken, error) {
+		if tokenHash == domain.HashToken("session-refresh-token") {
+			return &domain.RefreshToken{ID: uuid.New(), UserID: userID, FamilyID: familyID}, nil
+		}
+		return nil, nil
+	}
+	var revokedFamily uuid.UUID
+	refreshRepoMock.RevokeFamilyFunc = func(id uuid.UUID, revokedAt time.Time) error {
+		revokedFamily = id
+		return nil
+	}
+
+	claims := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL).Unix(),
+	}
+	other := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL).Unix(),
+	}
+	useCase := NewLogoutUseCase(refreshRepoMock, store, loggerMock)
+
+	// Act
+	err := useCase.Execute(claims, "session-refresh-token")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if revoked, _ := store.IsRevoked(claims); !revoked {
+		t.Error("Expected the access token to be revoked")
+	}
+	// Outras sessões do usuário continuam válidas
+	if revoked, _ := store.IsRevoked(other); revoked {
+		t.Error("Expected other access tokens to stay valid")
+	}
+	if revokedFamily != familyID {
+		t.Errorf("Expected refresh token family %s to be revoked, got %s", familyID, revokedFamily)
+	}
+}
+
+func TestLogoutAllUseCase_Execute_RevokesEveryTokenIssuedBefore(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	store := authInfra.NewMemoryRevocationStore()
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	var revokedUser uuid.UUID
+	refreshRepoMock.RevokeAllForUserFunc = func(userID uuid.UUID, revokedAt time.Time) error {
+		revokedUser = userID
+		return nil
+	}
+
+	userID := uuid.New()
+	current := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL).Unix(),
+	}
+	// Outra sessão aberta no mesmo segundo do logout
+	sameSecond := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL).Unix(),
+	}
+	older := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Add(-2 * time.Hour).Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL - 2*time.Hour).Unix(),
+	}
+	otherUser := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  uuid.New(),
+		Iat:     time.Now().Add(-2 * time.Hour).Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL - 2*time.Hour).Unix(),
+	}
+	useCase := NewLogoutAllUseCase(refreshRepoMock, store, loggerMock)
+
+	// Act
+	err := useCase.Execute(current)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for name, claims := range map[string]*domain.Claims{"current": current, "same second": sameSecond, "older": older} {
+		if revoked, _ := store.IsRevoked(claims); !revoked {
+			t.Errorf("Expected %s token to be revoked", name)
+		}
+	}
+	if revoked, _ := store.IsRevoked(otherUser); revoked {
+		t.Error("Expected tokens of other users to stay valid")
+	}
+	// Um novo login depois do logout não é afetado
+	newLogin := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Add(time.Second).Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL + time.Second).Unix(),
+	}
+	if revoked, _ := store.IsRevoked(newLogin); revoked {
+		t.Error("Expected tokens issued after logout to stay valid")
+	}
+	if revokedUser != userID {
+		t.Errorf("Expected refresh tokens of %s to be revoked", userID)
+	}
+}
+
+func TestMemoryRevocationStore_PruneExpired(t *testing.T) {
+	// Arrange
+	store := authInfra.NewMemoryRevocationStore()
+	userID := uuid.New()
+	store.RevokeToken(uuid.New(), userID, time.Now().Add(-time.Minute))
+	store.RevokeToken(uuid.New(), userID, time.Now().Add(time.Hour))
+	store.RevokeUserTokens(uuid.New(), time.Now().Add(-2*domain.AccessTokenTTL))
+
+	// Act
+	pruned, err := store.PruneExpired(time.Now())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if pruned != 2 {
+		t.Errorf("Expected 2 expired entries to be pruned, got %d", pruned)
+	}
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	mailDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/mail"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type RequestPasswordResetUseCase struct {
+	authRepository      domain.AuthRepository
+	userTokenRepository domain.UserTokenRepository
+	mailer              mailDomain.Mailer
+	appURL              string
+	logger              logger.Logger
+}
+
+func NewRequestPasswordResetUseCase(authRepository domain.AuthRepository, userTokenRepository domain.UserTokenRepository, mailer mailDomain.Mailer, appURL string, logger logger.Logger) *RequestPasswordResetUseCase {
+	return &RequestPasswordResetUseCase{
+		authRepository:      authRepository,
+		userTokenRepository: userTokenRepository,
+		mailer:              mailer,
+		appURL:              appURL,
+		logger:              logger,
+	}
+}
+
+// Execute envia o link de redefinição. E-mail não cadastrado não é erro, para não revelar
+// quais e-mails têm conta.
+func (uc *RequestPasswordResetUseCase) Execute(email string) error {
+	uc.logger.Info("Processing password reset request", zap.String("email", email))
+
+	userInfo, err := uc.authRepository.FindUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && userInfo == nil) {
+		uc.logger.Info("Password reset requested for unknown e-mail", zap.String("email", email))
+		return nil
+	}
+	if err != nil {
+		uc.logger.Error("Error finding user", zap.Error(err))
+		return errors.New("error requesting password reset")
+	}
+
+	token, err := issueUserToken(uc.userTokenRepository, userInfo.ID, domain.PurposePasswordReset, domain.PasswordResetTokenTTL)
+	if err != nil {
+		uc.logger.Error("Error issuing password reset token", zap.Error(err))
+		return errors.New("error requesting password reset")
+	}
+
+	message := mailDomain.Message{
+		To:      userInfo.Email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf("Olá, %s.\n\nRecebemos um pedido para redefinir a sua senha. Use o link abaixo em até %d minutos:\n\n%s/reset-password?token=%s\n\nSe você não fez o pedido, ignore este e-mail.\n",
+			userInfo.Username, int(domain.PasswordResetTokenTTL.Minutes()), uc.appURL, url.QueryEscape(token)),
+	}
+	if err := uc.mailer.Send(message); err != nil {
+		uc.logger.Error("Error sending password reset e-mail", zap.Error(err))
+		return errors.New("error requesting password reset")
+	}
+
+	uc.logger.Info("Password reset e-mail sent", zap.String("userID", userInfo.ID.String()))
+	return nil
+}
+
+type ResetPasswordUseCase struct {
+	unitOfWork      domain.UnitOfWork
+	revocationStore domain.TokenRevocationStore
+	logger          logger.Logger
+}
+
+func NewResetPasswordUseCase(unitOfWork domain.UnitOfWork, revocationStore domain.TokenRevocationStore, logger logger.Logger) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		unitOfWork:      unitOfWork,
+		revocationStore: revocationStore,
+		logger:          logger,
+	}
+}
+
+// Execute troca a senha usando o token recebido por e-mail e encerra todas as sessões abertas,
+// já que elas podem ter sido criadas por quem tinha a senha antiga
+func (uc *ResetPasswordUseCase) Execute(token string, newPassword string) error {
+	uc.logger.Info("Processing password reset")
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		uc.logger.Error("Error hashing password", zap.Error(err))
+		return errors.New("error resetting password")
+	}
+
+	// O token só fica consumido se a nova senha for gravada; uma falha desfaz o consumo e o
+	// link continua valendo para uma nova tentativa
+	now := time.Now()
+	var record *domain.UserToken
+	err = uc.unitOfWork.Execute(func(repos domain.Repositories) error {
+		var err error
+		record, err = consumeUserToken(repos.UserTokens, token, domain.PurposePasswordReset)
+		if err != nil {
+			uc.logger.Error("Invalid password reset token", zap.Error(err))
+			if errors.Is(err, domain.ErrInvalidUserToken) {
+				return err
+			}
+			return errors.New("error resetting password")
+		}
+
+		if err := repos.Auth.UpdatePassword(record.UserID, string(hash)); err != nil {
+			uc.logger.Error("Error updating password", zap.Error(err))
+			return errors.New("error resetting password")
+		}
+		if err := repos.RefreshTokens.RevokeAllForUser(record.UserID, now); err != nil {
+			uc.logger.Error("Error revoking refresh tokens", zap.Error(err))
+			return errors.New("error resetting password")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := uc.revocationStore.RevokeUserTokens(record.UserID, now); err != nil {
+		uc.logger.Error("Error revoking access tokens", zap.Error(err))
+		return errors.New("error resetting password")
+	}
+
+	uc.logger.Info("Password reset successfully", zap.String("userID", record.UserID.String()))
+	return nil
+}
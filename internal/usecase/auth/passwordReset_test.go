@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	mailDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/mail"
+	authInfra "github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// tokenFromMessage extrai o token do link enviado no e-mail
+func tokenFromMessage(t *testing.T, message mailDomain.Message) string {
+	t.Helper()
+	index := strings.Index(message.Body, "token=")
+	if index < 0 {
+		t.Fatalf("Expected a token link in the e-mail, got %q", message.Body)
+	}
+	value := strings.Fields(message.Body[index+len("token="):])[0]
+	token, err := url.QueryUnescape(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestRequestPasswordResetUseCase_Execute_SendsSingleUseLink(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userID := uuid.New()
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByEmailFunc = func(email string) (*domain.UserInfo, error) {
+		return &domain.UserInfo{ID: userID, Email: email, Username: "joao"}, nil
+	}
+	userTokenRepoMock := &mocks.UserTokenRepositoryMock{}
+	invalidated := false
+	userTokenRepoMock.InvalidateForUserFunc = func(id uuid.UUID, purpose string, usedAt time.Time) error {
+		invalidated = id == userID && purpose == domain.PurposePasswordReset
+		return nil
+	}
+	var stored *domain.UserToken
+	userTokenRepoMock.CreateFunc = func(token *domain.UserToken) error {
+		stored = token
+		return nil
+	}
+	var sent *mailDomain.Message
+	mailerMock := &mocks.MailerMock{SendFunc: func(message mailDomain.Message) error {
+		sent = &message
+		return nil
+	}}
+	useCase := NewRequestPasswordResetUseCase(authRepoMock, userTokenRepoMock, mailerMock, "https://oficina.example", loggerMock)
+
+	// Act
+	err := useCase.Execute("joao@example.com")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !invalidated {
+		t.Error("Expected previous reset links to be invalidated")
+	}
+	if sent == nil || sent.To != "joao@example.com" || !strings.Contains(sent.Body, "https://oficina.example/reset-password?token=") {
+		t.Fatalf("Expected reset link to be e-mailed, got %+v", sent)
+	}
+	token := tokenFromMessage(t, *sent)
+	if stored == nil || stored.TokenHash != domain.HashToken(token) || stored.TokenHash == token {
+		t.Error("Expected only the hash of the e-mailed token to be stored")
+	}
+	if stored.ExpiresAt.After(time.Now().Add(domain.PasswordResetTokenTTL)) {
+		t.Errorf("Expected token to expire within %s", domain.PasswordResetTokenTTL)
+	}
+}
+
+func TestRequestPasswordResetUseCase_Execute_IgnoresUnknownEmail(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByEmailFunc = func(email string) (*domain.UserInfo, error) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	mailerMock := &mocks.MailerMock{SendFunc: func(message mailDomain.Message) error {
+		t.Fatal("Expected no e-mail to be sent")
+		return nil
+	}}
+	useCase := NewRequestPasswordResetUseCase(authRepoMock, &mocks.UserTokenRepositoryMock{}, mailerMock, "", loggerMock)
+
+	// Act
+	err := useCase.Execute("ninguem@example.com")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected unknown e-mail not to be revealed, got %v", err)
+	}
+}
+
+func TestResetPasswordUseCase_Execute_UpdatesPasswordAndEndsSessions(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userID := uuid.New()
+	userTokenRepoMock := &mocks.UserTokenRepositoryMock{}
+	userTokenRepoMock.FindByHashFunc = func(tokenHash string, purpose string) (*domain.UserToken, error) {
+		if tokenHash != domain.HashToken("reset-token") || purpose != domain.PurposePasswordReset {
+			return nil, nil
+		}
+		return &domain.UserToken{ID: uuid.New(), UserID: userID, Purpose: purpose, ExpiresAt: time.Now().Add(time.Minute)}, nil
+	}
+	var newHash string
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.UpdatePasswordFunc = func(id uuid.UUID, passwordHash string) error {
+		newHash = passwordHash
+		return nil
+	}
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	refreshRevoked := false
+	refreshRepoMock.RevokeAllForUserFunc = func(id uuid.UUID, revokedAt time.Time) error {
+		refreshRevoked = id == userID
+		return nil
+	}
+	store := authInfra.NewMemoryRevocationStore()
+	oldSession := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Add(-time.Hour).Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL - time.Hour).Unix(),
+	}
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{Repositories: domain.Repositories{Auth: authRepoMock, UserTokens: userTokenRepoMock, RefreshTokens: refreshRepoMock}}
+	useCase := NewResetPasswordUseCase(unitOfWorkMock, store, loggerMock)
+
+	// Act
+	err := useCase.Execute("reset-token", "nova-senha")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(newHash), []byte("nova-senha")) != nil {
+		t.Error("Expected the new password to be stored as a bcrypt hash")
+	}
+	if revoked, _ := store.IsRevoked(oldSession); !revoked || !refreshRevoked {
+		t.Error("Expected existing sessions to be revoked")
+	}
+}
+
+func TestResetPasswordUseCase_Execute_KeepsTokenWhenPasswordUpdateFails(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userID := uuid.New()
+	userTokenRepoMock := &mocks.UserTokenRepositoryMock{}
+	userTokenRepoMock.FindByHashFunc = func(tokenHash string, purpose string) (*domain.UserToken, error) {
+		return &domain.UserToken{ID: uuid.New(), UserID: userID, Purpose: purpose, ExpiresAt: time.Now().Add(time.Minute)}, nil
+	}
+	consumed := false
+	userTokenRepoMock.MarkUsedFunc = func(id uuid.UUID, usedAt time.Time) (bool, error) {
+		consumed = true
+		return true, nil
+	}
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.UpdatePasswordFunc = func(id uuid.UUID, passwordHash string) error {
+		return errors.New("database error")
+	}
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	refreshRepoMock.RevokeAllForUserFunc = func(id uuid.UUID, revokedAt time.Time) error {
+		t.Error("Expected sessions to stay open when the password is not changed")
+		return nil
+	}
+	// O consumo do token e a troca da senha precisam acontecer na mesma transação
+	var transactionErr error
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{ExecuteFunc: func(fn func(repos domain.Repositories) error) error {
+		transactionErr = fn(domain.Repositories{Auth: authRepoMock, UserTokens: userTokenRepoMock, RefreshTokens: refreshRepoMock})
+		return transactionErr
+	}}
+	store := authInfra.NewMemoryRevocationStore()
+	oldSession := &domain.Claims{
+		TokenID: uuid.New(),
+		UserID:  userID,
+		Iat:     time.Now().Add(-time.Hour).Unix(),
+		Exp:     time.Now().Add(domain.AccessTokenTTL - time.Hour).Unix(),
+	}
+	useCase := NewResetPasswordUseCase(unitOfWorkMock, store, loggerMock)
+
+	// Act
+	err := useCase.Execute("reset-token", "nova-senha")
+
+	// Assert
+	if err == nil || err.Error() != "error resetting password" {
+		t.Errorf("Expected 'error resetting password', got %v", err)
+	}
+	if !consumed || transactionErr == nil {
+		t.Error("Expected the token consumption to be rolled back together with the failed update")
+	}
+	if revoked, _ := store.IsRevoked(oldSession); revoked {
+		t.Error("Expected access tokens to stay valid when the password is not changed")
+	}
+}
+
+func TestResetPasswordUseCase_Execute_RejectsUsedOrExpiredTokens(t *testing.T) {
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	usedAt := time.Now().Add(-time.Minute)
+	cases := map[string]*domain.UserToken{
+		"used":    {ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt},
+		"expired": {ID: uuid.New(), ExpiresAt: time.Now().Add(-time.Second)},
+		"unknown": nil,
+	}
+
+	for name, record := range cases {
+		userTokenRepoMock := &mocks.UserTokenRepositoryMock{}
+		userTokenRepoMock.FindByHashFunc = func(tokenHash string, purpose string) (*domain.UserToken, error) {
+			return record, nil
+		}
+		authRepoMock := &mocks.AuthRepositoryMock{}
+		authRepoMock.UpdatePasswordFunc = func(id uuid.UUID, passwordHash string) error {
+			t.Fatalf("Expected password not to change with a %s token", name)
+			return nil
+		}
+		unitOfWorkMock := &mocks.AuthUnitOfWorkMock{Repositories: domain.Repositories{Auth: authRepoMock, UserTokens: userTokenRepoMock, RefreshTokens: &mocks.RefreshTokenRepositoryMock{}}}
+		useCase := NewResetPasswordUseCase(unitOfWorkMock, authInfra.NewMemoryRevocationStore(), loggerMock)
+
+		if err := useCase.Execute("reset-token", "nova-senha"); !errors.Is(err, domain.ErrInvalidUserToken) {
+			t.Errorf("Expected %s token to be rejected, got %v", name, err)
+		}
+	}
+
+	// Dois usos simultâneos: só o primeiro consome o token
+	userTokenRepoMock := &mocks.UserTokenRepositoryMock{}
+	userTokenRepoMock.FindByHashFunc = func(tokenHash string, purpose string) (*domain.UserToken, error) {
+		return &domain.UserToken{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+	userTokenRepoMock.MarkUsedFunc = func(id uuid.UUID, usedAt time.Time) (bool, error) {
+		return false, nil
+	}
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{Repositories: domain.Repositories{Auth: &mocks.AuthRepositoryMock{}, UserTokens: userTokenRepoMock, RefreshTokens: &mocks.RefreshTokenRepositoryMock{}}}
+	useCase := NewResetPasswordUseCase(unitOfWorkMock, authInfra.NewMemoryRevocationStore(), loggerMock)
+	if err := useCase.Execute("reset-token", "nova-senha"); !errors.Is(err, domain.ErrInvalidUserToken) {
+		t.Errorf("Expected an already consumed token to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyEmailUseCase_Execute_MarksEmailVerified(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userID := uuid.New()
+	userTokenRepoMock := &mocks.UserTokenRepositoryMock{}
+	userTokenRepoMock.FindByHashFunc = func(tokenHash string, purpose string) (*domain.UserToken, error) {
+		if purpose != domain.PurposeEmailVerification {
+			return nil, nil
+		}
+		return &domain.UserToken{ID: uuid.New(), UserID: userID, Purpose: purpose, ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+	var verified uuid.UUID
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.MarkEmailVerifiedFunc = func(id uuid.UUID, verifiedAt time.Time) error {
+		verified = id
+		return nil
+	}
+
+	// Act
+	err := NewVerifyEmailUseCase(authRepoMock, userTokenRepoMock, loggerMock).Execute("verify-token")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if verified != userID {
+		t.Errorf("Expected user %s to be verified, got %s", userID, verified)
+	}
+}
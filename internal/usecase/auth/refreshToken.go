@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// issueRefreshToken gera um refresh token da família informada e guarda apenas o seu hash
+func issueRefreshToken(repository domain.RefreshTokenRepository, tokenService domain.TokenService, id uuid.UUID, userID uuid.UUID, familyID uuid.UUID, device string) (string, *domain.RefreshToken, error) {
+	value, err := tokenService.GenerateRefreshToken(userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	record := &domain.RefreshToken{
+		ID:        id,
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: domain.HashToken(value),
+		Device:    device,
+		ExpiresAt: time.Now().Add(domain.DefaultRefreshTokenTTL),
+	}
+	if err := repository.Create(record); err != nil {
+		return "", nil, err
+	}
+	return value, record, nil
+}
+
+type RefreshTokenUseCase struct {
+	authRepository         domain.AuthRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	unitOfWork             domain.UnitOfWork
+	tokenService           domain.TokenService
+	logger                 logger.Logger
+}
+
+func NewRefreshTokenUseCase(authRepository domain.AuthRepository, refreshTokenRepository domain.RefreshTokenRepository, unitOfWork domain.UnitOfWork, tokenService domain.TokenService, logger logger.Logger) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		authRepository:         authRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		unitOfWork:             unitOfWork,
+		tokenService:           tokenService,
+		logger:                 logger,
+	}
+}
+
+// revokeFamily invalida todos os tokens do login em que o token reapresentado foi emitido
+func (uc *RefreshTokenUseCase) revokeFamily(token *domain.RefreshToken) error {
+	uc.logger.Error("Refresh token reuse detected, revoking token family",
+		zap.String("userID", token.UserID.String()),
+		zap.String("familyID", token.FamilyID.String()))
+
+	if err := uc.refreshTokenRepository.RevokeFamily(token.FamilyID, time.Now()); err != nil {
+		uc.logger.Error("Error revoking refresh token family", zap.Error(err))
+		return errors.New("error revoking refresh tokens")
+	}
+	return domain.ErrRefreshTokenReuse
+}
+
+// Execute troca um refresh token ativo por um novo par de tokens. O token usado é rotacionado;
+// reapresentar um token já rotacionado revoga toda a família.
+func (uc *RefreshTokenUseCase) Execute(request domain.RefreshRequest) (*domain.LoginResponse, error) {
+	uc.logger.Info("Processing refresh token request")
+
+	if request.RefreshToken == "" {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	current, err := uc.refreshTokenRepository.FindByHash(domain.HashToken(request.RefreshToken))
+	if err != nil {
+		uc.logger.Error("Error finding refresh token", zap.Error(err))
+		return nil, errors.New("error finding refresh token")
+	}
+	if current == nil {
+		uc.logger.Error("Refresh token not found")
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	if current.RevokedAt != nil {
+		uc.logger.Error("Refresh token revoked", zap.String("familyID", current.FamilyID.String()))
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if current.RotatedAt != nil {
+		return nil, uc.revokeFamily(current)
+	}
+	now := time.Now()
+	if current.IsExpired(now) {
+		uc.logger.Error("Refresh token expired", zap.String("userID", current.UserID.String()))
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	userInfo, err := uc.authRepository.FindUserByID(current.UserID)
+	if err != nil || userInfo == nil {
+		uc.logger.Error("User of refresh token not found", zap.String("userID", current.UserID.String()))
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	token, err := uc.tokenService.GenerateToken(*userInfo)
+	if err != nil {
+		uc.logger.Error("Error generating token", zap.Error(err))
+		return nil, errors.New("error generating token")
+	}
+
+	device := request.Device
+	if device == "" {
+		device = current.Device
+	}
+
+	// A rotação e o novo token são gravados juntos; uma falha ao criar o substituto não pode
+	// deixar o token atual consumido sem sucessor
+	var refreshToken string
+	var record *domain.RefreshToken
+	rotated := false
+	err = uc.unitOfWork.Execute(func(repos domain.Repositories) error {
+		// O token só é rotacionado uma vez; perder a disputa para outro uso simultâneo também é reuso
+		replacementID := uuid.New()
+		var err error
+		rotated, err = repos.RefreshTokens.MarkRotated(current.ID, replacementID, now)
+		if err != nil {
+			uc.logger.Error("Error rotating refresh token", zap.Error(err))
+			return errors.New("error rotating refresh token")
+		}
+		if !rotated {
+			return nil
+		}
+
+		refreshToken, record, err = issueRefreshToken(repos.RefreshTokens, uc.tokenService, replacementID, current.UserID, current.FamilyID, device)
+		if err != nil {
+			uc.logger.Error("Error generating refresh token", zap.Error(err))
+			return errors.New("error generating refresh token")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, uc.revokeFamily(current)
+	}
+
+	uc.logger.Info("Refresh token rotated",
+		zap.String("userID", current.UserID.String()),
+		zap.String("familyID", current.FamilyID.String()))
+
+	return &domain.LoginResponse{
+		Token:                 token,
+		RefreshToken:          refreshToken,
+		ExpiresAt:             now.Add(domain.AccessTokenTTL),
+		RefreshTokenExpiresAt: record.ExpiresAt,
+		User:                  *userInfo,
+	}, nil
+}
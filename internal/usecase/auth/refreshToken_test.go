@@ -0,0 +1,327 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestRefreshTokenUseCase_Execute_RotatesToken(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByIDFunc = func(id uuid.UUID) (*domain.UserInfo, error) {
+		return &domain.UserInfo{ID: id, Email: "joao@example.com", Username: "joao", UserType: "mechanic"}, nil
+	}
+
+	tokenServiceMock := &mocks.TokenServiceMock{}
+	tokenServiceMock.GenerateTokenFunc = func(userInfo domain.UserInfo) (string, error) {
+		return "new-jwt-token", nil
+	}
+	tokenServiceMock.GenerateRefreshTokenFunc = func(userID uuid.UUID) (string, error) {
+		return "new-refresh-token", nil
+	}
+
+	stored := &domain.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		TokenHash: domain.HashToken("old-refresh-token"),
+		Device:    "app",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	refreshRepoMock.FindByHashFunc = func(tokenHash string) (*domain.RefreshToken, error) {
+		if tokenHash == stored.TokenHash {
+			return stored, nil
+		}
+		return nil, nil
+	}
+
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{Repositories: domain.Repositories{RefreshTokens: refreshRepoMock}}
+	useCase := NewRefreshTokenUseCase(authRepoMock, refreshRepoMock, unitOfWorkMock, tokenServiceMock, loggerMock)
+
+	var replacedBy uuid.UUID
+	refreshRepoMock.MarkRotatedFunc = func(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error) {
+		if id != stored.ID {
+			t.Errorf("Expected token %s to be rotated, got %s", stored.ID, id)
+		}
+		replacedBy = replacedByID
+		return true, nil
+	}
+	var created *domain.RefreshToken
+	refreshRepoMock.CreateFunc = func(token *domain.RefreshToken) error {
+		created = token
+		return nil
+	}
+
+	// Act
+	response, err := useCase.Execute(domain.RefreshRequest{RefreshToken: "old-refresh-token"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Token != "new-jwt-token" || response.RefreshToken != "new-refresh-token" {
+		t.Errorf("Expected a new token pair, got %+v", response)
+	}
+	if created == nil || created.ID != replacedBy || created.FamilyID != stored.FamilyID || created.UserID != stored.UserID {
+		t.Fatalf("Expected the replacement to be stored in the same family, got %+v", created)
+	}
+	// Só o hash é guardado, nunca o valor entregue ao cliente
+	if created.TokenHash != domain.HashToken("new-refresh-token") || created.Device != "app" {
+		t.Errorf("Expected hashed token keeping the device, got %+v", created)
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_RotationRollsBackWhenReplacementFails(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByIDFunc = func(id uuid.UUID) (*domain.UserInfo, error) {
+		return &domain.UserInfo{ID: id, Email: "joao@example.com", Username: "joao", UserType: "mechanic"}, nil
+	}
+
+	tokenServiceMock := &mocks.TokenServiceMock{}
+	tokenServiceMock.GenerateTokenFunc = func(userInfo domain.UserInfo) (string, error) {
+		return "new-jwt-token", nil
+	}
+	tokenServiceMock.GenerateRefreshTokenFunc = func(userID uuid.UUID) (string, error) {
+		return "new-refresh-token", nil
+	}
+
+	stored := &domain.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		TokenHash: domain.HashToken("old-refresh-token"),
+		Device:    "app",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	refreshRepoMock.FindByHashFunc = func(tokenHash string) (*domain.RefreshToken, error) {
+		if tokenHash == stored.TokenHash {
+			return stored, nil
+		}
+		return nil, nil
+	}
+
+	rotated := false
+	refreshRepoMock.MarkRotatedFunc = func(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error) {
+		rotated = true
+		return true, nil
+	}
+	refreshRepoMock.CreateFunc = func(token *domain.RefreshToken) error {
+		return errors.New("database error")
+	}
+	// A rotação e a criação do substituto precisam acontecer na mesma transação
+	var transactionErr error
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{ExecuteFunc: func(fn func(repos domain.Repositories) error) error {
+		transactionErr = fn(domain.Repositories{RefreshTokens: refreshRepoMock})
+		return transactionErr
+	}}
+	useCase := NewRefreshTokenUseCase(authRepoMock, refreshRepoMock, unitOfWorkMock, tokenServiceMock, loggerMock)
+
+	// Act
+	_, err := useCase.Execute(domain.RefreshRequest{RefreshToken: "old-refresh-token"})
+
+	// Assert
+	if err == nil || err.Error() != "error generating refresh token" {
+		t.Errorf("Expected 'error generating refresh token', got %v", err)
+	}
+	if !rotated || transactionErr == nil {
+		t.Error("Expected the rotation to be rolled back together with the failed replacement")
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_ReuseRevokesFamily(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByIDFunc = func(id uuid.UUID) (*domain.UserInfo, error) {
+		return &domain.UserInfo{ID: id, Email: "joao@example.com", Username: "joao", UserType: "mechanic"}, nil
+	}
+
+	tokenServiceMock := &mocks.TokenServiceMock{}
+	tokenServiceMock.GenerateTokenFunc = func(userInfo domain.UserInfo) (string, error) {
+		return "new-jwt-token", nil
+	}
+	tokenServiceMock.GenerateRefreshTokenFunc = func(userID uuid.UUID) (string, error) {
+		return "new-refresh-token", nil
+	}
+
+	stored := &domain.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		TokenHash: domain.HashToken("old-refresh-token"),
+		Device:    "app",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	rotatedAt := time.Now().Add(-time.Minute)
+	stored.RotatedAt = &rotatedAt
+
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	refreshRepoMock.FindByHashFunc = func(tokenHash string) (*domain.RefreshToken, error) {
+		if tokenHash == stored.TokenHash {
+			return stored, nil
+		}
+		return nil, nil
+	}
+
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{Repositories: domain.Repositories{RefreshTokens: refreshRepoMock}}
+	useCase := NewRefreshTokenUseCase(authRepoMock, refreshRepoMock, unitOfWorkMock, tokenServiceMock, loggerMock)
+
+	var revokedFamily uuid.UUID
+	refreshRepoMock.RevokeFamilyFunc = func(familyID uuid.UUID, revokedAt time.Time) error {
+		revokedFamily = familyID
+		return nil
+	}
+	refreshRepoMock.CreateFunc = func(token *domain.RefreshToken) error {
+		t.Fatal("Expected no token to be issued")
+		return nil
+	}
+
+	// Act
+	_, err := useCase.Execute(domain.RefreshRequest{RefreshToken: "old-refresh-token"})
+
+	// Assert
+	if !errors.Is(err, domain.ErrRefreshTokenReuse) {
+		t.Errorf("Expected reuse to be detected, got %v", err)
+	}
+	if revokedFamily != stored.FamilyID {
+		t.Errorf("Expected family %s to be revoked, got %s", stored.FamilyID, revokedFamily)
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_ConcurrentUseRevokesFamily(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	authRepoMock := &mocks.AuthRepositoryMock{}
+	authRepoMock.FindUserByIDFunc = func(id uuid.UUID) (*domain.UserInfo, error) {
+		return &domain.UserInfo{ID: id, Email: "joao@example.com", Username: "joao", UserType: "mechanic"}, nil
+	}
+
+	tokenServiceMock := &mocks.TokenServiceMock{}
+	tokenServiceMock.GenerateTokenFunc = func(userInfo domain.UserInfo) (string, error) {
+		return "new-jwt-token", nil
+	}
+	tokenServiceMock.GenerateRefreshTokenFunc = func(userID uuid.UUID) (string, error) {
+		return "new-refresh-token", nil
+	}
+
+	stored := &domain.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		TokenHash: domain.HashToken("old-refresh-token"),
+		Device:    "app",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	refreshRepoMock.FindByHashFunc = func(tokenHash string) (*domain.RefreshToken, error) {
+		if tokenHash == stored.TokenHash {
+			return stored, nil
+		}
+		return nil, nil
+	}
+
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{Repositories: domain.Repositories{RefreshTokens: refreshRepoMock}}
+	useCase := NewRefreshTokenUseCase(authRepoMock, refreshRepoMock, unitOfWorkMock, tokenServiceMock, loggerMock)
+
+	// Outro uso do mesmo token rotacionou antes
+	refreshRepoMock.MarkRotatedFunc = func(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error) {
+		return false, nil
+	}
+	revoked := false
+	refreshRepoMock.RevokeFamilyFunc = func(familyID uuid.UUID, revokedAt time.Time) error {
+		revoked = true
+		return nil
+	}
+
+	// Act
+	_, err := useCase.Execute(domain.RefreshRequest{RefreshToken: "old-refresh-token"})
+
+	// Assert
+	if !errors.Is(err, domain.ErrRefreshTokenReuse) || !revoked {
+		t.Errorf("Expected reuse to be detected and the family revoked, got %v", err)
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_RejectsInvalidTokens(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	revokedAt := time.Now()
+	stored := map[string]*domain.RefreshToken{
+		"expired-token": {
+			ID:        uuid.New(),
+			UserID:    uuid.New(),
+			FamilyID:  uuid.New(),
+			TokenHash: domain.HashToken("expired-token"),
+			ExpiresAt: time.Now().Add(-time.Second),
+		},
+		"revoked-token": {
+			ID:        uuid.New(),
+			UserID:    uuid.New(),
+			FamilyID:  uuid.New(),
+			TokenHash: domain.HashToken("revoked-token"),
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		},
+	}
+
+	refreshRepoMock := &mocks.RefreshTokenRepositoryMock{}
+	refreshRepoMock.FindByHashFunc = func(tokenHash string) (*domain.RefreshToken, error) {
+		for _, token := range stored {
+			if token.TokenHash == tokenHash {
+				return token, nil
+			}
+		}
+		return nil, nil
+	}
+	refreshRepoMock.MarkRotatedFunc = func(id uuid.UUID, replacedByID uuid.UUID, rotatedAt time.Time) (bool, error) {
+		t.Fatal("Expected no token to be rotated")
+		return false, nil
+	}
+
+	unitOfWorkMock := &mocks.AuthUnitOfWorkMock{Repositories: domain.Repositories{RefreshTokens: refreshRepoMock}}
+	useCase := NewRefreshTokenUseCase(&mocks.AuthRepositoryMock{}, refreshRepoMock, unitOfWorkMock, &mocks.TokenServiceMock{}, loggerMock)
+
+	for _, value := range []string{"expired-token", "revoked-token", "unknown-token"} {
+		// Act
+		_, err := useCase.Execute(domain.RefreshRequest{RefreshToken: value})
+
+		// Assert
+		if !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Errorf("Expected %s to be rejected, got %v", value, err)
+		}
+	}
+}
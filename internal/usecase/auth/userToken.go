@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/auth"
+)
+
+// issueUserToken invalida os tokens pendentes da mesma finalidade e gera um novo, guardando só o hash
+func issueUserToken(repository domain.UserTokenRepository, userID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	value := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now()
+	if err := repository.InvalidateForUser(userID, purpose, now); err != nil {
+		return "", err
+	}
+	record := &domain.UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: domain.HashToken(value),
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := repository.Create(record); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// consumeUserToken valida e consome o token. Token desconhecido, vencido ou já usado resulta em
+// ErrInvalidUserToken, sem distinguir o motivo para quem chama.
+func consumeUserToken(repository domain.UserTokenRepository, value string, purpose string) (*domain.UserToken, error) {
+	if value == "" {
+		return nil, domain.ErrInvalidUserToken
+	}
+
+	record, err := repository.FindByHash(domain.HashToken(value), purpose)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if record == nil || !record.IsUsable(now) {
+		return nil, domain.ErrInvalidUserToken
+	}
+
+	consumed, err := repository.MarkUsed(record.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, domain.ErrInvalidUserToken
+	}
+	return record, nil
+}
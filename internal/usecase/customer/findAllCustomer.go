@@ -14,35 +14,48 @@ type FindAllCustomer struct {
 	Logger             logger.Logger
 }
 
-// FetchCustomersFromDB busca todos os customers do banco
-func (uc *FindAllCustomer) FetchCustomersFromDB() ([]models.Customer, error) {
-	customers, err := uc.CustomerRepository.FindAll()
+// ValidateQuery valida a paginação da listagem
+func (uc *FindAllCustomer) ValidateQuery(spec *repository.QuerySpec) error {
+	if err := spec.Validate(); err != nil {
+		uc.Logger.Error("Invalid query", zap.Error(err), zap.Int("limit", spec.Limit))
+		return err
+	}
+	return nil
+}
+
+// FetchCustomersFromDB busca uma página de customers do banco
+func (uc *FindAllCustomer) FetchCustomersFromDB(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
+	page, err := uc.CustomerRepository.FindAll(spec)
 	if err != nil {
 		uc.Logger.Error("Database error fetching customers", zap.Error(err))
 		return nil, err
 	}
 
-	uc.Logger.Info("Successfully fetched customers from database", zap.Int("count", len(customers)))
-	return customers, nil
+	uc.Logger.Info("Successfully fetched customers from database",
+		zap.Int("count", len(page.Items)),
+		zap.Int64("total", page.Total))
+	return page, nil
 }
 
-func (uc *FindAllCustomer) Process() ([]domain.Customer, error) {
+func (uc *FindAllCustomer) Process(spec repository.QuerySpec) (*repository.Page[domain.Customer], error) {
 	uc.Logger.Info("Processing find all customers")
 
+	if err := uc.ValidateQuery(&spec); err != nil {
+		return nil, err
+	}
+
 	// Busca customers do banco
-	customers, err := uc.FetchCustomersFromDB()
+	page, err := uc.FetchCustomersFromDB(spec)
 	if err != nil {
 		return nil, err
 	}
 
 	// Mapeia para o domínio usando persistence
-	var domainCustomers []domain.Customer
-	for _, customer := range customers {
-		domainCustomer := persistence.CustomerPersistence{}.ToEntity(&customer)
-		domainCustomers = append(domainCustomers, *domainCustomer)
-	}
+	domainPage := repository.MapPage(page, func(customer *models.Customer) domain.Customer {
+		return *persistence.CustomerPersistence{}.ToEntity(customer)
+	})
 
-	uc.Logger.Info("Successfully mapped customers to domain", zap.Int("count", len(domainCustomers)))
+	uc.Logger.Info("Successfully mapped customers to domain", zap.Int("count", len(domainPage.Items)))
 
-	return domainCustomers, nil
+	return domainPage, nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
 )
@@ -47,8 +48,8 @@ func TestFindAllCustomer_Process_Success(t *testing.T) {
 		},
 	}
 
-	customerRepoMock.FindAllFunc = func() ([]models.Customer, error) {
-		return mockCustomers, nil
+	customerRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
+		return &repository.Page[models.Customer]{Items: mockCustomers}, nil
 	}
 
 	useCase := &FindAllCustomer{
@@ -57,15 +58,15 @@ func TestFindAllCustomer_Process_Success(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process()
+	result, err := useCase.Process(repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 2 {
-		t.Errorf("Expected 2 customers, got %d", len(result))
+	if len(result.Items) != 2 {
+		t.Errorf("Expected 2 customers, got %d", len(result.Items))
 	}
 
 	// Verifica se os logs corretos foram chamados
@@ -110,7 +111,7 @@ func TestFindAllCustomer_Process_DatabaseError(t *testing.T) {
 	}
 
 	expectedError := errors.New("database connection failed")
-	customerRepoMock.FindAllFunc = func() ([]models.Customer, error) {
+	customerRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
 		return nil, expectedError
 	}
 
@@ -120,7 +121,7 @@ func TestFindAllCustomer_Process_DatabaseError(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process()
+	result, err := useCase.Process(repository.QuerySpec{})
 
 	// Assert
 	if err == nil {
@@ -170,8 +171,8 @@ func TestFindAllCustomer_Process_EmptyResult(t *testing.T) {
 	}
 
 	// Mock empty result
-	customerRepoMock.FindAllFunc = func() ([]models.Customer, error) {
-		return []models.Customer{}, nil
+	customerRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
+		return &repository.Page[models.Customer]{Items: []models.Customer{}}, nil
 	}
 
 	useCase := &FindAllCustomer{
@@ -180,15 +181,15 @@ func TestFindAllCustomer_Process_EmptyResult(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process()
+	result, err := useCase.Process(repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 0 {
-		t.Errorf("Expected 0 customers, got %d", len(result))
+	if len(result.Items) != 0 {
+		t.Errorf("Expected 0 customers, got %d", len(result.Items))
 	}
 
 	// Verifica se os logs corretos foram chamados
@@ -243,8 +244,8 @@ func TestFindAllCustomer_FetchCustomersFromDB_Success(t *testing.T) {
 		},
 	}
 
-	customerRepoMock.FindAllFunc = func() ([]models.Customer, error) {
-		return mockCustomers, nil
+	customerRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
+		return &repository.Page[models.Customer]{Items: mockCustomers}, nil
 	}
 
 	useCase := &FindAllCustomer{
@@ -253,15 +254,15 @@ func TestFindAllCustomer_FetchCustomersFromDB_Success(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.FetchCustomersFromDB()
+	result, err := useCase.FetchCustomersFromDB(repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 1 {
-		t.Errorf("Expected 1 customer, got %d", len(result))
+	if len(result.Items) != 1 {
+		t.Errorf("Expected 1 customer, got %d", len(result.Items))
 	}
 
 	if len(loggedInfo) != 1 {
@@ -294,7 +295,7 @@ func TestFindAllCustomer_FetchCustomersFromDB_Error(t *testing.T) {
 	}
 
 	expectedError := errors.New("database timeout")
-	customerRepoMock.FindAllFunc = func() ([]models.Customer, error) {
+	customerRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
 		return nil, expectedError
 	}
 
@@ -304,7 +305,7 @@ func TestFindAllCustomer_FetchCustomersFromDB_Error(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.FetchCustomersFromDB()
+	result, err := useCase.FetchCustomersFromDB(repository.QuerySpec{})
 
 	// Assert
 	if err == nil {
@@ -331,3 +332,34 @@ func TestFindAllCustomer_FetchCustomersFromDB_Error(t *testing.T) {
 		t.Errorf("Expected error log 'Database error fetching customers', got '%s'", loggedErrors[0])
 	}
 }
+
+func TestFindAllCustomer_Process_InvalidLimit(t *testing.T) {
+	// Arrange
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	customerRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Customer], error) {
+		t.Error("Customers should not be fetched with an invalid limit")
+		return nil, nil
+	}
+
+	useCase := &FindAllCustomer{
+		CustomerRepository: customerRepoMock,
+		Logger:             loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(repository.QuerySpec{Limit: repository.MaxQueryLimit + 1})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInvalidLimit) {
+		t.Errorf("Expected invalid limit error, got %v", err)
+	}
+
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
+	}
+}
@@ -0,0 +1,143 @@
+package input
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+// AdjustInputStock registra movimentações manuais de estoque: reposição, ajuste e inventário
+type AdjustInputStock struct {
+	InputRepository         repository.InputRepository
+	Logger                  logger.Logger
+	IncreaseQuantityInput   *IncreaseQuantityInput
+	DecreaseQuantityInput   *DecreaseQuantityInput
+	RecordInventoryMovement *RecordInventoryMovement
+	UnitOfWork              repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *AdjustInputStock) WithRepositories(repos repository.Repositories) *AdjustInputStock {
+	return &AdjustInputStock{
+		InputRepository:         repos.Inputs,
+		Logger:                  uc.Logger,
+		IncreaseQuantityInput:   uc.IncreaseQuantityInput.WithRepositories(repos),
+		DecreaseQuantityInput:   uc.DecreaseQuantityInput.WithRepositories(repos),
+		RecordInventoryMovement: uc.RecordInventoryMovement.WithRepositories(repos),
+		UnitOfWork:              repos.UnitOfWork,
+	}
+}
+
+// ValidateMovement valida o tipo de movimentação manual e a quantidade informada
+func (uc *AdjustInputStock) ValidateMovement(movementType string, quantity int) error {
+	switch movementType {
+	case domain.MovementTypeManualRestock:
+		if quantity <= 0 {
+			uc.Logger.Error("Invalid restock quantity", zap.Int("quantity", quantity))
+			return errors.New("restock quantity must be greater than zero")
+		}
+	case domain.MovementTypeAdjustment:
+		if quantity == 0 {
+			uc.Logger.Error("Invalid adjustment quantity", zap.Int("quantity", quantity))
+			return errors.New("adjustment quantity must not be zero")
+		}
+	case domain.MovementTypeStocktake:
+		if quantity < 0 {
+			uc.Logger.Error("Invalid counted quantity", zap.Int("quantity", quantity))
+			return errors.New("counted quantity must not be negative")
+		}
+	default:
+		uc.Logger.Error("Invalid manual movement type", zap.String("movementType", movementType))
+		return errors.New("invalid movement type")
+	}
+	return nil
+}
+
+// FetchInputFromDB busca o input bloqueando a linha e garante que ele controla estoque. O
+// bloqueio mantém o saldo lido até a gravação, então o inventário chega ao valor contado.
+func (uc *AdjustInputStock) FetchInputFromDB(inputID uuid.UUID) (*models.Input, error) {
+	input, err := uc.InputRepository.FindByIDForUpdate(inputID)
+	if err != nil {
+		uc.Logger.Error("Input not found", zap.String("inputID", inputID.String()))
+		return nil, errors.New("input not found")
+	}
+
+	if input.InputType == "service" {
+		uc.Logger.Error("Service inputs do not track stock", zap.String("inputID", inputID.String()))
+		return nil, errors.New("service inputs do not track stock")
+	}
+
+	uc.Logger.Info("Input found",
+		zap.String("inputID", input.ID.String()),
+		zap.Int("currentQuantity", input.Quantity))
+
+	return input, nil
+}
+
+// CalculateDelta calcula a variação de estoque a partir do tipo de movimentação
+func (uc *AdjustInputStock) CalculateDelta(movementType string, quantity int, currentQuantity int) int {
+	if movementType == domain.MovementTypeStocktake {
+		// No inventário a quantidade informada é o saldo contado
+		return quantity - currentQuantity
+	}
+	return quantity
+}
+
+// ApplyDelta aplica a variação ao estoque registrando a movimentação
+func (uc *AdjustInputStock) ApplyDelta(input *models.Input, delta int, movement domain.MovementContext) error {
+	switch {
+	case delta > 0:
+		return uc.IncreaseQuantityInput.Process(input.ID, delta, movement)
+	case delta < 0:
+		return uc.DecreaseQuantityInput.Process(input.ID, -delta, movement)
+	default:
+		// Inventário sem divergência ainda é registrado para auditoria
+		return uc.RecordInventoryMovement.Process(input.ID, 0, input.Quantity, movement)
+	}
+}
+
+func (uc *AdjustInputStock) Process(inputID uuid.UUID, movementType string, quantity int, userID uuid.UUID, reason string) error {
+	uc.Logger.Info("Processing manual stock movement",
+		zap.String("inputID", inputID.String()),
+		zap.String("movementType", movementType),
+		zap.Int("quantity", quantity))
+
+	// Valida tipo e quantidade
+	if err := uc.ValidateMovement(movementType, quantity); err != nil {
+		return err
+	}
+
+	movement := domain.MovementContext{
+		MovementType: movementType,
+		UserID:       &userID,
+		Reason:       reason,
+	}
+
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		// Busca o input
+		input, err := tx.FetchInputFromDB(inputID)
+		if err != nil {
+			return err
+		}
+
+		// Calcula e aplica a variação
+		delta := tx.CalculateDelta(movementType, quantity, input.Quantity)
+		if err := tx.ApplyDelta(input, delta, movement); err != nil {
+			return err
+		}
+
+		uc.Logger.Info("Manual stock movement processed",
+			zap.String("inputID", inputID.String()),
+			zap.String("movementType", movementType),
+			zap.Int("delta", delta))
+
+		return nil
+	})
+}
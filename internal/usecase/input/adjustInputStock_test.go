@@ -0,0 +1,260 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestAdjustInputStock_Process_StocktakeRecordsShrinkage(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputID := uuid.New()
+	// O saldo contado é comparado com a leitura feita sob bloqueio da linha
+	locked := false
+	inputRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Input, error) {
+		locked = true
+		return &models.Input{ID: inputID, Quantity: 20, InputType: "supplie"}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: inputID, Quantity: 20, InputType: "supplie"}, nil
+	}
+
+	decreased := 0
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		decreased = quantity
+		return &models.Input{ID: id, Quantity: 20 - quantity}, nil
+	}
+
+	var recorded *models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = movement
+		return nil
+	}
+
+	record := &RecordInventoryMovement{InventoryMovementRepository: movementRepoMock, Logger: loggerMock}
+	useCase := &AdjustInputStock{
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		IncreaseQuantityInput:   &IncreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		DecreaseQuantityInput:   &DecreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		RecordInventoryMovement: record,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(inputID, inventoryMovement.MovementTypeStocktake, 17, uuid.New(), "monthly count")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !locked {
+		t.Error("Expected the input row to be locked during the stocktake")
+	}
+	if decreased != 3 {
+		t.Errorf("Expected stock to be decreased by 3, got %d", decreased)
+	}
+	if recorded == nil || recorded.MovementType != inventoryMovement.MovementTypeStocktake {
+		t.Fatalf("Expected stocktake movement to be recorded, got %+v", recorded)
+	}
+	if recorded.Delta != -3 || recorded.BalanceAfter != 17 || recorded.Reason != "monthly count" {
+		t.Errorf("Unexpected stocktake movement: %+v", recorded)
+	}
+}
+
+func TestAdjustInputStock_Process_StocktakeWithoutDivergence(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 20, InputType: "supplie"}, nil
+	}
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		t.Error("Expected stock not to be changed")
+		return nil, nil
+	}
+
+	var recorded *models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = movement
+		return nil
+	}
+
+	record := &RecordInventoryMovement{InventoryMovementRepository: movementRepoMock, Logger: loggerMock}
+	useCase := &AdjustInputStock{
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		IncreaseQuantityInput:   &IncreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		DecreaseQuantityInput:   &DecreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		RecordInventoryMovement: record,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), inventoryMovement.MovementTypeStocktake, 20, uuid.New(), "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recorded == nil || recorded.Delta != 0 || recorded.BalanceAfter != 20 {
+		t.Errorf("Expected zero-delta stocktake to be recorded, got %+v", recorded)
+	}
+}
+
+func TestAdjustInputStock_Process_ManualRestock(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 5, InputType: "supplie"}, nil
+	}
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 5 + quantity}, nil
+	}
+
+	var recorded *models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = movement
+		return nil
+	}
+
+	record := &RecordInventoryMovement{InventoryMovementRepository: movementRepoMock, Logger: loggerMock}
+	useCase := &AdjustInputStock{
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		IncreaseQuantityInput:   &IncreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		DecreaseQuantityInput:   &DecreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		RecordInventoryMovement: record,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), inventoryMovement.MovementTypeManualRestock, 10, uuid.New(), "supplier delivery")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recorded == nil || recorded.Delta != 10 || recorded.BalanceAfter != 15 {
+		t.Errorf("Expected restock of 10 to balance 15, got %+v", recorded)
+	}
+}
+
+func TestAdjustInputStock_Process_InvalidMovements(t *testing.T) {
+	tests := []struct {
+		name          string
+		movementType  string
+		quantity      int
+		expectedError string
+	}{
+		{"order consumption is not manual", inventoryMovement.MovementTypeOrderConsumption, 1, "invalid movement type"},
+		{"restock must be positive", inventoryMovement.MovementTypeManualRestock, 0, "restock quantity must be greater than zero"},
+		{"adjustment must not be zero", inventoryMovement.MovementTypeAdjustment, 0, "adjustment quantity must not be zero"},
+		{"counted quantity must not be negative", inventoryMovement.MovementTypeStocktake, -1, "counted quantity must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			inputRepoMock := &mocks.InputRepositoryMock{}
+			movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+			loggerMock := &mocks.LoggerMock{}
+
+			// Mock logger
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			record := &RecordInventoryMovement{InventoryMovementRepository: movementRepoMock, Logger: loggerMock}
+			useCase := &AdjustInputStock{
+				InputRepository:         inputRepoMock,
+				Logger:                  loggerMock,
+				IncreaseQuantityInput:   &IncreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+				DecreaseQuantityInput:   &DecreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+				RecordInventoryMovement: record,
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Inputs:             inputRepoMock,
+					InventoryMovements: movementRepoMock,
+				}},
+			}
+
+			// Act
+			err := useCase.Process(uuid.New(), tt.movementType, tt.quantity, uuid.New(), "")
+
+			// Assert
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("Expected error '%s', got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestAdjustInputStock_Process_ServiceInput(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 1, InputType: "service"}, nil
+	}
+
+	record := &RecordInventoryMovement{InventoryMovementRepository: movementRepoMock, Logger: loggerMock}
+	useCase := &AdjustInputStock{
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		IncreaseQuantityInput:   &IncreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		DecreaseQuantityInput:   &DecreaseQuantityInput{InputRepository: inputRepoMock, Logger: loggerMock, RecordInventoryMovement: record},
+		RecordInventoryMovement: record,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), inventoryMovement.MovementTypeAdjustment, -1, uuid.New(), "fix")
+
+	// Assert
+	if err == nil || err.Error() != "service inputs do not track stock" {
+		t.Errorf("Expected error 'service inputs do not track stock', got %v", err)
+	}
+}
@@ -3,7 +3,9 @@ package input
 import (
 	"errors"
 
+	"github.com/google/uuid"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/input"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
@@ -13,8 +15,20 @@ import (
 )
 
 type CreateInput struct {
-	InputRepository repository.InputRepository
-	Logger          logger.Logger
+	InputRepository         repository.InputRepository
+	Logger                  logger.Logger
+	RecordInventoryMovement *RecordInventoryMovement
+	UnitOfWork              repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *CreateInput) WithRepositories(repos repository.Repositories) *CreateInput {
+	return &CreateInput{
+		InputRepository:         repos.Inputs,
+		Logger:                  uc.Logger,
+		RecordInventoryMovement: uc.RecordInventoryMovement.WithRepositories(repos),
+		UnitOfWork:              repos.UnitOfWork,
+	}
 }
 
 // ValidateInputNameUniqueness verifica se o nome do input é único
@@ -46,10 +60,25 @@ func (uc *CreateInput) SaveInputToDB(model *models.Input) error {
 	return nil
 }
 
-func (uc *CreateInput) Process(entity *domain.Input) error {
+// RecordInitialStock registra o estoque inicial do input no ledger
+func (uc *CreateInput) RecordInitialStock(model *models.Input, userID uuid.UUID) error {
+	// Inputs do tipo "service" não controlam estoque
+	if model.InputType == "service" || model.Quantity == 0 {
+		return nil
+	}
+
+	movement := inventoryMovement.MovementContext{
+		MovementType: inventoryMovement.MovementTypeManualRestock,
+		UserID:       &userID,
+		Reason:       "initial stock",
+	}
+	return uc.RecordInventoryMovement.Process(model.ID, model.Quantity, model.Quantity, movement)
+}
+
+func (uc *CreateInput) Process(entity *domain.Input, userID uuid.UUID) error {
 	uc.Logger.Info("Processing input creation",
 		zap.String("name", entity.Name),
-		zap.Float64("price", entity.Price),
+		zap.Stringer("price", entity.Price),
 		zap.Int("quantity", entity.Quantity))
 
 	// Valida unicidade do nome
@@ -61,14 +90,19 @@ func (uc *CreateInput) Process(entity *domain.Input) error {
 	model := persistence.InputPersistence{}.ToModel(entity)
 	uc.Logger.Info("Model created",
 		zap.String("name", model.Name),
-		zap.Float64("price", model.Price),
+		zap.Stringer("price", model.Price),
 		zap.Int("quantity", model.Quantity))
 
-	// Salva no banco
-	err := uc.SaveInputToDB(model)
-	if err != nil {
-		return err
-	}
+	// Input e estoque inicial são gravados na mesma transação
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	return nil
+		// Salva no banco
+		if err := tx.SaveInputToDB(model); err != nil {
+			return err
+		}
+
+		// Registra o estoque inicial
+		return tx.RecordInitialStock(model, userID)
+	})
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/google/uuid"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -40,12 +42,17 @@ func TestCreateInput_Process_Success(t *testing.T) {
 	useCase := &CreateInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	input := &domain.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -53,7 +60,7 @@ func TestCreateInput_Process_Success(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.Process(input)
+	err := useCase.Process(input, uuid.New())
 
 	// Assert
 	if err != nil {
@@ -105,7 +112,7 @@ func TestCreateInput_Process_NameAlreadyExists(t *testing.T) {
 	existingInput := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  50,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -122,12 +129,17 @@ func TestCreateInput_Process_NameAlreadyExists(t *testing.T) {
 	useCase := &CreateInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	input := &domain.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     3.00,
+		Price:     money.MustParse("3.00"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -135,7 +147,7 @@ func TestCreateInput_Process_NameAlreadyExists(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.Process(input)
+	err := useCase.Process(input, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -192,12 +204,17 @@ func TestCreateInput_Process_DatabaseError(t *testing.T) {
 	useCase := &CreateInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	input := &domain.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -205,7 +222,7 @@ func TestCreateInput_Process_DatabaseError(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.Process(input)
+	err := useCase.Process(input, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -310,7 +327,7 @@ func TestCreateInput_ValidateInputNameUniqueness_AlreadyExists(t *testing.T) {
 	existingInput := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  50,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -382,7 +399,7 @@ func TestCreateInput_SaveInputToDB_Success(t *testing.T) {
 	input := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -439,7 +456,7 @@ func TestCreateInput_SaveInputToDB_Error(t *testing.T) {
 	input := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
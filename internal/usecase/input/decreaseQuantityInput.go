@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
@@ -13,6 +14,24 @@ import (
 type DecreaseQuantityInput struct {
 	InputRepository repository.InputRepository
 	Logger          logger.Logger
+	UnitOfWork      repository.UnitOfWork
+
+	// RecordInventoryMovement registra cada alteração de estoque no ledger
+	RecordInventoryMovement *RecordInventoryMovement
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *DecreaseQuantityInput) WithRepositories(repos repository.Repositories) *DecreaseQuantityInput {
+	if uc == nil {
+		return nil
+	}
+	return &DecreaseQuantityInput{
+		InputRepository: repos.Inputs,
+		Logger:          uc.Logger,
+		UnitOfWork:      repos.UnitOfWork,
+
+		RecordInventoryMovement: uc.RecordInventoryMovement.WithRepositories(repos),
+	}
 }
 
 // FetchInputFromDB busca um input específico do banco de dados
@@ -59,27 +78,37 @@ func (uc *DecreaseQuantityInput) CalculateNewQuantity(currentQuantity, quantityT
 	return newQuantity, nil
 }
 
-// UpdateInputQuantity atualiza a quantidade do input no banco de dados
-func (uc *DecreaseQuantityInput) UpdateInputQuantity(input *models.Input, newQuantity int) error {
-	// Atualiza a quantidade no modelo
-	input.Quantity = newQuantity
+// UpdateInputQuantity baixa a quantidade do input no banco de dados de forma atômica,
+// evitando que baixas concorrentes deixem o estoque negativo
+func (uc *DecreaseQuantityInput) UpdateInputQuantity(input *models.Input, quantityToDecrease int) error {
+	oldQuantity := input.Quantity
 
-	err := uc.InputRepository.Update(input)
+	updated, err := uc.InputRepository.DecreaseQuantity(input.ID, quantityToDecrease)
 	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientQuantity) {
+			uc.Logger.Error("Insufficient quantity",
+				zap.String("id", input.ID.String()),
+				zap.Int("quantityToDecrease", quantityToDecrease))
+			return repository.ErrInsufficientQuantity
+		}
 		uc.Logger.Error("Database error updating input quantity", zap.Error(err))
 		return err
 	}
 
+	// Atualiza o modelo com o saldo efetivamente gravado
+	input.Quantity = updated.Quantity
+
 	uc.Logger.Info("Input quantity decreased successfully",
 		zap.String("id", input.ID.String()),
 		zap.String("name", input.Name),
-		zap.Int("oldQuantity", input.Quantity),
-		zap.Int("newQuantity", newQuantity))
+		zap.Int("oldQuantity", oldQuantity),
+		zap.Int("newQuantity", input.Quantity))
 
 	return nil
 }
 
-func (uc *DecreaseQuantityInput) Process(id uuid.UUID, quantity int) error {
+// Process altera o estoque do input e registra a movimentação descrita em movement
+func (uc *DecreaseQuantityInput) Process(id uuid.UUID, quantity int, movement inventoryMovement.MovementContext) error {
 	uc.Logger.Info("Processing decrease quantity for input",
 		zap.String("id", id.String()),
 		zap.Int("quantityToDecrease", quantity))
@@ -89,23 +118,26 @@ func (uc *DecreaseQuantityInput) Process(id uuid.UUID, quantity int) error {
 		return err
 	}
 
-	// Busca o input
-	input, err := uc.FetchInputFromDB(id)
-	if err != nil {
-		return err
-	}
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	// Calcula nova quantidade
-	newQuantity, err := uc.CalculateNewQuantity(input.Quantity, quantity)
-	if err != nil {
-		return err
-	}
+		// Busca o input
+		input, err := tx.FetchInputFromDB(id)
+		if err != nil {
+			return err
+		}
 
-	// Atualiza a quantidade
-	err = uc.UpdateInputQuantity(input, newQuantity)
-	if err != nil {
-		return err
-	}
+		// Verifica previamente se há saldo disponível suficiente, sem contar o que está reservado
+		if _, err := tx.CalculateNewQuantity(input.Quantity-input.ReservedQuantity, quantity); err != nil {
+			return err
+		}
 
-	return nil
+		// Baixa a quantidade de forma atômica
+		if err := tx.UpdateInputQuantity(input, quantity); err != nil {
+			return err
+		}
+
+		// Registra a movimentação no ledger com o saldo resultante
+		return tx.RecordInventoryMovement.Process(input.ID, -quantity, input.Quantity, movement)
+	})
 }
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
 )
@@ -31,7 +34,7 @@ func TestDecreaseQuantityInput_Process_Success(t *testing.T) {
 	mockInput := &models.Input{
 		ID:        inputID,
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -45,17 +48,22 @@ func TestDecreaseQuantityInput_Process_Success(t *testing.T) {
 		return nil, errors.New("input not found")
 	}
 
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return nil
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 100 - quantity}, nil
 	}
 
 	useCase := &DecreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 30)
+	err := useCase.Process(inputID, 30, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment})
 
 	// Assert
 	if err != nil {
@@ -109,10 +117,15 @@ func TestDecreaseQuantityInput_Process_InvalidQuantity(t *testing.T) {
 	useCase := &DecreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 0)
+	err := useCase.Process(inputID, 0, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment})
 
 	// Assert
 	if err == nil {
@@ -161,7 +174,7 @@ func TestDecreaseQuantityInput_Process_InsufficientQuantity(t *testing.T) {
 	mockInput := &models.Input{
 		ID:        inputID,
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  50,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -178,10 +191,15 @@ func TestDecreaseQuantityInput_Process_InsufficientQuantity(t *testing.T) {
 	useCase := &DecreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 100)
+	err := useCase.Process(inputID, 100, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment})
 
 	// Assert
 	if err == nil {
@@ -220,6 +238,53 @@ func TestDecreaseQuantityInput_Process_InsufficientQuantity(t *testing.T) {
 	}
 }
 
+func TestDecreaseQuantityInput_Process_ConcurrentDecreaseExhaustsStock(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	var loggedErrors []string
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {
+		loggedErrors = append(loggedErrors, msg)
+	}
+
+	inputID := uuid.New()
+
+	// A leitura ainda enxerga saldo suficiente...
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: inputID, Name: "Parafuso M6", Quantity: 30}, nil
+	}
+
+	// ...mas outra transação consumiu o estoque antes do UPDATE condicional
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, repository.ErrInsufficientQuantity
+	}
+
+	useCase := &DecreaseQuantityInput{
+		InputRepository: inputRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
+	}
+
+	// Act
+	err := useCase.Process(inputID, 30, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment})
+
+	// Assert
+	if err == nil || err.Error() != "insufficient quantity" {
+		t.Errorf("Expected error 'insufficient quantity', got %v", err)
+	}
+
+	if len(loggedErrors) != 1 || loggedErrors[0] != "Insufficient quantity" {
+		t.Errorf("Expected error log 'Insufficient quantity', got %v", loggedErrors)
+	}
+}
+
 func TestDecreaseQuantityInput_Process_InputNotFound(t *testing.T) {
 	// Arrange
 	inputRepoMock := &mocks.InputRepositoryMock{}
@@ -245,10 +310,15 @@ func TestDecreaseQuantityInput_Process_InputNotFound(t *testing.T) {
 	useCase := &DecreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 30)
+	err := useCase.Process(inputID, 30, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment})
 
 	// Assert
 	if err == nil {
@@ -306,7 +376,7 @@ func TestDecreaseQuantityInput_Process_DatabaseError(t *testing.T) {
 	mockInput := &models.Input{
 		ID:        inputID,
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -321,17 +391,22 @@ func TestDecreaseQuantityInput_Process_DatabaseError(t *testing.T) {
 		return nil, errors.New("input not found")
 	}
 
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return expectedError
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, expectedError
 	}
 
 	useCase := &DecreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 30)
+	err := useCase.Process(inputID, 30, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment})
 
 	// Assert
 	if err == nil {
@@ -558,8 +633,8 @@ func TestDecreaseQuantityInput_UpdateInputQuantity_Success(t *testing.T) {
 		loggedErrors = append(loggedErrors, msg)
 	}
 
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return nil
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 100 - quantity}, nil
 	}
 
 	useCase := &DecreaseQuantityInput{
@@ -570,7 +645,7 @@ func TestDecreaseQuantityInput_UpdateInputQuantity_Success(t *testing.T) {
 	input := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -578,7 +653,7 @@ func TestDecreaseQuantityInput_UpdateInputQuantity_Success(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.UpdateInputQuantity(input, 70)
+	err := useCase.UpdateInputQuantity(input, 30)
 
 	// Assert
 	if err != nil {
@@ -619,8 +694,8 @@ func TestDecreaseQuantityInput_UpdateInputQuantity_Error(t *testing.T) {
 	}
 
 	expectedError := errors.New("update constraint violation")
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return expectedError
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, expectedError
 	}
 
 	useCase := &DecreaseQuantityInput{
@@ -631,7 +706,7 @@ func TestDecreaseQuantityInput_UpdateInputQuantity_Error(t *testing.T) {
 	input := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -639,7 +714,7 @@ func TestDecreaseQuantityInput_UpdateInputQuantity_Error(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.UpdateInputQuantity(input, 70)
+	err := useCase.UpdateInputQuantity(input, 30)
 
 	// Assert
 	if err == nil {
@@ -662,3 +737,99 @@ func TestDecreaseQuantityInput_UpdateInputQuantity_Error(t *testing.T) {
 		t.Errorf("Expected error log 'Database error updating input quantity', got '%s'", loggedErrors[0])
 	}
 }
+
+func TestDecreaseQuantityInput_Process_RecordsInventoryMovement(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	inputID := uuid.New()
+	orderID := uuid.New()
+	userID := uuid.New()
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: inputID, Name: "Parafuso M6", Quantity: 100}, nil
+	}
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 100 - quantity}, nil
+	}
+
+	var recorded *models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = movement
+		return nil
+	}
+
+	useCase := &DecreaseQuantityInput{
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	movement := inventoryMovement.MovementContext{
+		MovementType: inventoryMovement.MovementTypeOrderConsumption,
+		OrderID:      &orderID,
+		UserID:       &userID,
+	}
+
+	// Act
+	err := useCase.Process(inputID, 30, movement)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recorded == nil {
+		t.Fatal("Expected inventory movement to be recorded")
+	}
+	if recorded.Delta != -30 || recorded.BalanceAfter != 70 {
+		t.Errorf("Expected delta -30 and balance 70, got delta %d and balance %d", recorded.Delta, recorded.BalanceAfter)
+	}
+	if recorded.MovementType != inventoryMovement.MovementTypeOrderConsumption {
+		t.Errorf("Expected movement type %s, got %s", inventoryMovement.MovementTypeOrderConsumption, recorded.MovementType)
+	}
+	if recorded.OrderID == nil || *recorded.OrderID != orderID || recorded.UserID == nil || *recorded.UserID != userID {
+		t.Error("Expected order and user references to be recorded")
+	}
+}
+
+func TestDecreaseQuantityInput_Process_MovementErrorPropagates(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 100}, nil
+	}
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 100 - quantity}, nil
+	}
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		return errors.New("database error")
+	}
+
+	useCase := &DecreaseQuantityInput{
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), 30, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeAdjustment})
+
+	// Assert
+	// O erro do ledger é propagado para que a baixa de estoque seja desfeita
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
@@ -14,36 +14,49 @@ type FindAllInputs struct {
 	Logger          logger.Logger
 }
 
-// FetchInputsFromDB busca todos os inputs do banco de dados
-func (uc *FindAllInputs) FetchInputsFromDB() ([]models.Input, error) {
-	inputs, err := uc.InputRepository.FindAll()
+// ValidateQuery valida a paginação da listagem
+func (uc *FindAllInputs) ValidateQuery(spec *repository.QuerySpec) error {
+	if err := spec.Validate(); err != nil {
+		uc.Logger.Error("Invalid query", zap.Error(err), zap.Int("limit", spec.Limit))
+		return err
+	}
+	return nil
+}
+
+// FetchInputsFromDB busca uma página de inputs do banco de dados
+func (uc *FindAllInputs) FetchInputsFromDB(spec repository.QuerySpec) (*repository.Page[models.Input], error) {
+	page, err := uc.InputRepository.FindAll(spec)
 	if err != nil {
 		uc.Logger.Error("Database error finding all inputs", zap.Error(err))
-		return []models.Input{}, err
+		return nil, err
 	}
 
-	uc.Logger.Info("Found inputs in database", zap.Int("count", len(inputs)))
-	return inputs, nil
+	uc.Logger.Info("Found inputs in database",
+		zap.Int("count", len(page.Items)),
+		zap.Int64("total", page.Total))
+	return page, nil
 }
 
-func (uc *FindAllInputs) Process() ([]domain.Input, error) {
+func (uc *FindAllInputs) Process(spec repository.QuerySpec) (*repository.Page[domain.Input], error) {
 	uc.Logger.Info("Processing find all inputs")
 
+	if err := uc.ValidateQuery(&spec); err != nil {
+		return nil, err
+	}
+
 	// Busca inputs do banco
-	inputs, err := uc.FetchInputsFromDB()
+	page, err := uc.FetchInputsFromDB(spec)
 	if err != nil {
-		return []domain.Input{}, err
+		return nil, err
 	}
 
 	// Mapeia para o domínio usando persistence
-	domainInputs := make([]domain.Input, 0)
-	for _, input := range inputs {
-		domainInput := persistence.InputPersistence{}.ToEntity(&input)
-		domainInputs = append(domainInputs, *domainInput)
-	}
+	domainPage := repository.MapPage(page, func(input *models.Input) domain.Input {
+		return *persistence.InputPersistence{}.ToEntity(input)
+	})
 
-	uc.Logger.Info("Successfully mapped inputs to domain", zap.Int("count", len(domainInputs)))
+	uc.Logger.Info("Successfully mapped inputs to domain", zap.Int("count", len(domainPage.Items)))
 
 	// Sempre retorna uma lista (vazia se não encontrou inputs)
-	return domainInputs, nil
+	return domainPage, nil
 }
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
 )
@@ -32,7 +34,7 @@ func TestFindAllInputs_Process_Success(t *testing.T) {
 		{
 			ID:        uuid.New(),
 			Name:      "Parafuso M6",
-			Price:     2.50,
+			Price:     money.MustParse("2.50"),
 			Quantity:  100,
 			InputType: "supplie",
 			CreatedAt: time.Now(),
@@ -41,7 +43,7 @@ func TestFindAllInputs_Process_Success(t *testing.T) {
 		{
 			ID:        uuid.New(),
 			Name:      "Serviço de Troca de Óleo",
-			Price:     50.00,
+			Price:     money.MustParse("50.00"),
 			Quantity:  1,
 			InputType: "service",
 			CreatedAt: time.Now(),
@@ -49,8 +51,8 @@ func TestFindAllInputs_Process_Success(t *testing.T) {
 		},
 	}
 
-	inputRepoMock.FindAllFunc = func() ([]models.Input, error) {
-		return mockInputs, nil
+	inputRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Input], error) {
+		return &repository.Page[models.Input]{Items: mockInputs}, nil
 	}
 
 	useCase := &FindAllInputs{
@@ -59,15 +61,15 @@ func TestFindAllInputs_Process_Success(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process()
+	result, err := useCase.Process(repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 2 {
-		t.Errorf("Expected 2 inputs, got %d", len(result))
+	if len(result.Items) != 2 {
+		t.Errorf("Expected 2 inputs, got %d", len(result.Items))
 	}
 
 	// Verifica se os logs corretos foram chamados
@@ -112,7 +114,7 @@ func TestFindAllInputs_Process_DatabaseError(t *testing.T) {
 	}
 
 	expectedError := errors.New("database connection failed")
-	inputRepoMock.FindAllFunc = func() ([]models.Input, error) {
+	inputRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Input], error) {
 		return nil, expectedError
 	}
 
@@ -122,7 +124,7 @@ func TestFindAllInputs_Process_DatabaseError(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process()
+	result, err := useCase.Process(repository.QuerySpec{})
 
 	// Assert
 	if err == nil {
@@ -133,8 +135,8 @@ func TestFindAllInputs_Process_DatabaseError(t *testing.T) {
 		t.Errorf("Expected error %v, got %v", expectedError, err)
 	}
 
-	if len(result) != 0 {
-		t.Errorf("Expected empty result, got %d", len(result))
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
 	}
 
 	// Verifica se os logs corretos foram chamados
@@ -172,8 +174,8 @@ func TestFindAllInputs_Process_EmptyResult(t *testing.T) {
 	}
 
 	// Mock empty result
-	inputRepoMock.FindAllFunc = func() ([]models.Input, error) {
-		return []models.Input{}, nil
+	inputRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Input], error) {
+		return &repository.Page[models.Input]{Items: []models.Input{}}, nil
 	}
 
 	useCase := &FindAllInputs{
@@ -182,15 +184,15 @@ func TestFindAllInputs_Process_EmptyResult(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process()
+	result, err := useCase.Process(repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 0 {
-		t.Errorf("Expected 0 inputs, got %d", len(result))
+	if len(result.Items) != 0 {
+		t.Errorf("Expected 0 inputs, got %d", len(result.Items))
 	}
 
 	// Verifica se os logs corretos foram chamados
@@ -238,7 +240,7 @@ func TestFindAllInputs_FetchInputsFromDB_Success(t *testing.T) {
 		{
 			ID:        uuid.New(),
 			Name:      "Parafuso M6",
-			Price:     2.50,
+			Price:     money.MustParse("2.50"),
 			Quantity:  100,
 			InputType: "supplie",
 			CreatedAt: time.Now(),
@@ -246,8 +248,8 @@ func TestFindAllInputs_FetchInputsFromDB_Success(t *testing.T) {
 		},
 	}
 
-	inputRepoMock.FindAllFunc = func() ([]models.Input, error) {
-		return mockInputs, nil
+	inputRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Input], error) {
+		return &repository.Page[models.Input]{Items: mockInputs}, nil
 	}
 
 	useCase := &FindAllInputs{
@@ -256,15 +258,15 @@ func TestFindAllInputs_FetchInputsFromDB_Success(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.FetchInputsFromDB()
+	result, err := useCase.FetchInputsFromDB(repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 1 {
-		t.Errorf("Expected 1 input, got %d", len(result))
+	if len(result.Items) != 1 {
+		t.Errorf("Expected 1 input, got %d", len(result.Items))
 	}
 
 	if len(loggedInfo) != 1 {
@@ -297,7 +299,7 @@ func TestFindAllInputs_FetchInputsFromDB_Error(t *testing.T) {
 	}
 
 	expectedError := errors.New("database timeout")
-	inputRepoMock.FindAllFunc = func() ([]models.Input, error) {
+	inputRepoMock.FindAllFunc = func(spec repository.QuerySpec) (*repository.Page[models.Input], error) {
 		return nil, expectedError
 	}
 
@@ -307,7 +309,7 @@ func TestFindAllInputs_FetchInputsFromDB_Error(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.FetchInputsFromDB()
+	result, err := useCase.FetchInputsFromDB(repository.QuerySpec{})
 
 	// Assert
 	if err == nil {
@@ -318,8 +320,8 @@ func TestFindAllInputs_FetchInputsFromDB_Error(t *testing.T) {
 		t.Errorf("Expected error %v, got %v", expectedError, err)
 	}
 
-	if len(result) != 0 {
-		t.Errorf("Expected empty result, got %d", len(result))
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
 	}
 
 	if len(loggedInfo) > 0 {
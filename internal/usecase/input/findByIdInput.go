@@ -27,7 +27,7 @@ func (uc *FindByIdInput) FetchInputFromDB(id uuid.UUID) (*models.Input, error) {
 		zap.String("id", input.ID.String()),
 		zap.String("name", input.Name),
 		zap.String("inputType", input.InputType),
-		zap.Float64("price", input.Price),
+		zap.Stringer("price", input.Price),
 		zap.Int("quantity", input.Quantity))
 
 	return input, nil
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
@@ -31,7 +32,7 @@ func TestFindByIdInput_Process_Success(t *testing.T) {
 	mockInput := &models.Input{
 		ID:        inputID,
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -234,7 +235,7 @@ func TestFindByIdInput_FetchInputFromDB_Success(t *testing.T) {
 	mockInput := &models.Input{
 		ID:        inputID,
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -0,0 +1,56 @@
+package input
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+type FindInputMovements struct {
+	InputRepository             repository.InputRepository
+	InventoryMovementRepository repository.InventoryMovementRepository
+	Logger                      logger.Logger
+}
+
+// ValidateInputExists valida se o input existe
+func (uc *FindInputMovements) ValidateInputExists(inputID uuid.UUID) error {
+	_, err := uc.InputRepository.FindByID(inputID)
+	if err != nil {
+		uc.Logger.Error("Input not found", zap.String("inputID", inputID.String()))
+		return errors.New("input not found")
+	}
+	return nil
+}
+
+func (uc *FindInputMovements) Process(inputID uuid.UUID) ([]*domain.InventoryMovement, error) {
+	uc.Logger.Info("Processing find input movements", zap.String("inputID", inputID.String()))
+
+	// Valida se o input existe
+	if err := uc.ValidateInputExists(inputID); err != nil {
+		return nil, err
+	}
+
+	// Busca as movimentações do input
+	movements, err := uc.InventoryMovementRepository.FindByInputID(inputID)
+	if err != nil {
+		uc.Logger.Error("Database error finding input movements", zap.Error(err))
+		return nil, err
+	}
+
+	// Mapeia para o domínio usando persistence
+	var domainMovements []*domain.InventoryMovement
+	for i := range movements {
+		domainMovements = append(domainMovements, persistence.InventoryMovementPersistence{}.ToEntity(&movements[i]))
+	}
+
+	uc.Logger.Info("Successfully found input movements",
+		zap.String("inputID", inputID.String()),
+		zap.Int("count", len(domainMovements)))
+
+	return domainMovements, nil
+}
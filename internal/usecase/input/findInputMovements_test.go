@@ -0,0 +1,90 @@
+package input
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestFindInputMovements_Process_Success(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputID := uuid.New()
+	orderID := uuid.New()
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: inputID, Quantity: 7}, nil
+	}
+	movementRepoMock.FindByInputIDFunc = func(id uuid.UUID) ([]models.InventoryMovement, error) {
+		if id != inputID {
+			t.Errorf("Expected input ID %s, got %s", inputID, id)
+		}
+		return []models.InventoryMovement{
+			{ID: uuid.New(), InputID: inputID, OrderID: &orderID, MovementType: inventoryMovement.MovementTypeOrderConsumption, Delta: -3, BalanceAfter: 7, CreatedAt: time.Now()},
+			{ID: uuid.New(), InputID: inputID, MovementType: inventoryMovement.MovementTypeManualRestock, Delta: 10, BalanceAfter: 10, Reason: "initial stock", CreatedAt: time.Now().Add(-time.Hour)},
+		}, nil
+	}
+
+	useCase := &FindInputMovements{
+		InputRepository:             inputRepoMock,
+		InventoryMovementRepository: movementRepoMock,
+		Logger:                      loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(inputID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 movements, got %d", len(result))
+	}
+	if result[0].Delta != -3 || result[0].BalanceAfter != 7 || result[0].OrderID == nil || *result[0].OrderID != orderID {
+		t.Errorf("Unexpected first movement: %+v", result[0])
+	}
+	if result[1].Reason != "initial stock" {
+		t.Errorf("Expected reason 'initial stock', got '%s'", result[1].Reason)
+	}
+}
+
+func TestFindInputMovements_Process_InputNotFound(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return nil, errors.New("record not found")
+	}
+
+	useCase := &FindInputMovements{
+		InputRepository:             inputRepoMock,
+		InventoryMovementRepository: movementRepoMock,
+		Logger:                      loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(uuid.New())
+
+	// Assert
+	if err == nil || err.Error() != "input not found" {
+		t.Errorf("Expected error 'input not found', got %v", err)
+	}
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
@@ -13,6 +14,24 @@ import (
 type IncreaseQuantityInput struct {
 	InputRepository repository.InputRepository
 	Logger          logger.Logger
+	UnitOfWork      repository.UnitOfWork
+
+	// RecordInventoryMovement registra cada alteração de estoque no ledger
+	RecordInventoryMovement *RecordInventoryMovement
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *IncreaseQuantityInput) WithRepositories(repos repository.Repositories) *IncreaseQuantityInput {
+	if uc == nil {
+		return nil
+	}
+	return &IncreaseQuantityInput{
+		InputRepository: repos.Inputs,
+		Logger:          uc.Logger,
+		UnitOfWork:      repos.UnitOfWork,
+
+		RecordInventoryMovement: uc.RecordInventoryMovement.WithRepositories(repos),
+	}
 }
 
 // FetchInputFromDB busca um input específico do banco de dados
@@ -52,27 +71,30 @@ func (uc *IncreaseQuantityInput) CalculateNewQuantity(currentQuantity, quantityT
 	return newQuantity
 }
 
-// UpdateInputQuantity atualiza a quantidade do input no banco de dados
-func (uc *IncreaseQuantityInput) UpdateInputQuantity(input *models.Input, newQuantity int) error {
-	// Atualiza a quantidade no modelo
-	input.Quantity = newQuantity
+// UpdateInputQuantity repõe a quantidade do input no banco de dados de forma atômica
+func (uc *IncreaseQuantityInput) UpdateInputQuantity(input *models.Input, quantityToIncrease int) error {
+	oldQuantity := input.Quantity
 
-	err := uc.InputRepository.Update(input)
+	updated, err := uc.InputRepository.IncreaseQuantity(input.ID, quantityToIncrease)
 	if err != nil {
 		uc.Logger.Error("Database error updating input quantity", zap.Error(err))
 		return err
 	}
 
+	// Atualiza o modelo com o saldo efetivamente gravado
+	input.Quantity = updated.Quantity
+
 	uc.Logger.Info("Input quantity increased successfully",
 		zap.String("id", input.ID.String()),
 		zap.String("name", input.Name),
-		zap.Int("oldQuantity", input.Quantity),
-		zap.Int("newQuantity", newQuantity))
+		zap.Int("oldQuantity", oldQuantity),
+		zap.Int("newQuantity", input.Quantity))
 
 	return nil
 }
 
-func (uc *IncreaseQuantityInput) Process(id uuid.UUID, quantity int) error {
+// Process altera o estoque do input e registra a movimentação descrita em movement
+func (uc *IncreaseQuantityInput) Process(id uuid.UUID, quantity int, movement inventoryMovement.MovementContext) error {
 	uc.Logger.Info("Processing increase quantity for input",
 		zap.String("id", id.String()),
 		zap.Int("quantityToIncrease", quantity))
@@ -82,20 +104,21 @@ func (uc *IncreaseQuantityInput) Process(id uuid.UUID, quantity int) error {
 		return err
 	}
 
-	// Busca o input
-	input, err := uc.FetchInputFromDB(id)
-	if err != nil {
-		return err
-	}
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	// Calcula nova quantidade
-	newQuantity := uc.CalculateNewQuantity(input.Quantity, quantity)
+		// Busca o input
+		input, err := tx.FetchInputFromDB(id)
+		if err != nil {
+			return err
+		}
 
-	// Atualiza a quantidade
-	err = uc.UpdateInputQuantity(input, newQuantity)
-	if err != nil {
-		return err
-	}
+		// Repõe a quantidade de forma atômica
+		if err := tx.UpdateInputQuantity(input, quantity); err != nil {
+			return err
+		}
 
-	return nil
+		// Registra a movimentação no ledger com o saldo resultante
+		return tx.RecordInventoryMovement.Process(input.ID, quantity, input.Quantity, movement)
+	})
 }
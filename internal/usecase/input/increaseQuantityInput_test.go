@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
 )
@@ -31,7 +34,7 @@ func TestIncreaseQuantityInput_Process_Success(t *testing.T) {
 	mockInput := &models.Input{
 		ID:        inputID,
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -45,17 +48,22 @@ func TestIncreaseQuantityInput_Process_Success(t *testing.T) {
 		return nil, errors.New("input not found")
 	}
 
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return nil
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 100 + quantity}, nil
 	}
 
 	useCase := &IncreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 50)
+	err := useCase.Process(inputID, 50, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeManualRestock})
 
 	// Assert
 	if err != nil {
@@ -66,7 +74,6 @@ func TestIncreaseQuantityInput_Process_Success(t *testing.T) {
 	expectedInfoLogs := []string{
 		"Processing increase quantity for input",
 		"Found input",
-		"Calculated new quantity",
 		"Input quantity increased successfully",
 	}
 
@@ -109,10 +116,15 @@ func TestIncreaseQuantityInput_Process_InvalidQuantity(t *testing.T) {
 	useCase := &IncreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 0)
+	err := useCase.Process(inputID, 0, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeManualRestock})
 
 	// Assert
 	if err == nil {
@@ -166,10 +178,15 @@ func TestIncreaseQuantityInput_Process_InputNotFound(t *testing.T) {
 	useCase := &IncreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 50)
+	err := useCase.Process(inputID, 50, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeManualRestock})
 
 	// Assert
 	if err == nil {
@@ -227,7 +244,7 @@ func TestIncreaseQuantityInput_Process_DatabaseError(t *testing.T) {
 	mockInput := &models.Input{
 		ID:        inputID,
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -242,17 +259,22 @@ func TestIncreaseQuantityInput_Process_DatabaseError(t *testing.T) {
 		return nil, errors.New("input not found")
 	}
 
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return expectedError
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, expectedError
 	}
 
 	useCase := &IncreaseQuantityInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, 50)
+	err := useCase.Process(inputID, 50, inventoryMovement.MovementContext{MovementType: inventoryMovement.MovementTypeManualRestock})
 
 	// Assert
 	if err == nil {
@@ -267,7 +289,6 @@ func TestIncreaseQuantityInput_Process_DatabaseError(t *testing.T) {
 	expectedInfoLogs := []string{
 		"Processing increase quantity for input",
 		"Found input",
-		"Calculated new quantity",
 	}
 
 	for _, expectedLog := range expectedInfoLogs {
@@ -425,8 +446,8 @@ func TestIncreaseQuantityInput_UpdateInputQuantity_Success(t *testing.T) {
 		loggedErrors = append(loggedErrors, msg)
 	}
 
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return nil
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 100 + quantity}, nil
 	}
 
 	useCase := &IncreaseQuantityInput{
@@ -437,7 +458,7 @@ func TestIncreaseQuantityInput_UpdateInputQuantity_Success(t *testing.T) {
 	input := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -445,7 +466,7 @@ func TestIncreaseQuantityInput_UpdateInputQuantity_Success(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.UpdateInputQuantity(input, 150)
+	err := useCase.UpdateInputQuantity(input, 50)
 
 	// Assert
 	if err != nil {
@@ -486,8 +507,8 @@ func TestIncreaseQuantityInput_UpdateInputQuantity_Error(t *testing.T) {
 	}
 
 	expectedError := errors.New("update constraint violation")
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return expectedError
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, expectedError
 	}
 
 	useCase := &IncreaseQuantityInput{
@@ -498,7 +519,7 @@ func TestIncreaseQuantityInput_UpdateInputQuantity_Error(t *testing.T) {
 	input := &models.Input{
 		ID:        uuid.New(),
 		Name:      "Parafuso M6",
-		Price:     2.50,
+		Price:     money.MustParse("2.50"),
 		Quantity:  100,
 		InputType: "supplie",
 		CreatedAt: time.Now(),
@@ -506,7 +527,7 @@ func TestIncreaseQuantityInput_UpdateInputQuantity_Error(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.UpdateInputQuantity(input, 150)
+	err := useCase.UpdateInputQuantity(input, 50)
 
 	// Assert
 	if err == nil {
@@ -0,0 +1,118 @@
+package input
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+// ManageInputReservation controla as reservas de estoque feitas para orders ainda não aprovadas.
+// Deve ser chamado dentro da transação de quem altera a order.
+type ManageInputReservation struct {
+	InputRepository repository.InputRepository
+	Logger          logger.Logger
+
+	// RecordInventoryMovement registra no ledger a baixa feita ao consumir uma reserva
+	RecordInventoryMovement *RecordInventoryMovement
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *ManageInputReservation) WithRepositories(repos repository.Repositories) *ManageInputReservation {
+	if uc == nil {
+		return nil
+	}
+	return &ManageInputReservation{
+		InputRepository: repos.Inputs,
+		Logger:          uc.Logger,
+
+		RecordInventoryMovement: uc.RecordInventoryMovement.WithRepositories(repos),
+	}
+}
+
+// ValidateQuantity valida se a quantidade da reserva é válida
+func (uc *ManageInputReservation) ValidateQuantity(quantity int) error {
+	if quantity <= 0 {
+		uc.Logger.Error("Invalid reservation quantity", zap.Int("quantity", quantity))
+		return errors.New("reservation quantity must be greater than zero")
+	}
+	return nil
+}
+
+// Reserve reserva a quantidade no saldo disponível do input. O saldo físico não é alterado,
+// por isso a reserva não gera movimentação no ledger.
+func (uc *ManageInputReservation) Reserve(inputID uuid.UUID, quantity int) error {
+	if err := uc.ValidateQuantity(quantity); err != nil {
+		return err
+	}
+
+	updated, err := uc.InputRepository.ReserveQuantity(inputID, quantity)
+	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientQuantity) {
+			uc.Logger.Error("Insufficient available quantity to reserve",
+				zap.String("inputID", inputID.String()),
+				zap.Int("quantity", quantity))
+			return repository.ErrInsufficientQuantity
+		}
+		uc.Logger.Error("Database error reserving input quantity", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Input quantity reserved",
+		zap.String("inputID", inputID.String()),
+		zap.Int("quantity", quantity),
+		zap.Int("reservedQuantity", updated.ReservedQuantity),
+		zap.Int("onHandQuantity", updated.Quantity))
+
+	return nil
+}
+
+// Release libera uma reserva devolvendo a quantidade ao saldo disponível
+func (uc *ManageInputReservation) Release(inputID uuid.UUID, quantity int) error {
+	if err := uc.ValidateQuantity(quantity); err != nil {
+		return err
+	}
+
+	updated, err := uc.InputRepository.ReleaseReservation(inputID, quantity)
+	if err != nil {
+		uc.Logger.Error("Error releasing input reservation",
+			zap.String("inputID", inputID.String()),
+			zap.Int("quantity", quantity),
+			zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Input reservation released",
+		zap.String("inputID", inputID.String()),
+		zap.Int("quantity", quantity),
+		zap.Int("reservedQuantity", updated.ReservedQuantity))
+
+	return nil
+}
+
+// Consume converte a reserva em baixa do saldo físico e registra a movimentação no ledger
+func (uc *ManageInputReservation) Consume(inputID uuid.UUID, quantity int, movement inventoryMovement.MovementContext) error {
+	if err := uc.ValidateQuantity(quantity); err != nil {
+		return err
+	}
+
+	updated, err := uc.InputRepository.ConsumeReservation(inputID, quantity)
+	if err != nil {
+		uc.Logger.Error("Error consuming input reservation",
+			zap.String("inputID", inputID.String()),
+			zap.Int("quantity", quantity),
+			zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Input reservation consumed",
+		zap.String("inputID", inputID.String()),
+		zap.Int("quantity", quantity),
+		zap.Int("reservedQuantity", updated.ReservedQuantity),
+		zap.Int("onHandQuantity", updated.Quantity))
+
+	return uc.RecordInventoryMovement.Process(inputID, -quantity, updated.Quantity, movement)
+}
@@ -0,0 +1,105 @@
+package input
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestManageInputReservation_Reserve_InsufficientAvailableQuantity(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock.ReserveQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, repository.ErrInsufficientQuantity
+	}
+
+	useCase := &ManageInputReservation{InputRepository: inputRepoMock, Logger: loggerMock}
+
+	// Act
+	err := useCase.Reserve(uuid.New(), 3)
+
+	// Assert
+	if !errors.Is(err, repository.ErrInsufficientQuantity) {
+		t.Errorf("Expected insufficient quantity error, got %v", err)
+	}
+}
+
+func TestManageInputReservation_Reserve_InvalidQuantity(t *testing.T) {
+	// Arrange
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	useCase := &ManageInputReservation{InputRepository: &mocks.InputRepositoryMock{}, Logger: loggerMock}
+
+	// Act
+	err := useCase.Reserve(uuid.New(), 0)
+
+	// Assert
+	if err == nil || err.Error() != "reservation quantity must be greater than zero" {
+		t.Errorf("Expected invalid reservation quantity error, got %v", err)
+	}
+}
+
+func TestManageInputReservation_Consume_RecordsMovement(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputID := uuid.New()
+	orderID := uuid.New()
+
+	inputRepoMock.ConsumeReservationFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 6, ReservedQuantity: 1}, nil
+	}
+
+	var recorded *models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = movement
+		return nil
+	}
+
+	useCase := &ManageInputReservation{
+		InputRepository: inputRepoMock,
+		Logger:          loggerMock,
+		RecordInventoryMovement: &RecordInventoryMovement{
+			InventoryMovementRepository: movementRepoMock,
+			Logger:                      loggerMock,
+		},
+	}
+	movement := inventoryMovement.MovementContext{
+		MovementType: inventoryMovement.MovementTypeOrderConsumption,
+		OrderID:      &orderID,
+	}
+
+	// Act
+	err := useCase.Consume(inputID, 4, movement)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recorded == nil {
+		t.Fatal("Expected movement to be recorded")
+	}
+	if recorded.InputID != inputID || recorded.Delta != -4 || recorded.BalanceAfter != 6 {
+		t.Errorf("Unexpected movement recorded: %+v", recorded)
+	}
+	if recorded.OrderID == nil || *recorded.OrderID != orderID {
+		t.Errorf("Expected movement to reference order %s, got %v", orderID, recorded.OrderID)
+	}
+}
@@ -0,0 +1,55 @@
+package input
+
+import (
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+type RecordInventoryMovement struct {
+	InventoryMovementRepository repository.InventoryMovementRepository
+	Logger                      logger.Logger
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *RecordInventoryMovement) WithRepositories(repos repository.Repositories) *RecordInventoryMovement {
+	if uc == nil {
+		return nil
+	}
+	return &RecordInventoryMovement{
+		InventoryMovementRepository: repos.InventoryMovements,
+		Logger:                      uc.Logger,
+	}
+}
+
+// Process grava no ledger uma alteração de estoque já aplicada ao input
+func (uc *RecordInventoryMovement) Process(inputID uuid.UUID, delta int, balanceAfter int, movement domain.MovementContext) error {
+	entity := &domain.InventoryMovement{
+		ID:           uuid.New(),
+		InputID:      inputID,
+		OrderID:      movement.OrderID,
+		UserID:       movement.UserID,
+		MovementType: movement.MovementType,
+		Delta:        delta,
+		BalanceAfter: balanceAfter,
+		Reason:       movement.Reason,
+	}
+
+	model := persistence.InventoryMovementPersistence{}.ToModel(entity)
+	err := uc.InventoryMovementRepository.Create(model)
+	if err != nil {
+		uc.Logger.Error("Database error recording inventory movement", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Inventory movement recorded",
+		zap.String("inputID", inputID.String()),
+		zap.String("movementType", movement.MovementType),
+		zap.Int("delta", delta),
+		zap.Int("balanceAfter", balanceAfter))
+
+	return nil
+}
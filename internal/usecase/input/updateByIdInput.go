@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/input"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
@@ -13,13 +14,26 @@ import (
 )
 
 type UpdateByIdInput struct {
-	InputRepository repository.InputRepository
-	Logger          logger.Logger
+	InputRepository         repository.InputRepository
+	Logger                  logger.Logger
+	RecordInventoryMovement *RecordInventoryMovement
+	UnitOfWork              repository.UnitOfWork
 }
 
-// FetchInputFromDB busca um input específico do banco de dados
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *UpdateByIdInput) WithRepositories(repos repository.Repositories) *UpdateByIdInput {
+	return &UpdateByIdInput{
+		InputRepository:         repos.Inputs,
+		Logger:                  uc.Logger,
+		RecordInventoryMovement: uc.RecordInventoryMovement.WithRepositories(repos),
+		UnitOfWork:              repos.UnitOfWork,
+	}
+}
+
+// FetchInputFromDB busca o input bloqueando a linha, para que uma reserva ou baixa concorrente
+// não seja sobrescrita pela quantidade editada
 func (uc *UpdateByIdInput) FetchInputFromDB(id uuid.UUID) (*models.Input, error) {
-	input, err := uc.InputRepository.FindByID(id)
+	input, err := uc.InputRepository.FindByIDForUpdate(id)
 	if err != nil {
 		uc.Logger.Error("Database error finding input to update", zap.Error(err), zap.String("id", id.String()))
 		return nil, err
@@ -73,10 +87,22 @@ func (uc *UpdateByIdInput) UpdateInputFields(existingInput *models.Input, entity
 	uc.Logger.Info("Updated input fields",
 		zap.String("name", existingInput.Name),
 		zap.String("inputType", existingInput.InputType),
-		zap.Float64("price", existingInput.Price),
+		zap.Stringer("price", existingInput.Price),
 		zap.Int("quantity", existingInput.Quantity))
 }
 
+// ValidateReservedQuantity impede que o saldo físico fique abaixo do que está reservado para orders
+func (uc *UpdateByIdInput) ValidateReservedQuantity(input *models.Input) error {
+	if input.InputType != "service" && input.Quantity < input.ReservedQuantity {
+		uc.Logger.Error("Quantity lower than reserved quantity",
+			zap.String("id", input.ID.String()),
+			zap.Int("quantity", input.Quantity),
+			zap.Int("reservedQuantity", input.ReservedQuantity))
+		return errors.New("quantity cannot be lower than reserved quantity")
+	}
+	return nil
+}
+
 // SaveInputToDB salva as alterações do input no banco de dados
 func (uc *UpdateByIdInput) SaveInputToDB(input *models.Input) error {
 	err := uc.InputRepository.Update(input)
@@ -89,33 +115,61 @@ func (uc *UpdateByIdInput) SaveInputToDB(input *models.Input) error {
 	return nil
 }
 
-func (uc *UpdateByIdInput) Process(id uuid.UUID, entity *domain.Input) error {
+// RecordQuantityAdjustment registra no ledger a alteração de quantidade feita pela edição do input.
+// input deve ser a linha gravada, para que o saldo do ledger seja o do estoque.
+func (uc *UpdateByIdInput) RecordQuantityAdjustment(input *models.Input, oldQuantity int, userID uuid.UUID) error {
+	delta := input.Quantity - oldQuantity
+	if delta == 0 || input.InputType == "service" {
+		return nil
+	}
+
+	movement := inventoryMovement.MovementContext{
+		MovementType: inventoryMovement.MovementTypeAdjustment,
+		UserID:       &userID,
+		Reason:       "input updated",
+	}
+	return uc.RecordInventoryMovement.Process(input.ID, delta, input.Quantity, movement)
+}
+
+func (uc *UpdateByIdInput) Process(id uuid.UUID, entity *domain.Input, userID uuid.UUID) error {
 	uc.Logger.Info("Processing update input by ID",
 		zap.String("id", id.String()),
 		zap.String("name", entity.Name),
 		zap.String("inputType", entity.InputType))
 
-	// Busca o input existente
-	existingInput, err := uc.FetchInputFromDB(id)
-	if err != nil {
-		return err
-	}
+	// Input e ledger de estoque são gravados na mesma transação
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	// Verifica se o novo nome já existe (se foi alterado)
-	if entity.Name != existingInput.Name {
-		if err := uc.ValidateInputNameUniqueness(entity.Name, id); err != nil {
+		// Busca o input existente
+		existingInput, err := tx.FetchInputFromDB(id)
+		if err != nil {
 			return err
 		}
-	}
 
-	// Atualiza os campos do input
-	uc.UpdateInputFields(existingInput, entity)
+		// Verifica se o novo nome já existe (se foi alterado)
+		if entity.Name != existingInput.Name {
+			if err := tx.ValidateInputNameUniqueness(entity.Name, id); err != nil {
+				return err
+			}
+		}
 
-	// Salva as alterações
-	err = uc.SaveInputToDB(existingInput)
-	if err != nil {
-		return err
-	}
+		oldQuantity := existingInput.Quantity
 
-	return nil
+		// Atualiza os campos do input
+		tx.UpdateInputFields(existingInput, entity)
+
+		// Garante que as reservas continuem cobertas pelo novo saldo
+		if err := tx.ValidateReservedQuantity(existingInput); err != nil {
+			return err
+		}
+
+		// Salva as alterações
+		if err := tx.SaveInputToDB(existingInput); err != nil {
+			return err
+		}
+
+		// Registra a alteração de quantidade no ledger
+		return tx.RecordQuantityAdjustment(existingInput, oldQuantity, userID)
+	})
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/google/uuid"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -34,7 +36,7 @@ func TestUpdateByIdInput_Process_Success(t *testing.T) {
 		ID:          inputID,
 		Name:        "Parafuso M6",
 		Description: "Parafuso sextavado M6",
-		Price:       2.50,
+		Price:       money.MustParse("2.50"),
 		Quantity:    100,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -45,7 +47,7 @@ func TestUpdateByIdInput_Process_Success(t *testing.T) {
 		ID:          inputID,
 		Name:        "Parafuso M6 Atualizado",
 		Description: "Parafuso sextavado M6 atualizado",
-		Price:       3.00,
+		Price:       money.MustParse("3.00"),
 		Quantity:    150,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -70,10 +72,15 @@ func TestUpdateByIdInput_Process_Success(t *testing.T) {
 	useCase := &UpdateByIdInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, updateEntity)
+	err := useCase.Process(inputID, updateEntity, uuid.New())
 
 	// Assert
 	if err != nil {
@@ -128,7 +135,7 @@ func TestUpdateByIdInput_Process_InputNotFound(t *testing.T) {
 		ID:          inputID,
 		Name:        "Parafuso M6 Atualizado",
 		Description: "Parafuso sextavado M6 atualizado",
-		Price:       3.00,
+		Price:       money.MustParse("3.00"),
 		Quantity:    150,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -142,10 +149,15 @@ func TestUpdateByIdInput_Process_InputNotFound(t *testing.T) {
 	useCase := &UpdateByIdInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, updateEntity)
+	err := useCase.Process(inputID, updateEntity, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -195,7 +207,7 @@ func TestUpdateByIdInput_Process_NameAlreadyExists(t *testing.T) {
 		ID:          inputID,
 		Name:        "Parafuso M6",
 		Description: "Parafuso sextavado M6",
-		Price:       2.50,
+		Price:       money.MustParse("2.50"),
 		Quantity:    100,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -206,7 +218,7 @@ func TestUpdateByIdInput_Process_NameAlreadyExists(t *testing.T) {
 		ID:          inputID,
 		Name:        "Parafuso M8", // Nome diferente
 		Description: "Parafuso sextavado M6 atualizado",
-		Price:       3.00,
+		Price:       money.MustParse("3.00"),
 		Quantity:    150,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -217,7 +229,7 @@ func TestUpdateByIdInput_Process_NameAlreadyExists(t *testing.T) {
 		ID:          uuid.New(), // ID diferente
 		Name:        "Parafuso M8",
 		Description: "Parafuso M8 existente",
-		Price:       4.00,
+		Price:       money.MustParse("4.00"),
 		Quantity:    50,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -241,10 +253,15 @@ func TestUpdateByIdInput_Process_NameAlreadyExists(t *testing.T) {
 	useCase := &UpdateByIdInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, updateEntity)
+	err := useCase.Process(inputID, updateEntity, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -304,7 +321,7 @@ func TestUpdateByIdInput_Process_ServiceTypeQuantityAdjustment(t *testing.T) {
 		ID:          inputID,
 		Name:        "Serviço de Troca de Óleo",
 		Description: "Serviço completo de troca de óleo",
-		Price:       50.00,
+		Price:       money.MustParse("50.00"),
 		Quantity:    1,
 		InputType:   "service",
 		CreatedAt:   time.Now(),
@@ -315,7 +332,7 @@ func TestUpdateByIdInput_Process_ServiceTypeQuantityAdjustment(t *testing.T) {
 		ID:          inputID,
 		Name:        "Serviço de Troca de Óleo Atualizado",
 		Description: "Serviço completo de troca de óleo atualizado",
-		Price:       60.00,
+		Price:       money.MustParse("60.00"),
 		Quantity:    5, // Deveria ser forçado para 1
 		InputType:   "service",
 		CreatedAt:   time.Now(),
@@ -340,10 +357,15 @@ func TestUpdateByIdInput_Process_ServiceTypeQuantityAdjustment(t *testing.T) {
 	useCase := &UpdateByIdInput{
 		InputRepository: inputRepoMock,
 		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	// Act
-	err := useCase.Process(inputID, updateEntity)
+	err := useCase.Process(inputID, updateEntity, uuid.New())
 
 	// Assert
 	if err != nil {
@@ -378,6 +400,67 @@ func TestUpdateByIdInput_Process_ServiceTypeQuantityAdjustment(t *testing.T) {
 	}
 }
 
+func TestUpdateByIdInput_Process_ZeroQuantityRecordsWrittenBalance(t *testing.T) {
+	// Arrange
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputID := uuid.New()
+
+	// O input é lido com bloqueio da linha, para que reservas concorrentes não sejam sobrescritas
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		t.Error("Expected the input to be read with a row lock")
+		return nil, errors.New("unexpected unlocked read")
+	}
+	locked := false
+	inputRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Input, error) {
+		locked = true
+		return &models.Input{ID: id, Name: "Filtro de óleo", Price: money.MustParse("35.00"), Quantity: 7, InputType: "material"}, nil
+	}
+	var written int
+	inputRepoMock.UpdateFunc = func(input *models.Input) error {
+		written = input.Quantity
+		return nil
+	}
+	var recorded *models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = movement
+		return nil
+	}
+
+	useCase := &UpdateByIdInput{
+		InputRepository: inputRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Inputs:             inputRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+		RecordInventoryMovement: &RecordInventoryMovement{Logger: loggerMock},
+	}
+
+	// Act
+	err := useCase.Process(inputID, &domain.Input{Name: "Filtro de óleo", Price: money.MustParse("35.00"), Quantity: 0, InputType: "material"}, uuid.New())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !locked {
+		t.Error("Expected the input row to be locked while editing")
+	}
+	if written != 0 {
+		t.Errorf("Expected quantity 0 to be written, got %d", written)
+	}
+	if recorded == nil || recorded.Delta != -7 || recorded.BalanceAfter != 0 {
+		t.Errorf("Expected a -7 movement leaving a balance of 0, got %+v", recorded)
+	}
+}
+
 func TestUpdateByIdInput_AdjustQuantityForInputType_Service(t *testing.T) {
 	// Arrange
 	inputRepoMock := &mocks.InputRepositoryMock{}
@@ -469,7 +552,7 @@ func TestUpdateByIdInput_SaveInputToDB_Success(t *testing.T) {
 		ID:          uuid.New(),
 		Name:        "Parafuso M6",
 		Description: "Parafuso sextavado M6",
-		Price:       2.50,
+		Price:       money.MustParse("2.50"),
 		Quantity:    100,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -527,7 +610,7 @@ func TestUpdateByIdInput_SaveInputToDB_Error(t *testing.T) {
 		ID:          uuid.New(),
 		Name:        "Parafuso M6",
 		Description: "Parafuso sextavado M6",
-		Price:       2.50,
+		Price:       money.MustParse("2.50"),
 		Quantity:    100,
 		InputType:   "supplie",
 		CreatedAt:   time.Now(),
@@ -0,0 +1,30 @@
+package invoice
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindInvoiceById busca uma nota com suas linhas e notas de crédito
+type FindInvoiceById struct {
+	InvoiceRepository repository.InvoiceRepository
+	Logger            logger.Logger
+}
+
+func (uc *FindInvoiceById) Process(id uuid.UUID) (*domain.Invoice, error) {
+	uc.Logger.Info("Processing find invoice by ID", zap.String("invoiceID", id.String()))
+
+	invoice, err := uc.InvoiceRepository.FindByID(id)
+	if err != nil || invoice == nil {
+		uc.Logger.Error("Invoice not found", zap.String("invoiceID", id.String()), zap.Error(err))
+		return nil, errors.New("invoice not found")
+	}
+
+	return persistence.InvoicePersistence{}.ToEntity(invoice), nil
+}
@@ -0,0 +1,34 @@
+package invoice
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindInvoiceByOrderId busca a nota emitida para uma order
+type FindInvoiceByOrderId struct {
+	InvoiceRepository repository.InvoiceRepository
+	Logger            logger.Logger
+}
+
+func (uc *FindInvoiceByOrderId) Process(orderID uuid.UUID) (*domain.Invoice, error) {
+	uc.Logger.Info("Processing find invoice by order ID", zap.String("orderID", orderID.String()))
+
+	invoice, err := uc.InvoiceRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding invoice", zap.String("orderID", orderID.String()), zap.Error(err))
+		return nil, err
+	}
+	if invoice == nil {
+		uc.Logger.Error("Invoice not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("invoice not found")
+	}
+
+	return persistence.InvoicePersistence{}.ToEntity(invoice), nil
+}
@@ -0,0 +1,133 @@
+package invoice
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// IssueCreditNote emite uma nota de crédito para corrigir uma nota já emitida. A soma das notas
+// de crédito nunca ultrapassa o total da nota original.
+type IssueCreditNote struct {
+	InvoiceRepository repository.InvoiceRepository
+	WorkshopCode      string
+	Logger            logger.Logger
+	UnitOfWork        repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *IssueCreditNote) WithRepositories(repos repository.Repositories) *IssueCreditNote {
+	if uc == nil {
+		return nil
+	}
+	return &IssueCreditNote{
+		InvoiceRepository: repos.Invoices,
+		WorkshopCode:      uc.WorkshopCode,
+		Logger:            uc.Logger,
+		UnitOfWork:        repos.UnitOfWork,
+	}
+}
+
+// ValidateReason exige o motivo, que fica registrado no documento
+func (uc *IssueCreditNote) ValidateReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		uc.Logger.Error("Credit note reason is required")
+		return "", errors.New("credit note reason is required")
+	}
+	return reason, nil
+}
+
+// ResolveAmount estorna o saldo restante quando o valor não é informado e rejeita valores acima dele
+func (uc *IssueCreditNote) ResolveAmount(invoice *domain.Invoice, amount *money.Money) (money.Money, error) {
+	balance := invoice.CreditableAmount()
+	if !balance.IsPositive() {
+		uc.Logger.Error("Invoice is fully credited", zap.String("invoiceID", invoice.ID.String()))
+		return money.Zero, errors.New("invoice is fully credited")
+	}
+
+	if amount == nil {
+		return balance, nil
+	}
+	if !amount.IsPositive() {
+		uc.Logger.Error("Invalid credit note amount", zap.Stringer("amount", *amount))
+		return money.Zero, errors.New("credit note amount must be greater than zero")
+	}
+	if amount.GreaterThan(balance) {
+		uc.Logger.Error("Credit note exceeds invoice balance",
+			zap.String("invoiceID", invoice.ID.String()),
+			zap.Stringer("amount", *amount),
+			zap.Stringer("balance", balance))
+		return money.Zero, errors.New("credit note exceeds invoice balance")
+	}
+	return *amount, nil
+}
+
+func (uc *IssueCreditNote) Process(invoiceID uuid.UUID, amount *money.Money, reason string, userID *uuid.UUID) (*domain.CreditNote, error) {
+	uc.Logger.Info("Processing issue credit note", zap.String("invoiceID", invoiceID.String()))
+
+	reason, err := uc.ValidateReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	var creditNote *domain.CreditNote
+	err = uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		// Reservar o número primeiro bloqueia a sequência de notas de crédito da oficina; assim o
+		// saldo lido a seguir não muda até o commit e duas emissões simultâneas não estornam em dobro
+		number, err := tx.InvoiceRepository.NextNumber(uc.WorkshopCode, domain.DocumentTypeCreditNote)
+		if err != nil {
+			uc.Logger.Error("Database error reserving credit note number", zap.Error(err))
+			return err
+		}
+
+		model, err := tx.InvoiceRepository.FindByID(invoiceID)
+		if err != nil || model == nil {
+			uc.Logger.Error("Invoice not found", zap.String("invoiceID", invoiceID.String()))
+			return errors.New("invoice not found")
+		}
+		invoice := persistence.InvoicePersistence{}.ToEntity(model)
+
+		resolved, err := tx.ResolveAmount(invoice, amount)
+		if err != nil {
+			return err
+		}
+
+		creditNote = &domain.CreditNote{
+			ID:              uuid.New(),
+			InvoiceID:       invoice.ID,
+			WorkshopCode:    uc.WorkshopCode,
+			Number:          number,
+			FormattedNumber: domain.FormatNumber(uc.WorkshopCode, domain.DocumentTypeCreditNote, number),
+			Reason:          reason,
+			Amount:          resolved,
+			CreatedByUserID: userID,
+			IssuedAt:        time.Now(),
+		}
+		if err := tx.InvoiceRepository.CreateCreditNote(persistence.CreditNotePersistence{}.ToModel(creditNote)); err != nil {
+			uc.Logger.Error("Database error creating credit note", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info("Credit note issued successfully",
+		zap.String("invoiceID", invoiceID.String()),
+		zap.String("creditNoteNumber", creditNote.FormattedNumber),
+		zap.Stringer("amount", creditNote.Amount))
+
+	return creditNote, nil
+}
@@ -0,0 +1,187 @@
+package invoice
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestIssueCreditNote_Process_CreditsRemainingBalanceByDefault(t *testing.T) {
+	// Arrange
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	invoiceID := uuid.New()
+	invoiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{
+			ID:           id,
+			WorkshopCode: "MAIN",
+			Number:       1,
+			TotalPrice:   money.MustParse("253.05"),
+			CreditNotes: []models.CreditNote{
+				{ID: uuid.New(), InvoiceID: id, Number: 1, Amount: money.MustParse("50.00")},
+			},
+		}, nil
+	}
+
+	var requestedType string
+	invoiceRepoMock.NextNumberFunc = func(workshopCode string, documentType string) (int64, error) {
+		requestedType = documentType
+		return 2, nil
+	}
+	var created *models.CreditNote
+	invoiceRepoMock.CreateCreditNoteFunc = func(creditNote *models.CreditNote) error {
+		created = creditNote
+		return nil
+	}
+
+	useCase := &IssueCreditNote{
+		WorkshopCode: "MAIN",
+		Logger:       loggerMock,
+		UnitOfWork:   &mocks.UnitOfWorkMock{Repositories: repository.Repositories{Invoices: invoiceRepoMock}},
+	}
+
+	// Act
+	result, err := useCase.Process(invoiceID, nil, "Serviço refeito em garantia", nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requestedType != domain.DocumentTypeCreditNote {
+		t.Errorf("Expected credit note sequence, got %s", requestedType)
+	}
+	if created == nil || !created.Amount.Equal(money.MustParse("203.05")) {
+		t.Fatalf("Expected remaining balance of 203.05 to be credited, got %v", created)
+	}
+	if result.FormattedNumber != "MAIN-CN-000002" || created.InvoiceID != invoiceID {
+		t.Errorf("Expected MAIN-CN-000002 for the invoice, got %s", result.FormattedNumber)
+	}
+}
+
+func TestIssueCreditNote_Process_RejectsAmountAboveBalance(t *testing.T) {
+	// Arrange
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	invoiceID := uuid.New()
+	invoiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{
+			ID:           id,
+			WorkshopCode: "MAIN",
+			Number:       1,
+			TotalPrice:   money.MustParse("100.00"),
+			CreditNotes: []models.CreditNote{
+				{ID: uuid.New(), InvoiceID: id, Number: 1, Amount: money.MustParse("60.00")},
+			},
+		}, nil
+	}
+	invoiceRepoMock.CreateCreditNoteFunc = func(creditNote *models.CreditNote) error {
+		t.Fatal("Expected no credit note to be created")
+		return nil
+	}
+
+	useCase := &IssueCreditNote{
+		WorkshopCode: "MAIN",
+		Logger:       loggerMock,
+		UnitOfWork:   &mocks.UnitOfWorkMock{Repositories: repository.Repositories{Invoices: invoiceRepoMock}},
+	}
+
+	amount := money.MustParse("40.01")
+
+	// Act
+	_, err := useCase.Process(invoiceID, &amount, "Desconto concedido", nil)
+
+	// Assert
+	if err == nil || err.Error() != "credit note exceeds invoice balance" {
+		t.Errorf("Expected 'credit note exceeds invoice balance', got %v", err)
+	}
+}
+
+func TestIssueCreditNote_Process_RejectsFullyCreditedInvoice(t *testing.T) {
+	// Arrange
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	invoiceID := uuid.New()
+	invoiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{
+			ID:           id,
+			WorkshopCode: "MAIN",
+			Number:       1,
+			TotalPrice:   money.MustParse("100.00"),
+			CreditNotes: []models.CreditNote{
+				{ID: uuid.New(), InvoiceID: id, Number: 1, Amount: money.MustParse("60.00")},
+				{ID: uuid.New(), InvoiceID: id, Number: 2, Amount: money.MustParse("40.00")},
+			},
+		}, nil
+	}
+
+	useCase := &IssueCreditNote{
+		WorkshopCode: "MAIN",
+		Logger:       loggerMock,
+		UnitOfWork:   &mocks.UnitOfWorkMock{Repositories: repository.Repositories{Invoices: invoiceRepoMock}},
+	}
+
+	// Act
+	_, err := useCase.Process(invoiceID, nil, "Estorno", nil)
+
+	// Assert
+	if err == nil || err.Error() != "invoice is fully credited" {
+		t.Errorf("Expected 'invoice is fully credited', got %v", err)
+	}
+}
+
+func TestIssueCreditNote_Process_ValidatesReasonAndAmount(t *testing.T) {
+	// Arrange
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	invoiceID := uuid.New()
+	invoiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{
+			ID:           id,
+			WorkshopCode: "MAIN",
+			Number:       1,
+			TotalPrice:   money.MustParse("100.00"),
+		}, nil
+	}
+
+	useCase := &IssueCreditNote{
+		WorkshopCode: "MAIN",
+		Logger:       loggerMock,
+		UnitOfWork:   &mocks.UnitOfWorkMock{Repositories: repository.Repositories{Invoices: invoiceRepoMock}},
+	}
+
+	// Act & Assert
+	if _, err := useCase.Process(invoiceID, nil, "  ", nil); err == nil || err.Error() != "credit note reason is required" {
+		t.Errorf("Expected 'credit note reason is required', got %v", err)
+	}
+
+	zero := money.Zero
+	if _, err := useCase.Process(invoiceID, &zero, "Estorno", nil); err == nil || err.Error() != "credit note amount must be greater than zero" {
+		t.Errorf("Expected 'credit note amount must be greater than zero', got %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package invoice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	quoteDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
+	"go.uber.org/zap"
+)
+
+// IssueInvoice emite a nota de serviço de uma order concluída. As linhas vêm do orçamento aprovado
+// e, em orders sem orçamento versionado, do estado atual da order. Cliente e veículo são copiados
+// para que alterações posteriores no cadastro não mudem a nota.
+type IssueInvoice struct {
+	OrderRepository    repository.OrderRepository
+	CustomerRepository repository.CustomerRepository
+	VehicleRepository  repository.VehicleRepository
+	QuoteRepository    repository.QuoteRepository
+	InvoiceRepository  repository.InvoiceRepository
+	SnapshotOrderItems *quote.SnapshotOrderItems
+	// WorkshopCode identifica a oficina emissora e a sequência de numeração usada
+	WorkshopCode string
+	Logger       logger.Logger
+	UnitOfWork   repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *IssueInvoice) WithRepositories(repos repository.Repositories) *IssueInvoice {
+	if uc == nil {
+		return nil
+	}
+	return &IssueInvoice{
+		OrderRepository:    repos.Orders,
+		CustomerRepository: repos.Customers,
+		VehicleRepository:  repos.Vehicles,
+		QuoteRepository:    repos.Quotes,
+		InvoiceRepository:  repos.Invoices,
+		SnapshotOrderItems: uc.SnapshotOrderItems.WithRepositories(repos),
+		WorkshopCode:       uc.WorkshopCode,
+		Logger:             uc.Logger,
+		UnitOfWork:         repos.UnitOfWork,
+	}
+}
+
+// FetchOrderFromDB busca a order e garante que o serviço já foi concluído
+func (uc *IssueInvoice) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	if order.Status != orderDomain.StatusCompleted && order.Status != orderDomain.StatusDelivered {
+		uc.Logger.Error("Order is not completed, invoice cannot be issued",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return nil, errors.New("order is not completed")
+	}
+
+	return order, nil
+}
+
+// FetchExistingInvoice retorna a nota já emitida para a order, ou nil quando ainda não existe
+func (uc *IssueInvoice) FetchExistingInvoice(orderID uuid.UUID) (*domain.Invoice, error) {
+	existing, err := uc.InvoiceRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding invoice", zap.Error(err), zap.String("orderID", orderID.String()))
+		return nil, err
+	}
+	return persistence.InvoicePersistence{}.ToEntity(existing), nil
+}
+
+// SnapshotParties copia os dados do cliente e do veículo da order
+func (uc *IssueInvoice) SnapshotParties(order *models.Order) (domain.CustomerSnapshot, domain.VehicleSnapshot, error) {
+	customer, err := uc.CustomerRepository.FindByID(order.CustomerID)
+	if err != nil || customer == nil {
+		uc.Logger.Error("Customer not found", zap.String("customerID", order.CustomerID.String()))
+		return domain.CustomerSnapshot{}, domain.VehicleSnapshot{}, errors.New("customer not found")
+	}
+
+	vehicle, err := uc.VehicleRepository.FindByID(order.VehicleID)
+	if err != nil || vehicle == nil {
+		uc.Logger.Error("Vehicle not found", zap.String("vehicleID", order.VehicleID.String()))
+		return domain.CustomerSnapshot{}, domain.VehicleSnapshot{}, errors.New("vehicle not found")
+	}
+
+	return domain.CustomerSnapshot{
+		ID:             customer.ID,
+		Name:           customer.Name,
+		DocumentNumber: customer.DocumentNumber,
+		CustomerType:   customer.CustomerType,
+	}, domain.VehicleSnapshot{
+		ID:                          vehicle.ID,
+		Model:                       vehicle.Model,
+		Brand:                       vehicle.Brand,
+		ReleaseYear:                 vehicle.ReleaseYear,
+		VehicleIdentificationNumber: vehicle.VehicleIdentificationNumber,
+		NumberPlate:                 vehicle.NumberPlate,
+		Color:                       vehicle.Color,
+	}, nil
+}
+
+// BuildLines monta as linhas e os totais da nota. Com orçamento aprovado, a nota reproduz a versão
+// aceita pelo cliente; sem ele, usa as peças, a mão de obra e os ajustes atuais da order.
+func (uc *IssueInvoice) BuildLines(order *models.Order, invoice *domain.Invoice) error {
+	if order.ApprovedQuoteID != nil {
+		approved, err := uc.QuoteRepository.FindByID(*order.ApprovedQuoteID)
+		if err != nil {
+			uc.Logger.Error("Approved quote not found",
+				zap.String("orderID", order.ID.String()),
+				zap.String("quoteID", order.ApprovedQuoteID.String()),
+				zap.Error(err))
+			return errors.New("quote not found")
+		}
+
+		q := persistence.QuotePersistence{}.ToEntity(approved)
+		invoice.QuoteID = &q.ID
+		invoice.Items = invoiceItems(q.Items)
+		invoice.PartsSubtotal = q.PartsSubtotal
+		invoice.LaborSubtotal = q.LaborSubtotal
+		invoice.DiscountTotal = q.DiscountTotal
+		invoice.SurchargeTotal = q.SurchargeTotal
+		invoice.TaxTotal = q.TaxTotal
+		invoice.TotalPrice = q.TotalPrice
+		return nil
+	}
+
+	items, breakdown, err := uc.SnapshotOrderItems.ProcessWithBreakdown(order.ID)
+	if err != nil {
+		return err
+	}
+	invoice.Items = invoiceItems(items)
+	invoice.PartsSubtotal = breakdown.PartsSubtotal
+	invoice.LaborSubtotal = breakdown.LaborSubtotal
+	invoice.DiscountTotal = breakdown.DiscountTotal
+	invoice.SurchargeTotal = breakdown.SurchargeTotal
+	invoice.TaxTotal = breakdown.TaxTotal
+	invoice.TotalPrice = breakdown.TotalPrice
+	return nil
+}
+
+func invoiceItems(items []quoteDomain.QuoteItem) []domain.InvoiceItem {
+	result := make([]domain.InvoiceItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, domain.InvoiceItem{
+			ID:          uuid.New(),
+			ItemType:    item.ItemType,
+			ReferenceID: item.ReferenceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+		})
+	}
+	return result
+}
+
+// Process emite a nota da order. A emissão é idempotente: se a order já tem nota, ela é retornada
+// sem consumir um novo número.
+func (uc *IssueInvoice) Process(orderID uuid.UUID, userID *uuid.UUID) (*domain.Invoice, error) {
+	uc.Logger.Info("Processing issue invoice",
+		zap.String("orderID", orderID.String()),
+		zap.String("workshopCode", uc.WorkshopCode))
+
+	// O número é reservado e a nota gravada na mesma transação, para que uma falha não deixe lacunas
+	var invoice *domain.Invoice
+	issued := false
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		order, err := tx.FetchOrderFromDB(orderID)
+		if err != nil {
+			return err
+		}
+
+		invoice, err = tx.FetchExistingInvoice(orderID)
+		if err != nil || invoice != nil {
+			return err
+		}
+
+		invoice = &domain.Invoice{
+			ID:              uuid.New(),
+			WorkshopCode:    uc.WorkshopCode,
+			OrderID:         orderID,
+			CreatedByUserID: userID,
+			CreditNotes:     []domain.CreditNote{},
+			IssuedAt:        time.Now(),
+		}
+
+		invoice.Customer, invoice.Vehicle, err = tx.SnapshotParties(order)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.BuildLines(order, invoice); err != nil {
+			return err
+		}
+
+		invoice.Number, err = tx.InvoiceRepository.NextNumber(uc.WorkshopCode, domain.DocumentTypeInvoice)
+		if err != nil {
+			uc.Logger.Error("Database error reserving invoice number", zap.Error(err))
+			return err
+		}
+		invoice.FormattedNumber = domain.FormatNumber(uc.WorkshopCode, domain.DocumentTypeInvoice, invoice.Number)
+
+		if err := tx.InvoiceRepository.Create(persistence.InvoicePersistence{}.ToModel(invoice)); err != nil {
+			uc.Logger.Error("Database error creating invoice", zap.Error(err))
+			return err
+		}
+		issued = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !issued {
+		uc.Logger.Info("Order already has an invoice",
+			zap.String("orderID", orderID.String()),
+			zap.String("invoiceNumber", invoice.FormattedNumber))
+		return invoice, nil
+	}
+
+	uc.Logger.Info("Invoice issued successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("invoiceID", invoice.ID.String()),
+		zap.String("invoiceNumber", invoice.FormattedNumber),
+		zap.Stringer("totalPrice", invoice.TotalPrice))
+
+	return invoice, nil
+}
@@ -0,0 +1,274 @@
+package invoice
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
+	"go.uber.org/zap"
+)
+
+func TestIssueInvoice_Process_CopiesApprovedQuoteAndSnapshots(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	quoteID := uuid.New()
+	order := &models.Order{ID: uuid.New(), CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "Completed", ApprovedQuoteID: &quoteID}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return order, nil
+	}
+	customerRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Customer, error) {
+		return &models.Customer{ID: id, Name: "Maria Souza", DocumentNumber: "12345678900", CustomerType: "individual"}, nil
+	}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: id, Brand: "Fiat", Model: "Uno", ReleaseYear: 2015, NumberPlate: "ABC1D23"}, nil
+	}
+	quoteRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Quote, error) {
+		return &models.Quote{
+			ID:            id,
+			OrderID:       order.ID,
+			PartsSubtotal: money.MustParse("91.00"),
+			LaborSubtotal: money.MustParse("150.00"),
+			TaxTotal:      money.MustParse("12.05"),
+			TotalPrice:    money.MustParse("253.05"),
+			Items: []models.QuoteItem{
+				{ID: uuid.New(), QuoteID: id, ItemType: "part", ReferenceID: uuid.New(), Description: "Pastilha", Quantity: 2, UnitPrice: money.MustParse("45.50"), TotalPrice: money.MustParse("91.00")},
+				{ID: uuid.New(), QuoteID: id, ItemType: "labor", ReferenceID: uuid.New(), Description: "Troca", Quantity: 1.5, UnitPrice: money.MustParse("100"), TotalPrice: money.MustParse("150.00")},
+			},
+		}, nil
+	}
+
+	var requestedType string
+	invoiceRepoMock.NextNumberFunc = func(workshopCode string, documentType string) (int64, error) {
+		requestedType = documentType
+		return 42, nil
+	}
+	var created *models.Invoice
+	invoiceRepoMock.CreateFunc = func(invoice *models.Invoice) error {
+		created = invoice
+		return nil
+	}
+
+	useCase := &IssueInvoice{
+		SnapshotOrderItems: &quote.SnapshotOrderItems{Logger: loggerMock},
+		WorkshopCode:       "MAIN",
+		Logger:             loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:    orderRepoMock,
+			Customers: customerRepoMock,
+			Vehicles:  vehicleRepoMock,
+			Quotes:    quoteRepoMock,
+			Invoices:  invoiceRepoMock,
+		}},
+	}
+
+	userID := uuid.New()
+
+	// Act
+	result, err := useCase.Process(order.ID, &userID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created == nil {
+		t.Fatal("Expected invoice to be saved")
+	}
+	if requestedType != domain.DocumentTypeInvoice {
+		t.Errorf("Expected invoice sequence, got %s", requestedType)
+	}
+	if result.FormattedNumber != "MAIN-INV-000042" || created.Number != 42 {
+		t.Errorf("Expected number MAIN-INV-000042, got %s", result.FormattedNumber)
+	}
+	if result.QuoteID == nil || *result.QuoteID != quoteID {
+		t.Error("Expected invoice to reference the approved quote")
+	}
+	if len(created.Items) != 2 {
+		t.Fatalf("Expected 2 items copied from the quote, got %d", len(created.Items))
+	}
+	for _, item := range created.Items {
+		if item.InvoiceID != created.ID {
+			t.Error("Expected every item to reference the invoice")
+		}
+	}
+	if !created.TotalPrice.Equal(money.MustParse("253.05")) || !created.TaxTotal.Equal(money.MustParse("12.05")) {
+		t.Errorf("Expected totals copied from the quote, got total %s and tax %s", created.TotalPrice, created.TaxTotal)
+	}
+	if created.CustomerName != "Maria Souza" || created.VehicleNumberPlate != "ABC1D23" {
+		t.Errorf("Expected customer and vehicle snapshot, got %s / %s", created.CustomerName, created.VehicleNumberPlate)
+	}
+	if created.CreatedByUserID == nil || *created.CreatedByUserID != userID {
+		t.Error("Expected invoice to record the issuing user")
+	}
+}
+
+func TestIssueInvoice_Process_ReturnsExistingInvoiceWithoutNewNumber(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	existingID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "Delivered"}, nil
+	}
+	invoiceRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{ID: existingID, WorkshopCode: "MAIN", Number: 7, OrderID: orderID}, nil
+	}
+	invoiceRepoMock.NextNumberFunc = func(workshopCode string, documentType string) (int64, error) {
+		t.Fatal("Expected no number to be reserved for an already invoiced order")
+		return 0, nil
+	}
+	invoiceRepoMock.CreateFunc = func(invoice *models.Invoice) error {
+		t.Fatal("Expected no invoice to be created")
+		return nil
+	}
+
+	useCase := &IssueInvoice{
+		SnapshotOrderItems: &quote.SnapshotOrderItems{Logger: loggerMock},
+		WorkshopCode:       "MAIN",
+		Logger:             loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:   orderRepoMock,
+			Invoices: invoiceRepoMock,
+		}},
+	}
+
+	// Act
+	result, err := useCase.Process(orderID, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.ID != existingID || result.FormattedNumber != "MAIN-INV-000007" {
+		t.Errorf("Expected existing invoice MAIN-INV-000007, got %s", result.FormattedNumber)
+	}
+}
+
+func TestIssueInvoice_Process_FallsBackToOrderItemsWithoutQuote(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "Completed"}, nil
+	}
+	customerRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Customer, error) {
+		return &models.Customer{ID: id, Name: "Maria Souza", DocumentNumber: "12345678900", CustomerType: "individual"}, nil
+	}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: id, Brand: "Fiat", Model: "Uno", ReleaseYear: 2015, NumberPlate: "ABC1D23"}, nil
+	}
+	orderInputRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: orderID, InputID: uuid.New(), Quantity: 3, UnitPrice: money.MustParse("0.10"), TotalPrice: money.MustParse("0.30")},
+		}, nil
+	}
+	invoiceRepoMock.NextNumberFunc = func(workshopCode string, documentType string) (int64, error) {
+		return 42, nil
+	}
+	var created *models.Invoice
+	invoiceRepoMock.CreateFunc = func(invoice *models.Invoice) error {
+		created = invoice
+		return nil
+	}
+
+	useCase := &IssueInvoice{
+		SnapshotOrderItems: &quote.SnapshotOrderItems{Logger: loggerMock},
+		WorkshopCode:       "MAIN",
+		Logger:             loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:        orderRepoMock,
+			Customers:     customerRepoMock,
+			Vehicles:      vehicleRepoMock,
+			Invoices:      invoiceRepoMock,
+			OrderInputs:   orderInputRepoMock,
+			Inputs:        &mocks.InputRepositoryMock{},
+			OrderServices: &mocks.OrderServiceRepositoryMock{},
+			LaborServices: &mocks.LaborServiceRepositoryMock{},
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.QuoteID != nil {
+		t.Error("Expected no quote reference for an order without approved quote")
+	}
+	if len(created.Items) != 1 || !created.TotalPrice.Equal(money.MustParse("0.30")) {
+		t.Errorf("Expected the order parts to be invoiced for 0.30, got %d items and %s", len(created.Items), created.TotalPrice)
+	}
+}
+
+func TestIssueInvoice_Process_RejectsOrderNotCompleted(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "In progress"}, nil
+	}
+	invoiceRepoMock.NextNumberFunc = func(workshopCode string, documentType string) (int64, error) {
+		t.Fatal("Expected no number to be reserved")
+		return 0, nil
+	}
+
+	useCase := &IssueInvoice{
+		SnapshotOrderItems: &quote.SnapshotOrderItems{Logger: loggerMock},
+		WorkshopCode:       "MAIN",
+		Logger:             loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:   orderRepoMock,
+			Invoices: invoiceRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), nil)
+
+	// Assert
+	if err == nil || err.Error() != "order is not completed" {
+		t.Errorf("Expected 'order is not completed', got %v", err)
+	}
+}
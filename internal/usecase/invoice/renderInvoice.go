@@ -0,0 +1,132 @@
+package invoice
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/invoice"
+	quoteDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/document"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// itemTypeLabels traduz o tipo da linha para a descrição impressa
+var itemTypeLabels = map[string]string{
+	quoteDomain.ItemTypePart:      "Peça",
+	quoteDomain.ItemTypeLabor:     "Mão de obra",
+	quoteDomain.ItemTypeDiscount:  "Desconto",
+	quoteDomain.ItemTypeSurcharge: "Acréscimo",
+}
+
+// ItemDescription monta a descrição impressa de uma linha de nota ou orçamento, com o tipo traduzido
+func ItemDescription(itemType string, description string) string {
+	label := itemTypeLabels[itemType]
+	if description == "" {
+		return label
+	}
+	return label + " - " + description
+}
+
+// RenderInvoice gera a versão imprimível da nota em HTML ou PDF, sem serviços externos
+type RenderInvoice struct {
+	FindInvoiceById *FindInvoiceById
+	// Workshop identifica a oficina no cabeçalho do documento
+	Workshop document.Workshop
+	Logger   logger.Logger
+}
+
+// BuildDocument monta o documento impresso a partir da nota e das notas de crédito emitidas
+func (uc *RenderInvoice) BuildDocument(invoice *domain.Invoice) document.Document {
+	rows := make([][]string, 0, len(invoice.Items))
+	for _, item := range invoice.Items {
+		rows = append(rows, []string{
+			ItemDescription(item.ItemType, item.Description),
+			document.FormatQuantity(item.Quantity),
+			document.FormatMoney(item.UnitPrice),
+			document.FormatMoney(item.TotalPrice),
+		})
+	}
+
+	totals := []document.Field{
+		{Label: "Peças", Value: document.FormatMoney(invoice.PartsSubtotal)},
+		{Label: "Mão de obra", Value: document.FormatMoney(invoice.LaborSubtotal)},
+	}
+	if !invoice.DiscountTotal.IsZero() {
+		totals = append(totals, document.Field{Label: "Descontos", Value: document.FormatMoney(invoice.DiscountTotal.Neg())})
+	}
+	if !invoice.SurchargeTotal.IsZero() {
+		totals = append(totals, document.Field{Label: "Acréscimos", Value: document.FormatMoney(invoice.SurchargeTotal)})
+	}
+	if !invoice.TaxTotal.IsZero() {
+		totals = append(totals, document.Field{Label: "Impostos", Value: document.FormatMoney(invoice.TaxTotal)})
+	}
+	totals = append(totals, document.Field{Label: "Total", Value: document.FormatMoney(invoice.TotalPrice)})
+
+	notes := []string{}
+	for _, creditNote := range invoice.CreditNotes {
+		notes = append(notes, fmt.Sprintf("Nota de crédito %s de %s em %s: %s",
+			creditNote.FormattedNumber,
+			document.FormatMoney(creditNote.Amount),
+			creditNote.IssuedAt.Format("02/01/2006"),
+			creditNote.Reason))
+	}
+	if len(invoice.CreditNotes) > 0 {
+		notes = append(notes, "Saldo após notas de crédito: "+document.FormatMoney(invoice.CreditableAmount()))
+	}
+
+	return document.Document{
+		Workshop: uc.Workshop,
+		Title:    "Nota de serviço",
+		Number:   invoice.FormattedNumber,
+		IssuedAt: invoice.IssuedAt,
+		Sections: []document.Section{
+			{
+				Title: "Cliente",
+				Fields: []document.Field{
+					{Label: "Nome", Value: invoice.Customer.Name},
+					{Label: "Documento", Value: invoice.Customer.DocumentNumber},
+				},
+			},
+			{
+				Title: "Veículo",
+				Fields: []document.Field{
+					{Label: "Modelo", Value: fmt.Sprintf("%s %s %d", invoice.Vehicle.Brand, invoice.Vehicle.Model, invoice.Vehicle.ReleaseYear)},
+					{Label: "Placa", Value: invoice.Vehicle.NumberPlate},
+					{Label: "Chassi", Value: invoice.Vehicle.VehicleIdentificationNumber},
+				},
+			},
+		},
+		Table: document.Table{
+			Headers: []string{"Descrição", "Qtd.", "Unitário", "Total"},
+			Rows:    rows,
+		},
+		Totals: totals,
+		Notes:  notes,
+	}
+}
+
+// Process retorna o conteúdo gerado e o Content-Type correspondente ao formato
+func (uc *RenderInvoice) Process(id uuid.UUID, format string) ([]byte, string, error) {
+	uc.Logger.Info("Processing render invoice",
+		zap.String("invoiceID", id.String()),
+		zap.String("format", format))
+
+	if !document.IsValidFormat(format) {
+		uc.Logger.Error("Invalid document format", zap.String("format", format))
+		return nil, "", document.ErrInvalidFormat
+	}
+
+	invoice, err := uc.FindInvoiceById.Process(id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := document.Render(uc.BuildDocument(invoice), format)
+	if err != nil {
+		uc.Logger.Error("Error rendering invoice", zap.Error(err))
+		return nil, "", err
+	}
+
+	return content, document.ContentType(format), nil
+}
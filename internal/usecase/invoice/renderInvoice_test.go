@@ -0,0 +1,98 @@
+package invoice
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestRenderInvoice_Process(t *testing.T) {
+	// Arrange
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	invoiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{
+			ID:                 id,
+			WorkshopCode:       "MAIN",
+			Number:             42,
+			CustomerName:       "João (Oficina)",
+			VehicleBrand:       "Fiat",
+			VehicleModel:       "Uno",
+			VehicleNumberPlate: "ABC1D23",
+			PartsSubtotal:      money.MustParse("1234.50"),
+			TotalPrice:         money.MustParse("1234.50"),
+			Items: []models.InvoiceItem{
+				{ID: uuid.New(), InvoiceID: id, ItemType: "part", Description: "Pastilha de freio", Quantity: 2, UnitPrice: money.MustParse("617.25"), TotalPrice: money.MustParse("1234.50")},
+			},
+			CreditNotes: []models.CreditNote{
+				{ID: uuid.New(), InvoiceID: id, WorkshopCode: "MAIN", Number: 3, Reason: "Garantia", Amount: money.MustParse("100"), IssuedAt: time.Now()},
+			},
+			IssuedAt: time.Now(),
+		}, nil
+	}
+
+	useCase := &RenderInvoice{
+		FindInvoiceById: &FindInvoiceById{InvoiceRepository: invoiceRepoMock, Logger: loggerMock},
+		Logger:          loggerMock,
+	}
+
+	t.Run("html", func(t *testing.T) {
+		// Act
+		content, contentType, err := useCase.Process(uuid.New(), "html")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.HasPrefix(contentType, "text/html") {
+			t.Errorf("Expected HTML content type, got %s", contentType)
+		}
+		for _, expected := range []string{"MAIN-INV-000042", "R$ 1.234,50", "MAIN-CN-000003", "Saldo após notas de crédito: R$ 1.134,50"} {
+			if !strings.Contains(string(content), expected) {
+				t.Errorf("Expected HTML to contain %q", expected)
+			}
+		}
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		// Act
+		content, contentType, err := useCase.Process(uuid.New(), "pdf")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if contentType != "application/pdf" {
+			t.Errorf("Expected PDF content type, got %s", contentType)
+		}
+		if !bytes.HasPrefix(content, []byte("%PDF-1.4")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+			t.Error("Expected a complete PDF document")
+		}
+		// Parênteses do texto são escapados para não encerrar a string do PDF
+		if !bytes.Contains(content, []byte(`\(Oficina\)`)) {
+			t.Error("Expected parentheses in text to be escaped")
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		// Act
+		_, _, err := useCase.Process(uuid.New(), "docx")
+
+		// Assert
+		if err == nil || err.Error() != "invalid document format" {
+			t.Errorf("Expected 'invalid document format', got %v", err)
+		}
+	})
+}
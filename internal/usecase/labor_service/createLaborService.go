@@ -0,0 +1,64 @@
+package labor_service
+
+import (
+	"errors"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type CreateLaborService struct {
+	LaborServiceRepository repository.LaborServiceRepository
+	Logger                 logger.Logger
+}
+
+// ValidateLaborServiceNameUniqueness verifica se o nome do serviço é único no catálogo
+func (uc *CreateLaborService) ValidateLaborServiceNameUniqueness(name string) error {
+	_, err := uc.LaborServiceRepository.FindByName(name)
+	if err == nil {
+		uc.Logger.Error("Labor service name already exists", zap.String("name", name))
+		return errors.New("labor service name already exists")
+	} else if err != gorm.ErrRecordNotFound {
+		uc.Logger.Error("Error checking labor service name uniqueness", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Labor service name is unique", zap.String("name", name))
+	return nil
+}
+
+// SaveLaborServiceToDB salva o serviço no banco de dados
+func (uc *CreateLaborService) SaveLaborServiceToDB(model *models.LaborService) error {
+	err := uc.LaborServiceRepository.Create(model)
+	if err != nil {
+		uc.Logger.Error("Database error creating labor service", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Labor service created in database",
+		zap.String("id", model.ID.String()),
+		zap.String("name", model.Name))
+	return nil
+}
+
+func (uc *CreateLaborService) Process(entity *domain.LaborService) error {
+	uc.Logger.Info("Processing labor service creation",
+		zap.String("name", entity.Name),
+		zap.Float64("defaultHours", entity.DefaultHours),
+		zap.Stringer("hourlyRate", entity.HourlyRate))
+
+	// Valida unicidade do nome
+	if err := uc.ValidateLaborServiceNameUniqueness(entity.Name); err != nil {
+		return err
+	}
+
+	// Mapeia entidade para modelo usando persistence
+	model := persistence.LaborServicePersistence{}.ToModel(entity)
+
+	return uc.SaveLaborServiceToDB(model)
+}
@@ -0,0 +1,82 @@
+package labor_service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestCreateLaborService_Process_Success(t *testing.T) {
+	// Arrange
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	laborServiceRepoMock.FindByNameFunc = func(name string) (*models.LaborService, error) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	var created *models.LaborService
+	laborServiceRepoMock.CreateFunc = func(laborService *models.LaborService) error {
+		created = laborService
+		return nil
+	}
+
+	useCase := &CreateLaborService{
+		LaborServiceRepository: laborServiceRepoMock,
+		Logger:                 loggerMock,
+	}
+
+	entity := &domain.LaborService{ID: uuid.New(), Name: "Troca de pastilhas", DefaultHours: 1.5, HourlyRate: money.MustParse("110.0")}
+
+	// Act
+	err := useCase.Process(entity)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created == nil || created.ID != entity.ID || !created.HourlyRate.Equal(money.MustParse("110.0")) || created.DefaultHours != 1.5 {
+		t.Errorf("Expected labor service to be saved with catalog values, got %+v", created)
+	}
+}
+
+func TestCreateLaborService_Process_DuplicateName(t *testing.T) {
+	// Arrange
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	laborServiceRepoMock.FindByNameFunc = func(name string) (*models.LaborService, error) {
+		return &models.LaborService{ID: uuid.New(), Name: name}, nil
+	}
+	laborServiceRepoMock.CreateFunc = func(laborService *models.LaborService) error {
+		t.Error("Labor service should not be created")
+		return nil
+	}
+
+	useCase := &CreateLaborService{
+		LaborServiceRepository: laborServiceRepoMock,
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(&domain.LaborService{ID: uuid.New(), Name: "Alinhamento", DefaultHours: 1, HourlyRate: money.MustParse("80.0")})
+
+	// Assert
+	if err == nil || err.Error() != "labor service name already exists" {
+		t.Errorf("Expected 'labor service name already exists' error, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package labor_service
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+type DeleteByIdLaborService struct {
+	LaborServiceRepository repository.LaborServiceRepository
+	Logger                 logger.Logger
+}
+
+func (uc *DeleteByIdLaborService) Process(id uuid.UUID) error {
+	uc.Logger.Info("Processing delete labor service by ID", zap.String("id", id.String()))
+
+	err := uc.LaborServiceRepository.Delete(id)
+	if err != nil {
+		uc.Logger.Error("Database error deleting labor service", zap.Error(err), zap.String("id", id.String()))
+		return err
+	}
+
+	uc.Logger.Info("Labor service deleted successfully", zap.String("id", id.String()))
+	return nil
+}
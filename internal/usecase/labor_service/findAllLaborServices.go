@@ -0,0 +1,56 @@
+package labor_service
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+type FindAllLaborServices struct {
+	LaborServiceRepository repository.LaborServiceRepository
+	Logger                 logger.Logger
+}
+
+// ValidateQuery valida a paginação da listagem
+func (uc *FindAllLaborServices) ValidateQuery(spec *repository.QuerySpec) error {
+	if err := spec.Validate(); err != nil {
+		uc.Logger.Error("Invalid query", zap.Error(err), zap.Int("limit", spec.Limit))
+		return err
+	}
+	return nil
+}
+
+// FetchLaborServicesFromDB busca uma página do catálogo de serviços
+func (uc *FindAllLaborServices) FetchLaborServicesFromDB(spec repository.QuerySpec) (*repository.Page[models.LaborService], error) {
+	page, err := uc.LaborServiceRepository.FindAll(spec)
+	if err != nil {
+		uc.Logger.Error("Database error finding labor services", zap.Error(err))
+		return nil, err
+	}
+
+	uc.Logger.Info("Found labor services in database",
+		zap.Int("count", len(page.Items)),
+		zap.Int64("total", page.Total))
+	return page, nil
+}
+
+func (uc *FindAllLaborServices) Process(spec repository.QuerySpec) (*repository.Page[domain.LaborService], error) {
+	uc.Logger.Info("Processing find all labor services")
+
+	if err := uc.ValidateQuery(&spec); err != nil {
+		return nil, err
+	}
+
+	page, err := uc.FetchLaborServicesFromDB(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mapeia para o domínio usando persistence
+	return repository.MapPage(page, func(laborService *models.LaborService) domain.LaborService {
+		return *persistence.LaborServicePersistence{}.ToEntity(laborService)
+	}), nil
+}
@@ -0,0 +1,44 @@
+package labor_service
+
+import (
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+type FindByIdLaborService struct {
+	LaborServiceRepository repository.LaborServiceRepository
+	Logger                 logger.Logger
+}
+
+// FetchLaborServiceFromDB busca um serviço específico do catálogo
+func (uc *FindByIdLaborService) FetchLaborServiceFromDB(id uuid.UUID) (*models.LaborService, error) {
+	laborService, err := uc.LaborServiceRepository.FindByID(id)
+	if err != nil {
+		uc.Logger.Error("Database error finding labor service by ID", zap.Error(err), zap.String("id", id.String()))
+		return nil, err
+	}
+
+	uc.Logger.Info("Found labor service in database",
+		zap.String("id", laborService.ID.String()),
+		zap.String("name", laborService.Name))
+
+	return laborService, nil
+}
+
+func (uc *FindByIdLaborService) Process(id uuid.UUID) (*domain.LaborService, error) {
+	uc.Logger.Info("Processing find labor service by ID", zap.String("id", id.String()))
+
+	// Busca o serviço do banco
+	laborService, err := uc.FetchLaborServiceFromDB(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mapeia para o domínio usando persistence
+	return persistence.LaborServicePersistence{}.ToEntity(laborService), nil
+}
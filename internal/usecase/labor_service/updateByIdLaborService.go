@@ -0,0 +1,80 @@
+package labor_service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// UpdateByIdLaborService altera um serviço do catálogo. As orders já abertas mantêm o valor
+// da hora copiado quando o serviço foi adicionado.
+type UpdateByIdLaborService struct {
+	LaborServiceRepository repository.LaborServiceRepository
+	Logger                 logger.Logger
+}
+
+// FetchLaborServiceFromDB busca o serviço que será alterado
+func (uc *UpdateByIdLaborService) FetchLaborServiceFromDB(id uuid.UUID) (*models.LaborService, error) {
+	laborService, err := uc.LaborServiceRepository.FindByID(id)
+	if err != nil {
+		uc.Logger.Error("Database error finding labor service to update", zap.Error(err), zap.String("id", id.String()))
+		return nil, err
+	}
+	return laborService, nil
+}
+
+// ValidateLaborServiceNameUniqueness verifica se o nome do serviço é único (para update)
+func (uc *UpdateByIdLaborService) ValidateLaborServiceNameUniqueness(name string, laborServiceID uuid.UUID) error {
+	laborServiceWithSameName, err := uc.LaborServiceRepository.FindByName(name)
+	if err == nil && laborServiceWithSameName.ID != laborServiceID {
+		uc.Logger.Error("Labor service name already exists", zap.String("name", name))
+		return errors.New("labor service name already exists")
+	} else if err != nil && err != gorm.ErrRecordNotFound {
+		uc.Logger.Error("Error checking labor service name uniqueness", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// SaveLaborServiceToDB salva as alterações do serviço no banco de dados
+func (uc *UpdateByIdLaborService) SaveLaborServiceToDB(laborService *models.LaborService) error {
+	err := uc.LaborServiceRepository.Update(laborService)
+	if err != nil {
+		uc.Logger.Error("Database error updating labor service", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Labor service updated successfully", zap.String("id", laborService.ID.String()))
+	return nil
+}
+
+func (uc *UpdateByIdLaborService) Process(id uuid.UUID, entity *domain.LaborService) error {
+	uc.Logger.Info("Processing update labor service by ID",
+		zap.String("id", id.String()),
+		zap.String("name", entity.Name))
+
+	existing, err := uc.FetchLaborServiceFromDB(id)
+	if err != nil {
+		return err
+	}
+
+	// Verifica se o novo nome já existe (se foi alterado)
+	if entity.Name != existing.Name {
+		if err := uc.ValidateLaborServiceNameUniqueness(entity.Name, id); err != nil {
+			return err
+		}
+	}
+
+	existing.Name = entity.Name
+	existing.Description = entity.Description
+	existing.DefaultHours = entity.DefaultHours
+	existing.HourlyRate = entity.HourlyRate
+
+	return uc.SaveLaborServiceToDB(existing)
+}
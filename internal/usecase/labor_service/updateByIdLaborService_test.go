@@ -0,0 +1,88 @@
+package labor_service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/labor_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestUpdateByIdLaborService_Process_Success(t *testing.T) {
+	// Arrange
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	id := uuid.New()
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return &models.LaborService{ID: id, Name: "Alinhamento", DefaultHours: 1, HourlyRate: money.MustParse("80.0")}, nil
+	}
+	laborServiceRepoMock.FindByNameFunc = func(name string) (*models.LaborService, error) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	var updated *models.LaborService
+	laborServiceRepoMock.UpdateFunc = func(laborService *models.LaborService) error {
+		updated = laborService
+		return nil
+	}
+
+	useCase := &UpdateByIdLaborService{
+		LaborServiceRepository: laborServiceRepoMock,
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(id, &domain.LaborService{Name: "Alinhamento 3D", DefaultHours: 1.25, HourlyRate: money.MustParse("95.0")})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated == nil || updated.Name != "Alinhamento 3D" || !updated.HourlyRate.Equal(money.MustParse("95.0")) || updated.DefaultHours != 1.25 {
+		t.Errorf("Expected labor service to be updated, got %+v", updated)
+	}
+}
+
+func TestUpdateByIdLaborService_Process_NameTakenByAnotherService(t *testing.T) {
+	// Arrange
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	id := uuid.New()
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return &models.LaborService{ID: id, Name: "Alinhamento"}, nil
+	}
+	laborServiceRepoMock.FindByNameFunc = func(name string) (*models.LaborService, error) {
+		return &models.LaborService{ID: uuid.New(), Name: name}, nil
+	}
+	laborServiceRepoMock.UpdateFunc = func(laborService *models.LaborService) error {
+		t.Error("Labor service should not be updated")
+		return nil
+	}
+
+	useCase := &UpdateByIdLaborService{
+		LaborServiceRepository: laborServiceRepoMock,
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(id, &domain.LaborService{Name: "Balanceamento", DefaultHours: 1, HourlyRate: money.MustParse("80.0")})
+
+	// Assert
+	if err == nil || err.Error() != "labor service name already exists" {
+		t.Errorf("Expected 'labor service name already exists' error, got %v", err)
+	}
+}
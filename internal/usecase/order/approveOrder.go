@@ -0,0 +1,206 @@
+package order
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	statusHistory "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
+	quoteDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
+	"go.uber.org/zap"
+)
+
+// ApproveOrder registra a aprovação do cliente sobre uma versão específica do orçamento
+type ApproveOrder struct {
+	OrderRepository   repository.OrderRepository
+	UserRepository    repository.UserRepository
+	Logger            logger.Logger
+	UpdateOrderStatus *UpdateOrderStatus
+	UnitOfWork        repository.UnitOfWork
+
+	QuoteRepository    repository.QuoteRepository
+	SnapshotOrderItems *quote.SnapshotOrderItems
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *ApproveOrder) WithRepositories(repos repository.Repositories) *ApproveOrder {
+	return &ApproveOrder{
+		OrderRepository:   repos.Orders,
+		UserRepository:    repos.Users,
+		Logger:            uc.Logger,
+		UpdateOrderStatus: uc.UpdateOrderStatus.WithRepositories(repos),
+		UnitOfWork:        repos.UnitOfWork,
+
+		QuoteRepository:    repos.Quotes,
+		SnapshotOrderItems: uc.SnapshotOrderItems.WithRepositories(repos),
+	}
+}
+
+// FetchUserFromDB busca o usuário que está aprovando a order
+func (uc *ApproveOrder) FetchUserFromDB(userID uuid.UUID) (*models.User, error) {
+	user, err := uc.UserRepository.FindByID(userID)
+	if err != nil {
+		uc.Logger.Error("User not found", zap.String("userID", userID.String()))
+		return nil, errors.New("user not found")
+	}
+	uc.Logger.Info("User found", zap.String("userID", user.ID.String()), zap.String("userType", user.UserType))
+	return user, nil
+}
+
+// FetchOrderFromDB busca a order bloqueando a linha, para que a aprovação não concorra com um
+// cancelamento ou outra mudança de status da mesma order
+func (uc *ApproveOrder) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByIDForUpdate(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+	uc.Logger.Info("Order found", zap.String("orderID", order.ID.String()), zap.String("status", order.Status))
+	return order, nil
+}
+
+// ValidateOrderOwnership valida se a order pertence ao customer do usuário
+func (uc *ApproveOrder) ValidateOrderOwnership(user *models.User, order *models.Order) error {
+	if user.CustomerID == nil || *user.CustomerID != order.CustomerID {
+		uc.Logger.Error("Order does not belong to user customer",
+			zap.String("userID", user.ID.String()),
+			zap.String("orderID", order.ID.String()),
+			zap.String("orderCustomerID", order.CustomerID.String()))
+		return errors.New("order does not belong to user customer")
+	}
+	uc.Logger.Info("Order belongs to user customer", zap.String("customerID", order.CustomerID.String()))
+	return nil
+}
+
+// ValidateAwaitingApproval valida se a order está aguardando aprovação
+func (uc *ApproveOrder) ValidateAwaitingApproval(order *models.Order) error {
+	if order.Status != domain.StatusAwaitingApproval {
+		uc.Logger.Error("Order is not awaiting approval",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is not awaiting approval")
+	}
+	return nil
+}
+
+// FetchQuoteFromDB busca a versão do orçamento que o cliente está aprovando
+func (uc *ApproveOrder) FetchQuoteFromDB(orderID uuid.UUID, version int) (*models.Quote, error) {
+	quote, err := uc.QuoteRepository.FindByOrderIDAndVersion(orderID, version)
+	if err != nil || quote == nil {
+		uc.Logger.Error("Quote not found",
+			zap.String("orderID", orderID.String()),
+			zap.Int("version", version))
+		return nil, errors.New("quote not found")
+	}
+	return quote, nil
+}
+
+// ValidateQuoteIsCurrent garante que o cliente aprova a versão mais recente, ainda válida e que
+// corresponde ao estado atual da order
+func (uc *ApproveOrder) ValidateQuoteIsCurrent(quote *models.Quote) error {
+	latest, err := uc.QuoteRepository.FindLatestByOrderID(quote.OrderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding latest quote", zap.Error(err))
+		return err
+	}
+	if latest != nil && latest.Version != quote.Version {
+		uc.Logger.Error("Quote is not the latest version",
+			zap.Int("version", quote.Version),
+			zap.Int("latestVersion", latest.Version))
+		return errors.New("quote is outdated")
+	}
+
+	entity := persistence.QuotePersistence{}.ToEntity(quote)
+	if entity.IsExpired(time.Now()) {
+		uc.Logger.Error("Quote has expired",
+			zap.Int("version", quote.Version),
+			zap.Time("validUntil", quote.ValidUntil))
+		return errors.New("quote has expired")
+	}
+
+	current, err := uc.SnapshotOrderItems.Process(quote.OrderID)
+	if err != nil {
+		return err
+	}
+	if !quoteDomain.SameItems(entity.Items, current) {
+		uc.Logger.Error("Order changed after the quote was generated", zap.Int("version", quote.Version))
+		return errors.New("quote is outdated")
+	}
+
+	return nil
+}
+
+func (uc *ApproveOrder) Process(orderID uuid.UUID, userID uuid.UUID, quoteVersion int, comment string) error {
+	uc.Logger.Info("Processing order approval",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", userID.String()),
+		zap.Int("quoteVersion", quoteVersion))
+
+	// Leitura, validação e mudança de status acontecem na mesma transação
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		// Busca o usuário
+		user, err := tx.FetchUserFromDB(userID)
+		if err != nil {
+			return err
+		}
+
+		// Busca a order
+		order, err := tx.FetchOrderFromDB(orderID)
+		if err != nil {
+			return err
+		}
+
+		// Valida se a order pertence ao customer do usuário
+		if err := tx.ValidateOrderOwnership(user, order); err != nil {
+			return err
+		}
+
+		// Valida se a order está aguardando aprovação
+		if err := tx.ValidateAwaitingApproval(order); err != nil {
+			return err
+		}
+
+		// Valida a versão do orçamento aprovada
+		quote, err := tx.FetchQuoteFromDB(orderID, quoteVersion)
+		if err != nil {
+			return err
+		}
+		if err := tx.ValidateQuoteIsCurrent(quote); err != nil {
+			return err
+		}
+
+		// Move a order para "In progress" registrando quem aprovou
+		change := statusHistory.StatusChange{
+			ChangedByUserID: &user.ID,
+			Note:            comment,
+			Source:          statusHistory.SourceCustomer,
+		}
+		if _, err := tx.UpdateOrderStatus.Process(orderID, domain.StatusInProgress, user.UserType, change); err != nil {
+			return err
+		}
+
+		// Registra qual versão do orçamento foi aprovada
+		if err := tx.OrderRepository.SetApprovedQuote(orderID, &quote.ID); err != nil {
+			uc.Logger.Error("Database error saving approved quote", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.Logger.Info("Order approved successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", userID.String()))
+
+	return nil
+}
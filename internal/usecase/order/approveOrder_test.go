@@ -0,0 +1,411 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_status_history"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
+	"go.uber.org/zap"
+)
+
+func TestApproveOrder_Process_Success(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	orderID := uuid.New()
+	userID := uuid.New()
+	customerID := uuid.New()
+	comment := "Pode seguir com o reparo"
+
+	mockOrder := &models.Order{
+		ID:         orderID,
+		CustomerID: customerID,
+		VehicleID:  uuid.New(),
+		Status:     "Awaiting approval",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	// Mock UserRepository
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: userID, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+	}
+
+	// Mock OrderRepository
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return mockOrder, nil
+	}
+
+	var updatedStatus string
+	orderRepoMock.UpdateFunc = func(order *models.Order) error {
+		updatedStatus = order.Status
+		return nil
+	}
+
+	// Mock OrderStatusHistoryRepository
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: "Awaiting approval", StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	orderStatusHistoryRepoMock.UpdateFunc = func(statusHistory *models.OrderStatusHistory) error {
+		return nil
+	}
+
+	var createdHistory *models.OrderStatusHistory
+	orderStatusHistoryRepoMock.CreateFunc = func(statusHistory *models.OrderStatusHistory) error {
+		createdHistory = statusHistory
+		return nil
+	}
+
+	statusHistoryManager := &order_status_history.ManageOrderStatusHistory{
+		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+		Logger:                       loggerMock,
+	}
+
+	quoteID := uuid.New()
+	approvedQuote := &models.Quote{ID: quoteID, OrderID: orderID, Version: 1, ValidUntil: time.Now().Add(time.Hour)}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{
+		FindByOrderIDAndVersionFunc: func(orderID uuid.UUID, version int) (*models.Quote, error) {
+			if approvedQuote.Version != version {
+				return nil, errors.New("record not found")
+			}
+			return approvedQuote, nil
+		},
+		FindLatestByOrderIDFunc: func(orderID uuid.UUID) (*models.Quote, error) {
+			return &models.Quote{ID: uuid.New(), OrderID: orderID, Version: 1}, nil
+		},
+	}
+
+	var approvedQuoteID *uuid.UUID
+	orderRepoMock.SetApprovedQuoteFunc = func(id uuid.UUID, quoteID *uuid.UUID) error {
+		approvedQuoteID = quoteID
+		return nil
+	}
+
+	useCase := &ApproveOrder{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+		UpdateOrderStatus: &UpdateOrderStatus{
+			OrderRepository:      orderRepoMock,
+			Logger:               loggerMock,
+			StatusHistoryManager: statusHistoryManager,
+			OrderReservations: &order_input.ManageOrderReservations{
+				Logger: loggerMock,
+				ManageInputReservation: &input.ManageInputReservation{
+					Logger:                  loggerMock,
+					RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+				},
+			},
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderInputs:        &mocks.OrderInputRepositoryMock{},
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Users:              userRepoMock,
+			Quotes:             quoteRepoMock,
+			OrderServices:      &mocks.OrderServiceRepositoryMock{},
+		}},
+		SnapshotOrderItems: &quote.SnapshotOrderItems{
+			OrderInputRepository:   &mocks.OrderInputRepositoryMock{},
+			InputRepository:        &mocks.InputRepositoryMock{},
+			OrderServiceRepository: &mocks.OrderServiceRepositoryMock{},
+			LaborServiceRepository: &mocks.LaborServiceRepositoryMock{},
+			Logger:                 loggerMock,
+		},
+	}
+
+	// Act
+	err := useCase.Process(orderID, userID, 1, comment)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updatedStatus != "In progress" {
+		t.Errorf("Expected order status 'In progress', got '%s'", updatedStatus)
+	}
+
+	if createdHistory == nil {
+		t.Fatal("Expected status history to be created")
+	}
+
+	if createdHistory.ChangedByUserID == nil || *createdHistory.ChangedByUserID != userID {
+		t.Errorf("Expected status history to record user %s", userID)
+	}
+
+	if createdHistory.Note != comment {
+		t.Errorf("Expected status history note '%s', got '%s'", comment, createdHistory.Note)
+	}
+
+	if approvedQuoteID == nil || *approvedQuoteID != quoteID {
+		t.Error("Expected approved quote version to be recorded on the order")
+	}
+}
+
+func TestApproveOrder_Process_QuoteValidation(t *testing.T) {
+	orderID := uuid.New()
+	customerID := uuid.New()
+	inputID := uuid.New()
+
+	tests := []struct {
+		name          string
+		quote         *models.Quote
+		latestVersion int
+		orderInputs   []models.OrderInput
+		expected      string
+	}{
+		{
+			name:          "unknown version",
+			quote:         nil,
+			latestVersion: 1,
+			expected:      "quote not found",
+		},
+		{
+			name:          "older version",
+			quote:         &models.Quote{ID: uuid.New(), OrderID: orderID, Version: 1, ValidUntil: time.Now().Add(time.Hour)},
+			latestVersion: 2,
+			expected:      "quote is outdated",
+		},
+		{
+			name:          "expired",
+			quote:         &models.Quote{ID: uuid.New(), OrderID: orderID, Version: 1, ValidUntil: time.Now().Add(-time.Hour)},
+			latestVersion: 1,
+			expected:      "quote has expired",
+		},
+		{
+			name:          "order changed after the quote",
+			quote:         &models.Quote{ID: uuid.New(), OrderID: orderID, Version: 1, ValidUntil: time.Now().Add(time.Hour)},
+			latestVersion: 1,
+			orderInputs:   []models.OrderInput{{ID: uuid.New(), OrderID: orderID, InputID: inputID, Quantity: 1, UnitPrice: money.MustParse("50"), TotalPrice: money.MustParse("50")}},
+			expected:      "quote is outdated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerMock := &mocks.LoggerMock{}
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			userRepoMock := &mocks.UserRepositoryMock{}
+			userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+				return &models.User{ID: id, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+			}
+
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: id, CustomerID: customerID, Status: "Awaiting approval"}, nil
+			}
+			orderRepoMock.UpdateFunc = func(order *models.Order) error {
+				t.Error("Order status should not be updated")
+				return nil
+			}
+
+			orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+			orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+				return tt.orderInputs, nil
+			}
+
+			snapshot := &quote.SnapshotOrderItems{
+				OrderInputRepository:   &mocks.OrderInputRepositoryMock{},
+				InputRepository:        &mocks.InputRepositoryMock{},
+				OrderServiceRepository: &mocks.OrderServiceRepositoryMock{},
+				LaborServiceRepository: &mocks.LaborServiceRepositoryMock{},
+				Logger:                 loggerMock,
+			}
+			useCase := &ApproveOrder{
+				Logger:            loggerMock,
+				UpdateOrderStatus: &UpdateOrderStatus{Logger: loggerMock},
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Orders:        orderRepoMock,
+					Users:         userRepoMock,
+					OrderInputs:   orderInputRepoMock,
+					Inputs:        &mocks.InputRepositoryMock{},
+					OrderServices: &mocks.OrderServiceRepositoryMock{},
+					LaborServices: &mocks.LaborServiceRepositoryMock{},
+					Quotes: &mocks.QuoteRepositoryMock{
+						FindByOrderIDAndVersionFunc: func(orderID uuid.UUID, version int) (*models.Quote, error) {
+							if tt.quote == nil || tt.quote.Version != version {
+								return nil, errors.New("record not found")
+							}
+							return tt.quote, nil
+						},
+						FindLatestByOrderIDFunc: func(orderID uuid.UUID) (*models.Quote, error) {
+							return &models.Quote{ID: uuid.New(), OrderID: orderID, Version: tt.latestVersion}, nil
+						},
+					},
+				}},
+				SnapshotOrderItems: snapshot,
+			}
+
+			err := useCase.Process(orderID, uuid.New(), 1, "")
+
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestApproveOrder_Process_OrderFromAnotherCustomer(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	customerID := uuid.New()
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+	}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: uuid.New(), Status: "Awaiting approval"}, nil
+	}
+
+	useCase := &ApproveOrder{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders: orderRepoMock,
+			Users:  userRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), uuid.New(), 1, "")
+
+	// Assert
+	if err == nil || err.Error() != "order does not belong to user customer" {
+		t.Errorf("Expected error 'order does not belong to user customer', got %v", err)
+	}
+}
+
+func TestApproveOrder_Process_UserWithoutCustomer(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, UserType: "vehicle_owner"}, nil
+	}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: uuid.New(), Status: "Awaiting approval"}, nil
+	}
+
+	useCase := &ApproveOrder{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders: orderRepoMock,
+			Users:  userRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), uuid.New(), 1, "")
+
+	// Assert
+	if err == nil || err.Error() != "order does not belong to user customer" {
+		t.Errorf("Expected error 'order does not belong to user customer', got %v", err)
+	}
+}
+
+func TestApproveOrder_Process_NotAwaitingApproval(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	customerID := uuid.New()
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+	}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: customerID, Status: "Undergoing diagnosis"}, nil
+	}
+
+	useCase := &ApproveOrder{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders: orderRepoMock,
+			Users:  userRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), uuid.New(), 1, "")
+
+	// Assert
+	if err == nil || err.Error() != "order is not awaiting approval" {
+		t.Errorf("Expected error 'order is not awaiting approval', got %v", err)
+	}
+}
+
+func TestApproveOrder_Process_UserNotFound(t *testing.T) {
+	// Arrange
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return nil, errors.New("record not found")
+	}
+
+	useCase := &ApproveOrder{
+		UserRepository: userRepoMock,
+		Logger:         loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Users: userRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), uuid.New(), 1, "")
+
+	// Assert
+	if err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected error 'user not found', got %v", err)
+	}
+}
@@ -19,6 +19,19 @@ type CreateOrder struct {
 	VehicleRepository    repository.VehicleRepository
 	Logger               logger.Logger
 	StatusHistoryManager *order_status_history.ManageOrderStatusHistory
+	UnitOfWork           repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *CreateOrder) WithRepositories(repos repository.Repositories) *CreateOrder {
+	return &CreateOrder{
+		OrderRepository:      repos.Orders,
+		CustomerRepository:   repos.Customers,
+		VehicleRepository:    repos.Vehicles,
+		Logger:               uc.Logger,
+		StatusHistoryManager: uc.StatusHistoryManager.WithRepositories(repos),
+		UnitOfWork:           repos.UnitOfWork,
+	}
 }
 
 // FetchCustomerFromDB busca um customer específico do banco de dados
@@ -72,9 +85,8 @@ func (uc *CreateOrder) StartOrderStatusHistory(orderID uuid.UUID, status string)
 	err := uc.StatusHistoryManager.StartNewStatus(orderID, status)
 	if err != nil {
 		uc.Logger.Error("Error starting status history", zap.Error(err))
-		// Não retorna erro aqui, pois a order já foi criada
-		// Apenas loga o erro para monitoramento
-		return nil
+		// Order e histórico são gravados na mesma transação, então o erro desfaz a criação da order
+		return err
 	}
 
 	uc.Logger.Info("Status history started successfully",
@@ -114,14 +126,16 @@ func (uc *CreateOrder) Process(entity *domain.Order) error {
 		zap.String("vehicleID", model.VehicleID.String()),
 		zap.String("status", model.Status))
 
-	// Salva no banco
-	err = uc.SaveOrderToDB(model)
-	if err != nil {
-		return err
-	}
+	// Order e histórico inicial são gravados na mesma transação
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	// Inicia o histórico de status com o status inicial
-	uc.StartOrderStatusHistory(model.ID, model.Status)
+		// Salva no banco
+		if err := tx.SaveOrderToDB(model); err != nil {
+			return err
+		}
 
-	return nil
+		// Inicia o histórico de status com o status inicial
+		return tx.StartOrderStatusHistory(model.ID, model.Status)
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_status_history"
 	"go.uber.org/zap"
@@ -90,6 +91,12 @@ func TestCreateOrder_Process_Success(t *testing.T) {
 		VehicleRepository:    vehicleRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Customers:          customerRepoMock,
+			Vehicles:           vehicleRepoMock,
+		}},
 	}
 
 	order := &domain.Order{
@@ -176,6 +183,12 @@ func TestCreateOrder_Process_CustomerNotFound(t *testing.T) {
 		VehicleRepository:    vehicleRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Customers:          customerRepoMock,
+			Vehicles:           vehicleRepoMock,
+		}},
 	}
 
 	order := &domain.Order{
@@ -270,6 +283,12 @@ func TestCreateOrder_Process_VehicleNotFound(t *testing.T) {
 		VehicleRepository:    vehicleRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Customers:          customerRepoMock,
+			Vehicles:           vehicleRepoMock,
+		}},
 	}
 
 	order := &domain.Order{
@@ -391,6 +410,12 @@ func TestCreateOrder_Process_VehicleDoesNotBelongToCustomer(t *testing.T) {
 		VehicleRepository:    vehicleRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Customers:          customerRepoMock,
+			Vehicles:           vehicleRepoMock,
+		}},
 	}
 
 	order := &domain.Order{
@@ -517,6 +542,12 @@ func TestCreateOrder_Process_DatabaseError(t *testing.T) {
 		VehicleRepository:    vehicleRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Customers:          customerRepoMock,
+			Vehicles:           vehicleRepoMock,
+		}},
 	}
 
 	order := &domain.Order{
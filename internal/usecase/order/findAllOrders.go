@@ -0,0 +1,95 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	userDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindAllOrders lista as orders com filtros e paginação. Mecânicos e admins veem todas as orders,
+// donos de veículo apenas as do seu customer.
+type FindAllOrders struct {
+	OrderRepository repository.OrderRepository
+	UserRepository  repository.UserRepository
+	Logger          logger.Logger
+}
+
+// ValidateFilter valida os filtros e a paginação da listagem
+func (uc *FindAllOrders) ValidateFilter(filter repository.OrderFilter, spec *repository.QuerySpec) error {
+	if filter.Status != "" && !domain.IsValidStatus(filter.Status) {
+		uc.Logger.Error("Invalid status filter", zap.String("status", filter.Status))
+		return errors.New("invalid status")
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		uc.Logger.Error("Invalid created at range",
+			zap.Time("createdFrom", *filter.CreatedFrom),
+			zap.Time("createdTo", *filter.CreatedTo))
+		return errors.New("invalid created at range")
+	}
+
+	if err := spec.Validate(); err != nil {
+		uc.Logger.Error("Invalid query", zap.Error(err), zap.Int("limit", spec.Limit))
+		return err
+	}
+
+	return nil
+}
+
+// RestrictToUserCustomer limita o filtro ao customer do dono de veículo, ignorando
+// qualquer customer informado na requisição
+func (uc *FindAllOrders) RestrictToUserCustomer(filter *repository.OrderFilter, userID uuid.UUID) error {
+	user, err := uc.UserRepository.FindByID(userID)
+	if err != nil {
+		uc.Logger.Error("User not found", zap.String("userID", userID.String()))
+		return errors.New("user not found")
+	}
+
+	if user.CustomerID == nil {
+		uc.Logger.Error("User has no customer", zap.String("userID", userID.String()))
+		return errors.New("user has no customer")
+	}
+
+	filter.CustomerID = user.CustomerID
+	uc.Logger.Info("Order list restricted to user customer", zap.String("customerID", user.CustomerID.String()))
+	return nil
+}
+
+func (uc *FindAllOrders) Process(filter repository.OrderFilter, spec repository.QuerySpec, userID uuid.UUID, userType string) (*repository.Page[domain.Order], error) {
+	uc.Logger.Info("Processing find all orders",
+		zap.String("userID", userID.String()),
+		zap.String("userType", userType))
+
+	if err := uc.ValidateFilter(filter, &spec); err != nil {
+		return nil, err
+	}
+
+	if userType == userDomain.UserTypeVehicleOwner {
+		if err := uc.RestrictToUserCustomer(&filter, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := uc.OrderRepository.FindAll(filter, spec)
+	if err != nil {
+		uc.Logger.Error("Database error fetching orders", zap.Error(err))
+		return nil, err
+	}
+
+	domainPage := repository.MapPage(page, func(order *models.Order) domain.Order {
+		return *persistence.OrderPersistence{}.ToEntity(order)
+	})
+
+	uc.Logger.Info("Successfully fetched orders",
+		zap.Int("count", len(domainPage.Items)),
+		zap.Int64("total", domainPage.Total))
+
+	return domainPage, nil
+}
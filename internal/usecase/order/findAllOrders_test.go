@@ -0,0 +1,205 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestFindAllOrders_Process_MechanicAppliesDefaults(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+
+	customerID := uuid.New()
+	var receivedFilter repository.OrderFilter
+	var receivedSpec repository.QuerySpec
+	orderRepoMock.FindAllFunc = func(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error) {
+		receivedFilter = filter
+		receivedSpec = spec
+		return &repository.Page[models.Order]{
+			Items: []models.Order{
+				{ID: uuid.New(), CustomerID: customerID, VehicleID: uuid.New(), Status: "Received", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			},
+			NextCursor: "next",
+			Total:      42,
+		}, nil
+	}
+
+	useCase := &FindAllOrders{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(repository.OrderFilter{CustomerID: &customerID, Status: "Received"}, repository.QuerySpec{}, uuid.New(), "mechanic")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Items) != 1 || result.Total != 42 || result.NextCursor != "next" {
+		t.Errorf("Expected 1 order of 42 with next cursor, got %d of %d", len(result.Items), result.Total)
+	}
+	if receivedSpec.Limit != repository.DefaultQueryLimit {
+		t.Errorf("Expected default limit, got %d", receivedSpec.Limit)
+	}
+	if receivedFilter.CustomerID == nil || *receivedFilter.CustomerID != customerID {
+		t.Error("Expected mechanic customer filter to be kept")
+	}
+}
+
+func TestFindAllOrders_Process_VehicleOwnerRestrictedToCustomer(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+
+	userID := uuid.New()
+	ownCustomerID := uuid.New()
+	otherCustomerID := uuid.New()
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: userID, UserType: "vehicle_owner", CustomerID: &ownCustomerID}, nil
+	}
+
+	var receivedFilter repository.OrderFilter
+	orderRepoMock.FindAllFunc = func(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error) {
+		receivedFilter = filter
+		return &repository.Page[models.Order]{Items: []models.Order{}}, nil
+	}
+
+	useCase := &FindAllOrders{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(repository.OrderFilter{CustomerID: &otherCustomerID}, repository.QuerySpec{}, userID, "vehicle_owner")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if receivedFilter.CustomerID == nil || *receivedFilter.CustomerID != ownCustomerID {
+		t.Error("Expected filter to be restricted to the user customer")
+	}
+}
+
+func TestFindAllOrders_Process_VehicleOwnerWithoutCustomer(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, UserType: "vehicle_owner"}, nil
+	}
+	orderRepoMock.FindAllFunc = func(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error) {
+		t.Error("Orders should not be fetched for a user without customer")
+		return nil, nil
+	}
+
+	useCase := &FindAllOrders{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(repository.OrderFilter{}, repository.QuerySpec{}, uuid.New(), "vehicle_owner")
+
+	// Assert
+	if err == nil || err.Error() != "user has no customer" {
+		t.Errorf("Expected 'user has no customer' error, got %v", err)
+	}
+}
+
+func TestFindAllOrders_Process_InvalidFilter(t *testing.T) {
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		filter   repository.OrderFilter
+		spec     repository.QuerySpec
+		expected string
+	}{
+		{"invalid status", repository.OrderFilter{Status: "Unknown"}, repository.QuerySpec{}, "invalid status"},
+		{"inverted range", repository.OrderFilter{CreatedFrom: &from, CreatedTo: &to}, repository.QuerySpec{}, "invalid created at range"},
+		{"limit too high", repository.OrderFilter{}, repository.QuerySpec{Limit: repository.MaxQueryLimit + 1}, "invalid limit"},
+		{"negative limit", repository.OrderFilter{}, repository.QuerySpec{Limit: -1}, "invalid limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := &FindAllOrders{
+				OrderRepository: &mocks.OrderRepositoryMock{},
+				UserRepository:  &mocks.UserRepositoryMock{},
+				Logger:          loggerMock,
+			}
+
+			_, err := useCase.Process(tt.filter, tt.spec, uuid.New(), "admin")
+
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestFindAllOrders_Process_DatabaseError(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindAllFunc = func(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error) {
+		return nil, errors.New("database error")
+	}
+
+	useCase := &FindAllOrders{
+		OrderRepository: orderRepoMock,
+		UserRepository:  &mocks.UserRepositoryMock{},
+		Logger:          loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(repository.OrderFilter{}, repository.QuerySpec{}, uuid.New(), "admin")
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if result != nil {
+		t.Error("Expected nil result on error")
+	}
+}
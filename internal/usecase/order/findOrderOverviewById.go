@@ -3,12 +3,21 @@ package order
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	adjustmentDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	assignmentDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_assignment"
+	pricingDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/pricing"
 	"go.uber.org/zap"
 )
 
@@ -18,16 +27,51 @@ type FindOrderOverviewById struct {
 	OrderInputRepository         repository.OrderInputRepository
 	OrderStatusHistoryRepository repository.OrderStatusHistoryRepository
 	InputRepository              repository.InputRepository
+	OrderServiceRepository       repository.OrderServiceRepository
+	LaborServiceRepository       repository.LaborServiceRepository
 	Logger                       logger.Logger
+	// Pricing aplica descontos, acréscimos e impostos. Sem ele, o total é apenas a soma das linhas.
+	Pricing *pricing.CalculateOrderPricing
+	// Balance informa o valor pago e o saldo em aberto. Sem ele, os dois campos ficam zerados.
+	Balance *payment.CalculateOrderBalance
+	// OrderAssignmentRepository informa os mecânicos designados. Sem ele, a lista fica vazia.
+	OrderAssignmentRepository repository.OrderAssignmentRepository
 }
 
 type OrderWithInputs struct {
-	Order       *domain.Order       `json:"order"`
-	Vehicle     VehicleDetails      `json:"vehicle"`
-	Inputs      []OrderInputDetails `json:"inputs"`
-	TotalPrice  float64             `json:"total_price"`
-	Timeline    map[string]string   `json:"timeline"`
-	AverageTime string              `json:"average_time"`
+	Order    *domain.Order         `json:"order"`
+	Vehicle  VehicleDetails        `json:"vehicle"`
+	Inputs   []OrderInputDetails   `json:"inputs"`
+	Services []OrderServiceDetails `json:"services"`
+	// PartsSubtotal soma peças e insumos, LaborSubtotal a mão de obra e TotalPrice é o total
+	// geral, já com ajustes e impostos. A composição completa fica em Pricing.
+	PartsSubtotal money.Money                        `json:"parts_subtotal"`
+	LaborSubtotal money.Money                        `json:"labor_subtotal"`
+	TotalPrice    money.Money                        `json:"total_price"`
+	Adjustments   []adjustmentDomain.OrderAdjustment `json:"adjustments"`
+	Pricing       *pricingDomain.Breakdown           `json:"pricing"`
+	// AmountPaid soma os pagamentos recebidos e AmountDue é o que falta receber do valor cobrado
+	AmountPaid money.Money `json:"amount_paid"`
+	AmountDue  money.Money `json:"amount_due"`
+	// Mechanics são os mecânicos designados no momento; o histórico completo tem endpoint próprio
+	Mechanics   []assignmentDomain.OrderAssignment `json:"mechanics"`
+	Timeline    []TimelineEntry                    `json:"timeline"`
+	AverageTime string                             `json:"average_time"`
+}
+
+// TimelineEntry é uma etapa do histórico de status, com quem fez a mudança, o comentário e a
+// origem. Em uma etapa em andamento (Ongoing), a duração é o tempo decorrido até agora. Status
+// finais aparecem como etapas próprias, sem duração.
+type TimelineEntry struct {
+	Status          string     `json:"status"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	Ongoing         bool       `json:"ongoing"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Duration        string     `json:"duration"`
+	ChangedByUserID *uuid.UUID `json:"changed_by_user_id,omitempty"`
+	Note            string     `json:"note,omitempty"`
+	Source          string     `json:"source"`
 }
 
 type VehicleDetails struct {
@@ -41,12 +85,27 @@ type VehicleDetails struct {
 }
 
 type OrderInputDetails struct {
-	ID         string  `json:"id"`
-	InputID    string  `json:"input_id"`
-	InputName  string  `json:"input_name"`
-	Quantity   int     `json:"quantity"`
-	UnitPrice  float64 `json:"unit_price"`
-	TotalPrice float64 `json:"total_price"`
+	ID         string      `json:"id"`
+	InputID    string      `json:"input_id"`
+	InputName  string      `json:"input_name"`
+	Quantity   int         `json:"quantity"`
+	UnitPrice  money.Money `json:"unit_price"`
+	TotalPrice money.Money `json:"total_price"`
+	// StockStatus indica se a peça está reservada, baixada, liberada ou devolvida ao estoque
+	StockStatus   string `json:"stock_status"`
+	UsedOrDamaged bool   `json:"used_or_damaged"`
+	Voided        bool   `json:"voided"`
+}
+
+type OrderServiceDetails struct {
+	ID               string      `json:"id"`
+	LaborServiceID   string      `json:"labor_service_id"`
+	LaborServiceName string      `json:"labor_service_name"`
+	MechanicID       *string     `json:"mechanic_id,omitempty"`
+	EstimatedHours   float64     `json:"estimated_hours"`
+	ActualHours      *float64    `json:"actual_hours,omitempty"`
+	HourlyRate       money.Money `json:"hourly_rate"`
+	TotalPrice       money.Money `json:"total_price"`
 }
 
 // FormatDurationFromSeconds converte segundos para formato HH:MM:SS
@@ -132,13 +191,17 @@ func (uc *FindOrderOverviewById) MapOrderInputToDetails(orderInput models.OrderI
 		Quantity:   orderInput.Quantity,
 		UnitPrice:  orderInput.UnitPrice,
 		TotalPrice: orderInput.TotalPrice,
+
+		StockStatus:   orderInput.StockStatus,
+		UsedOrDamaged: orderInput.UsedOrDamaged,
+		Voided:        orderInput.VoidedAt != nil,
 	}
 }
 
 // ProcessOrderInputs processa os inputs da order e calcula o total
-func (uc *FindOrderOverviewById) ProcessOrderInputs(orderInputs []models.OrderInput) ([]OrderInputDetails, float64) {
+func (uc *FindOrderOverviewById) ProcessOrderInputs(orderInputs []models.OrderInput) ([]OrderInputDetails, money.Money) {
 	var inputs []OrderInputDetails
-	var totalPrice float64 = 0
+	totalPrice := money.Zero
 
 	for _, orderInput := range orderInputs {
 		// Busca o nome do input
@@ -152,20 +215,117 @@ func (uc *FindOrderOverviewById) ProcessOrderInputs(orderInputs []models.OrderIn
 
 		inputDetail := uc.MapOrderInputToDetails(orderInput, input.Name)
 		inputs = append(inputs, inputDetail)
-		totalPrice += orderInput.TotalPrice
+		totalPrice = totalPrice.Add(orderInput.TotalPrice)
 
 		uc.Logger.Info("Added input detail",
 			zap.String("inputID", orderInput.InputID.String()),
 			zap.String("inputName", input.Name),
 			zap.Int("quantity", orderInput.Quantity),
-			zap.Float64("unitPrice", orderInput.UnitPrice),
-			zap.Float64("totalPrice", orderInput.TotalPrice))
+			zap.Stringer("unitPrice", orderInput.UnitPrice),
+			zap.Stringer("totalPrice", orderInput.TotalPrice))
 	}
 
-	uc.Logger.Info("Calculated total price", zap.Float64("totalPrice", totalPrice))
+	uc.Logger.Info("Calculated total price", zap.Stringer("totalPrice", totalPrice))
 	return inputs, totalPrice
 }
 
+// FetchOrderServicesFromDB busca as linhas de mão de obra da order
+func (uc *FindOrderOverviewById) FetchOrderServicesFromDB(orderID uuid.UUID) ([]models.OrderService, error) {
+	orderServices, err := uc.OrderServiceRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order services", zap.Error(err))
+		return nil, err
+	}
+
+	uc.Logger.Info("Order services found", zap.Int("count", len(orderServices)))
+	return orderServices, nil
+}
+
+// MapOrderServiceToDetails mapeia uma linha de mão de obra para OrderServiceDetails
+func (uc *FindOrderOverviewById) MapOrderServiceToDetails(orderService models.OrderService, laborServiceName string) OrderServiceDetails {
+	details := OrderServiceDetails{
+		ID:               orderService.ID.String(),
+		LaborServiceID:   orderService.LaborServiceID.String(),
+		LaborServiceName: laborServiceName,
+		EstimatedHours:   orderService.EstimatedHours,
+		ActualHours:      orderService.ActualHours,
+		HourlyRate:       orderService.HourlyRate,
+		TotalPrice:       orderService.TotalPrice,
+	}
+	if orderService.MechanicID != nil {
+		mechanicID := orderService.MechanicID.String()
+		details.MechanicID = &mechanicID
+	}
+	return details
+}
+
+// ProcessOrderServices processa as linhas de mão de obra e calcula o subtotal de mão de obra
+func (uc *FindOrderOverviewById) ProcessOrderServices(orderServices []models.OrderService) ([]OrderServiceDetails, money.Money) {
+	services := []OrderServiceDetails{}
+	laborSubtotal := money.Zero
+
+	for _, orderService := range orderServices {
+		// O nome é apenas informativo, a linha continua sendo cobrada se o serviço sair do catálogo
+		laborServiceName := ""
+		laborService, err := uc.LaborServiceRepository.FindByID(orderService.LaborServiceID)
+		if err != nil {
+			uc.Logger.Error("Labor service not found for order service",
+				zap.String("laborServiceID", orderService.LaborServiceID.String()),
+				zap.String("orderServiceID", orderService.ID.String()))
+		} else {
+			laborServiceName = laborService.Name
+		}
+
+		services = append(services, uc.MapOrderServiceToDetails(orderService, laborServiceName))
+		laborSubtotal = laborSubtotal.Add(orderService.TotalPrice)
+	}
+
+	uc.Logger.Info("Calculated labor subtotal", zap.Stringer("laborSubtotal", laborSubtotal))
+	return services, laborSubtotal
+}
+
+// CalculatePricing compõe o total da order a partir das linhas cobradas. Itens anulados pelo
+// cancelamento não entram e a mão de obra usa as horas cobradas de cada linha.
+func (uc *FindOrderOverviewById) CalculatePricing(orderID uuid.UUID, orderInputs []models.OrderInput, orderServices []models.OrderService) (*pricingDomain.Breakdown, []adjustmentDomain.OrderAdjustment, error) {
+	lines := make([]pricingDomain.Line, 0, len(orderInputs)+len(orderServices))
+	for _, orderInput := range orderInputs {
+		if orderInput.VoidedAt != nil {
+			continue
+		}
+		lines = append(lines, pricingDomain.Line{ID: orderInput.ID, Category: pricingDomain.CategoryPart, Amount: orderInput.TotalPrice})
+	}
+	for _, orderService := range orderServices {
+		lines = append(lines, pricingDomain.Line{ID: orderService.ID, Category: pricingDomain.CategoryLabor, Amount: orderService.TotalPrice})
+	}
+
+	if uc.Pricing == nil {
+		breakdown := pricingDomain.Calculate(lines, nil, nil)
+		return &breakdown, []adjustmentDomain.OrderAdjustment{}, nil
+	}
+	return uc.Pricing.Process(orderID, lines)
+}
+
+// FetchActiveMechanics busca os mecânicos designados no momento para a order
+func (uc *FindOrderOverviewById) FetchActiveMechanics(orderID uuid.UUID) ([]assignmentDomain.OrderAssignment, error) {
+	mechanics := []assignmentDomain.OrderAssignment{}
+	if uc.OrderAssignmentRepository == nil {
+		return mechanics, nil
+	}
+
+	records, err := uc.OrderAssignmentRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order assignments", zap.Error(err))
+		return nil, err
+	}
+
+	for i := range records {
+		if assignment := (persistence.OrderAssignmentPersistence{}).ToEntity(&records[i]); assignment.IsActive() {
+			mechanics = append(mechanics, *assignment)
+		}
+	}
+	return mechanics, nil
+}
+
 // MapOrderToDomain mapeia a order para o domínio
 func (uc *FindOrderOverviewById) MapOrderToDomain(order *models.Order) *domain.Order {
 	return &domain.Order{
@@ -173,49 +333,71 @@ func (uc *FindOrderOverviewById) MapOrderToDomain(order *models.Order) *domain.O
 		CustomerID: order.CustomerID,
 		VehicleID:  order.VehicleID,
 		Status:     order.Status,
-		CreatedAt:  order.CreatedAt,
-		UpdatedAt:  order.UpdatedAt,
+
+		ApprovedQuoteID: order.ApprovedQuoteID,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
 	}
 }
 
-// CalculateTimeline calcula timeline e tempo médio baseado no histórico de status
-func (uc *FindOrderOverviewById) CalculateTimeline(orderID uuid.UUID) (map[string]string, string) {
+// CalculateTimeline monta o histórico de status em ordem cronológica. O tempo médio considera
+// apenas as etapas já encerradas, sem os status finais, que não têm duração.
+func (uc *FindOrderOverviewById) CalculateTimeline(orderID uuid.UUID) ([]TimelineEntry, string) {
+	timeline := []TimelineEntry{}
 	history, err := uc.OrderStatusHistoryRepository.FindByOrderID(orderID)
 	if err != nil {
 		uc.Logger.Error("Error fetching order status history", zap.Error(err))
-		return make(map[string]string), "00:00:00"
+		return timeline, "00:00:00"
 	}
 
 	uc.Logger.Info("Found order status history",
 		zap.String("orderID", orderID.String()),
 		zap.Int("historyCount", len(history)))
 
-	timeline := make(map[string]string)
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].StartedAt.Before(history[j].StartedAt)
+	})
+
+	now := time.Now()
 	var totalSeconds int
 	var completedStatuses int
 
 	for _, status := range history {
+		entry := TimelineEntry{
+			Status:          status.Status,
+			StartedAt:       status.StartedAt,
+			EndedAt:         status.EndedAt,
+			ChangedByUserID: status.ChangedByUserID,
+			Note:            status.Note,
+			Source:          status.Source,
+		}
+
 		if status.EndedAt != nil {
 			// Status finalizado - calcula duração baseada em started_at e ended_at
-			duration := status.EndedAt.Sub(status.StartedAt)
-			durationSeconds := int(duration.Seconds())
+			entry.DurationSeconds = int64(status.EndedAt.Sub(status.StartedAt).Seconds())
 
-			timeline[status.Status] = FormatDurationFromSeconds(durationSeconds)
-			totalSeconds += durationSeconds
-			completedStatuses++
+			if !domain.IsFinalStatus(status.Status) {
+				totalSeconds += int(entry.DurationSeconds)
+				completedStatuses++
+			}
 
 			uc.Logger.Info("Status duration calculated",
 				zap.String("status", status.Status),
 				zap.Time("startedAt", status.StartedAt),
 				zap.Time("endedAt", *status.EndedAt),
-				zap.Int("durationSeconds", durationSeconds))
+				zap.Int64("durationSeconds", entry.DurationSeconds))
 		} else {
-			// Status atual (não finalizado)
-			timeline[status.Status] = "00:00:00"
+			// Status atual (não finalizado) - a duração é o tempo decorrido até agora
+			entry.Ongoing = true
+			entry.DurationSeconds = int64(now.Sub(status.StartedAt).Seconds())
 			uc.Logger.Info("Status not completed yet",
 				zap.String("status", status.Status),
-				zap.Time("startedAt", status.StartedAt))
+				zap.Time("startedAt", status.StartedAt),
+				zap.Int64("elapsedSeconds", entry.DurationSeconds))
 		}
+
+		entry.Duration = FormatDurationFromSeconds(int(entry.DurationSeconds))
+		timeline = append(timeline, entry)
 	}
 
 	// Calcula tempo médio
@@ -257,8 +439,37 @@ func (uc *FindOrderOverviewById) Process(orderID uuid.UUID) (*OrderWithInputs, e
 		return nil, err
 	}
 
-	// Processa os inputs e calcula o total
-	inputs, totalPrice := uc.ProcessOrderInputs(orderInputs)
+	// Processa os inputs da order
+	inputs, _ := uc.ProcessOrderInputs(orderInputs)
+
+	// Busca e processa a mão de obra da order
+	orderServices, err := uc.FetchOrderServicesFromDB(orderID)
+	if err != nil {
+		return nil, err
+	}
+	services, _ := uc.ProcessOrderServices(orderServices)
+
+	// Aplica descontos, acréscimos e impostos
+	breakdown, adjustments, err := uc.CalculatePricing(orderID, orderInputs, orderServices)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calcula o valor pago e o saldo em aberto
+	amountPaid, amountDue := money.Zero, money.Zero
+	if uc.Balance != nil {
+		balance, _, err := uc.Balance.Process(order)
+		if err != nil {
+			return nil, err
+		}
+		amountPaid, amountDue = balance.AmountPaid, balance.AmountDue
+	}
+
+	// Busca os mecânicos designados
+	mechanics, err := uc.FetchActiveMechanics(orderID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Mapeia para o domínio
 	domainOrder := uc.MapOrderToDomain(order)
@@ -273,14 +484,24 @@ func (uc *FindOrderOverviewById) Process(orderID uuid.UUID) (*OrderWithInputs, e
 		Order:       domainOrder,
 		Vehicle:     vehicleDetails,
 		Inputs:      inputs,
-		TotalPrice:  totalPrice,
+		Services:    services,
 		Timeline:    timeline,
 		AverageTime: averageTime,
+
+		PartsSubtotal: breakdown.PartsSubtotal,
+		LaborSubtotal: breakdown.LaborSubtotal,
+		TotalPrice:    breakdown.TotalPrice,
+		Adjustments:   adjustments,
+		Pricing:       breakdown,
+		AmountPaid:    amountPaid,
+		AmountDue:     amountDue,
+		Mechanics:     mechanics,
 	}
 
 	uc.Logger.Info("Completed order with inputs and timeline retrieved successfully",
 		zap.String("orderID", orderID.String()),
 		zap.Int("inputsCount", len(inputs)),
+		zap.Int("servicesCount", len(services)),
 		zap.Int("timelineEntries", len(timeline)),
 		zap.String("averageTime", averageTime))
 
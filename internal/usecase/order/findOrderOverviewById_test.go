@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
@@ -65,8 +66,8 @@ func TestFindOrderOverviewById_Process_Success(t *testing.T) {
 			OrderID:    orderID,
 			InputID:    inputID1,
 			Quantity:   2,
-			UnitPrice:  50.0,
-			TotalPrice: 100.0,
+			UnitPrice:  money.MustParse("50.0"),
+			TotalPrice: money.MustParse("100.0"),
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
 		},
@@ -75,8 +76,8 @@ func TestFindOrderOverviewById_Process_Success(t *testing.T) {
 			OrderID:    orderID,
 			InputID:    inputID2,
 			Quantity:   1,
-			UnitPrice:  75.0,
-			TotalPrice: 75.0,
+			UnitPrice:  money.MustParse("75.0"),
+			TotalPrice: money.MustParse("75.0"),
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
 		},
@@ -86,7 +87,7 @@ func TestFindOrderOverviewById_Process_Success(t *testing.T) {
 		ID:          inputID1,
 		Name:        "Óleo de Motor",
 		Description: "Óleo de motor sintético",
-		Price:       50.0,
+		Price:       money.MustParse("50.0"),
 		Quantity:    100,
 		InputType:   "Lubrificante",
 		CreatedAt:   time.Now(),
@@ -97,7 +98,7 @@ func TestFindOrderOverviewById_Process_Success(t *testing.T) {
 		ID:          inputID2,
 		Name:        "Filtro de Ar",
 		Description: "Filtro de ar do motor",
-		Price:       75.0,
+		Price:       money.MustParse("75.0"),
 		Quantity:    50,
 		InputType:   "Filtro",
 		CreatedAt:   time.Now(),
@@ -175,6 +176,8 @@ func TestFindOrderOverviewById_Process_Success(t *testing.T) {
 		OrderInputRepository:         orderInputRepoMock,
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+		LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
 		Logger:                       loggerMock,
 	}
 
@@ -202,8 +205,8 @@ func TestFindOrderOverviewById_Process_Success(t *testing.T) {
 		t.Errorf("Expected 2 inputs, got %d", len(result.Inputs))
 	}
 
-	if result.TotalPrice != 175.0 {
-		t.Errorf("Expected total price 175.0, got %f", result.TotalPrice)
+	if !result.TotalPrice.Equal(money.MustParse("175.0")) {
+		t.Errorf("Expected total price 175.0, got %s", result.TotalPrice)
 	}
 
 	if len(result.Timeline) != 3 {
@@ -277,6 +280,8 @@ func TestFindOrderOverviewById_Process_OrderNotFound(t *testing.T) {
 		OrderInputRepository:         orderInputRepoMock,
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+		LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
 		Logger:                       loggerMock,
 	}
 
@@ -364,6 +369,8 @@ func TestFindOrderOverviewById_Process_VehicleNotFound(t *testing.T) {
 		OrderInputRepository:         orderInputRepoMock,
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+		LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
 		Logger:                       loggerMock,
 	}
 
@@ -481,6 +488,8 @@ func TestFindOrderOverviewById_Process_OrderInputsNotFound(t *testing.T) {
 		OrderInputRepository:         orderInputRepoMock,
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+		LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
 		Logger:                       loggerMock,
 	}
 
@@ -619,8 +628,8 @@ func TestFindOrderOverviewById_MapOrderInputToDetails(t *testing.T) {
 		OrderID:    uuid.New(),
 		InputID:    inputID,
 		Quantity:   3,
-		UnitPrice:  50.0,
-		TotalPrice: 150.0,
+		UnitPrice:  money.MustParse("50.0"),
+		TotalPrice: money.MustParse("150.0"),
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
@@ -647,12 +656,12 @@ func TestFindOrderOverviewById_MapOrderInputToDetails(t *testing.T) {
 		t.Errorf("Expected quantity 3, got %d", result.Quantity)
 	}
 
-	if result.UnitPrice != 50.0 {
-		t.Errorf("Expected unit price 50.0, got %f", result.UnitPrice)
+	if !result.UnitPrice.Equal(money.MustParse("50.0")) {
+		t.Errorf("Expected unit price 50.0, got %s", result.UnitPrice)
 	}
 
-	if result.TotalPrice != 150.0 {
-		t.Errorf("Expected total price 150.0, got %f", result.TotalPrice)
+	if !result.TotalPrice.Equal(money.MustParse("150.0")) {
+		t.Errorf("Expected total price 150.0, got %s", result.TotalPrice)
 	}
 }
 
@@ -756,6 +765,8 @@ func TestFindOrderOverviewById_CalculateTimeline_WithHistory(t *testing.T) {
 		OrderInputRepository:         orderInputRepoMock,
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+		LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
 		Logger:                       loggerMock,
 	}
 
@@ -764,19 +775,26 @@ func TestFindOrderOverviewById_CalculateTimeline_WithHistory(t *testing.T) {
 
 	// Assert
 	if len(timeline) != 3 {
-		t.Errorf("Expected 3 timeline entries, got %d", len(timeline))
+		t.Fatalf("Expected 3 timeline entries, got %d", len(timeline))
 	}
 
-	if timeline["Received"] == "00:00:00" {
+	if timeline[0].Status != "Received" || timeline[0].Duration == "00:00:00" {
 		t.Error("Expected non-zero duration for 'Received' status")
 	}
 
-	if timeline["In Progress"] == "00:00:00" {
+	if timeline[1].Status != "In Progress" || timeline[1].Duration == "00:00:00" {
 		t.Error("Expected non-zero duration for 'In Progress' status")
 	}
 
-	if timeline["Completed"] != "00:00:00" {
-		t.Error("Expected zero duration for current 'Completed' status")
+	// A etapa atual continua contando o tempo decorrido
+	if timeline[2].Status != "Completed" || !timeline[2].Ongoing || timeline[2].EndedAt != nil {
+		t.Error("Expected current 'Completed' status to be ongoing")
+	}
+	if timeline[2].DurationSeconds < 1800 {
+		t.Errorf("Expected elapsed time of at least 30 minutes, got %d seconds", timeline[2].DurationSeconds)
+	}
+	if timeline[0].Ongoing || timeline[0].DurationSeconds != 3600 {
+		t.Errorf("Expected 'Received' to last 3600 seconds, got %d", timeline[0].DurationSeconds)
 	}
 
 	if averageTime == "00:00:00" {
@@ -810,6 +828,97 @@ func TestFindOrderOverviewById_CalculateTimeline_WithHistory(t *testing.T) {
 	}
 }
 
+func TestFindOrderOverviewById_CalculateTimeline_ReturnsOrderedEntriesWithChangeDetails(t *testing.T) {
+	// Arrange
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	ownerID := uuid.New()
+	now := time.Now()
+	receivedEnd := now.Add(-time.Hour)
+
+	// O repositório devolve a etapa atual antes da anterior; a timeline deve vir em ordem cronológica
+	orderStatusHistoryRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderStatusHistory, error) {
+		return []models.OrderStatusHistory{
+			{ID: uuid.New(), OrderID: id, Status: "In progress", ChangedByUserID: &ownerID, Note: "Pode fazer", Source: "customer", StartedAt: receivedEnd},
+			{ID: uuid.New(), OrderID: id, Status: "Received", Source: "api", StartedAt: now.Add(-2 * time.Hour), EndedAt: &receivedEnd},
+		}, nil
+	}
+
+	useCase := &FindOrderOverviewById{
+		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+		Logger:                       loggerMock,
+	}
+
+	// Act
+	timeline, _ := useCase.CalculateTimeline(orderID)
+
+	// Assert
+	if len(timeline) != 2 {
+		t.Fatalf("Expected 2 timeline entries, got %d", len(timeline))
+	}
+	if timeline[0].Status != "Received" || timeline[0].Duration != "01:00:00" {
+		t.Errorf("Expected 'Received' first with 01:00:00, got %s with %s", timeline[0].Status, timeline[0].Duration)
+	}
+	current := timeline[1]
+	if current.ChangedByUserID == nil || *current.ChangedByUserID != ownerID || current.Note != "Pode fazer" || current.Source != "customer" {
+		t.Errorf("Expected change details on the current entry, got %+v", current)
+	}
+}
+
+func TestFindOrderOverviewById_CalculateTimeline_KeepsRepeatedStatusesAndFinalEntry(t *testing.T) {
+	// Arrange
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	firstEnd := start.Add(time.Hour)
+	diagnosisEnd := firstEnd.Add(30 * time.Minute)
+	secondEnd := diagnosisEnd.Add(2 * time.Hour)
+
+	// A order voltou para "In progress" depois de um novo diagnóstico e foi entregue em seguida
+	orderStatusHistoryRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderStatusHistory, error) {
+		return []models.OrderStatusHistory{
+			{ID: uuid.New(), OrderID: id, Status: "Delivered", StartedAt: secondEnd, EndedAt: &secondEnd},
+			{ID: uuid.New(), OrderID: id, Status: "In progress", StartedAt: diagnosisEnd, EndedAt: &secondEnd},
+			{ID: uuid.New(), OrderID: id, Status: "Undergoing diagnosis", StartedAt: firstEnd, EndedAt: &diagnosisEnd},
+			{ID: uuid.New(), OrderID: id, Status: "In progress", StartedAt: start, EndedAt: &firstEnd},
+		}, nil
+	}
+
+	useCase := &FindOrderOverviewById{
+		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+		Logger:                       loggerMock,
+	}
+
+	// Act
+	timeline, averageTime := useCase.CalculateTimeline(uuid.New())
+
+	// Assert
+	if len(timeline) != 4 {
+		t.Fatalf("Expected 4 timeline entries, got %d", len(timeline))
+	}
+	if timeline[0].Status != "In progress" || timeline[0].DurationSeconds != 3600 {
+		t.Errorf("Expected first 'In progress' interval of 3600 seconds, got %s with %d", timeline[0].Status, timeline[0].DurationSeconds)
+	}
+	if timeline[2].Status != "In progress" || timeline[2].DurationSeconds != 7200 {
+		t.Errorf("Expected second 'In progress' interval of 7200 seconds, got %s with %d", timeline[2].Status, timeline[2].DurationSeconds)
+	}
+	final := timeline[3]
+	if final.Status != "Delivered" || final.Ongoing || final.DurationSeconds != 0 {
+		t.Errorf("Expected 'Delivered' as its own closed entry without duration, got %+v", final)
+	}
+	// O status final não entra na média: (3600 + 1800 + 7200) / 3
+	if averageTime != "01:10:00" {
+		t.Errorf("Expected average time 01:10:00, got %s", averageTime)
+	}
+}
+
 func TestFindOrderOverviewById_CalculateTimeline_NoHistory(t *testing.T) {
 	// Arrange
 	orderRepoMock := &mocks.OrderRepositoryMock{}
@@ -842,6 +951,8 @@ func TestFindOrderOverviewById_CalculateTimeline_NoHistory(t *testing.T) {
 		OrderInputRepository:         orderInputRepoMock,
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+		LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
 		Logger:                       loggerMock,
 	}
 
@@ -913,6 +1024,8 @@ func TestFindOrderOverviewById_CalculateTimeline_Error(t *testing.T) {
 		OrderInputRepository:         orderInputRepoMock,
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+		LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
 		Logger:                       loggerMock,
 	}
 
@@ -937,3 +1050,78 @@ func TestFindOrderOverviewById_CalculateTimeline_Error(t *testing.T) {
 		t.Errorf("Expected error log 'Error fetching order status history', got '%s'", loggedErrors[0])
 	}
 }
+
+func TestFindOrderOverviewById_Process_LaborAndPartsSubtotals(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	vehicleID := uuid.New()
+	inputID := uuid.New()
+	laborServiceID := uuid.New()
+	actualHours := 1.5
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, VehicleID: vehicleID, Status: "In progress"}, nil
+	}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: vehicleID}, nil
+	}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: orderID, InputID: inputID, Quantity: 2, UnitPrice: money.MustParse("40.0"), TotalPrice: money.MustParse("80.0")},
+		}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: inputID, Name: "Filtro de óleo"}, nil
+	}
+	orderServiceRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderService, error) {
+		return []models.OrderService{
+			{ID: uuid.New(), OrderID: orderID, LaborServiceID: laborServiceID, EstimatedHours: 1, HourlyRate: money.MustParse("120.0"), TotalPrice: money.MustParse("120.0")},
+			{ID: uuid.New(), OrderID: orderID, LaborServiceID: laborServiceID, EstimatedHours: 1, ActualHours: &actualHours, HourlyRate: money.MustParse("100.0"), TotalPrice: money.MustParse("150.0")},
+		}, nil
+	}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return &models.LaborService{ID: laborServiceID, Name: "Troca de óleo"}, nil
+	}
+
+	useCase := &FindOrderOverviewById{
+		OrderRepository:              orderRepoMock,
+		VehicleRepository:            vehicleRepoMock,
+		OrderInputRepository:         orderInputRepoMock,
+		OrderStatusHistoryRepository: &mocks.OrderStatusHistoryRepositoryMock{},
+		InputRepository:              inputRepoMock,
+		OrderServiceRepository:       orderServiceRepoMock,
+		LaborServiceRepository:       laborServiceRepoMock,
+		Logger:                       loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(orderID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.PartsSubtotal.Equal(money.MustParse("80.0")) {
+		t.Errorf("Expected parts subtotal 80.0, got %s", result.PartsSubtotal)
+	}
+	if !result.LaborSubtotal.Equal(money.MustParse("270.0")) {
+		t.Errorf("Expected labor subtotal 270.0, got %s", result.LaborSubtotal)
+	}
+	if !result.TotalPrice.Equal(money.MustParse("350.0")) {
+		t.Errorf("Expected total price 350.0, got %s", result.TotalPrice)
+	}
+	if len(result.Services) != 2 || result.Services[0].LaborServiceName != "Troca de óleo" {
+		t.Errorf("Expected 2 named services, got %+v", result.Services)
+	}
+}
@@ -0,0 +1,133 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	orderInput "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
+	statusHistory "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+type RejectOrder struct {
+	OrderRepository   repository.OrderRepository
+	UserRepository    repository.UserRepository
+	Logger            logger.Logger
+	UpdateOrderStatus *UpdateOrderStatus
+	UnitOfWork        repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *RejectOrder) WithRepositories(repos repository.Repositories) *RejectOrder {
+	return &RejectOrder{
+		OrderRepository:   repos.Orders,
+		UserRepository:    repos.Users,
+		Logger:            uc.Logger,
+		UpdateOrderStatus: uc.UpdateOrderStatus.WithRepositories(repos),
+		UnitOfWork:        repos.UnitOfWork,
+	}
+}
+
+// FetchUserFromDB busca o usuário que está rejeitando a order
+func (uc *RejectOrder) FetchUserFromDB(userID uuid.UUID) (*models.User, error) {
+	user, err := uc.UserRepository.FindByID(userID)
+	if err != nil {
+		uc.Logger.Error("User not found", zap.String("userID", userID.String()))
+		return nil, errors.New("user not found")
+	}
+	uc.Logger.Info("User found", zap.String("userID", user.ID.String()), zap.String("userType", user.UserType))
+	return user, nil
+}
+
+// FetchOrderFromDB busca a order bloqueando a linha, serializando a rejeição com as demais
+// mudanças de status da order
+func (uc *RejectOrder) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByIDForUpdate(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+	uc.Logger.Info("Order found", zap.String("orderID", order.ID.String()), zap.String("status", order.Status))
+	return order, nil
+}
+
+// ValidateOrderOwnership valida se a order pertence ao customer do usuário
+func (uc *RejectOrder) ValidateOrderOwnership(user *models.User, order *models.Order) error {
+	if user.CustomerID == nil || *user.CustomerID != order.CustomerID {
+		uc.Logger.Error("Order does not belong to user customer",
+			zap.String("userID", user.ID.String()),
+			zap.String("orderID", order.ID.String()),
+			zap.String("orderCustomerID", order.CustomerID.String()))
+		return errors.New("order does not belong to user customer")
+	}
+	uc.Logger.Info("Order belongs to user customer", zap.String("customerID", order.CustomerID.String()))
+	return nil
+}
+
+// ValidateAwaitingApproval valida se a order está aguardando aprovação
+func (uc *RejectOrder) ValidateAwaitingApproval(order *models.Order) error {
+	if order.Status != domain.StatusAwaitingApproval {
+		uc.Logger.Error("Order is not awaiting approval",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is not awaiting approval")
+	}
+	return nil
+}
+
+// Process cancela a order aguardando aprovação e retorna o resumo das peças devolvidas ao estoque
+func (uc *RejectOrder) Process(orderID uuid.UUID, userID uuid.UUID, comment string) (*orderInput.RestockSummary, error) {
+	uc.Logger.Info("Processing order rejection",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", userID.String()))
+
+	// Leitura, validação e mudança de status acontecem na mesma transação
+	var summary *orderInput.RestockSummary
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		// Busca o usuário
+		user, err := tx.FetchUserFromDB(userID)
+		if err != nil {
+			return err
+		}
+
+		// Busca a order
+		order, err := tx.FetchOrderFromDB(orderID)
+		if err != nil {
+			return err
+		}
+
+		// Valida se a order pertence ao customer do usuário
+		if err := tx.ValidateOrderOwnership(user, order); err != nil {
+			return err
+		}
+
+		// Valida se a order está aguardando aprovação
+		if err := tx.ValidateAwaitingApproval(order); err != nil {
+			return err
+		}
+
+		// Cancela a order registrando quem rejeitou
+		change := statusHistory.StatusChange{
+			ChangedByUserID: &user.ID,
+			Note:            comment,
+			Source:          statusHistory.SourceCustomer,
+		}
+		summary, err = tx.UpdateOrderStatus.Process(orderID, domain.StatusCanceled, user.UserType, change)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info("Order rejected successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", userID.String()))
+
+	return summary, nil
+}
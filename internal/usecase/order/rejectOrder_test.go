@@ -0,0 +1,206 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_status_history"
+	"go.uber.org/zap"
+)
+
+func TestRejectOrder_Process_Success(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	orderID := uuid.New()
+	userID := uuid.New()
+	customerID := uuid.New()
+	comment := "Orçamento acima do esperado"
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: userID, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+	}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, CustomerID: customerID, Status: "Awaiting approval"}, nil
+	}
+
+	var updatedStatus string
+	orderRepoMock.UpdateFunc = func(order *models.Order) error {
+		updatedStatus = order.Status
+		return nil
+	}
+
+	// Canceled é um status final: o registro atual é encerrado e o cancelamento vira uma etapa própria
+	var finalHistory *models.OrderStatusHistory
+	var closedHistory *models.OrderStatusHistory
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: "Awaiting approval", StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	orderStatusHistoryRepoMock.UpdateFunc = func(statusHistory *models.OrderStatusHistory) error {
+		closedHistory = statusHistory
+		return nil
+	}
+
+	orderStatusHistoryRepoMock.CreateFunc = func(statusHistory *models.OrderStatusHistory) error {
+		finalHistory = statusHistory
+		return nil
+	}
+
+	statusHistoryManager := &order_status_history.ManageOrderStatusHistory{
+		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+		Logger:                       loggerMock,
+	}
+
+	useCase := &RejectOrder{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+		UpdateOrderStatus: &UpdateOrderStatus{
+			OrderRepository:      orderRepoMock,
+			Logger:               loggerMock,
+			StatusHistoryManager: statusHistoryManager,
+			OrderReservations: &order_input.ManageOrderReservations{
+				Logger: loggerMock,
+				ManageInputReservation: &input.ManageInputReservation{
+					Logger:                  loggerMock,
+					RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+				},
+			},
+			CancelOrderInputs: &order_input.CancelOrderInputs{
+				Logger:                 loggerMock,
+				ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+				IncreaseQuantityInput: &input.IncreaseQuantityInput{
+					Logger:                  loggerMock,
+					RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+				},
+			},
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderInputs:        &mocks.OrderInputRepositoryMock{},
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Users:              userRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, userID, comment)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updatedStatus != "Canceled" {
+		t.Errorf("Expected order status 'Canceled', got '%s'", updatedStatus)
+	}
+
+	if finalHistory == nil || finalHistory.Status != "Canceled" {
+		t.Fatal("Expected status history to be finalized as 'Canceled'")
+	}
+
+	if closedHistory == nil || closedHistory.Status != "Awaiting approval" || closedHistory.EndedAt == nil {
+		t.Error("Expected 'Awaiting approval' to be closed keeping its status")
+	}
+
+	if finalHistory.ChangedByUserID == nil || *finalHistory.ChangedByUserID != userID {
+		t.Errorf("Expected status history to record user %s", userID)
+	}
+
+	if finalHistory.Note != comment {
+		t.Errorf("Expected status history note '%s', got '%s'", comment, finalHistory.Note)
+	}
+}
+
+func TestRejectOrder_Process_OrderFromAnotherCustomer(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	customerID := uuid.New()
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+	}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: uuid.New(), Status: "Awaiting approval"}, nil
+	}
+
+	useCase := &RejectOrder{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders: orderRepoMock,
+			Users:  userRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), uuid.New(), "")
+
+	// Assert
+	if err == nil || err.Error() != "order does not belong to user customer" {
+		t.Errorf("Expected error 'order does not belong to user customer', got %v", err)
+	}
+}
+
+func TestRejectOrder_Process_NotAwaitingApproval(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	customerID := uuid.New()
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+	}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, CustomerID: customerID, Status: "In progress"}, nil
+	}
+
+	useCase := &RejectOrder{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders: orderRepoMock,
+			Users:  userRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), uuid.New(), "")
+
+	// Assert
+	if err == nil || err.Error() != "order is not awaiting approval" {
+		t.Errorf("Expected error 'order is not awaiting approval', got %v", err)
+	}
+}
@@ -0,0 +1,422 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	paymentDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/payment"
+	quoteDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/document"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/payment"
+	"go.uber.org/zap"
+)
+
+const (
+	// DocumentTypeWorkOrder é a ordem de serviço com as peças e a mão de obra atuais da order
+	DocumentTypeWorkOrder = "work_order"
+	// DocumentTypeQuote é o orçamento versionado apresentado ao cliente
+	DocumentTypeQuote = "quote"
+	// DocumentTypeReceipt é o comprovante de entrega do veículo, com os pagamentos recebidos
+	DocumentTypeReceipt = "receipt"
+)
+
+// statusLabels traduz o status da order para o texto impresso
+var statusLabels = map[string]string{
+	domain.StatusReceived:            "Recebida",
+	domain.StatusUndergoingDiagnosis: "Em diagnóstico",
+	domain.StatusAwaitingApproval:    "Aguardando aprovação",
+	domain.StatusInProgress:          "Em execução",
+	domain.StatusCompleted:           "Finalizada",
+	domain.StatusDelivered:           "Entregue",
+	domain.StatusCanceled:            "Cancelada",
+}
+
+// paymentMethodLabels traduz a forma de pagamento para o texto impresso
+var paymentMethodLabels = map[string]string{
+	paymentDomain.MethodCash:     "Dinheiro",
+	paymentDomain.MethodCard:     "Cartão",
+	paymentDomain.MethodPix:      "PIX",
+	paymentDomain.MethodBankSlip: "Boleto",
+}
+
+// IsValidDocumentType verifica se o tipo de documento da order é suportado
+func IsValidDocumentType(documentType string) bool {
+	switch documentType {
+	case DocumentTypeWorkOrder, DocumentTypeQuote, DocumentTypeReceipt:
+		return true
+	}
+	return false
+}
+
+// RenderOrderDocument gera os documentos imprimíveis da order: ordem de serviço, orçamento e
+// comprovante de entrega. Todos trazem a identificação da oficina, o cliente, o veículo, as
+// linhas, os totais e o histórico de status.
+type RenderOrderDocument struct {
+	OrderRepository              repository.OrderRepository
+	CustomerRepository           repository.CustomerRepository
+	QuoteRepository              repository.QuoteRepository
+	InvoiceRepository            repository.InvoiceRepository
+	OrderStatusHistoryRepository repository.OrderStatusHistoryRepository
+	FindOrderOverviewById        *FindOrderOverviewById
+	Balance                      *payment.CalculateOrderBalance
+	// Workshop identifica a oficina no cabeçalho do documento
+	Workshop document.Workshop
+	Logger   logger.Logger
+}
+
+// FetchOrderFromDB busca a order do documento
+func (uc *RenderOrderDocument) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil || order == nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+	return order, nil
+}
+
+// CustomerSection monta a seção com os dados do cliente da order
+func (uc *RenderOrderDocument) CustomerSection(customerID uuid.UUID) document.Section {
+	section := document.Section{Title: "Cliente"}
+	customer, err := uc.CustomerRepository.FindByID(customerID)
+	if err != nil || customer == nil {
+		uc.Logger.Error("Customer not found for document", zap.String("customerID", customerID.String()))
+		return section
+	}
+	section.Fields = []document.Field{
+		{Label: "Nome", Value: customer.Name},
+		{Label: "Documento", Value: customer.DocumentNumber},
+	}
+	return section
+}
+
+// VehicleSection monta a seção com os dados do veículo da order
+func VehicleSection(vehicle VehicleDetails) document.Section {
+	return document.Section{
+		Title: "Veículo",
+		Fields: []document.Field{
+			{Label: "Modelo", Value: fmt.Sprintf("%s %s %d", vehicle.Brand, vehicle.Model, vehicle.ReleaseYear)},
+			{Label: "Placa", Value: vehicle.NumberPlate},
+			{Label: "Chassi", Value: vehicle.VehicleIdentificationNumber},
+			{Label: "Cor", Value: vehicle.Color},
+		},
+	}
+}
+
+// TimelineSection monta o histórico de status em ordem cronológica, com a duração de cada etapa.
+// A etapa atual aparece como em andamento e os status finais só com o horário em que ocorreram.
+func (uc *RenderOrderDocument) TimelineSection(orderID uuid.UUID) document.Section {
+	section := document.Section{Title: "Histórico de status"}
+	timeline, _ := uc.FindOrderOverviewById.CalculateTimeline(orderID)
+
+	for _, entry := range timeline {
+		value := entry.StartedAt.Format("02/01/2006 15:04")
+		switch {
+		case entry.Ongoing:
+			value += " (em andamento)"
+		case !domain.IsFinalStatus(entry.Status):
+			value += fmt.Sprintf(" a %s (%s)", entry.EndedAt.Format("02/01/2006 15:04"), entry.Duration)
+		}
+		if entry.Note != "" {
+			value += " - " + entry.Note
+		}
+		section.Fields = append(section.Fields, document.Field{Label: StatusLabel(entry.Status), Value: value})
+	}
+	return section
+}
+
+// StatusLabel retorna o status traduzido, ou o próprio status quando não há tradução
+func StatusLabel(status string) string {
+	if label, ok := statusLabels[status]; ok {
+		return label
+	}
+	return status
+}
+
+// TotalsFields monta os totais impressos, omitindo descontos, acréscimos e impostos zerados
+func TotalsFields(parts, labor, discount, surcharge, tax, total money.Money) []document.Field {
+	totals := []document.Field{
+		{Label: "Peças", Value: document.FormatMoney(parts)},
+		{Label: "Mão de obra", Value: document.FormatMoney(labor)},
+	}
+	if !discount.IsZero() {
+		totals = append(totals, document.Field{Label: "Descontos", Value: document.FormatMoney(discount.Neg())})
+	}
+	if !surcharge.IsZero() {
+		totals = append(totals, document.Field{Label: "Acréscimos", Value: document.FormatMoney(surcharge)})
+	}
+	if !tax.IsZero() {
+		totals = append(totals, document.Field{Label: "Impostos", Value: document.FormatMoney(tax)})
+	}
+	return append(totals, document.Field{Label: "Total", Value: document.FormatMoney(total)})
+}
+
+// QuoteItemRows monta as linhas da tabela a partir de linhas de orçamento
+func QuoteItemRows(items []quoteDomain.QuoteItem) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			invoice.ItemDescription(item.ItemType, item.Description),
+			document.FormatQuantity(item.Quantity),
+			document.FormatMoney(item.UnitPrice),
+			document.FormatMoney(item.TotalPrice),
+		})
+	}
+	return rows
+}
+
+// OverviewItemRows monta as linhas da tabela com as peças e a mão de obra atuais da order.
+// Peças anuladas no cancelamento não são impressas.
+func OverviewItemRows(overview *OrderWithInputs) [][]string {
+	rows := [][]string{}
+	for _, input := range overview.Inputs {
+		if input.Voided {
+			continue
+		}
+		rows = append(rows, []string{
+			invoice.ItemDescription(quoteDomain.ItemTypePart, input.InputName),
+			document.FormatQuantity(float64(input.Quantity)),
+			document.FormatMoney(input.UnitPrice),
+			document.FormatMoney(input.TotalPrice),
+		})
+	}
+	for _, service := range overview.Services {
+		hours := service.EstimatedHours
+		if service.ActualHours != nil {
+			hours = *service.ActualHours
+		}
+		rows = append(rows, []string{
+			invoice.ItemDescription(quoteDomain.ItemTypeLabor, service.LaborServiceName),
+			document.FormatQuantity(hours),
+			document.FormatMoney(service.HourlyRate),
+			document.FormatMoney(service.TotalPrice),
+		})
+	}
+	return rows
+}
+
+// itemTable monta a tabela de linhas no layout usado por todos os documentos
+func itemTable(rows [][]string) document.Table {
+	return document.Table{
+		Headers: []string{"Descrição", "Qtd.", "Unitário", "Total"},
+		Rows:    rows,
+	}
+}
+
+// orderNumber é a identificação curta da order impressa nos documentos
+func orderNumber(orderID uuid.UUID) string {
+	return "OS-" + strings.ToUpper(orderID.String()[:8])
+}
+
+// BuildWorkOrder monta a ordem de serviço com o estado atual da order
+func (uc *RenderOrderDocument) BuildWorkOrder(order *models.Order, overview *OrderWithInputs) document.Document {
+	breakdown := overview.Pricing
+	return document.Document{
+		Workshop: uc.Workshop,
+		Title:    "Ordem de serviço",
+		Number:   orderNumber(order.ID),
+		IssuedAt: time.Now(),
+		Sections: []document.Section{
+			{
+				Title: "Ordem",
+				Fields: []document.Field{
+					{Label: "Status", Value: StatusLabel(order.Status)},
+					{Label: "Abertura", Value: order.CreatedAt.Format("02/01/2006 15:04")},
+				},
+			},
+			uc.CustomerSection(order.CustomerID),
+			VehicleSection(overview.Vehicle),
+			uc.TimelineSection(order.ID),
+		},
+		Table:  itemTable(OverviewItemRows(overview)),
+		Totals: TotalsFields(breakdown.PartsSubtotal, breakdown.LaborSubtotal, breakdown.DiscountTotal, breakdown.SurchargeTotal, breakdown.TaxTotal, breakdown.TotalPrice),
+	}
+}
+
+// FetchQuote busca a versão pedida do orçamento, ou a mais recente quando version é zero
+func (uc *RenderOrderDocument) FetchQuote(orderID uuid.UUID, version int) (*quoteDomain.Quote, error) {
+	var record *models.Quote
+	var err error
+	if version > 0 {
+		record, err = uc.QuoteRepository.FindByOrderIDAndVersion(orderID, version)
+	} else {
+		record, err = uc.QuoteRepository.FindLatestByOrderID(orderID)
+	}
+	if err != nil || record == nil {
+		uc.Logger.Error("Quote not found for document",
+			zap.String("orderID", orderID.String()),
+			zap.Int("version", version))
+		return nil, errors.New("quote not found")
+	}
+	return persistence.QuotePersistence{}.ToEntity(record), nil
+}
+
+// BuildQuote monta o orçamento a partir de uma versão gravada
+func (uc *RenderOrderDocument) BuildQuote(order *models.Order, overview *OrderWithInputs, q *quoteDomain.Quote) document.Document {
+	notes := []string{"Orçamento válido até " + q.ValidUntil.Format("02/01/2006") + "."}
+	if order.ApprovedQuoteID != nil && *order.ApprovedQuoteID == q.ID {
+		notes = append(notes, "Orçamento aprovado pelo cliente.")
+	}
+
+	return document.Document{
+		Workshop: uc.Workshop,
+		Title:    "Orçamento",
+		Number:   fmt.Sprintf("%s v%d", orderNumber(order.ID), q.Version),
+		IssuedAt: q.CreatedAt,
+		Sections: []document.Section{
+			uc.CustomerSection(order.CustomerID),
+			VehicleSection(overview.Vehicle),
+			uc.TimelineSection(order.ID),
+		},
+		Table:  itemTable(QuoteItemRows(q.Items)),
+		Totals: TotalsFields(q.PartsSubtotal, q.LaborSubtotal, q.DiscountTotal, q.SurchargeTotal, q.TaxTotal, q.TotalPrice),
+		Notes:  notes,
+	}
+}
+
+// BuildReceipt monta o comprovante de entrega. As linhas vêm da nota emitida e, sem ela, do
+// estado atual da order. Os pagamentos recebidos e o saldo ficam em uma seção própria.
+func (uc *RenderOrderDocument) BuildReceipt(order *models.Order, overview *OrderWithInputs) (document.Document, error) {
+	breakdown := overview.Pricing
+	rows := OverviewItemRows(overview)
+	totals := TotalsFields(breakdown.PartsSubtotal, breakdown.LaborSubtotal, breakdown.DiscountTotal, breakdown.SurchargeTotal, breakdown.TaxTotal, breakdown.TotalPrice)
+	notes := []string{}
+
+	record, err := uc.InvoiceRepository.FindByOrderID(order.ID)
+	if err != nil {
+		uc.Logger.Error("Database error finding invoice", zap.Error(err), zap.String("orderID", order.ID.String()))
+		return document.Document{}, err
+	}
+	if issued := (persistence.InvoicePersistence{}).ToEntity(record); issued != nil {
+		rows = make([][]string, 0, len(issued.Items))
+		for _, item := range issued.Items {
+			rows = append(rows, []string{
+				invoice.ItemDescription(item.ItemType, item.Description),
+				document.FormatQuantity(item.Quantity),
+				document.FormatMoney(item.UnitPrice),
+				document.FormatMoney(item.TotalPrice),
+			})
+		}
+		totals = TotalsFields(issued.PartsSubtotal, issued.LaborSubtotal, issued.DiscountTotal, issued.SurchargeTotal, issued.TaxTotal, issued.TotalPrice)
+		notes = append(notes, "Nota de serviço "+issued.FormattedNumber+".")
+	}
+
+	payments := document.Section{Title: "Pagamentos"}
+	if uc.Balance != nil {
+		balance, received, err := uc.Balance.Process(order)
+		if err != nil {
+			return document.Document{}, err
+		}
+		for _, p := range received {
+			label := p.PaidAt.Format("02/01/2006") + " " + paymentMethodLabels[p.Method]
+			if p.Reference != "" {
+				label += " (" + p.Reference + ")"
+			}
+			payments.Fields = append(payments.Fields, document.Field{Label: label, Value: document.FormatMoney(p.Amount)})
+		}
+		payments.Fields = append(payments.Fields,
+			document.Field{Label: "Total pago", Value: document.FormatMoney(balance.AmountPaid)},
+			document.Field{Label: "Saldo em aberto", Value: document.FormatMoney(balance.AmountDue)})
+	}
+
+	deliveredAt := order.UpdatedAt
+	return document.Document{
+		Workshop: uc.Workshop,
+		Title:    "Comprovante de entrega",
+		Number:   orderNumber(order.ID),
+		IssuedAt: deliveredAt,
+		Sections: []document.Section{
+			uc.CustomerSection(order.CustomerID),
+			VehicleSection(overview.Vehicle),
+			payments,
+			uc.TimelineSection(order.ID),
+		},
+		Table:  itemTable(rows),
+		Totals: totals,
+		Notes:  append(notes, "Veículo entregue ao cliente em "+deliveredAt.Format("02/01/2006 15:04")+"."),
+	}, nil
+}
+
+// BuildDocument monta o documento do tipo pedido
+func (uc *RenderOrderDocument) BuildDocument(order *models.Order, documentType string, version int) (document.Document, error) {
+	// O comprovante só existe depois que o veículo foi entregue
+	if documentType == DocumentTypeReceipt && order.Status != domain.StatusDelivered {
+		uc.Logger.Error("Receipt requested for order not delivered",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return document.Document{}, errors.New("order is not delivered")
+	}
+
+	var q *quoteDomain.Quote
+	if documentType == DocumentTypeQuote {
+		var err error
+		if q, err = uc.FetchQuote(order.ID, version); err != nil {
+			return document.Document{}, err
+		}
+	}
+
+	overview, err := uc.FindOrderOverviewById.Process(order.ID)
+	if err != nil {
+		return document.Document{}, err
+	}
+
+	switch documentType {
+	case DocumentTypeQuote:
+		return uc.BuildQuote(order, overview, q), nil
+	case DocumentTypeReceipt:
+		return uc.BuildReceipt(order, overview)
+	default:
+		return uc.BuildWorkOrder(order, overview), nil
+	}
+}
+
+// Process retorna o documento gerado e o Content-Type correspondente ao formato. Para o
+// orçamento, version escolhe a versão impressa; zero usa a mais recente.
+func (uc *RenderOrderDocument) Process(orderID uuid.UUID, documentType string, format string, version int) ([]byte, string, error) {
+	uc.Logger.Info("Processing render order document",
+		zap.String("orderID", orderID.String()),
+		zap.String("documentType", documentType),
+		zap.String("format", format),
+		zap.Int("version", version))
+
+	if !IsValidDocumentType(documentType) {
+		uc.Logger.Error("Invalid document type", zap.String("documentType", documentType))
+		return nil, "", errors.New("invalid document type")
+	}
+	if !document.IsValidFormat(format) {
+		uc.Logger.Error("Invalid document format", zap.String("format", format))
+		return nil, "", document.ErrInvalidFormat
+	}
+
+	order, err := uc.FetchOrderFromDB(orderID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	doc, err := uc.BuildDocument(order, documentType, version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := document.Render(doc, format)
+	if err != nil {
+		uc.Logger.Error("Error rendering order document", zap.Error(err))
+		return nil, "", err
+	}
+
+	uc.Logger.Info("Order document rendered successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("documentType", documentType),
+		zap.Int("size", len(content)))
+
+	return content, document.ContentType(format), nil
+}
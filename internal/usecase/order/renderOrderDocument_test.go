@@ -0,0 +1,300 @@
+package order
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/document"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestRenderOrderDocument_Process_QuoteHTML(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	startedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	order := &models.Order{ID: orderID, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "Awaiting approval", CreatedAt: startedAt, UpdatedAt: startedAt}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return order, nil
+	}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: id, Brand: "Fiat", Model: "Uno", ReleaseYear: 2015, NumberPlate: "ABC1D23"}, nil
+	}
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	customerRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Customer, error) {
+		return &models.Customer{ID: id, Name: "Maria Souza", DocumentNumber: "12345678900"}, nil
+	}
+	endedAt := startedAt.Add(90 * time.Minute)
+	historyRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	historyRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderStatusHistory, error) {
+		// O repositório não garante a ordem, o documento ordena pelo início de cada etapa
+		return []models.OrderStatusHistory{
+			{ID: uuid.New(), OrderID: id, Status: "Undergoing diagnosis", StartedAt: endedAt},
+			{ID: uuid.New(), OrderID: id, Status: "Received", StartedAt: startedAt, EndedAt: &endedAt},
+		}, nil
+	}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+
+	useCase := &RenderOrderDocument{
+		OrderRepository:    orderRepoMock,
+		CustomerRepository: customerRepoMock,
+		QuoteRepository:    quoteRepoMock,
+		InvoiceRepository:  &mocks.InvoiceRepositoryMock{},
+		FindOrderOverviewById: &FindOrderOverviewById{
+			OrderRepository:              orderRepoMock,
+			VehicleRepository:            vehicleRepoMock,
+			OrderInputRepository:         &mocks.OrderInputRepositoryMock{},
+			OrderStatusHistoryRepository: historyRepoMock,
+			InputRepository:              &mocks.InputRepositoryMock{},
+			OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+			LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
+			Logger:                       loggerMock,
+		},
+		Workshop: document.Workshop{Name: "Oficina Central", Phone: "(11) 4000-0000"},
+		Logger:   loggerMock,
+	}
+
+	var requestedVersion int
+	quoteRepoMock.FindByOrderIDAndVersionFunc = func(id uuid.UUID, version int) (*models.Quote, error) {
+		requestedVersion = version
+		return &models.Quote{
+			ID:            uuid.New(),
+			OrderID:       id,
+			Version:       version,
+			ValidUntil:    time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			PartsSubtotal: money.MustParse("91.00"),
+			TotalPrice:    money.MustParse("91.00"),
+			Items: []models.QuoteItem{
+				{ID: uuid.New(), ItemType: "part", ReferenceID: uuid.New(), Description: "Pastilha", Quantity: 2, UnitPrice: money.MustParse("45.50"), TotalPrice: money.MustParse("91.00")},
+			},
+		}, nil
+	}
+
+	// Act
+	content, contentType, err := useCase.Process(orderID, DocumentTypeQuote, "html", 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requestedVersion != 2 {
+		t.Errorf("Expected quote version 2 to be rendered, got %d", requestedVersion)
+	}
+	if !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("Expected HTML content type, got %s", contentType)
+	}
+	html := string(content)
+	for _, expected := range []string{"Oficina Central", "Orçamento", "Maria Souza", "ABC1D23", "Peça - Pastilha", "R$ 91,00", "16/10/2026", "01:30:00", "em andamento"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected HTML to contain %q", expected)
+		}
+	}
+	if strings.Index(html, "Recebida") > strings.Index(html, "Em diagnóstico") {
+		t.Error("Expected the status timeline in chronological order")
+	}
+}
+
+func TestRenderOrderDocument_Process_WorkOrderPDF(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	startedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	order := &models.Order{ID: orderID, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "In progress", CreatedAt: startedAt, UpdatedAt: startedAt}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return order, nil
+	}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: id, Brand: "Fiat", Model: "Uno", ReleaseYear: 2015, NumberPlate: "ABC1D23"}, nil
+	}
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	customerRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Customer, error) {
+		return &models.Customer{ID: id, Name: "Maria Souza", DocumentNumber: "12345678900"}, nil
+	}
+	endedAt := startedAt.Add(90 * time.Minute)
+	historyRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	historyRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderStatusHistory, error) {
+		// O repositório não garante a ordem, o documento ordena pelo início de cada etapa
+		return []models.OrderStatusHistory{
+			{ID: uuid.New(), OrderID: id, Status: "Undergoing diagnosis", StartedAt: endedAt},
+			{ID: uuid.New(), OrderID: id, Status: "Received", StartedAt: startedAt, EndedAt: &endedAt},
+		}, nil
+	}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+
+	useCase := &RenderOrderDocument{
+		OrderRepository:    orderRepoMock,
+		CustomerRepository: customerRepoMock,
+		QuoteRepository:    quoteRepoMock,
+		InvoiceRepository:  &mocks.InvoiceRepositoryMock{},
+		FindOrderOverviewById: &FindOrderOverviewById{
+			OrderRepository:              orderRepoMock,
+			VehicleRepository:            vehicleRepoMock,
+			OrderInputRepository:         &mocks.OrderInputRepositoryMock{},
+			OrderStatusHistoryRepository: historyRepoMock,
+			InputRepository:              &mocks.InputRepositoryMock{},
+			OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+			LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
+			Logger:                       loggerMock,
+		},
+		Workshop: document.Workshop{Name: "Oficina Central", Phone: "(11) 4000-0000"},
+		Logger:   loggerMock,
+	}
+
+	// Act
+	content, contentType, err := useCase.Process(orderID, DocumentTypeWorkOrder, "pdf", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if contentType != "application/pdf" {
+		t.Errorf("Expected PDF content type, got %s", contentType)
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Error("Expected a complete PDF document")
+	}
+}
+
+func TestRenderOrderDocument_Process_ReceiptRequiresDelivery(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	startedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	order := &models.Order{ID: orderID, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "Completed", CreatedAt: startedAt, UpdatedAt: startedAt}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return order, nil
+	}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: id, Brand: "Fiat", Model: "Uno", ReleaseYear: 2015, NumberPlate: "ABC1D23"}, nil
+	}
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	customerRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Customer, error) {
+		return &models.Customer{ID: id, Name: "Maria Souza", DocumentNumber: "12345678900"}, nil
+	}
+	endedAt := startedAt.Add(90 * time.Minute)
+	historyRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	historyRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderStatusHistory, error) {
+		// O repositório não garante a ordem, o documento ordena pelo início de cada etapa
+		return []models.OrderStatusHistory{
+			{ID: uuid.New(), OrderID: id, Status: "Undergoing diagnosis", StartedAt: endedAt},
+			{ID: uuid.New(), OrderID: id, Status: "Received", StartedAt: startedAt, EndedAt: &endedAt},
+		}, nil
+	}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+
+	useCase := &RenderOrderDocument{
+		OrderRepository:    orderRepoMock,
+		CustomerRepository: customerRepoMock,
+		QuoteRepository:    quoteRepoMock,
+		InvoiceRepository:  &mocks.InvoiceRepositoryMock{},
+		FindOrderOverviewById: &FindOrderOverviewById{
+			OrderRepository:              orderRepoMock,
+			VehicleRepository:            vehicleRepoMock,
+			OrderInputRepository:         &mocks.OrderInputRepositoryMock{},
+			OrderStatusHistoryRepository: historyRepoMock,
+			InputRepository:              &mocks.InputRepositoryMock{},
+			OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+			LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
+			Logger:                       loggerMock,
+		},
+		Workshop: document.Workshop{Name: "Oficina Central", Phone: "(11) 4000-0000"},
+		Logger:   loggerMock,
+	}
+
+	// Act
+	_, _, err := useCase.Process(orderID, DocumentTypeReceipt, "pdf", 0)
+
+	// Assert
+	if err == nil || err.Error() != "order is not delivered" {
+		t.Errorf("Expected 'order is not delivered', got %v", err)
+	}
+}
+
+func TestRenderOrderDocument_Process_RejectsUnknownType(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	startedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	order := &models.Order{ID: orderID, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "Received", CreatedAt: startedAt, UpdatedAt: startedAt}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return order, nil
+	}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: id, Brand: "Fiat", Model: "Uno", ReleaseYear: 2015, NumberPlate: "ABC1D23"}, nil
+	}
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	customerRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Customer, error) {
+		return &models.Customer{ID: id, Name: "Maria Souza", DocumentNumber: "12345678900"}, nil
+	}
+	endedAt := startedAt.Add(90 * time.Minute)
+	historyRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	historyRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderStatusHistory, error) {
+		// O repositório não garante a ordem, o documento ordena pelo início de cada etapa
+		return []models.OrderStatusHistory{
+			{ID: uuid.New(), OrderID: id, Status: "Undergoing diagnosis", StartedAt: endedAt},
+			{ID: uuid.New(), OrderID: id, Status: "Received", StartedAt: startedAt, EndedAt: &endedAt},
+		}, nil
+	}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+
+	useCase := &RenderOrderDocument{
+		OrderRepository:    orderRepoMock,
+		CustomerRepository: customerRepoMock,
+		QuoteRepository:    quoteRepoMock,
+		InvoiceRepository:  &mocks.InvoiceRepositoryMock{},
+		FindOrderOverviewById: &FindOrderOverviewById{
+			OrderRepository:              orderRepoMock,
+			VehicleRepository:            vehicleRepoMock,
+			OrderInputRepository:         &mocks.OrderInputRepositoryMock{},
+			OrderStatusHistoryRepository: historyRepoMock,
+			InputRepository:              &mocks.InputRepositoryMock{},
+			OrderServiceRepository:       &mocks.OrderServiceRepositoryMock{},
+			LaborServiceRepository:       &mocks.LaborServiceRepositoryMock{},
+			Logger:                       loggerMock,
+		},
+		Workshop: document.Workshop{Name: "Oficina Central", Phone: "(11) 4000-0000"},
+		Logger:   loggerMock,
+	}
+
+	// Act
+	_, _, err := useCase.Process(orderID, "invoice", "pdf", 0)
+
+	// Assert
+	if err == nil || err.Error() != "invalid document type" {
+		t.Errorf("Expected 'invalid document type', got %v", err)
+	}
+}
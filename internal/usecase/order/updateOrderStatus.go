@@ -4,10 +4,18 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	orderInput "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
+	statusHistory "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_input"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_status_history"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
 	"go.uber.org/zap"
 )
 
@@ -15,37 +23,50 @@ type UpdateOrderStatus struct {
 	OrderRepository      repository.OrderRepository
 	Logger               logger.Logger
 	StatusHistoryManager *order_status_history.ManageOrderStatusHistory
+	UnitOfWork           repository.UnitOfWork
+
+	// OrderReservations baixa as peças reservadas quando a order entra em execução
+	OrderReservations *order_input.ManageOrderReservations
+	// CancelOrderInputs devolve as peças ao estoque e anula os itens quando a order é cancelada
+	CancelOrderInputs *order_input.CancelOrderInputs
+	// ApprovedQuote confere, antes da conclusão, se a order ainda corresponde ao orçamento aprovado
+	ApprovedQuote *quote.VerifyApprovedQuote
+	// IssueInvoice emite a nota de serviço quando a order é concluída
+	IssueInvoice *invoice.IssueInvoice
+	// Balance confere, antes da entrega, se a order não tem saldo em aberto
+	Balance *payment.CalculateOrderBalance
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *UpdateOrderStatus) WithRepositories(repos repository.Repositories) *UpdateOrderStatus {
+	if uc == nil {
+		return nil
+	}
+	return &UpdateOrderStatus{
+		OrderRepository:      repos.Orders,
+		Logger:               uc.Logger,
+		StatusHistoryManager: uc.StatusHistoryManager.WithRepositories(repos),
+		UnitOfWork:           repos.UnitOfWork,
+
+		OrderReservations: uc.OrderReservations.WithRepositories(repos),
+		CancelOrderInputs: uc.CancelOrderInputs.WithRepositories(repos),
+		ApprovedQuote:     uc.ApprovedQuote.WithRepositories(repos),
+		IssueInvoice:      uc.IssueInvoice.WithRepositories(repos),
+		Balance:           uc.Balance.WithRepositories(repos),
+	}
 }
 
 // GetValidStatuses retorna a lista de status válidos
 func (uc *UpdateOrderStatus) GetValidStatuses() []string {
-	return []string{
-		"Received",
-		"Undergoing diagnosis",
-		"Awaiting approval",
-		"In progress",
-		"Completed",
-		"Delivered",
-		"Canceled",
-	}
+	return domain.ValidStatuses()
 }
 
 // ValidateOrderStatus valida se o status é válido
 func (uc *UpdateOrderStatus) ValidateOrderStatus(newStatus string) error {
-	validStatuses := uc.GetValidStatuses()
-
-	isValidStatus := false
-	for _, status := range validStatuses {
-		if status == newStatus {
-			isValidStatus = true
-			break
-		}
-	}
-
-	if !isValidStatus {
+	if !domain.IsValidStatus(newStatus) {
 		uc.Logger.Error("Invalid order status",
 			zap.String("newStatus", newStatus),
-			zap.Strings("validStatuses", validStatuses))
+			zap.Strings("validStatuses", uc.GetValidStatuses()))
 		return errors.New("invalid order status")
 	}
 
@@ -53,9 +74,31 @@ func (uc *UpdateOrderStatus) ValidateOrderStatus(newStatus string) error {
 	return nil
 }
 
-// FetchOrderFromDB busca um order específico do banco de dados
+// ValidateStatusTransition valida a transição do status atual para o novo status de acordo com a máquina de estados
+func (uc *UpdateOrderStatus) ValidateStatusTransition(currentStatus, newStatus, userType string) error {
+	err := domain.ValidateTransition(currentStatus, newStatus, userType)
+	if err != nil {
+		uc.Logger.Error("Status transition rejected",
+			zap.String("currentStatus", currentStatus),
+			zap.String("newStatus", newStatus),
+			zap.String("userType", userType),
+			zap.Strings("allowedStatuses", domain.AllowedNextStatuses(currentStatus)),
+			zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Status transition validation passed",
+		zap.String("currentStatus", currentStatus),
+		zap.String("newStatus", newStatus),
+		zap.String("userType", userType))
+	return nil
+}
+
+// FetchOrderFromDB busca a order bloqueando a linha até o fim da transação. Sem o bloqueio, duas
+// transições simultâneas (por exemplo, dois cancelamentos) passariam pela validação e devolveriam
+// as mesmas peças ao estoque duas vezes.
 func (uc *UpdateOrderStatus) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
-	order, err := uc.OrderRepository.FindByID(orderID)
+	order, err := uc.OrderRepository.FindByIDForUpdate(orderID)
 	if err != nil {
 		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
 		return nil, errors.New("order not found")
@@ -68,6 +111,33 @@ func (uc *UpdateOrderStatus) FetchOrderFromDB(orderID uuid.UUID) (*models.Order,
 	return order, nil
 }
 
+// ValidateApprovedQuote impede a conclusão de uma order alterada depois da aprovação. Nesse caso
+// a order deve voltar para "Awaiting approval" e o cliente aprovar uma nova versão do orçamento.
+func (uc *UpdateOrderStatus) ValidateApprovedQuote(order *models.Order, newStatus string) error {
+	if newStatus != domain.StatusCompleted || uc.ApprovedQuote == nil {
+		return nil
+	}
+	return uc.ApprovedQuote.Process(order)
+}
+
+// ResetApprovedQuote descarta a aprovação anterior quando a order volta a aguardar aprovação
+func (uc *UpdateOrderStatus) ResetApprovedQuote(order *models.Order, newStatus string) error {
+	if newStatus != domain.StatusAwaitingApproval || order.ApprovedQuoteID == nil {
+		return nil
+	}
+
+	if err := uc.OrderRepository.SetApprovedQuote(order.ID, nil); err != nil {
+		uc.Logger.Error("Database error resetting approved quote", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Approved quote reset, order needs a new approval",
+		zap.String("orderID", order.ID.String()),
+		zap.String("previousQuoteID", order.ApprovedQuoteID.String()))
+	order.ApprovedQuoteID = nil
+	return nil
+}
+
 // UpdateOrderStatusInDB atualiza o status da order no banco de dados
 func (uc *UpdateOrderStatus) UpdateOrderStatusInDB(order *models.Order, newStatus string) error {
 	// Atualiza o status no modelo
@@ -88,13 +158,12 @@ func (uc *UpdateOrderStatus) UpdateOrderStatusInDB(order *models.Order, newStatu
 }
 
 // UpdateStatusHistory atualiza o histórico de status
-func (uc *UpdateOrderStatus) UpdateStatusHistory(orderID uuid.UUID, newStatus string) error {
-	historyErr := uc.StatusHistoryManager.UpdateStatus(orderID, newStatus)
+func (uc *UpdateOrderStatus) UpdateStatusHistory(orderID uuid.UUID, newStatus string, change statusHistory.StatusChange) error {
+	historyErr := uc.StatusHistoryManager.UpdateStatusWithChange(orderID, newStatus, change)
 	if historyErr != nil {
 		uc.Logger.Error("Error updating status history", zap.Error(historyErr))
-		// Não retorna erro aqui, pois a order já foi atualizada
-		// Apenas loga o erro para monitoramento
-		return nil
+		// Status e histórico são gravados na mesma transação, então o erro desfaz a atualização da order
+		return historyErr
 	}
 
 	uc.Logger.Info("Status history updated successfully",
@@ -104,35 +173,134 @@ func (uc *UpdateOrderStatus) UpdateStatusHistory(orderID uuid.UUID, newStatus st
 	return nil
 }
 
-func (uc *UpdateOrderStatus) Process(orderID uuid.UUID, newStatus string) error {
-	uc.Logger.Info("Processing update order status",
-		zap.String("orderID", orderID.String()),
-		zap.String("newStatus", newStatus))
+// ApplyStockChanges consome as reservas quando a order entra em execução e, quando ela é cancelada,
+// devolve as peças ao estoque retornando o resumo da reposição
+func (uc *UpdateOrderStatus) ApplyStockChanges(orderID uuid.UUID, newStatus string, change statusHistory.StatusChange) (*orderInput.RestockSummary, error) {
+	switch newStatus {
+	case domain.StatusInProgress:
+		return nil, uc.OrderReservations.ConsumeReservations(orderID, change.ChangedByUserID)
+	case domain.StatusCanceled:
+		return uc.CancelOrderInputs.Process(orderID, change.ChangedByUserID)
+	}
+	return nil, nil
+}
 
-	// Valida se o status é válido
-	if err := uc.ValidateOrderStatus(newStatus); err != nil {
-		return err
+// IssueInvoiceOnCompletion emite a nota na conclusão da order. A emissão é idempotente, então a
+// entrega de uma order concluída antes da existência das notas também gera a nota que faltava.
+func (uc *UpdateOrderStatus) IssueInvoiceOnCompletion(orderID uuid.UUID, newStatus string, change statusHistory.StatusChange) error {
+	if uc.IssueInvoice == nil || (newStatus != domain.StatusCompleted && newStatus != domain.StatusDelivered) {
+		return nil
+	}
+	_, err := uc.IssueInvoice.Process(orderID, change.ChangedByUserID)
+	return err
+}
+
+// ValidateOutstandingBalance impede a entrega de uma order com saldo em aberto. Um admin pode
+// liberar a entrega informando OverrideBalance na mudança de status.
+func (uc *UpdateOrderStatus) ValidateOutstandingBalance(order *models.Order, newStatus string, userType string, change statusHistory.StatusChange) error {
+	if newStatus != domain.StatusDelivered || uc.Balance == nil {
+		return nil
 	}
 
-	// Busca a order
-	order, err := uc.FetchOrderFromDB(orderID)
+	balance, _, err := uc.Balance.Process(order)
 	if err != nil {
 		return err
 	}
+	if balance.IsSettled() {
+		return nil
+	}
 
-	uc.Logger.Info("Order found",
+	if !change.OverrideBalance {
+		uc.Logger.Error("Order has outstanding balance, delivery blocked",
+			zap.String("orderID", order.ID.String()),
+			zap.Stringer("amountDue", balance.AmountDue))
+		return errors.New("order has outstanding balance")
+	}
+	if userType != user.UserTypeAdmin {
+		uc.Logger.Error("Balance override requires admin",
+			zap.String("orderID", order.ID.String()),
+			zap.String("userType", userType))
+		return errors.New("balance override requires admin")
+	}
+
+	uc.Logger.Info("Delivery with outstanding balance authorized by admin",
 		zap.String("orderID", order.ID.String()),
-		zap.String("currentStatus", order.Status),
-		zap.String("newStatus", newStatus))
+		zap.Stringer("amountDue", balance.AmountDue))
+	return nil
+}
 
-	// Atualiza o status da order
-	err = uc.UpdateOrderStatusInDB(order, newStatus)
-	if err != nil {
-		return err
+// Process atualiza o status da order. Quando a order é cancelada, retorna o resumo das peças
+// devolvidas ao estoque; nas demais transições o resumo é nil.
+func (uc *UpdateOrderStatus) Process(orderID uuid.UUID, newStatus string, userType string, change statusHistory.StatusChange) (*orderInput.RestockSummary, error) {
+	uc.Logger.Info("Processing update order status",
+		zap.String("orderID", orderID.String()),
+		zap.String("newStatus", newStatus),
+		zap.String("userType", userType))
+
+	// Valida se o status é válido
+	if err := uc.ValidateOrderStatus(newStatus); err != nil {
+		return nil, err
 	}
 
-	// Atualiza o histórico de status
-	uc.UpdateStatusHistory(orderID, newStatus)
+	// Status da order, estoque e histórico são gravados na mesma transação
+	var summary *orderInput.RestockSummary
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	return nil
+		// Busca a order
+		order, err := tx.FetchOrderFromDB(orderID)
+		if err != nil {
+			return err
+		}
+
+		uc.Logger.Info("Order found",
+			zap.String("orderID", order.ID.String()),
+			zap.String("currentStatus", order.Status),
+			zap.String("newStatus", newStatus))
+
+		// Valida se a transição é permitida a partir do status atual
+		if err := tx.ValidateStatusTransition(order.Status, newStatus, userType); err != nil {
+			return err
+		}
+
+		// Valida se a order ainda corresponde ao orçamento aprovado
+		if err := tx.ValidateApprovedQuote(order, newStatus); err != nil {
+			return err
+		}
+
+		// Descarta a aprovação anterior quando a order volta para aprovação
+		if err := tx.ResetApprovedQuote(order, newStatus); err != nil {
+			return err
+		}
+
+		// Atualiza o status da order
+		if err := tx.UpdateOrderStatusInDB(order, newStatus); err != nil {
+			return err
+		}
+
+		// Consome as reservas ou devolve as peças ao estoque conforme o novo status
+		summary, err = tx.ApplyStockChanges(orderID, newStatus, change)
+		if err != nil {
+			return err
+		}
+
+		// Emite a nota de serviço na mesma transação da conclusão
+		if err := tx.IssueInvoiceOnCompletion(orderID, newStatus, change); err != nil {
+			return err
+		}
+
+		// Bloqueia a entrega com saldo em aberto. Roda depois da emissão para que o saldo seja o
+		// da nota; um erro aqui desfaz a transação inteira.
+		if err := tx.ValidateOutstandingBalance(order, newStatus, userType, change); err != nil {
+			return err
+		}
+
+		// Atualiza o histórico de status
+		return tx.UpdateStatusHistory(orderID, newStatus, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
 }
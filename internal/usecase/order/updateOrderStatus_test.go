@@ -6,9 +6,18 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	statusHistory "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/invoice"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_input"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_status_history"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/quote"
 	"go.uber.org/zap"
 )
 
@@ -19,7 +28,7 @@ func TestUpdateOrderStatus_Process_Success(t *testing.T) {
 	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
 
 	orderID := uuid.New()
-	newStatus := "In progress"
+	newStatus := "Undergoing diagnosis"
 
 	mockOrder := &models.Order{
 		ID:         orderID,
@@ -71,10 +80,14 @@ func TestUpdateOrderStatus_Process_Success(t *testing.T) {
 		OrderRepository:      orderRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, newStatus)
+	_, err := useCase.Process(orderID, newStatus, "mechanic", statusHistory.StatusChange{})
 
 	// Assert
 	if err != nil {
@@ -105,10 +118,14 @@ func TestUpdateOrderStatus_Process_InvalidStatus(t *testing.T) {
 		OrderRepository:      orderRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, invalidStatus)
+	_, err := useCase.Process(orderID, invalidStatus, "mechanic", statusHistory.StatusChange{})
 
 	// Assert
 	if err == nil {
@@ -148,10 +165,14 @@ func TestUpdateOrderStatus_Process_OrderNotFound(t *testing.T) {
 		OrderRepository:      orderRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, newStatus)
+	_, err := useCase.Process(orderID, newStatus, "mechanic", statusHistory.StatusChange{})
 
 	// Assert
 	if err == nil {
@@ -170,7 +191,7 @@ func TestUpdateOrderStatus_Process_UpdateOrderError(t *testing.T) {
 	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
 
 	orderID := uuid.New()
-	newStatus := "In progress"
+	newStatus := "Undergoing diagnosis"
 
 	mockOrder := &models.Order{
 		ID:         orderID,
@@ -204,10 +225,14 @@ func TestUpdateOrderStatus_Process_UpdateOrderError(t *testing.T) {
 		OrderRepository:      orderRepoMock,
 		Logger:               loggerMock,
 		StatusHistoryManager: statusHistoryManager,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, newStatus)
+	_, err := useCase.Process(orderID, newStatus, "mechanic", statusHistory.StatusChange{})
 
 	// Assert
 	if err == nil {
@@ -402,6 +427,45 @@ func TestUpdateOrderStatus_FetchOrderFromDB_NotFound(t *testing.T) {
 	}
 }
 
+func TestUpdateOrderStatus_FetchOrderFromDB_LocksOrder(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	orderID := uuid.New()
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	// Mock OrderRepository: a leitura sem bloqueio não pode ser usada na transição
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		t.Error("Expected the order to be read with a row lock")
+		return nil, errors.New("unexpected unlocked read")
+	}
+	locked := false
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		locked = true
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	result, err := useCase.FetchOrderFromDB(orderID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !locked || result.ID != orderID {
+		t.Error("Expected the order to be locked until the end of the transition")
+	}
+}
+
 func TestUpdateOrderStatus_UpdateOrderStatusInDB_Success(t *testing.T) {
 	// Arrange
 	orderRepoMock := &mocks.OrderRepositoryMock{}
@@ -530,7 +594,7 @@ func TestUpdateOrderStatus_UpdateStatusHistory_Success(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.UpdateStatusHistory(orderID, newStatus)
+	err := useCase.UpdateStatusHistory(orderID, newStatus, statusHistory.StatusChange{})
 
 	// Assert
 	if err != nil {
@@ -567,11 +631,957 @@ func TestUpdateOrderStatus_UpdateStatusHistory_Error(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.UpdateStatusHistory(orderID, newStatus)
+	err := useCase.UpdateStatusHistory(orderID, newStatus, statusHistory.StatusChange{})
+
+	// Assert
+	// O erro do histórico é propagado para que a transação faça rollback
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
+
+func TestUpdateOrderStatus_Process_StatusHistoryErrorRollsBack(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	orderID := uuid.New()
+	committed := false
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, Status: "Received"}, nil
+	}
+	orderRepoMock.UpdateFunc = func(order *models.Order) error {
+		return nil
+	}
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return nil, errors.New("database error")
+	}
+
+	unitOfWork := &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+		Orders:             orderRepoMock,
+		OrderStatusHistory: orderStatusHistoryRepoMock,
+	}}
+	unitOfWork.ExecuteFunc = func(fn func(repos repository.Repositories) error) error {
+		// Simula o commit somente quando a função termina sem erro
+		if err := fn(unitOfWork.Repositories); err != nil {
+			return err
+		}
+		committed = true
+		return nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+		StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+			OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+			Logger:                       loggerMock,
+		},
+		UnitOfWork: unitOfWork,
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, "Undergoing diagnosis", "mechanic", statusHistory.StatusChange{})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error when status history fails, got nil")
+	}
+	if committed {
+		t.Error("Expected transaction not to be committed")
+	}
+}
+
+func TestUpdateOrderStatus_Process_InvalidTransition(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	orderID := uuid.New()
+	updateCalled := false
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	// Mock OrderRepository
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, Status: "Received"}, nil
+	}
+
+	orderRepoMock.UpdateFunc = func(order *models.Order) error {
+		updateCalled = true
+		return nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders: orderRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, "Delivered", "admin", statusHistory.StatusChange{})
+
+	// Assert
+	var transitionErr *domain.InvalidTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected InvalidTransitionError, got %v", err)
+	}
+
+	expectedAllowed := []string{"Undergoing diagnosis", "Canceled"}
+	if len(transitionErr.Allowed) != len(expectedAllowed) {
+		t.Fatalf("Expected %d allowed statuses, got %d", len(expectedAllowed), len(transitionErr.Allowed))
+	}
+
+	for i, status := range expectedAllowed {
+		if transitionErr.Allowed[i] != status {
+			t.Errorf("Expected allowed status '%s' at position %d, got '%s'", status, i, transitionErr.Allowed[i])
+		}
+	}
+
+	if updateCalled {
+		t.Error("Expected order not to be updated for an invalid transition")
+	}
+}
+
+func TestUpdateOrderStatus_Process_UserNotAllowed(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	orderID := uuid.New()
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	// Mock OrderRepository
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, Status: "Awaiting approval"}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders: orderRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, "In progress", "mechanic", statusHistory.StatusChange{})
+
+	// Assert
+	if !errors.Is(err, domain.ErrTransitionNotAllowedForUser) {
+		t.Errorf("Expected ErrTransitionNotAllowedForUser, got %v", err)
+	}
+}
+
+func TestUpdateOrderStatus_ValidateStatusTransition(t *testing.T) {
+	// Arrange
+	loggerMock := &mocks.LoggerMock{}
+	useCase := &UpdateOrderStatus{
+		Logger: loggerMock,
+	}
+
+	tests := []struct {
+		name          string
+		currentStatus string
+		newStatus     string
+		userType      string
+		expectError   bool
+	}{
+		{"Received to diagnosis by mechanic", "Received", "Undergoing diagnosis", "mechanic", false},
+		{"Diagnosis to awaiting approval by mechanic", "Undergoing diagnosis", "Awaiting approval", "mechanic", false},
+		{"Approval by vehicle owner", "Awaiting approval", "In progress", "vehicle_owner", false},
+		{"Approval by admin", "Awaiting approval", "In progress", "admin", false},
+		{"Approval by mechanic", "Awaiting approval", "In progress", "mechanic", true},
+		{"Rejection by vehicle owner", "Awaiting approval", "Canceled", "vehicle_owner", false},
+		{"In progress to completed", "In progress", "Completed", "mechanic", false},
+		{"Cancel in progress by mechanic", "In progress", "Canceled", "mechanic", true},
+		{"Completed to delivered", "Completed", "Delivered", "mechanic", false},
+		{"Received straight to delivered", "Received", "Delivered", "admin", true},
+		{"Leaving canceled", "Canceled", "Received", "admin", true},
+		{"Leaving delivered", "Delivered", "In progress", "admin", true},
+		{"Same status", "In progress", "In progress", "admin", true},
+	}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := useCase.ValidateStatusTransition(tt.currentStatus, tt.newStatus, tt.userType)
+
+			// Assert
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for transition '%s' -> '%s' by '%s', got nil", tt.currentStatus, tt.newStatus, tt.userType)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for transition '%s' -> '%s' by '%s', got %v", tt.currentStatus, tt.newStatus, tt.userType, err)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatus_Process_InProgressConsumesReservations(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+
+	orderID := uuid.New()
+	userID := uuid.New()
+	reservedInputID := uuid.New()
+
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: id, InputID: reservedInputID, Quantity: 2, StockStatus: "reserved"},
+			{ID: uuid.New(), OrderID: id, InputID: uuid.New(), Quantity: 1, StockStatus: "consumed"},
+		}, nil
+	}
+
+	var consumed []uuid.UUID
+	inputRepoMock.ConsumeReservationFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		consumed = append(consumed, id)
+		if quantity != 2 {
+			t.Errorf("Expected to consume 2 units, got %d", quantity)
+		}
+		return &models.Input{ID: id, Quantity: 8, ReservedQuantity: 0}, nil
+	}
+
+	var updatedLines []models.OrderInput
+	orderInputRepoMock.UpdateFunc = func(orderInput *models.OrderInput) error {
+		updatedLines = append(updatedLines, *orderInput)
+		return nil
+	}
+
+	var recorded *models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = movement
+		return nil
+	}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Awaiting approval"}, nil
+	}
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: "Awaiting approval", StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		Logger: loggerMock,
+		StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+			Logger: loggerMock,
+		},
+		OrderReservations: &order_input.ManageOrderReservations{
+			Logger: loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		CancelOrderInputs: &order_input.CancelOrderInputs{
+			Logger:                 loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+			IncreaseQuantityInput: &input.IncreaseQuantityInput{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, "In progress", "admin", statusHistory.StatusChange{ChangedByUserID: &userID})
 
 	// Assert
-	// Should not return error even if status history update fails
 	if err != nil {
-		t.Errorf("Expected no error (status history errors are logged but not returned), got %v", err)
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(consumed) != 1 || consumed[0] != reservedInputID {
+		t.Fatalf("Expected only the reserved input to be consumed, got %v", consumed)
+	}
+	if len(updatedLines) != 1 || updatedLines[0].StockStatus != "consumed" {
+		t.Errorf("Expected reserved line to be marked as consumed, got %+v", updatedLines)
+	}
+	if recorded == nil {
+		t.Fatal("Expected consumption to be recorded in the ledger")
+	}
+	if recorded.MovementType != "order_consumption" || recorded.Delta != -2 || recorded.BalanceAfter != 8 {
+		t.Errorf("Unexpected movement recorded: %+v", recorded)
+	}
+	if recorded.OrderID == nil || *recorded.OrderID != orderID || recorded.UserID == nil || *recorded.UserID != userID {
+		t.Errorf("Expected movement to reference order and user, got %+v", recorded)
+	}
+}
+
+func TestUpdateOrderStatus_Process_CanceledRestocksAndVoidsOrderInputs(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	movementRepoMock := &mocks.InventoryMovementRepositoryMock{}
+
+	orderID := uuid.New()
+	userID := uuid.New()
+	reservedInputID := uuid.New()
+	consumedInputID := uuid.New()
+	damagedInputID := uuid.New()
+
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: id, InputID: reservedInputID, Quantity: 3, StockStatus: "reserved"},
+			{ID: uuid.New(), OrderID: id, InputID: consumedInputID, Quantity: 2, StockStatus: "consumed"},
+			{ID: uuid.New(), OrderID: id, InputID: damagedInputID, Quantity: 1, StockStatus: "consumed", UsedOrDamaged: true},
+		}, nil
+	}
+
+	var released int
+	inputRepoMock.ReleaseReservationFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		released += quantity
+		return &models.Input{ID: id, Quantity: 10, ReservedQuantity: 0}, nil
+	}
+
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, InputType: "material", Quantity: 5}, nil
+	}
+	var restocked []uuid.UUID
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		restocked = append(restocked, id)
+		return &models.Input{ID: id, Quantity: 5 + quantity}, nil
+	}
+
+	var voided []models.OrderInput
+	orderInputRepoMock.UpdateFunc = func(orderInput *models.OrderInput) error {
+		voided = append(voided, *orderInput)
+		return nil
+	}
+
+	var recorded []*models.InventoryMovement
+	movementRepoMock.CreateFunc = func(movement *models.InventoryMovement) error {
+		recorded = append(recorded, movement)
+		return nil
+	}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: "In progress", StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		Logger: loggerMock,
+		StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+			Logger: loggerMock,
+		},
+		OrderReservations: &order_input.ManageOrderReservations{
+			Logger: loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		CancelOrderInputs: &order_input.CancelOrderInputs{
+			Logger:                 loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+			IncreaseQuantityInput: &input.IncreaseQuantityInput{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			InventoryMovements: movementRepoMock,
+		}},
+	}
+
+	// Act
+	summary, err := useCase.Process(orderID, "Canceled", "admin", statusHistory.StatusChange{ChangedByUserID: &userID})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if released != 3 {
+		t.Errorf("Expected 3 units released, got %d", released)
+	}
+	if len(restocked) != 1 || restocked[0] != consumedInputID {
+		t.Errorf("Expected only the consumed, undamaged input to be restocked, got %v", restocked)
+	}
+	if len(recorded) != 1 || recorded[0].MovementType != "order_return" || recorded[0].Delta != 2 || recorded[0].Reason != "order canceled" {
+		t.Errorf("Expected one order_return movement of 2 units, got %+v", recorded)
+	}
+
+	if len(voided) != 3 {
+		t.Fatalf("Expected all 3 order inputs to be voided, got %d", len(voided))
+	}
+	expectedStatuses := map[uuid.UUID]string{
+		reservedInputID: "released",
+		consumedInputID: "returned",
+		damagedInputID:  "consumed",
+	}
+	for _, line := range voided {
+		if line.VoidedAt == nil {
+			t.Errorf("Expected order input %s to have voided_at set", line.InputID)
+		}
+		if line.StockStatus != expectedStatuses[line.InputID] {
+			t.Errorf("Expected stock status %s for input %s, got %s", expectedStatuses[line.InputID], line.InputID, line.StockStatus)
+		}
+	}
+
+	if summary == nil {
+		t.Fatal("Expected restock summary")
+	}
+	if len(summary.Restocked) != 1 || summary.Restocked[0].Quantity != 2 {
+		t.Errorf("Expected 2 units restocked in summary, got %+v", summary.Restocked)
+	}
+	if len(summary.Released) != 1 || summary.Released[0].Quantity != 3 {
+		t.Errorf("Expected 3 units released in summary, got %+v", summary.Released)
+	}
+	if len(summary.Retained) != 1 || summary.Retained[0].InputID != damagedInputID {
+		t.Errorf("Expected damaged input retained in summary, got %+v", summary.Retained)
+	}
+	if summary.VoidedItems != 3 {
+		t.Errorf("Expected 3 voided items, got %d", summary.VoidedItems)
+	}
+}
+
+func TestUpdateOrderStatus_Process_CancelRestockErrorRollsBack(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: id, InputID: uuid.New(), Quantity: 2, StockStatus: "consumed"},
+		}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, InputType: "material", Quantity: 5}, nil
+	}
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, errors.New("database error")
+	}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: "In progress", StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		Logger: loggerMock,
+		StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+			Logger: loggerMock,
+		},
+		OrderReservations: &order_input.ManageOrderReservations{
+			Logger: loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		CancelOrderInputs: &order_input.CancelOrderInputs{
+			Logger:                 loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+			IncreaseQuantityInput: &input.IncreaseQuantityInput{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+	}
+
+	// Act
+	summary, err := useCase.Process(uuid.New(), "Canceled", "admin", statusHistory.StatusChange{})
+
+	// Assert
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+	if summary != nil {
+		t.Errorf("Expected no summary on error, got %+v", summary)
+	}
+}
+
+func TestUpdateOrderStatus_Process_ReservationErrorRollsBack(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: id, InputID: uuid.New(), Quantity: 2, StockStatus: "reserved"},
+		}, nil
+	}
+	inputRepoMock.ConsumeReservationFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return nil, repository.ErrInsufficientReservation
+	}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Awaiting approval"}, nil
+	}
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: "Awaiting approval", StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		Logger: loggerMock,
+		StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+			Logger: loggerMock,
+		},
+		OrderReservations: &order_input.ManageOrderReservations{
+			Logger: loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		CancelOrderInputs: &order_input.CancelOrderInputs{
+			Logger:                 loggerMock,
+			ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+			IncreaseQuantityInput: &input.IncreaseQuantityInput{
+				Logger:                  loggerMock,
+				RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+			},
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), "In progress", "vehicle_owner", statusHistory.StatusChange{})
+
+	// Assert
+	if !errors.Is(err, repository.ErrInsufficientReservation) {
+		t.Errorf("Expected insufficient reservation error, got %v", err)
+	}
+}
+
+func TestUpdateOrderStatus_Process_CompletedRequiresApprovedQuoteToMatch(t *testing.T) {
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	quoteID := uuid.New()
+	inputID := uuid.New()
+	approvedItems := []models.QuoteItem{{ID: uuid.New(), QuoteID: quoteID, ItemType: "part", ReferenceID: inputID, Quantity: 2, UnitPrice: money.MustParse("30"), TotalPrice: money.MustParse("60")}}
+
+	tests := []struct {
+		name        string
+		orderInputs []models.OrderInput
+		expected    string
+	}{
+		{"order unchanged", []models.OrderInput{{ID: uuid.New(), OrderID: orderID, InputID: inputID, Quantity: 2, UnitPrice: money.MustParse("30"), TotalPrice: money.MustParse("60")}}, ""},
+		{"part added after approval", []models.OrderInput{
+			{ID: uuid.New(), OrderID: orderID, InputID: inputID, Quantity: 2, UnitPrice: money.MustParse("30"), TotalPrice: money.MustParse("60")},
+			{ID: uuid.New(), OrderID: orderID, InputID: uuid.New(), Quantity: 1, UnitPrice: money.MustParse("15"), TotalPrice: money.MustParse("15")},
+		}, "order changed after approval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &models.Order{ID: orderID, Status: "In progress", ApprovedQuoteID: &quoteID}
+			approvedQuote := &models.Quote{ID: quoteID, OrderID: orderID, Version: 1, Items: approvedItems}
+
+			updated := false
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderRepoMock.UpdateFunc = func(order *models.Order) error {
+				updated = true
+				return nil
+			}
+
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return order, nil
+			}
+
+			orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+			orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+				return tt.orderInputs, nil
+			}
+
+			quoteRepoMock := &mocks.QuoteRepositoryMock{}
+			quoteRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Quote, error) {
+				return approvedQuote, nil
+			}
+
+			orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+			orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+				return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: order.Status, StartedAt: time.Now().Add(-time.Hour)}, nil
+			}
+
+			useCase := &UpdateOrderStatus{
+				OrderRepository: orderRepoMock,
+				Logger:          loggerMock,
+				StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+					OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+					Logger:                       loggerMock,
+				},
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Orders:             orderRepoMock,
+					OrderInputs:        orderInputRepoMock,
+					Inputs:             &mocks.InputRepositoryMock{},
+					OrderServices:      &mocks.OrderServiceRepositoryMock{},
+					LaborServices:      &mocks.LaborServiceRepositoryMock{},
+					OrderStatusHistory: orderStatusHistoryRepoMock,
+					Quotes:             quoteRepoMock,
+				}},
+				ApprovedQuote: &quote.VerifyApprovedQuote{
+					SnapshotOrderItems: &quote.SnapshotOrderItems{
+						OrderInputRepository:   &mocks.OrderInputRepositoryMock{},
+						InputRepository:        &mocks.InputRepositoryMock{},
+						OrderServiceRepository: &mocks.OrderServiceRepositoryMock{},
+						LaborServiceRepository: &mocks.LaborServiceRepositoryMock{},
+						Logger:                 loggerMock,
+					},
+					Logger: loggerMock,
+				},
+			}
+
+			_, err := useCase.Process(orderID, "Completed", "mechanic", statusHistory.StatusChange{})
+
+			if tt.expected == "" {
+				if err != nil || !updated {
+					t.Errorf("Expected order to be completed, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+			if updated {
+				t.Error("Order status should not be updated")
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatus_Process_CompletedIssuesInvoice(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	quoteID := uuid.New()
+	inputID := uuid.New()
+	order := &models.Order{ID: orderID, CustomerID: uuid.New(), VehicleID: uuid.New(), Status: "In progress", ApprovedQuoteID: &quoteID}
+	approvedQuote := &models.Quote{ID: quoteID, OrderID: orderID, Version: 1, PartsSubtotal: money.MustParse("60"), TotalPrice: money.MustParse("60"), Items: []models.QuoteItem{
+		{ID: uuid.New(), QuoteID: quoteID, ItemType: "part", ReferenceID: inputID, Quantity: 2, UnitPrice: money.MustParse("30"), TotalPrice: money.MustParse("60")},
+	}}
+	orderInputs := []models.OrderInput{{ID: uuid.New(), OrderID: orderID, InputID: inputID, Quantity: 2, UnitPrice: money.MustParse("30"), TotalPrice: money.MustParse("60")}}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return order, nil
+	}
+
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return orderInputs, nil
+	}
+
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+	quoteRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Quote, error) {
+		return approvedQuote, nil
+	}
+
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: order.Status, StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+		StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+			OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+			Logger:                       loggerMock,
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			Inputs:             &mocks.InputRepositoryMock{},
+			OrderServices:      &mocks.OrderServiceRepositoryMock{},
+			LaborServices:      &mocks.LaborServiceRepositoryMock{},
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Quotes:             quoteRepoMock,
+		}},
+		ApprovedQuote: &quote.VerifyApprovedQuote{
+			SnapshotOrderItems: &quote.SnapshotOrderItems{
+				OrderInputRepository:   &mocks.OrderInputRepositoryMock{},
+				InputRepository:        &mocks.InputRepositoryMock{},
+				OrderServiceRepository: &mocks.OrderServiceRepositoryMock{},
+				LaborServiceRepository: &mocks.LaborServiceRepositoryMock{},
+				Logger:                 loggerMock,
+			},
+			Logger: loggerMock,
+		},
+	}
+
+	var created *models.Invoice
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	invoiceRepoMock.NextNumberFunc = func(workshopCode string, documentType string) (int64, error) {
+		return 1, nil
+	}
+	invoiceRepoMock.CreateFunc = func(invoice *models.Invoice) error {
+		created = invoice
+		return nil
+	}
+	customerRepoMock := &mocks.CustomerRepositoryMock{}
+	customerRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Customer, error) {
+		return &models.Customer{ID: id, Name: "Maria Souza"}, nil
+	}
+	vehicleRepoMock := &mocks.VehicleRepositoryMock{}
+	vehicleRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Vehicle, error) {
+		return &models.Vehicle{ID: id, NumberPlate: "ABC1D23"}, nil
+	}
+
+	unitOfWork := useCase.UnitOfWork.(*mocks.UnitOfWorkMock)
+	unitOfWork.Repositories.Invoices = invoiceRepoMock
+	unitOfWork.Repositories.Customers = customerRepoMock
+	unitOfWork.Repositories.Vehicles = vehicleRepoMock
+	useCase.IssueInvoice = &invoice.IssueInvoice{WorkshopCode: "MAIN", Logger: useCase.Logger}
+
+	// Act
+	_, err := useCase.Process(orderID, "Completed", "mechanic", statusHistory.StatusChange{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created == nil {
+		t.Fatal("Expected invoice to be issued on completion")
+	}
+	if created.OrderID != orderID || !created.TotalPrice.Equal(money.MustParse("60")) {
+		t.Errorf("Expected invoice of 60 for the order, got %s", created.TotalPrice)
+	}
+}
+
+func TestUpdateOrderStatus_Process_BackToAwaitingApprovalResetsApprovedQuote(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	quoteID := uuid.New()
+	order := &models.Order{ID: orderID, Status: "In progress", ApprovedQuoteID: &quoteID}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	resetCalled := false
+	orderRepoMock.SetApprovedQuoteFunc = func(id uuid.UUID, quoteID *uuid.UUID) error {
+		resetCalled = id == orderID && quoteID == nil
+		return nil
+	}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return order, nil
+	}
+
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return nil, nil
+	}
+
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+	quoteRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Quote, error) {
+		return nil, nil
+	}
+
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+		return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: order.Status, StartedAt: time.Now().Add(-time.Hour)}, nil
+	}
+
+	useCase := &UpdateOrderStatus{
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+		StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+			OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+			Logger:                       loggerMock,
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			Inputs:             &mocks.InputRepositoryMock{},
+			OrderServices:      &mocks.OrderServiceRepositoryMock{},
+			LaborServices:      &mocks.LaborServiceRepositoryMock{},
+			OrderStatusHistory: orderStatusHistoryRepoMock,
+			Quotes:             quoteRepoMock,
+		}},
+		ApprovedQuote: &quote.VerifyApprovedQuote{
+			SnapshotOrderItems: &quote.SnapshotOrderItems{
+				OrderInputRepository:   &mocks.OrderInputRepositoryMock{},
+				InputRepository:        &mocks.InputRepositoryMock{},
+				OrderServiceRepository: &mocks.OrderServiceRepositoryMock{},
+				LaborServiceRepository: &mocks.LaborServiceRepositoryMock{},
+				Logger:                 loggerMock,
+			},
+			Logger: loggerMock,
+		},
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, "Awaiting approval", "mechanic", statusHistory.StatusChange{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resetCalled {
+		t.Error("Expected approved quote to be reset")
+	}
+	if order.Status != "Awaiting approval" || order.ApprovedQuoteID != nil {
+		t.Errorf("Expected order awaiting a new approval, got status %s", order.Status)
+	}
+}
+
+func TestUpdateOrderStatus_Process_DeliveredRequiresSettledBalance(t *testing.T) {
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	tests := []struct {
+		name     string
+		paid     string
+		userType string
+		override bool
+		expected string
+	}{
+		{"fully paid", "250.00", "mechanic", false, ""},
+		{"outstanding balance", "200.00", "mechanic", false, "order has outstanding balance"},
+		{"override by mechanic", "200.00", "mechanic", true, "balance override requires admin"},
+		{"override by admin", "200.00", "admin", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderID := uuid.New()
+			order := &models.Order{ID: orderID, Status: "Completed"}
+
+			updated := false
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderRepoMock.UpdateFunc = func(order *models.Order) error {
+				updated = true
+				return nil
+			}
+
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return order, nil
+			}
+
+			invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+			invoiceRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) (*models.Invoice, error) {
+				return &models.Invoice{ID: uuid.New(), OrderID: orderID, TotalPrice: money.MustParse("250.00")}, nil
+			}
+			paymentRepoMock := &mocks.PaymentRepositoryMock{}
+			paymentRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) ([]models.Payment, error) {
+				return []models.Payment{{ID: uuid.New(), OrderID: orderID, Method: "cash", Amount: money.MustParse(tt.paid)}}, nil
+			}
+
+			orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+			orderStatusHistoryRepoMock.FindCurrentByOrderIDFunc = func(orderID uuid.UUID) (*models.OrderStatusHistory, error) {
+				return &models.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: order.Status, StartedAt: time.Now().Add(-time.Hour)}, nil
+			}
+
+			useCase := &UpdateOrderStatus{
+				OrderRepository: orderRepoMock,
+				Logger:          loggerMock,
+				StatusHistoryManager: &order_status_history.ManageOrderStatusHistory{
+					OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+					Logger:                       loggerMock,
+				},
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Orders:             orderRepoMock,
+					OrderStatusHistory: orderStatusHistoryRepoMock,
+					Invoices:           invoiceRepoMock,
+					Quotes:             &mocks.QuoteRepositoryMock{},
+					Payments:           paymentRepoMock,
+				}},
+				Balance: &payment.CalculateOrderBalance{Logger: loggerMock},
+			}
+
+			_, err := useCase.Process(orderID, "Delivered", tt.userType, statusHistory.StatusChange{OverrideBalance: tt.override})
+
+			if tt.expected == "" {
+				if err != nil || !updated {
+					t.Errorf("Expected order to be delivered, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
 	}
 }
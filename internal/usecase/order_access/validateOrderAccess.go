@@ -0,0 +1,87 @@
+package order_access
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	userDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+// ValidateOrderAccess garante que donos de veículo só consultem os dados das orders do seu
+// customer. Mecânicos e admins acessam todas as orders.
+type ValidateOrderAccess struct {
+	OrderRepository   repository.OrderRepository
+	UserRepository    repository.UserRepository
+	InvoiceRepository repository.InvoiceRepository
+	Logger            logger.Logger
+}
+
+// FetchOrderFromDB busca a order consultada
+func (uc *ValidateOrderAccess) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil || order == nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+	return order, nil
+}
+
+// ValidateOrderOwnership valida se a order pertence ao customer do usuário
+func (uc *ValidateOrderAccess) ValidateOrderOwnership(userID uuid.UUID, order *models.Order) error {
+	user, err := uc.UserRepository.FindByID(userID)
+	if err != nil {
+		uc.Logger.Error("User not found", zap.String("userID", userID.String()))
+		return errors.New("user not found")
+	}
+
+	if user.CustomerID == nil || *user.CustomerID != order.CustomerID {
+		uc.Logger.Error("Order does not belong to user customer",
+			zap.String("userID", user.ID.String()),
+			zap.String("orderID", order.ID.String()),
+			zap.String("orderCustomerID", order.CustomerID.String()))
+		return errors.New("order does not belong to user customer")
+	}
+	return nil
+}
+
+func (uc *ValidateOrderAccess) Process(orderID uuid.UUID, userID uuid.UUID, userType string) error {
+	uc.Logger.Info("Processing validate order access",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", userID.String()),
+		zap.String("userType", userType))
+
+	if userType != userDomain.UserTypeVehicleOwner {
+		return nil
+	}
+
+	order, err := uc.FetchOrderFromDB(orderID)
+	if err != nil {
+		return err
+	}
+
+	return uc.ValidateOrderOwnership(userID, order)
+}
+
+// ProcessInvoice valida o acesso à nota fiscal pela order a que ela pertence
+func (uc *ValidateOrderAccess) ProcessInvoice(invoiceID uuid.UUID, userID uuid.UUID, userType string) error {
+	uc.Logger.Info("Processing validate invoice access",
+		zap.String("invoiceID", invoiceID.String()),
+		zap.String("userID", userID.String()),
+		zap.String("userType", userType))
+
+	if userType != userDomain.UserTypeVehicleOwner {
+		return nil
+	}
+
+	invoice, err := uc.InvoiceRepository.FindByID(invoiceID)
+	if err != nil || invoice == nil {
+		uc.Logger.Error("Invoice not found", zap.String("invoiceID", invoiceID.String()))
+		return errors.New("invoice not found")
+	}
+
+	return uc.Process(invoice.OrderID, userID, userType)
+}
@@ -0,0 +1,186 @@
+package order_access
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestValidateOrderAccess_Process_VehicleOwnerOfAnotherCustomer(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	userID := uuid.New()
+	otherCustomerID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, CustomerID: uuid.New()}, nil
+	}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: userID, UserType: "vehicle_owner", CustomerID: &otherCustomerID}, nil
+	}
+
+	useCase := &ValidateOrderAccess{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(orderID, userID, "vehicle_owner")
+
+	// Assert
+	if err == nil || err.Error() != "order does not belong to user customer" {
+		t.Errorf("Expected 'order does not belong to user customer' error, got %v", err)
+	}
+}
+
+func TestValidateOrderAccess_Process_VehicleOwnerOfOrderCustomer(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	userID := uuid.New()
+	customerID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, CustomerID: customerID}, nil
+	}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: userID, UserType: "vehicle_owner", CustomerID: &customerID}, nil
+	}
+
+	useCase := &ValidateOrderAccess{
+		OrderRepository: orderRepoMock,
+		UserRepository:  userRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(orderID, userID, "vehicle_owner")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestValidateOrderAccess_Process_MechanicSkipsOwnership(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		t.Error("Expected order not to be loaded for a mechanic")
+		return nil, errors.New("unexpected lookup")
+	}
+
+	useCase := &ValidateOrderAccess{
+		OrderRepository: orderRepoMock,
+		UserRepository:  &mocks.UserRepositoryMock{},
+		Logger:          loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), uuid.New(), "mechanic")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestValidateOrderAccess_ProcessInvoice_VehicleOwnerOfAnotherCustomer(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	invoiceID := uuid.New()
+	orderID := uuid.New()
+	userID := uuid.New()
+	otherCustomerID := uuid.New()
+
+	invoiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{ID: invoiceID, OrderID: orderID}, nil
+	}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		if id != orderID {
+			t.Errorf("Expected order %s to be checked, got %s", orderID, id)
+		}
+		return &models.Order{ID: orderID, CustomerID: uuid.New()}, nil
+	}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: userID, UserType: "vehicle_owner", CustomerID: &otherCustomerID}, nil
+	}
+
+	useCase := &ValidateOrderAccess{
+		OrderRepository:   orderRepoMock,
+		UserRepository:    userRepoMock,
+		InvoiceRepository: invoiceRepoMock,
+		Logger:            loggerMock,
+	}
+
+	// Act
+	err := useCase.ProcessInvoice(invoiceID, userID, "vehicle_owner")
+
+	// Assert
+	if err == nil || err.Error() != "order does not belong to user customer" {
+		t.Errorf("Expected 'order does not belong to user customer' error, got %v", err)
+	}
+}
+
+func TestValidateOrderAccess_ProcessInvoice_InvoiceNotFound(t *testing.T) {
+	// Arrange
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	invoiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Invoice, error) {
+		return nil, errors.New("record not found")
+	}
+
+	useCase := &ValidateOrderAccess{
+		OrderRepository:   &mocks.OrderRepositoryMock{},
+		UserRepository:    &mocks.UserRepositoryMock{},
+		InvoiceRepository: invoiceRepoMock,
+		Logger:            loggerMock,
+	}
+
+	// Act
+	err := useCase.ProcessInvoice(uuid.New(), uuid.New(), "vehicle_owner")
+
+	// Assert
+	if err == nil || err.Error() != "invoice not found" {
+		t.Errorf("Expected 'invoice not found' error, got %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package order_adjustment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	pricingDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	userDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/pricing"
+	"go.uber.org/zap"
+)
+
+// AddOrderAdjustment registra um desconto ou acréscimo em uma linha ou na order inteira.
+// Descontos de mecânicos que levem o desconto total da order acima do limite configurado
+// ficam pendentes até a aprovação de um admin.
+type AddOrderAdjustment struct {
+	OrderRepository           repository.OrderRepository
+	OrderInputRepository      repository.OrderInputRepository
+	OrderServiceRepository    repository.OrderServiceRepository
+	OrderAdjustmentRepository repository.OrderAdjustmentRepository
+	PricingSettings           *pricing.FindPricingSettings
+	Pricing                   *pricing.CalculateOrderPricing
+	Logger                    logger.Logger
+	UnitOfWork                repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *AddOrderAdjustment) WithRepositories(repos repository.Repositories) *AddOrderAdjustment {
+	return &AddOrderAdjustment{
+		OrderRepository:           repos.Orders,
+		OrderInputRepository:      repos.OrderInputs,
+		OrderServiceRepository:    repos.OrderServices,
+		OrderAdjustmentRepository: repos.OrderAdjustments,
+		PricingSettings:           uc.PricingSettings.WithRepositories(repos),
+		Pricing:                   uc.Pricing.WithRepositories(repos),
+		Logger:                    uc.Logger,
+		UnitOfWork:                repos.UnitOfWork,
+	}
+}
+
+// FetchOrderFromDB valida se a order existe e ainda pode ser alterada. A linha fica bloqueada até
+// o fim da transação, para que descontos simultâneos na mesma order sejam comparados com o limite
+// um depois do outro.
+func (uc *AddOrderAdjustment) FetchOrderFromDB(orderID uuid.UUID) error {
+	order, err := uc.OrderRepository.FindByIDForUpdate(orderID)
+	if err != nil || order == nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, adjustments cannot be added",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is finalized")
+	}
+	return nil
+}
+
+// ValidateAdjustment valida o tipo, a forma de cálculo, o valor e o motivo do ajuste
+func (uc *AddOrderAdjustment) ValidateAdjustment(adjustment *domain.OrderAdjustment) error {
+	if !domain.IsValidKind(adjustment.Kind) {
+		uc.Logger.Error("Invalid adjustment kind", zap.String("kind", adjustment.Kind))
+		return errors.New("invalid adjustment kind")
+	}
+	if !domain.IsValidValueType(adjustment.ValueType) {
+		uc.Logger.Error("Invalid adjustment value type", zap.String("valueType", adjustment.ValueType))
+		return errors.New("invalid adjustment value type")
+	}
+	if adjustment.ValueType == domain.ValueTypePercentage {
+		if adjustment.Percentage == nil || adjustment.Amount != nil {
+			uc.Logger.Error("Percentage adjustment without percentage")
+			return errors.New("adjustment value does not match value type")
+		}
+		if *adjustment.Percentage <= 0 {
+			uc.Logger.Error("Invalid adjustment percentage", zap.Float64("percentage", *adjustment.Percentage))
+			return errors.New("adjustment value must be greater than zero")
+		}
+		if *adjustment.Percentage > 100 {
+			uc.Logger.Error("Adjustment percentage above 100", zap.Float64("percentage", *adjustment.Percentage))
+			return errors.New("adjustment percentage cannot exceed 100")
+		}
+	}
+	if adjustment.ValueType == domain.ValueTypeFixed {
+		if adjustment.Amount == nil || adjustment.Percentage != nil {
+			uc.Logger.Error("Fixed adjustment without amount")
+			return errors.New("adjustment value does not match value type")
+		}
+		if !adjustment.Amount.IsPositive() {
+			uc.Logger.Error("Invalid adjustment amount", zap.String("amount", adjustment.Amount.String()))
+			return errors.New("adjustment value must be greater than zero")
+		}
+	}
+	if adjustment.Reason == "" {
+		uc.Logger.Error("Adjustment without reason")
+		return errors.New("adjustment reason is required")
+	}
+	return nil
+}
+
+// FetchOrderLines monta as linhas cobradas da order. Itens anulados pelo cancelamento não entram.
+func (uc *AddOrderAdjustment) FetchOrderLines(orderID uuid.UUID) ([]pricingDomain.Line, error) {
+	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order inputs", zap.Error(err))
+		return nil, err
+	}
+
+	orderServices, err := uc.OrderServiceRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order services", zap.Error(err))
+		return nil, err
+	}
+
+	lines := make([]pricingDomain.Line, 0, len(orderInputs)+len(orderServices))
+	for _, orderInput := range orderInputs {
+		if orderInput.VoidedAt != nil {
+			continue
+		}
+		lines = append(lines, pricingDomain.Line{ID: orderInput.ID, Category: pricingDomain.CategoryPart, Amount: orderInput.TotalPrice})
+	}
+	for _, orderService := range orderServices {
+		lines = append(lines, pricingDomain.Line{ID: orderService.ID, Category: pricingDomain.CategoryLabor, Amount: orderService.TotalPrice})
+	}
+	return lines, nil
+}
+
+// ValidateLine garante que o ajuste de linha aponta para um item ou serviço da própria order
+func (uc *AddOrderAdjustment) ValidateLine(adjustment *domain.OrderAdjustment, lines []pricingDomain.Line) error {
+	if adjustment.LineID == nil {
+		return nil
+	}
+	for _, line := range lines {
+		if line.ID == *adjustment.LineID {
+			return nil
+		}
+	}
+	uc.Logger.Error("Order line not found",
+		zap.String("orderID", adjustment.OrderID.String()),
+		zap.String("lineID", adjustment.LineID.String()))
+	return errors.New("order line not found")
+}
+
+// ResolveStatus aprova o ajuste de imediato ou o deixa pendente. Apenas descontos de mecânicos
+// que ultrapassam o limite configurado precisam de aprovação; nos demais casos o próprio autor
+// fica registrado como aprovador.
+func (uc *AddOrderAdjustment) ResolveStatus(adjustment *domain.OrderAdjustment, userType string, lines []pricingDomain.Line) error {
+	now := time.Now()
+	approvedBy := adjustment.CreatedByUserID
+	adjustment.Status = domain.StatusApproved
+	adjustment.ApprovedByUserID = &approvedBy
+	adjustment.ApprovedAt = &now
+
+	if adjustment.Kind != domain.KindDiscount || userType != userDomain.UserTypeMechanic {
+		return nil
+	}
+
+	settings, err := uc.PricingSettings.Process()
+	if err != nil {
+		return err
+	}
+
+	// O limite vale para o desconto total da order, para que vários descontos pequenos
+	// não somem mais do que o mecânico poderia conceder de uma vez
+	existing, err := uc.Pricing.FetchAdjustments(adjustment.OrderID)
+	if err != nil {
+		return err
+	}
+	breakdown := pricingDomain.Calculate(lines, append(existing, *adjustment), nil)
+
+	if breakdown.DiscountPercent() > settings.MechanicDiscountCapPercent {
+		uc.Logger.Info("Discount above mechanic cap, approval required",
+			zap.String("orderID", adjustment.OrderID.String()),
+			zap.Float64("discountPercent", breakdown.DiscountPercent()),
+			zap.Float64("cap", settings.MechanicDiscountCapPercent))
+		adjustment.Status = domain.StatusPendingApproval
+		adjustment.ApprovedByUserID = nil
+		adjustment.ApprovedAt = nil
+	}
+	return nil
+}
+
+func (uc *AddOrderAdjustment) Process(adjustment *domain.OrderAdjustment, userType string) (*domain.OrderAdjustment, error) {
+	uc.Logger.Info("Processing add order adjustment",
+		zap.String("orderID", adjustment.OrderID.String()),
+		zap.String("kind", adjustment.Kind),
+		zap.String("valueType", adjustment.ValueType))
+
+	// O limite de desconto é conferido e o ajuste gravado na mesma transação, com a order bloqueada
+	var model *models.OrderAdjustment
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		if err := tx.FetchOrderFromDB(adjustment.OrderID); err != nil {
+			return err
+		}
+
+		if err := tx.ValidateAdjustment(adjustment); err != nil {
+			return err
+		}
+
+		lines, err := tx.FetchOrderLines(adjustment.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.ValidateLine(adjustment, lines); err != nil {
+			return err
+		}
+
+		if err := tx.ResolveStatus(adjustment, userType, lines); err != nil {
+			return err
+		}
+
+		model = persistence.OrderAdjustmentPersistence{}.ToModel(adjustment)
+		if err := tx.OrderAdjustmentRepository.Create(model); err != nil {
+			uc.Logger.Error("Database error creating order adjustment", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info("Order adjustment added",
+		zap.String("orderID", model.OrderID.String()),
+		zap.String("adjustmentID", model.ID.String()),
+		zap.String("status", model.Status))
+
+	return persistence.OrderAdjustmentPersistence{}.ToEntity(model), nil
+}
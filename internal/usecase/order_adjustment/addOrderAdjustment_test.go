@@ -0,0 +1,303 @@
+package order_adjustment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/pricing"
+	"go.uber.org/zap"
+)
+
+func TestAddOrderAdjustment_Process_ApprovalByDiscountCap(t *testing.T) {
+	partLineID := uuid.New()
+	existingAmount := money.MustParse("8")
+	fixedAmount := money.MustParse("15")
+	withinCap, stacked, aboveCap := 10.0, 5.0, 40.0
+
+	tests := []struct {
+		name           string
+		userType       string
+		valueType      string
+		percentage     *float64
+		amount         *money.Money
+		existing       []models.OrderAdjustment
+		expectedStatus string
+	}{
+		{"mechanic within cap", "mechanic", "percentage", &withinCap, nil, nil, domain.StatusApproved},
+		{"mechanic above cap", "mechanic", "fixed", nil, &fixedAmount, nil, domain.StatusPendingApproval},
+		{"mechanic stacking discounts above cap", "mechanic", "percentage", &stacked, nil, []models.OrderAdjustment{
+			{ID: uuid.New(), Kind: "discount", ValueType: "fixed", Amount: &existingAmount, Status: "approved"},
+		}, domain.StatusPendingApproval},
+		{"admin above cap", "admin", "percentage", &aboveCap, nil, nil, domain.StatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+			orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+			adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+			pricingRepoMock := &mocks.PricingRepositoryMock{}
+			loggerMock := &mocks.LoggerMock{}
+
+			// Mock logger
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			// Order aberta com uma peça de 100 e limite de desconto de 10% para mecânicos
+			// A order fica bloqueada enquanto o limite é conferido
+			orderID := uuid.New()
+			locked := false
+			orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+				locked = true
+				return &models.Order{ID: id, Status: "In progress"}, nil
+			}
+			orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+				return []models.OrderInput{{ID: partLineID, OrderID: id, InputID: uuid.New(), Quantity: 1, UnitPrice: money.MustParse("100"), TotalPrice: money.MustParse("100")}}, nil
+			}
+			pricingRepoMock.FindSettingsFunc = func() (*models.PricingSettings, error) {
+				return &models.PricingSettings{ID: models.PricingSettingsID, MechanicDiscountCapPercent: 10}, nil
+			}
+			adjustmentRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderAdjustment, error) {
+				return tt.existing, nil
+			}
+			var created *models.OrderAdjustment
+			adjustmentRepoMock.CreateFunc = func(adjustment *models.OrderAdjustment) error {
+				created = adjustment
+				return nil
+			}
+
+			useCase := &AddOrderAdjustment{
+				OrderRepository:           orderRepoMock,
+				OrderInputRepository:      orderInputRepoMock,
+				OrderServiceRepository:    orderServiceRepoMock,
+				OrderAdjustmentRepository: adjustmentRepoMock,
+				PricingSettings:           &pricing.FindPricingSettings{PricingRepository: pricingRepoMock, Logger: loggerMock},
+				Pricing: &pricing.CalculateOrderPricing{
+					OrderAdjustmentRepository: adjustmentRepoMock,
+					PricingRepository:         pricingRepoMock,
+					Logger:                    loggerMock,
+				},
+				Logger: loggerMock,
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Orders:           orderRepoMock,
+					OrderInputs:      orderInputRepoMock,
+					OrderServices:    orderServiceRepoMock,
+					OrderAdjustments: adjustmentRepoMock,
+					Pricing:          pricingRepoMock,
+				}},
+			}
+
+			adjustment := &domain.OrderAdjustment{
+				ID:              uuid.New(),
+				OrderID:         orderID,
+				LineID:          &partLineID,
+				Kind:            domain.KindDiscount,
+				ValueType:       tt.valueType,
+				Percentage:      tt.percentage,
+				Amount:          tt.amount,
+				Reason:          "Cliente fidelidade",
+				CreatedByUserID: uuid.New(),
+			}
+
+			// Act
+			result, err := useCase.Process(adjustment, tt.userType)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !locked {
+				t.Error("Expected the order to be locked while checking the discount cap")
+			}
+			if created == nil || created.Status != tt.expectedStatus || result.Status != tt.expectedStatus {
+				t.Fatalf("Expected status %s, got %+v", tt.expectedStatus, created)
+			}
+			if tt.expectedStatus == domain.StatusApproved {
+				if created.ApprovedByUserID == nil || *created.ApprovedByUserID != adjustment.CreatedByUserID {
+					t.Error("Expected the author to be recorded as approver")
+				}
+			} else if created.ApprovedByUserID != nil {
+				t.Error("Expected pending adjustment without approver")
+			}
+			if tt.valueType == domain.ValueTypeFixed {
+				if created.Percentage != nil || created.Amount == nil || created.Amount.Cents() != 1500 {
+					t.Errorf("Expected fixed amount stored as 1500 cents without percentage, got %+v", created)
+				}
+				if result.Currency != money.Currency {
+					t.Errorf("Expected currency %s, got %q", money.Currency, result.Currency)
+				}
+			}
+		})
+	}
+}
+
+func TestAddOrderAdjustment_Process_SurchargeDoesNotNeedApproval(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+	pricingRepoMock := &mocks.PricingRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{{ID: uuid.New(), OrderID: id, InputID: uuid.New(), Quantity: 1, UnitPrice: money.MustParse("100"), TotalPrice: money.MustParse("100")}}, nil
+	}
+	pricingRepoMock.FindSettingsFunc = func() (*models.PricingSettings, error) {
+		t.Error("Settings should not be checked for surcharges")
+		return nil, nil
+	}
+
+	useCase := &AddOrderAdjustment{
+		OrderRepository:           orderRepoMock,
+		OrderInputRepository:      orderInputRepoMock,
+		OrderServiceRepository:    orderServiceRepoMock,
+		OrderAdjustmentRepository: adjustmentRepoMock,
+		PricingSettings:           &pricing.FindPricingSettings{PricingRepository: pricingRepoMock, Logger: loggerMock},
+		Pricing: &pricing.CalculateOrderPricing{
+			OrderAdjustmentRepository: adjustmentRepoMock,
+			PricingRepository:         pricingRepoMock,
+			Logger:                    loggerMock,
+		},
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			OrderInputs:      orderInputRepoMock,
+			OrderServices:    orderServiceRepoMock,
+			OrderAdjustments: adjustmentRepoMock,
+			Pricing:          pricingRepoMock,
+		}},
+	}
+
+	percentage := 50.0
+	adjustment := &domain.OrderAdjustment{
+		ID:              uuid.New(),
+		OrderID:         uuid.New(),
+		Kind:            domain.KindSurcharge,
+		ValueType:       domain.ValueTypePercentage,
+		Percentage:      &percentage,
+		Reason:          "Serviço fora do horário",
+		CreatedByUserID: uuid.New(),
+	}
+
+	// Act
+	result, err := useCase.Process(adjustment, "mechanic")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Status != domain.StatusApproved {
+		t.Errorf("Expected surcharge to be approved, got %s", result.Status)
+	}
+}
+
+func TestAddOrderAdjustment_Process_ValidationErrors(t *testing.T) {
+	partLineID := uuid.New()
+	otherLineID := uuid.New()
+
+	tests := []struct {
+		name        string
+		orderStatus string
+		mutate      func(adjustment *domain.OrderAdjustment)
+		expected    string
+	}{
+		{"finalized order", "Delivered", func(a *domain.OrderAdjustment) {}, "order is finalized"},
+		{"invalid kind", "In progress", func(a *domain.OrderAdjustment) { a.Kind = "bonus" }, "invalid adjustment kind"},
+		{"invalid value type", "In progress", func(a *domain.OrderAdjustment) { a.ValueType = "ratio" }, "invalid adjustment value type"},
+		{"zero percentage", "In progress", func(a *domain.OrderAdjustment) { *a.Percentage = 0 }, "adjustment value must be greater than zero"},
+		{"percentage above 100", "In progress", func(a *domain.OrderAdjustment) { *a.Percentage = 120 }, "adjustment percentage cannot exceed 100"},
+		{"fixed without amount", "In progress", func(a *domain.OrderAdjustment) { a.ValueType = domain.ValueTypeFixed }, "adjustment value does not match value type"},
+		{"zero amount", "In progress", func(a *domain.OrderAdjustment) {
+			zero := money.Zero
+			a.ValueType = domain.ValueTypeFixed
+			a.Percentage = nil
+			a.Amount = &zero
+		}, "adjustment value must be greater than zero"},
+		{"missing reason", "In progress", func(a *domain.OrderAdjustment) { a.Reason = "" }, "adjustment reason is required"},
+		{"line from another order", "In progress", func(a *domain.OrderAdjustment) { a.LineID = &otherLineID }, "order line not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+			orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+			adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+			pricingRepoMock := &mocks.PricingRepositoryMock{}
+			loggerMock := &mocks.LoggerMock{}
+
+			// Mock logger
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: id, Status: tt.orderStatus}, nil
+			}
+			orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+				return []models.OrderInput{{ID: partLineID, OrderID: id, InputID: uuid.New(), Quantity: 1, UnitPrice: money.MustParse("100"), TotalPrice: money.MustParse("100")}}, nil
+			}
+			adjustmentRepoMock.CreateFunc = func(adjustment *models.OrderAdjustment) error {
+				t.Error("Adjustment should not be created")
+				return nil
+			}
+
+			useCase := &AddOrderAdjustment{
+				OrderRepository:           orderRepoMock,
+				OrderInputRepository:      orderInputRepoMock,
+				OrderServiceRepository:    orderServiceRepoMock,
+				OrderAdjustmentRepository: adjustmentRepoMock,
+				PricingSettings:           &pricing.FindPricingSettings{PricingRepository: pricingRepoMock, Logger: loggerMock},
+				Pricing: &pricing.CalculateOrderPricing{
+					OrderAdjustmentRepository: adjustmentRepoMock,
+					PricingRepository:         pricingRepoMock,
+					Logger:                    loggerMock,
+				},
+				Logger: loggerMock,
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Orders:           orderRepoMock,
+					OrderInputs:      orderInputRepoMock,
+					OrderServices:    orderServiceRepoMock,
+					OrderAdjustments: adjustmentRepoMock,
+					Pricing:          pricingRepoMock,
+				}},
+			}
+
+			percentage := 5.0
+			adjustment := &domain.OrderAdjustment{
+				ID:              uuid.New(),
+				OrderID:         uuid.New(),
+				LineID:          &partLineID,
+				Kind:            domain.KindDiscount,
+				ValueType:       domain.ValueTypePercentage,
+				Percentage:      &percentage,
+				Reason:          "Cliente fidelidade",
+				CreatedByUserID: uuid.New(),
+			}
+			tt.mutate(adjustment)
+
+			// Act
+			_, err := useCase.Process(adjustment, "admin")
+
+			// Assert
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
+	}
+}
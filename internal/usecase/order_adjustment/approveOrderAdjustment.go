@@ -0,0 +1,80 @@
+package order_adjustment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// ApproveOrderAdjustment aprova um desconto que ultrapassou o limite do mecânico
+type ApproveOrderAdjustment struct {
+	OrderRepository           repository.OrderRepository
+	OrderAdjustmentRepository repository.OrderAdjustmentRepository
+	Logger                    logger.Logger
+}
+
+// FetchPendingAdjustment busca o ajuste da order e garante que ele aguarda aprovação
+func (uc *ApproveOrderAdjustment) FetchPendingAdjustment(orderID, adjustmentID uuid.UUID) (*models.OrderAdjustment, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil || order == nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, adjustments cannot be approved",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return nil, errors.New("order is finalized")
+	}
+
+	adjustment, err := uc.OrderAdjustmentRepository.FindByID(adjustmentID)
+	if err != nil || adjustment == nil || adjustment.OrderID != orderID {
+		uc.Logger.Error("Order adjustment not found",
+			zap.String("orderID", orderID.String()),
+			zap.String("adjustmentID", adjustmentID.String()))
+		return nil, errors.New("order adjustment not found")
+	}
+
+	if adjustment.Status != domain.StatusPendingApproval {
+		uc.Logger.Error("Order adjustment is not pending approval",
+			zap.String("adjustmentID", adjustment.ID.String()),
+			zap.String("status", adjustment.Status))
+		return nil, errors.New("order adjustment is not pending approval")
+	}
+
+	return adjustment, nil
+}
+
+func (uc *ApproveOrderAdjustment) Process(orderID, adjustmentID, approvedByUserID uuid.UUID) (*domain.OrderAdjustment, error) {
+	uc.Logger.Info("Processing approve order adjustment",
+		zap.String("orderID", orderID.String()),
+		zap.String("adjustmentID", adjustmentID.String()),
+		zap.String("approvedByUserID", approvedByUserID.String()))
+
+	adjustment, err := uc.FetchPendingAdjustment(orderID, adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	adjustment.Status = domain.StatusApproved
+	adjustment.ApprovedByUserID = &approvedByUserID
+	adjustment.ApprovedAt = &now
+
+	if err := uc.OrderAdjustmentRepository.Update(adjustment); err != nil {
+		uc.Logger.Error("Database error approving order adjustment", zap.Error(err))
+		return nil, err
+	}
+
+	uc.Logger.Info("Order adjustment approved", zap.String("adjustmentID", adjustment.ID.String()))
+	return persistence.OrderAdjustmentPersistence{}.ToEntity(adjustment), nil
+}
@@ -0,0 +1,111 @@
+package order_adjustment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestApproveOrderAdjustment_Process_Success(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	adminID := uuid.New()
+	adjustment := &models.OrderAdjustment{ID: uuid.New(), OrderID: orderID, Kind: "discount", Status: domain.StatusPendingApproval}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Awaiting approval"}, nil
+	}
+	adjustmentRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.OrderAdjustment, error) {
+		return adjustment, nil
+	}
+
+	var updated *models.OrderAdjustment
+	adjustmentRepoMock.UpdateFunc = func(a *models.OrderAdjustment) error {
+		updated = a
+		return nil
+	}
+
+	useCase := &ApproveOrderAdjustment{
+		OrderRepository:           orderRepoMock,
+		OrderAdjustmentRepository: adjustmentRepoMock,
+		Logger:                    loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(orderID, adjustment.ID, adminID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated == nil || updated.Status != domain.StatusApproved || result.Status != domain.StatusApproved {
+		t.Fatalf("Expected adjustment to be approved, got %+v", updated)
+	}
+	if updated.ApprovedByUserID == nil || *updated.ApprovedByUserID != adminID || updated.ApprovedAt == nil {
+		t.Error("Expected approver and approval date to be recorded")
+	}
+}
+
+func TestApproveOrderAdjustment_Process_Errors(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		name       string
+		adjustment *models.OrderAdjustment
+		expected   string
+	}{
+		{"already approved", &models.OrderAdjustment{ID: uuid.New(), OrderID: orderID, Status: domain.StatusApproved}, "order adjustment is not pending approval"},
+		{"from another order", &models.OrderAdjustment{ID: uuid.New(), OrderID: uuid.New(), Status: domain.StatusPendingApproval}, "order adjustment not found"},
+		{"not found", nil, "order adjustment not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+			loggerMock := &mocks.LoggerMock{}
+
+			// Mock logger
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: id, Status: "Awaiting approval"}, nil
+			}
+			adjustmentRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.OrderAdjustment, error) {
+				return tt.adjustment, nil
+			}
+			adjustmentRepoMock.UpdateFunc = func(a *models.OrderAdjustment) error {
+				t.Error("Adjustment should not be updated")
+				return nil
+			}
+
+			useCase := &ApproveOrderAdjustment{
+				OrderRepository:           orderRepoMock,
+				OrderAdjustmentRepository: adjustmentRepoMock,
+				Logger:                    loggerMock,
+			}
+
+			// Act
+			_, err := useCase.Process(orderID, uuid.New(), uuid.New())
+
+			// Assert
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
+	}
+}
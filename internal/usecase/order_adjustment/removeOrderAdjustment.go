@@ -0,0 +1,61 @@
+package order_adjustment
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+// RemoveOrderAdjustment remove um desconto ou acréscimo de uma order ainda aberta
+type RemoveOrderAdjustment struct {
+	OrderRepository           repository.OrderRepository
+	OrderAdjustmentRepository repository.OrderAdjustmentRepository
+	Logger                    logger.Logger
+}
+
+// ValidateOrderAcceptsChanges valida se a order existe e ainda pode ser alterada
+func (uc *RemoveOrderAdjustment) ValidateOrderAcceptsChanges(orderID uuid.UUID) error {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil || order == nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, adjustments cannot be removed",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is finalized")
+	}
+	return nil
+}
+
+func (uc *RemoveOrderAdjustment) Process(orderID uuid.UUID, adjustmentID uuid.UUID) error {
+	uc.Logger.Info("Processing remove order adjustment",
+		zap.String("orderID", orderID.String()),
+		zap.String("adjustmentID", adjustmentID.String()))
+
+	if err := uc.ValidateOrderAcceptsChanges(orderID); err != nil {
+		return err
+	}
+
+	adjustment, err := uc.OrderAdjustmentRepository.FindByID(adjustmentID)
+	if err != nil || adjustment == nil || adjustment.OrderID != orderID {
+		uc.Logger.Error("Order adjustment not found",
+			zap.String("orderID", orderID.String()),
+			zap.String("adjustmentID", adjustmentID.String()))
+		return errors.New("order adjustment not found")
+	}
+
+	if err := uc.OrderAdjustmentRepository.Delete(adjustment.ID); err != nil {
+		uc.Logger.Error("Database error deleting order adjustment", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Order adjustment removed", zap.String("adjustmentID", adjustment.ID.String()))
+	return nil
+}
@@ -0,0 +1,129 @@
+package order_assignment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_assignment"
+	userDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// fetchOpenOrder bloqueia a order até o fim da transação e garante que ela ainda não foi
+// entregue nem cancelada. O bloqueio serializa designações concorrentes na mesma order.
+func fetchOpenOrder(orders repository.OrderRepository, log logger.Logger, orderID uuid.UUID) error {
+	order, err := orders.FindByIDForUpdate(orderID)
+	if err != nil || order == nil {
+		log.Error("Order not found", zap.String("orderID", orderID.String()))
+		return errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		log.Error("Order is finalized, mechanics cannot be changed",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is finalized")
+	}
+	return nil
+}
+
+// fetchMechanic garante que o usuário existe e é um mecânico
+func fetchMechanic(users repository.UserRepository, log logger.Logger, mechanicID uuid.UUID) error {
+	mechanic, err := users.FindByID(mechanicID)
+	if err != nil || mechanic == nil {
+		log.Error("Mechanic not found", zap.String("mechanicID", mechanicID.String()))
+		return errors.New("mechanic not found")
+	}
+
+	if mechanic.UserType != userDomain.UserTypeMechanic {
+		log.Error("User is not a mechanic",
+			zap.String("userID", mechanicID.String()),
+			zap.String("userType", mechanic.UserType))
+		return errors.New("user is not a mechanic")
+	}
+	return nil
+}
+
+// AssignMechanic designa um mecânico para trabalhar em uma order. Uma order pode ter vários
+// mecânicos designados ao mesmo tempo.
+type AssignMechanic struct {
+	OrderRepository           repository.OrderRepository
+	UserRepository            repository.UserRepository
+	OrderAssignmentRepository repository.OrderAssignmentRepository
+	Logger                    logger.Logger
+	UnitOfWork                repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *AssignMechanic) WithRepositories(repos repository.Repositories) *AssignMechanic {
+	if uc == nil {
+		return nil
+	}
+	return &AssignMechanic{
+		OrderRepository:           repos.Orders,
+		UserRepository:            repos.Users,
+		OrderAssignmentRepository: repos.OrderAssignments,
+		Logger:                    uc.Logger,
+		UnitOfWork:                repos.UnitOfWork,
+	}
+}
+
+func (uc *AssignMechanic) Process(orderID uuid.UUID, mechanicID uuid.UUID, assignedByUserID uuid.UUID) (*domain.OrderAssignment, error) {
+	uc.Logger.Info("Processing assign mechanic",
+		zap.String("orderID", orderID.String()),
+		zap.String("mechanicID", mechanicID.String()),
+		zap.String("assignedByUserID", assignedByUserID.String()))
+
+	assignment := &domain.OrderAssignment{
+		ID:               uuid.New(),
+		OrderID:          orderID,
+		MechanicID:       mechanicID,
+		AssignedByUserID: assignedByUserID,
+		AssignedAt:       time.Now(),
+	}
+
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		if err := fetchOpenOrder(tx.OrderRepository, uc.Logger, orderID); err != nil {
+			return err
+		}
+
+		if err := fetchMechanic(tx.UserRepository, uc.Logger, mechanicID); err != nil {
+			return err
+		}
+
+		active, err := tx.OrderAssignmentRepository.FindActive(orderID, mechanicID)
+		if err != nil {
+			uc.Logger.Error("Database error finding order assignment", zap.Error(err))
+			return err
+		}
+		if active != nil {
+			uc.Logger.Error("Mechanic already assigned to order",
+				zap.String("orderID", orderID.String()),
+				zap.String("mechanicID", mechanicID.String()))
+			return errors.New("mechanic already assigned")
+		}
+
+		if err := tx.OrderAssignmentRepository.Create(persistence.OrderAssignmentPersistence{}.ToModel(assignment)); err != nil {
+			uc.Logger.Error("Database error creating order assignment", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info("Mechanic assigned successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("mechanicID", mechanicID.String()),
+		zap.String("assignmentID", assignment.ID.String()))
+
+	return assignment, nil
+}
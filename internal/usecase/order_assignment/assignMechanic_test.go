@@ -0,0 +1,268 @@
+package order_assignment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestAssignMechanic_Process_RecordsAssigningUser(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	assignmentRepoMock := &mocks.OrderAssignmentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	mechanicID := uuid.New()
+	adminID := uuid.New()
+
+	var locked bool
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		locked = true
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, Username: "carlos", UserType: "mechanic"}, nil
+	}
+	var created *models.OrderAssignment
+	assignmentRepoMock.CreateFunc = func(assignment *models.OrderAssignment) error {
+		created = assignment
+		return nil
+	}
+
+	useCase := &AssignMechanic{
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			Users:            userRepoMock,
+			OrderAssignments: assignmentRepoMock,
+		}},
+	}
+
+	// Act
+	result, err := useCase.Process(orderID, mechanicID, adminID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !locked {
+		t.Error("Expected the order to be locked while assigning")
+	}
+	if created == nil {
+		t.Fatal("Expected assignment to be saved")
+	}
+	if created.OrderID != orderID || created.MechanicID != mechanicID || created.AssignedByUserID != adminID {
+		t.Errorf("Expected assignment of the mechanic by the acting user, got %+v", created)
+	}
+	if !result.IsActive() {
+		t.Error("Expected a new assignment to be active")
+	}
+}
+
+func TestAssignMechanic_Process_RejectsDuplicateAssignment(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	assignmentRepoMock := &mocks.OrderAssignmentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, Username: "carlos", UserType: "mechanic"}, nil
+	}
+	assignmentRepoMock.FindActiveFunc = func(orderID uuid.UUID, mechanicID uuid.UUID) (*models.OrderAssignment, error) {
+		return &models.OrderAssignment{ID: uuid.New(), OrderID: orderID, MechanicID: mechanicID}, nil
+	}
+	assignmentRepoMock.CreateFunc = func(assignment *models.OrderAssignment) error {
+		t.Fatal("Expected no assignment to be created")
+		return nil
+	}
+
+	useCase := &AssignMechanic{
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			Users:            userRepoMock,
+			OrderAssignments: assignmentRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), uuid.New(), uuid.New())
+
+	// Assert
+	if err == nil || err.Error() != "mechanic already assigned" {
+		t.Errorf("Expected 'mechanic already assigned', got %v", err)
+	}
+}
+
+func TestAssignMechanic_Process_RejectsUserWhoIsNotMechanic(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	assignmentRepoMock := &mocks.OrderAssignmentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Received"}, nil
+	}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, Username: "ana", UserType: "vehicle_owner"}, nil
+	}
+
+	useCase := &AssignMechanic{
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			Users:            userRepoMock,
+			OrderAssignments: assignmentRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), uuid.New(), uuid.New())
+
+	// Assert
+	if err == nil || err.Error() != "user is not a mechanic" {
+		t.Errorf("Expected 'user is not a mechanic', got %v", err)
+	}
+}
+
+func TestAssignMechanic_Process_RejectsFinalizedOrder(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	assignmentRepoMock := &mocks.OrderAssignmentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Delivered"}, nil
+	}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, Username: "carlos", UserType: "mechanic"}, nil
+	}
+
+	useCase := &AssignMechanic{
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			Users:            userRepoMock,
+			OrderAssignments: assignmentRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), uuid.New(), uuid.New())
+
+	// Assert
+	if err == nil || err.Error() != "order is finalized" {
+		t.Errorf("Expected 'order is finalized', got %v", err)
+	}
+}
+
+func TestUnassignMechanic_Process_ClosesActiveAssignment(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	assignmentRepoMock := &mocks.OrderAssignmentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	mechanicID := uuid.New()
+	actingUserID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+	assignmentRepoMock.FindActiveFunc = func(orderID uuid.UUID, mechanicID uuid.UUID) (*models.OrderAssignment, error) {
+		return &models.OrderAssignment{ID: uuid.New(), OrderID: orderID, MechanicID: mechanicID, AssignedByUserID: uuid.New()}, nil
+	}
+	var updated *models.OrderAssignment
+	assignmentRepoMock.UpdateFunc = func(assignment *models.OrderAssignment) error {
+		updated = assignment
+		return nil
+	}
+
+	useCase := &UnassignMechanic{
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			OrderAssignments: assignmentRepoMock,
+		}},
+	}
+
+	// Act
+	result, err := useCase.Process(orderID, mechanicID, actingUserID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated == nil || updated.UnassignedAt == nil {
+		t.Fatal("Expected the assignment to be closed instead of deleted")
+	}
+	if updated.UnassignedByUserID == nil || *updated.UnassignedByUserID != actingUserID {
+		t.Error("Expected the acting user to be recorded on unassignment")
+	}
+	if result.IsActive() {
+		t.Error("Expected the returned assignment to be inactive")
+	}
+}
+
+func TestUnassignMechanic_Process_RejectsMechanicNotAssigned(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	assignmentRepoMock := &mocks.OrderAssignmentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+
+	useCase := &UnassignMechanic{
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			OrderAssignments: assignmentRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), uuid.New(), uuid.New())
+
+	// Assert
+	if err == nil || err.Error() != "mechanic not assigned" {
+		t.Errorf("Expected 'mechanic not assigned', got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package order_assignment
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindMechanicOrders retorna a fila de trabalho do mecânico: as orders em que ele está designado.
+// Sem filtro de status, orders entregues e canceladas ficam de fora.
+type FindMechanicOrders struct {
+	UserRepository  repository.UserRepository
+	OrderRepository repository.OrderRepository
+	Logger          logger.Logger
+}
+
+func (uc *FindMechanicOrders) Process(mechanicID uuid.UUID, status string, spec repository.QuerySpec) (*repository.Page[orderDomain.Order], error) {
+	uc.Logger.Info("Processing find mechanic orders",
+		zap.String("mechanicID", mechanicID.String()),
+		zap.String("status", status))
+
+	if status != "" && !orderDomain.IsValidStatus(status) {
+		uc.Logger.Error("Invalid status filter", zap.String("status", status))
+		return nil, errors.New("invalid status")
+	}
+
+	if err := spec.Validate(); err != nil {
+		uc.Logger.Error("Invalid query", zap.Error(err), zap.Int("limit", spec.Limit))
+		return nil, err
+	}
+
+	if err := fetchMechanic(uc.UserRepository, uc.Logger, mechanicID); err != nil {
+		return nil, err
+	}
+
+	filter := repository.OrderFilter{Status: status, MechanicID: &mechanicID}
+	if status == "" {
+		filter.ExcludeStatuses = []string{orderDomain.StatusDelivered, orderDomain.StatusCanceled}
+	}
+
+	page, err := uc.OrderRepository.FindAll(filter, spec)
+	if err != nil {
+		uc.Logger.Error("Database error fetching mechanic orders", zap.Error(err))
+		return nil, err
+	}
+
+	domainPage := repository.MapPage(page, func(order *models.Order) orderDomain.Order {
+		return *persistence.OrderPersistence{}.ToEntity(order)
+	})
+
+	uc.Logger.Info("Successfully fetched mechanic orders",
+		zap.String("mechanicID", mechanicID.String()),
+		zap.Int("count", len(domainPage.Items)),
+		zap.Int64("total", domainPage.Total))
+
+	return domainPage, nil
+}
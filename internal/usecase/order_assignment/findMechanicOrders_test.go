@@ -0,0 +1,123 @@
+package order_assignment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestFindMechanicOrders_Process_ExcludesClosedOrdersByDefault(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	mechanicID := uuid.New()
+	order := models.Order{ID: uuid.New(), Status: "In progress"}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, Username: "carlos", UserType: "mechanic"}, nil
+	}
+	var usedFilter repository.OrderFilter
+	orderRepoMock.FindAllFunc = func(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error) {
+		usedFilter = filter
+		return &repository.Page[models.Order]{Items: []models.Order{order}, Total: 1}, nil
+	}
+
+	useCase := &FindMechanicOrders{
+		UserRepository:  userRepoMock,
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	page, err := useCase.Process(mechanicID, "", repository.QuerySpec{Limit: 20})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if usedFilter.MechanicID == nil || *usedFilter.MechanicID != mechanicID {
+		t.Error("Expected the listing to be restricted to the mechanic")
+	}
+	if len(usedFilter.ExcludeStatuses) != 2 {
+		t.Errorf("Expected delivered and canceled orders to be excluded, got %v", usedFilter.ExcludeStatuses)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != order.ID {
+		t.Errorf("Expected the assigned order in the queue, got %+v", page.Items)
+	}
+}
+
+func TestFindMechanicOrders_Process_StatusFilterIncludesClosedOrders(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, Username: "carlos", UserType: "mechanic"}, nil
+	}
+	var usedFilter repository.OrderFilter
+	orderRepoMock.FindAllFunc = func(filter repository.OrderFilter, spec repository.QuerySpec) (*repository.Page[models.Order], error) {
+		usedFilter = filter
+		return &repository.Page[models.Order]{}, nil
+	}
+
+	useCase := &FindMechanicOrders{
+		UserRepository:  userRepoMock,
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), "Delivered", repository.QuerySpec{Limit: 20})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if usedFilter.Status != "Delivered" || len(usedFilter.ExcludeStatuses) != 0 {
+		t.Errorf("Expected only the requested status, got %+v", usedFilter)
+	}
+}
+
+func TestFindMechanicOrders_Process_RejectsUnknownMechanic(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	userRepoMock := &mocks.UserRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return nil, nil
+	}
+
+	useCase := &FindMechanicOrders{
+		UserRepository:  userRepoMock,
+		OrderRepository: orderRepoMock,
+		Logger:          loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), "", repository.QuerySpec{Limit: 20})
+
+	// Assert
+	if err == nil || err.Error() != "mechanic not found" {
+		t.Errorf("Expected 'mechanic not found', got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package order_assignment
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_assignment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindOrderAssignments retorna o histórico de designações da order, incluindo as já encerradas
+type FindOrderAssignments struct {
+	OrderRepository           repository.OrderRepository
+	OrderAssignmentRepository repository.OrderAssignmentRepository
+	Logger                    logger.Logger
+}
+
+func (uc *FindOrderAssignments) Process(orderID uuid.UUID) ([]domain.OrderAssignment, error) {
+	uc.Logger.Info("Processing find order assignments", zap.String("orderID", orderID.String()))
+
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil || order == nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	records, err := uc.OrderAssignmentRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order assignments", zap.Error(err))
+		return nil, err
+	}
+
+	assignments := make([]domain.OrderAssignment, 0, len(records))
+	for i := range records {
+		assignments = append(assignments, *persistence.OrderAssignmentPersistence{}.ToEntity(&records[i]))
+	}
+
+	uc.Logger.Info("Order assignments found",
+		zap.String("orderID", orderID.String()),
+		zap.Int("count", len(assignments)))
+
+	return assignments, nil
+}
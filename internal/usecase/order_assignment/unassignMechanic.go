@@ -0,0 +1,84 @@
+package order_assignment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_assignment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// UnassignMechanic retira um mecânico da order. A designação é encerrada, não apagada, para
+// manter o histórico de quem trabalhou na order.
+type UnassignMechanic struct {
+	OrderRepository           repository.OrderRepository
+	OrderAssignmentRepository repository.OrderAssignmentRepository
+	Logger                    logger.Logger
+	UnitOfWork                repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *UnassignMechanic) WithRepositories(repos repository.Repositories) *UnassignMechanic {
+	if uc == nil {
+		return nil
+	}
+	return &UnassignMechanic{
+		OrderRepository:           repos.Orders,
+		OrderAssignmentRepository: repos.OrderAssignments,
+		Logger:                    uc.Logger,
+		UnitOfWork:                repos.UnitOfWork,
+	}
+}
+
+func (uc *UnassignMechanic) Process(orderID uuid.UUID, mechanicID uuid.UUID, unassignedByUserID uuid.UUID) (*domain.OrderAssignment, error) {
+	uc.Logger.Info("Processing unassign mechanic",
+		zap.String("orderID", orderID.String()),
+		zap.String("mechanicID", mechanicID.String()),
+		zap.String("unassignedByUserID", unassignedByUserID.String()))
+
+	var assignment *domain.OrderAssignment
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		if err := fetchOpenOrder(tx.OrderRepository, uc.Logger, orderID); err != nil {
+			return err
+		}
+
+		active, err := tx.OrderAssignmentRepository.FindActive(orderID, mechanicID)
+		if err != nil {
+			uc.Logger.Error("Database error finding order assignment", zap.Error(err))
+			return err
+		}
+		if active == nil {
+			uc.Logger.Error("Mechanic not assigned to order",
+				zap.String("orderID", orderID.String()),
+				zap.String("mechanicID", mechanicID.String()))
+			return errors.New("mechanic not assigned")
+		}
+
+		now := time.Now()
+		active.UnassignedByUserID = &unassignedByUserID
+		active.UnassignedAt = &now
+		if err := tx.OrderAssignmentRepository.Update(active); err != nil {
+			uc.Logger.Error("Database error updating order assignment", zap.Error(err))
+			return err
+		}
+
+		assignment = persistence.OrderAssignmentPersistence{}.ToEntity(active)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info("Mechanic unassigned successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("mechanicID", mechanicID.String()),
+		zap.String("assignmentID", assignment.ID.String()))
+
+	return assignment, nil
+}
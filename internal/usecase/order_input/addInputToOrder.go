@@ -4,6 +4,10 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
@@ -17,11 +21,30 @@ type AddInputToOrder struct {
 	OrderInputRepository  repository.OrderInputRepository
 	Logger                logger.Logger
 	DecreaseQuantityInput *input.DecreaseQuantityInput
+	UnitOfWork            repository.UnitOfWork
+
+	// ManageInputReservation reserva as peças enquanto a order ainda não foi aprovada
+	ManageInputReservation *input.ManageInputReservation
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *AddInputToOrder) WithRepositories(repos repository.Repositories) *AddInputToOrder {
+	return &AddInputToOrder{
+		OrderRepository:       repos.Orders,
+		InputRepository:       repos.Inputs,
+		OrderInputRepository:  repos.OrderInputs,
+		Logger:                uc.Logger,
+		DecreaseQuantityInput: uc.DecreaseQuantityInput.WithRepositories(repos),
+		UnitOfWork:            repos.UnitOfWork,
+
+		ManageInputReservation: uc.ManageInputReservation.WithRepositories(repos),
+	}
 }
 
-// FetchOrderFromDB busca um order específico do banco de dados
+// FetchOrderFromDB busca a order bloqueando a linha, para que a inclusão de itens não concorra
+// com a mudança de status que reserva, baixa ou devolve as peças da order
 func (uc *AddInputToOrder) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
-	order, err := uc.OrderRepository.FindByID(orderID)
+	order, err := uc.OrderRepository.FindByIDForUpdate(orderID)
 	if err != nil {
 		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
 		return nil, errors.New("order not found")
@@ -30,6 +53,17 @@ func (uc *AddInputToOrder) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, e
 	return order, nil
 }
 
+// ValidateOrderAcceptsInputs valida se a order ainda pode receber novos itens
+func (uc *AddInputToOrder) ValidateOrderAcceptsInputs(order *models.Order) error {
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, inputs cannot be added",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is finalized")
+	}
+	return nil
+}
+
 // FetchInputFromDB busca um input específico do banco de dados
 func (uc *AddInputToOrder) FetchInputFromDB(inputID uuid.UUID) (*models.Input, error) {
 	input, err := uc.InputRepository.FindByID(inputID)
@@ -41,7 +75,8 @@ func (uc *AddInputToOrder) FetchInputFromDB(inputID uuid.UUID) (*models.Input, e
 		zap.String("inputID", inputID.String()),
 		zap.String("name", input.Name),
 		zap.String("inputType", input.InputType),
-		zap.Int("availableQuantity", input.Quantity))
+		zap.Int("onHandQuantity", input.Quantity),
+		zap.Int("reservedQuantity", input.ReservedQuantity))
 	return input, nil
 }
 
@@ -65,13 +100,14 @@ func (uc *AddInputToOrder) ValidateInputAvailability(input *models.Input, quanti
 		return nil
 	}
 
-	// Verifica se há quantidade suficiente (apenas para inputs que não são service)
-	if input.Quantity < quantity {
+	// Verifica se há saldo disponível suficiente, descontando o que já está reservado para outras orders
+	availableQuantity := input.Quantity - input.ReservedQuantity
+	if availableQuantity < quantity {
 		uc.Logger.Error("Insufficient input quantity",
 			zap.String("inputID", input.ID.String()),
 			zap.String("name", input.Name),
 			zap.Int("requestedQuantity", quantity),
-			zap.Int("availableQuantity", input.Quantity))
+			zap.Int("availableQuantity", availableQuantity))
 		return errors.New("insufficient input quantity")
 	}
 
@@ -79,41 +115,43 @@ func (uc *AddInputToOrder) ValidateInputAvailability(input *models.Input, quanti
 }
 
 // ValidateInputPrice valida se o preço do input é válido
-func (uc *AddInputToOrder) ValidateInputPrice(input *models.Input) (float64, error) {
+func (uc *AddInputToOrder) ValidateInputPrice(input *models.Input) (money.Money, error) {
 	unitPrice := input.Price
-	if unitPrice <= 0 {
+	if !unitPrice.IsPositive() {
 		uc.Logger.Error("Input has invalid price",
 			zap.String("inputID", input.ID.String()),
 			zap.String("name", input.Name),
-			zap.Float64("price", unitPrice))
-		return 0, errors.New("input has invalid price")
+			zap.Stringer("price", unitPrice))
+		return money.Zero, errors.New("input has invalid price")
 	}
 
 	uc.Logger.Info("Input price retrieved",
 		zap.String("inputID", input.ID.String()),
 		zap.String("name", input.Name),
-		zap.Float64("unitPrice", unitPrice))
+		zap.Stringer("unitPrice", unitPrice))
 
 	return unitPrice, nil
 }
 
-// FetchExistingOrderInput busca um order input existente
-func (uc *AddInputToOrder) FetchExistingOrderInput(orderID, inputID uuid.UUID) (*models.OrderInput, error) {
+// FetchExistingOrderInput busca a linha ativa do input com a mesma situação de estoque. Peças
+// reservadas e baixadas ficam em linhas separadas: somar unidades reservadas a uma linha já
+// baixada faria a reserva nunca ser consumida e a devolução repor unidades que não saíram.
+func (uc *AddInputToOrder) FetchExistingOrderInput(orderID, inputID uuid.UUID, stockStatus string) (*models.OrderInput, error) {
 	// Busca todos os order inputs para este order
 	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Procura por um order input com o input_id específico
+	// Procura por um order input com o input_id e a situação de estoque
 	for _, orderInput := range orderInputs {
-		if orderInput.InputID == inputID {
+		if orderInput.InputID == inputID && orderInput.StockStatus == stockStatus && orderInput.VoidedAt == nil {
 			uc.Logger.Info("Existing order input found",
 				zap.String("orderInputID", orderInput.ID.String()),
 				zap.String("orderID", orderInput.OrderID.String()),
 				zap.String("inputID", orderInput.InputID.String()),
 				zap.Int("currentQuantity", orderInput.Quantity),
-				zap.Float64("currentTotalPrice", orderInput.TotalPrice))
+				zap.Stringer("currentTotalPrice", orderInput.TotalPrice))
 			return &orderInput, nil
 		}
 	}
@@ -125,10 +163,10 @@ func (uc *AddInputToOrder) FetchExistingOrderInput(orderID, inputID uuid.UUID) (
 }
 
 // UpdateExistingOrderInput atualiza um order input existente
-func (uc *AddInputToOrder) UpdateExistingOrderInput(existingOrderInput *models.OrderInput, quantity int, unitPrice float64) error {
+func (uc *AddInputToOrder) UpdateExistingOrderInput(existingOrderInput *models.OrderInput, quantity int, unitPrice money.Money) error {
 	// Calcula novos valores
 	newQuantity := existingOrderInput.Quantity + quantity
-	newTotalPrice := float64(newQuantity) * unitPrice
+	newTotalPrice := unitPrice.Mul(int64(newQuantity))
 
 	// Atualiza o order input
 	existingOrderInput.Quantity = newQuantity
@@ -144,14 +182,14 @@ func (uc *AddInputToOrder) UpdateExistingOrderInput(existingOrderInput *models.O
 		zap.String("orderInputID", existingOrderInput.ID.String()),
 		zap.Int("oldQuantity", existingOrderInput.Quantity-quantity),
 		zap.Int("newQuantity", newQuantity),
-		zap.Float64("oldTotalPrice", existingOrderInput.TotalPrice-float64(quantity)*unitPrice),
-		zap.Float64("newTotalPrice", newTotalPrice))
+		zap.Stringer("oldTotalPrice", existingOrderInput.TotalPrice.Sub(unitPrice.Mul(int64(quantity)))),
+		zap.Stringer("newTotalPrice", newTotalPrice))
 
 	return nil
 }
 
 // DecreaseInputQuantity diminui a quantidade do input
-func (uc *AddInputToOrder) DecreaseInputQuantity(input *models.Input, quantity int) error {
+func (uc *AddInputToOrder) DecreaseInputQuantity(input *models.Input, quantity int, movement inventoryMovement.MovementContext) error {
 	// Para inputs do tipo "service", não diminuímos a quantidade
 	if input.InputType == "service" {
 		uc.Logger.Info("Skipping quantity decrease for service type",
@@ -162,7 +200,7 @@ func (uc *AddInputToOrder) DecreaseInputQuantity(input *models.Input, quantity i
 	}
 
 	// Usa o usecase de decrease quantity
-	err := uc.DecreaseQuantityInput.Process(input.ID, quantity)
+	err := uc.DecreaseQuantityInput.Process(input.ID, quantity, movement)
 	if err != nil {
 		uc.Logger.Error("Error decreasing input quantity", zap.Error(err))
 		return err
@@ -176,17 +214,51 @@ func (uc *AddInputToOrder) DecreaseInputQuantity(input *models.Input, quantity i
 	return nil
 }
 
+// ReserveInputQuantity reserva a quantidade do input para a order
+func (uc *AddInputToOrder) ReserveInputQuantity(input *models.Input, quantity int) error {
+	err := uc.ManageInputReservation.Reserve(input.ID, quantity)
+	if err != nil {
+		uc.Logger.Error("Error reserving input quantity", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Input quantity reserved successfully",
+		zap.String("inputID", input.ID.String()),
+		zap.String("name", input.Name),
+		zap.Int("quantityReserved", quantity))
+
+	return nil
+}
+
+// ApplyStockChange reserva ou baixa o estoque conforme o status da order e retorna a situação
+// do item no estoque. Antes da aprovação as peças ficam apenas reservadas; depois dela são baixadas.
+func (uc *AddInputToOrder) ApplyStockChange(order *models.Order, input *models.Input, quantity int, movement inventoryMovement.MovementContext) (string, error) {
+	// Serviços não controlam estoque e orders já aprovadas baixam o estoque diretamente
+	if input.InputType == "service" || !orderDomain.ReservesStock(order.Status) {
+		if err := uc.DecreaseInputQuantity(input, quantity, movement); err != nil {
+			return "", err
+		}
+		return domain.StockStatusConsumed, nil
+	}
+
+	if err := uc.ReserveInputQuantity(input, quantity); err != nil {
+		return "", err
+	}
+	return domain.StockStatusReserved, nil
+}
+
 // CreateNewOrderInput cria um novo order input
-func (uc *AddInputToOrder) CreateNewOrderInput(orderID, inputID uuid.UUID, quantity int, unitPrice float64) error {
-	totalPrice := float64(quantity) * unitPrice
+func (uc *AddInputToOrder) CreateNewOrderInput(orderID, inputID uuid.UUID, quantity int, unitPrice money.Money, stockStatus string) error {
+	totalPrice := unitPrice.Mul(int64(quantity))
 
 	newOrderInput := &models.OrderInput{
-		ID:         uuid.New(),
-		OrderID:    orderID,
-		InputID:    inputID,
-		Quantity:   quantity,
-		UnitPrice:  unitPrice,
-		TotalPrice: totalPrice,
+		ID:          uuid.New(),
+		OrderID:     orderID,
+		InputID:     inputID,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		TotalPrice:  totalPrice,
+		StockStatus: stockStatus,
 	}
 
 	err := uc.OrderInputRepository.Create(newOrderInput)
@@ -200,13 +272,14 @@ func (uc *AddInputToOrder) CreateNewOrderInput(orderID, inputID uuid.UUID, quant
 		zap.String("orderID", newOrderInput.OrderID.String()),
 		zap.String("inputID", newOrderInput.InputID.String()),
 		zap.Int("quantity", newOrderInput.Quantity),
-		zap.Float64("unitPrice", newOrderInput.UnitPrice),
-		zap.Float64("totalPrice", newOrderInput.TotalPrice))
+		zap.Stringer("unitPrice", newOrderInput.UnitPrice),
+		zap.Stringer("totalPrice", newOrderInput.TotalPrice),
+		zap.String("stockStatus", newOrderInput.StockStatus))
 
 	return nil
 }
 
-func (uc *AddInputToOrder) Process(orderID uuid.UUID, inputID uuid.UUID, quantity int) error {
+func (uc *AddInputToOrder) Process(orderID uuid.UUID, inputID uuid.UUID, quantity int, userID uuid.UUID) error {
 	uc.Logger.Info("Processing add input to order",
 		zap.String("orderID", orderID.String()),
 		zap.String("inputID", inputID.String()),
@@ -217,56 +290,72 @@ func (uc *AddInputToOrder) Process(orderID uuid.UUID, inputID uuid.UUID, quantit
 		return err
 	}
 
-	// Busca order
-	_, err := uc.FetchOrderFromDB(orderID)
-	if err != nil {
-		return err
-	}
+	// Estoque e itens da order são alterados na mesma transação
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	// Busca input
-	input, err := uc.FetchInputFromDB(inputID)
-	if err != nil {
-		return err
-	}
+		// Busca order
+		order, err := tx.FetchOrderFromDB(orderID)
+		if err != nil {
+			return err
+		}
 
-	// Valida disponibilidade do input
-	if err := uc.ValidateInputAvailability(input, quantity); err != nil {
-		return err
-	}
+		// Orders finalizadas não recebem novos itens
+		if err := tx.ValidateOrderAcceptsInputs(order); err != nil {
+			return err
+		}
 
-	// Valida e obtém preço do input
-	unitPrice, err := uc.ValidateInputPrice(input)
-	if err != nil {
-		return err
-	}
+		// Busca input
+		input, err := tx.FetchInputFromDB(inputID)
+		if err != nil {
+			return err
+		}
+
+		// Valida disponibilidade do input
+		if err := tx.ValidateInputAvailability(input, quantity); err != nil {
+			return err
+		}
 
-	uc.Logger.Info("Validation passed, checking if order input already exists")
+		// Valida e obtém preço do input
+		unitPrice, err := tx.ValidateInputPrice(input)
+		if err != nil {
+			return err
+		}
 
-	// Verifica se já existe um order_input com o mesmo input_id para este order
-	existingOrderInput, err := uc.FetchExistingOrderInput(orderID, inputID)
-	if err != nil {
-		return err
-	}
+		// Reserva ou diminui a quantidade do input conforme o status da order
+		movement := inventoryMovement.MovementContext{
+			MovementType: inventoryMovement.MovementTypeOrderConsumption,
+			OrderID:      &orderID,
+			UserID:       &userID,
+		}
+		stockStatus, err := tx.ApplyStockChange(order, input, quantity, movement)
+		if err != nil {
+			return err
+		}
 
-	if existingOrderInput != nil {
-		// Já existe um registro, vamos atualizar a quantidade e o total_price
-		uc.Logger.Info("Existing order input found, updating quantity and total price",
-			zap.String("orderInputID", existingOrderInput.ID.String()),
-			zap.Int("currentQuantity", existingOrderInput.Quantity),
-			zap.Int("quantityToAdd", quantity),
-			zap.Float64("currentTotalPrice", existingOrderInput.TotalPrice))
+		uc.Logger.Info("Stock change applied, checking if order input already exists", zap.String("stockStatus", stockStatus))
 
-		return uc.UpdateExistingOrderInput(existingOrderInput, quantity, unitPrice)
-	}
+		// Verifica se já existe um order_input do mesmo input e na mesma situação de estoque
+		existingOrderInput, err := tx.FetchExistingOrderInput(orderID, inputID, stockStatus)
+		if err != nil {
+			return err
+		}
 
-	// Não existe registro, vamos criar um novo
-	uc.Logger.Info("No existing order input found, creating new one")
+		if existingOrderInput != nil {
+			// Já existe um registro, vamos atualizar a quantidade e o total_price
+			uc.Logger.Info("Existing order input found, updating quantity and total price",
+				zap.String("orderInputID", existingOrderInput.ID.String()),
+				zap.Int("currentQuantity", existingOrderInput.Quantity),
+				zap.Int("quantityToAdd", quantity),
+				zap.Stringer("currentTotalPrice", existingOrderInput.TotalPrice))
 
-	// Diminui a quantidade do input
-	if err := uc.DecreaseInputQuantity(input, quantity); err != nil {
-		return err
-	}
+			return tx.UpdateExistingOrderInput(existingOrderInput, quantity, unitPrice)
+		}
+
+		// Não existe registro, vamos criar um novo
+		uc.Logger.Info("No existing order input found, creating new one")
 
-	// Cria novo order input
-	return uc.CreateNewOrderInput(orderID, inputID, quantity, unitPrice)
+		// Cria novo order input
+		return tx.CreateNewOrderInput(orderID, inputID, quantity, unitPrice, stockStatus)
+	})
 }
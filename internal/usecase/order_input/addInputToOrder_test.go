@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
 	"go.uber.org/zap"
@@ -19,8 +21,9 @@ func TestAddInputToOrder_Process_Success(t *testing.T) {
 	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
 	loggerMock := &mocks.LoggerMock{}
 	decreaseQuantityInputMock := &input.DecreaseQuantityInput{
-		InputRepository: inputRepoMock,
-		Logger:          loggerMock,
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	var loggedInfo []string
@@ -58,7 +61,7 @@ func TestAddInputToOrder_Process_Success(t *testing.T) {
 		Name:      "Test Input",
 		InputType: "material",
 		Quantity:  10,
-		Price:     15.50,
+		Price:     money.MustParse("15.50"),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -80,8 +83,8 @@ func TestAddInputToOrder_Process_Success(t *testing.T) {
 	}
 
 	// Mock Update Input quantity
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return nil
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 10 - quantity}, nil
 	}
 
 	useCase := &AddInputToOrder{
@@ -90,10 +93,16 @@ func TestAddInputToOrder_Process_Success(t *testing.T) {
 		OrderInputRepository:  orderInputRepoMock,
 		Logger:                loggerMock,
 		DecreaseQuantityInput: decreaseQuantityInputMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, inputID, quantity)
+	err := useCase.Process(orderID, inputID, quantity, uuid.New())
 
 	// Assert
 	if err != nil {
@@ -132,8 +141,9 @@ func TestAddInputToOrder_Process_OrderNotFound(t *testing.T) {
 	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
 	loggerMock := &mocks.LoggerMock{}
 	decreaseQuantityInputMock := &input.DecreaseQuantityInput{
-		InputRepository: inputRepoMock,
-		Logger:          loggerMock,
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	var loggedInfo []string
@@ -161,10 +171,16 @@ func TestAddInputToOrder_Process_OrderNotFound(t *testing.T) {
 		OrderInputRepository:  orderInputRepoMock,
 		Logger:                loggerMock,
 		DecreaseQuantityInput: decreaseQuantityInputMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, inputID, quantity)
+	err := useCase.Process(orderID, inputID, quantity, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -196,8 +212,9 @@ func TestAddInputToOrder_Process_InputNotFound(t *testing.T) {
 	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
 	loggerMock := &mocks.LoggerMock{}
 	decreaseQuantityInputMock := &input.DecreaseQuantityInput{
-		InputRepository: inputRepoMock,
-		Logger:          loggerMock,
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	var loggedInfo []string
@@ -240,10 +257,16 @@ func TestAddInputToOrder_Process_InputNotFound(t *testing.T) {
 		OrderInputRepository:  orderInputRepoMock,
 		Logger:                loggerMock,
 		DecreaseQuantityInput: decreaseQuantityInputMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, inputID, quantity)
+	err := useCase.Process(orderID, inputID, quantity, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -335,7 +358,7 @@ func TestAddInputToOrder_ValidateInputAvailability_SufficientQuantity(t *testing
 		Name:      "Test Input",
 		InputType: "material",
 		Quantity:  10,
-		Price:     15.50,
+		Price:     money.MustParse("15.50"),
 	}
 
 	// Act
@@ -365,7 +388,7 @@ func TestAddInputToOrder_ValidateInputAvailability_InsufficientQuantity(t *testi
 		Name:      "Test Input",
 		InputType: "material",
 		Quantity:  3,
-		Price:     15.50,
+		Price:     money.MustParse("15.50"),
 	}
 
 	// Act
@@ -412,7 +435,7 @@ func TestAddInputToOrder_ValidateInputAvailability_ServiceType(t *testing.T) {
 		Name:      "Test Service",
 		InputType: "service",
 		Quantity:  0,
-		Price:     50.00,
+		Price:     money.MustParse("50.00"),
 	}
 
 	// Act
@@ -455,7 +478,7 @@ func TestAddInputToOrder_ValidateInputPrice_Valid(t *testing.T) {
 		Name:      "Test Input",
 		InputType: "material",
 		Quantity:  10,
-		Price:     25.50,
+		Price:     money.MustParse("25.50"),
 	}
 
 	// Act
@@ -466,8 +489,8 @@ func TestAddInputToOrder_ValidateInputPrice_Valid(t *testing.T) {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if price != 25.50 {
-		t.Errorf("Expected price 25.50, got %f", price)
+	if !price.Equal(money.MustParse("25.50")) {
+		t.Errorf("Expected price 25.50, got %s", price)
 	}
 
 	// Verifica se o log de info foi chamado
@@ -502,7 +525,7 @@ func TestAddInputToOrder_ValidateInputPrice_Invalid(t *testing.T) {
 		Name:      "Test Input",
 		InputType: "material",
 		Quantity:  10,
-		Price:     0.0,
+		Price:     money.MustParse("0.0"),
 	}
 
 	// Act
@@ -513,8 +536,8 @@ func TestAddInputToOrder_ValidateInputPrice_Invalid(t *testing.T) {
 		t.Error("Expected error, got nil")
 	}
 
-	if price != 0 {
-		t.Errorf("Expected price 0, got %f", price)
+	if !price.Equal(money.MustParse("0")) {
+		t.Errorf("Expected price 0, got %s", price)
 	}
 
 	if err.Error() != "input has invalid price" {
@@ -534,3 +557,392 @@ func TestAddInputToOrder_ValidateInputPrice_Invalid(t *testing.T) {
 		t.Errorf("Expected error log message '%s' not found", expectedErrorLog)
 	}
 }
+
+func TestAddInputToOrder_Process_ExistingOrderInputDecreasesStock(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	inputID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, Status: "open"}, nil
+	}
+
+	stockInput := &models.Input{
+		ID:        inputID,
+		Name:      "Test Input",
+		InputType: "material",
+		Quantity:  10,
+		Price:     money.MustParse("15.50"),
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return stockInput, nil
+	}
+
+	var updatedQuantity int
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		updatedQuantity = stockInput.Quantity - quantity
+		return &models.Input{ID: id, Quantity: updatedQuantity}, nil
+	}
+
+	// Mock OrderInput - já existe uma linha para o input
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: orderID, InputID: inputID, Quantity: 2, UnitPrice: money.MustParse("15.50"), TotalPrice: money.MustParse("31.00"), StockStatus: "consumed"},
+		}, nil
+	}
+
+	var updatedOrderInput *models.OrderInput
+	orderInputRepoMock.UpdateFunc = func(orderInput *models.OrderInput) error {
+		updatedOrderInput = orderInput
+		return nil
+	}
+
+	useCase := &AddInputToOrder{
+		OrderRepository:      orderRepoMock,
+		InputRepository:      inputRepoMock,
+		OrderInputRepository: orderInputRepoMock,
+		Logger:               loggerMock,
+		DecreaseQuantityInput: &input.DecreaseQuantityInput{
+			InputRepository:         inputRepoMock,
+			Logger:                  loggerMock,
+			RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+		},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+	}
+
+	// Act
+	err := useCase.Process(orderID, inputID, 3, uuid.New())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updatedQuantity != 7 {
+		t.Errorf("Expected stock to be decreased to 7, got %d", updatedQuantity)
+	}
+	if updatedOrderInput == nil || updatedOrderInput.Quantity != 5 {
+		t.Errorf("Expected order input quantity 5, got %+v", updatedOrderInput)
+	}
+}
+
+func TestAddInputToOrder_Process_ReservesStockBeforeApproval(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	inputID := uuid.New()
+
+	// A order fica bloqueada para que a inclusão não concorra com uma mudança de status
+	locked := false
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		locked = true
+		return &models.Order{ID: orderID, Status: "Undergoing diagnosis"}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: inputID, Name: "Brake pad", InputType: "material", Quantity: 10, ReservedQuantity: 4, Price: money.MustParse("80.00")}, nil
+	}
+
+	var reserved int
+	inputRepoMock.ReserveQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		reserved = quantity
+		return &models.Input{ID: id, Quantity: 10, ReservedQuantity: 4 + quantity}, nil
+	}
+	inputRepoMock.DecreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		t.Error("Expected stock not to be decreased before approval")
+		return nil, errors.New("unexpected decrease")
+	}
+
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{}, nil
+	}
+	var created *models.OrderInput
+	orderInputRepoMock.CreateFunc = func(orderInput *models.OrderInput) error {
+		created = orderInput
+		return nil
+	}
+
+	useCase := &AddInputToOrder{
+		OrderRepository:      orderRepoMock,
+		InputRepository:      inputRepoMock,
+		OrderInputRepository: orderInputRepoMock,
+		Logger:               loggerMock,
+		DecreaseQuantityInput: &input.DecreaseQuantityInput{
+			InputRepository:         inputRepoMock,
+			Logger:                  loggerMock,
+			RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
+		},
+		ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
+	}
+
+	// Act
+	err := useCase.Process(orderID, inputID, 6, uuid.New())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !locked {
+		t.Error("Expected the order to be locked while adding inputs")
+	}
+	if reserved != 6 {
+		t.Errorf("Expected 6 units reserved, got %d", reserved)
+	}
+	if created == nil || created.StockStatus != "reserved" {
+		t.Errorf("Expected order input to be created as reserved, got %+v", created)
+	}
+}
+
+func TestAddInputToOrder_Process_ReservedQuantityIsNotAvailable(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Received"}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Name: "Oil filter", InputType: "material", Quantity: 5, ReservedQuantity: 4, Price: money.MustParse("30.00")}, nil
+	}
+
+	useCase := &AddInputToOrder{
+		Logger:                 loggerMock,
+		ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:      orderRepoMock,
+			Inputs:      inputRepoMock,
+			OrderInputs: &mocks.OrderInputRepositoryMock{},
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), uuid.New(), 2, uuid.New())
+
+	// Assert
+	if err == nil || err.Error() != "insufficient input quantity" {
+		t.Errorf("Expected 'insufficient input quantity' error, got %v", err)
+	}
+}
+
+func TestAddInputToOrder_Process_FinalizedOrder(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Canceled"}, nil
+	}
+
+	useCase := &AddInputToOrder{
+		Logger: loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:      orderRepoMock,
+			Inputs:      &mocks.InputRepositoryMock{},
+			OrderInputs: &mocks.OrderInputRepositoryMock{},
+		}},
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), uuid.New(), 1, uuid.New())
+
+	// Assert
+	if err == nil || err.Error() != "order is finalized" {
+		t.Errorf("Expected 'order is finalized' error, got %v", err)
+	}
+}
+
+func TestAddInputToOrder_Process_ReopenedOrderKeepsReservedUnitsOnSeparateLine(t *testing.T) {
+	// Arrange
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	inputID := uuid.New()
+	consumedLineID := uuid.New()
+
+	// Estado de cada cenário: a order voltou para aprovação com 2 peças já baixadas
+	type state struct {
+		onHand   int
+		reserved int
+		lines    []models.OrderInput
+		repos    repository.Repositories
+	}
+	newState := func() *state {
+		s := &state{onHand: 10}
+		s.lines = []models.OrderInput{
+			{ID: consumedLineID, OrderID: orderID, InputID: inputID, Quantity: 2, UnitPrice: money.MustParse("40.00"), TotalPrice: money.MustParse("80.00"), StockStatus: "consumed"},
+		}
+
+		orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+		orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+			return append([]models.OrderInput{}, s.lines...), nil
+		}
+		orderInputRepoMock.CreateFunc = func(orderInput *models.OrderInput) error {
+			s.lines = append(s.lines, *orderInput)
+			return nil
+		}
+		orderInputRepoMock.UpdateFunc = func(orderInput *models.OrderInput) error {
+			for i := range s.lines {
+				if s.lines[i].ID == orderInput.ID {
+					s.lines[i] = *orderInput
+				}
+			}
+			return nil
+		}
+		orderInputRepoMock.DeleteFunc = func(id uuid.UUID) error {
+			for i := range s.lines {
+				if s.lines[i].ID == id {
+					s.lines = append(s.lines[:i], s.lines[i+1:]...)
+					return nil
+				}
+			}
+			return errors.New("order input not found")
+		}
+
+		inputRepoMock := &mocks.InputRepositoryMock{}
+		inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+			return &models.Input{ID: inputID, Name: "Brake disc", InputType: "material", Quantity: s.onHand, ReservedQuantity: s.reserved, Price: money.MustParse("40.00")}, nil
+		}
+		inputRepoMock.ReserveQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+			s.reserved += quantity
+			return &models.Input{ID: id, Quantity: s.onHand, ReservedQuantity: s.reserved}, nil
+		}
+		inputRepoMock.ReleaseReservationFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+			s.reserved -= quantity
+			return &models.Input{ID: id, Quantity: s.onHand, ReservedQuantity: s.reserved}, nil
+		}
+		inputRepoMock.ConsumeReservationFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+			s.onHand -= quantity
+			s.reserved -= quantity
+			return &models.Input{ID: id, Quantity: s.onHand, ReservedQuantity: s.reserved}, nil
+		}
+		inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+			s.onHand += quantity
+			return &models.Input{ID: id, Quantity: s.onHand, ReservedQuantity: s.reserved}, nil
+		}
+
+		s.repos = repository.Repositories{
+			Orders: &mocks.OrderRepositoryMock{FindByIDFunc: func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: orderID, Status: "Awaiting approval"}, nil
+			}},
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}
+		s.repos.UnitOfWork = &mocks.UnitOfWorkMock{Repositories: s.repos}
+		return s
+	}
+
+	reservation := &input.ManageInputReservation{Logger: loggerMock, RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock}}
+	addMore := func(s *state) {
+		useCase := &AddInputToOrder{
+			Logger:                 loggerMock,
+			DecreaseQuantityInput:  &input.DecreaseQuantityInput{Logger: loggerMock, RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock}},
+			ManageInputReservation: reservation,
+			UnitOfWork:             s.repos.UnitOfWork,
+		}
+		if err := useCase.Process(orderID, inputID, 3, uuid.New()); err != nil {
+			t.Fatalf("Expected no error adding input, got %v", err)
+		}
+		if len(s.lines) != 2 || s.lines[0].Quantity != 2 || s.lines[1].StockStatus != "reserved" || s.lines[1].Quantity != 3 {
+			t.Fatalf("Expected reserved units on a new line, got %+v", s.lines)
+		}
+	}
+
+	t.Run("approval consumes the reservation", func(t *testing.T) {
+		s := newState()
+		addMore(s)
+
+		// Act
+		err := (&ManageOrderReservations{Logger: loggerMock, ManageInputReservation: reservation}).WithRepositories(s.repos).ConsumeReservations(orderID, nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s.reserved != 0 || s.onHand != 7 {
+			t.Errorf("Expected reservation consumed leaving 7 on hand and 0 reserved, got %d on hand and %d reserved", s.onHand, s.reserved)
+		}
+	})
+
+	t.Run("cancel returns only deducted units", func(t *testing.T) {
+		s := newState()
+		addMore(s)
+		cancel := &CancelOrderInputs{
+			Logger:                 loggerMock,
+			ManageInputReservation: reservation,
+			IncreaseQuantityInput:  &input.IncreaseQuantityInput{Logger: loggerMock, RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock}},
+		}
+
+		// Act
+		summary, err := cancel.WithRepositories(s.repos).Process(orderID, nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s.reserved != 0 || s.onHand != 12 {
+			t.Errorf("Expected 2 units restocked and 3 released, got %d on hand and %d reserved", s.onHand, s.reserved)
+		}
+		if len(summary.Restocked) != 1 || summary.Restocked[0].Quantity != 2 || len(summary.Released) != 1 || summary.Released[0].Quantity != 3 {
+			t.Errorf("Expected summary to restock 2 and release 3, got %+v", summary)
+		}
+	})
+
+	t.Run("removal releases reserved units before returning deducted ones", func(t *testing.T) {
+		s := newState()
+		addMore(s)
+		remove := &RemoveInputFromOrder{
+			Logger:                 loggerMock,
+			IncreaseQuantityInput:  &input.IncreaseQuantityInput{Logger: loggerMock, RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock}},
+			ManageInputReservation: reservation,
+			UnitOfWork:             s.repos.UnitOfWork,
+		}
+
+		// Act
+		err := remove.Process(orderID, inputID, 4, uuid.New())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s.reserved != 0 || s.onHand != 11 {
+			t.Errorf("Expected 3 units released and 1 restocked, got %d on hand and %d reserved", s.onHand, s.reserved)
+		}
+		if len(s.lines) != 1 || s.lines[0].ID != consumedLineID || s.lines[0].Quantity != 1 {
+			t.Errorf("Expected only the consumed line left with 1 unit, got %+v", s.lines)
+		}
+	})
+}
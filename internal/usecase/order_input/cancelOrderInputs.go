@@ -0,0 +1,176 @@
+package order_input
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
+	"go.uber.org/zap"
+)
+
+// CancelOrderInputs devolve ao estoque as peças de uma order cancelada e anula os seus itens.
+// Deve ser chamado dentro da transação que grava o status de cancelamento.
+type CancelOrderInputs struct {
+	OrderInputRepository   repository.OrderInputRepository
+	InputRepository        repository.InputRepository
+	Logger                 logger.Logger
+	ManageInputReservation *input.ManageInputReservation
+	IncreaseQuantityInput  *input.IncreaseQuantityInput
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *CancelOrderInputs) WithRepositories(repos repository.Repositories) *CancelOrderInputs {
+	if uc == nil {
+		return nil
+	}
+	return &CancelOrderInputs{
+		OrderInputRepository:   repos.OrderInputs,
+		InputRepository:        repos.Inputs,
+		Logger:                 uc.Logger,
+		ManageInputReservation: uc.ManageInputReservation.WithRepositories(repos),
+		IncreaseQuantityInput:  uc.IncreaseQuantityInput.WithRepositories(repos),
+	}
+}
+
+// FetchOrderInputsFromDB busca os itens da order que ainda não foram anulados
+func (uc *CancelOrderInputs) FetchOrderInputsFromDB(orderID uuid.UUID) ([]models.OrderInput, error) {
+	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order inputs", zap.Error(err), zap.String("orderID", orderID.String()))
+		return nil, err
+	}
+
+	active := []models.OrderInput{}
+	for _, orderInput := range orderInputs {
+		if orderInput.VoidedAt == nil {
+			active = append(active, orderInput)
+		}
+	}
+
+	uc.Logger.Info("Order inputs to cancel found",
+		zap.String("orderID", orderID.String()),
+		zap.Int("count", len(active)))
+
+	return active, nil
+}
+
+// IsServiceInput verifica se o item da order é um serviço, que não controla estoque
+func (uc *CancelOrderInputs) IsServiceInput(inputID uuid.UUID) (bool, error) {
+	input, err := uc.InputRepository.FindByID(inputID)
+	if err != nil {
+		uc.Logger.Error("Input not found", zap.String("inputID", inputID.String()))
+		return false, errors.New("input not found")
+	}
+	return input.InputType == "service", nil
+}
+
+// ReturnOrderInputStock devolve ao estoque a quantidade do item conforme a sua situação
+// e retorna o novo status de estoque do item
+func (uc *CancelOrderInputs) ReturnOrderInputStock(orderInput *models.OrderInput, userID *uuid.UUID, summary *domain.RestockSummary) (string, error) {
+	item := domain.RestockItem{
+		OrderInputID: orderInput.ID,
+		InputID:      orderInput.InputID,
+		Quantity:     orderInput.Quantity,
+	}
+
+	switch orderInput.StockStatus {
+	case domain.StockStatusReserved:
+		// Peças apenas reservadas nunca saíram do estoque, basta liberar a reserva
+		if err := uc.ManageInputReservation.Release(orderInput.InputID, orderInput.Quantity); err != nil {
+			return "", err
+		}
+		summary.Released = append(summary.Released, item)
+		return domain.StockStatusReleased, nil
+
+	case domain.StockStatusConsumed:
+		if orderInput.UsedOrDamaged {
+			uc.Logger.Info("Order input flagged as used or damaged, keeping it out of stock",
+				zap.String("orderInputID", orderInput.ID.String()))
+			summary.Retained = append(summary.Retained, item)
+			return orderInput.StockStatus, nil
+		}
+
+		isService, err := uc.IsServiceInput(orderInput.InputID)
+		if err != nil {
+			return "", err
+		}
+		if isService {
+			return orderInput.StockStatus, nil
+		}
+
+		movement := inventoryMovement.MovementContext{
+			MovementType: inventoryMovement.MovementTypeOrderReturn,
+			OrderID:      &orderInput.OrderID,
+			UserID:       userID,
+			Reason:       "order canceled",
+		}
+		if err := uc.IncreaseQuantityInput.Process(orderInput.InputID, orderInput.Quantity, movement); err != nil {
+			return "", err
+		}
+		summary.Restocked = append(summary.Restocked, item)
+		return domain.StockStatusReturned, nil
+	}
+
+	// Itens já liberados ou devolvidos não alteram o estoque novamente
+	return orderInput.StockStatus, nil
+}
+
+// VoidOrderInput marca o item como anulado gravando a sua situação final no estoque
+func (uc *CancelOrderInputs) VoidOrderInput(orderInput *models.OrderInput, stockStatus string, voidedAt time.Time) error {
+	orderInput.StockStatus = stockStatus
+	orderInput.VoidedAt = &voidedAt
+
+	err := uc.OrderInputRepository.Update(orderInput)
+	if err != nil {
+		uc.Logger.Error("Database error voiding order input", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Order input voided",
+		zap.String("orderInputID", orderInput.ID.String()),
+		zap.String("stockStatus", stockStatus))
+
+	return nil
+}
+
+// Process devolve as peças ao estoque, anula os itens da order e retorna o resumo do que foi reposto
+func (uc *CancelOrderInputs) Process(orderID uuid.UUID, userID *uuid.UUID) (*domain.RestockSummary, error) {
+	uc.Logger.Info("Processing cancel order inputs", zap.String("orderID", orderID.String()))
+
+	orderInputs, err := uc.FetchOrderInputsFromDB(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := domain.NewRestockSummary()
+	voidedAt := time.Now()
+
+	for i := range orderInputs {
+		orderInput := &orderInputs[i]
+
+		stockStatus, err := uc.ReturnOrderInputStock(orderInput, userID, summary)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := uc.VoidOrderInput(orderInput, stockStatus, voidedAt); err != nil {
+			return nil, err
+		}
+		summary.VoidedItems++
+	}
+
+	uc.Logger.Info("Order inputs canceled",
+		zap.String("orderID", orderID.String()),
+		zap.Int("restocked", len(summary.Restocked)),
+		zap.Int("released", len(summary.Released)),
+		zap.Int("retained", len(summary.Retained)),
+		zap.Int("voided", summary.VoidedItems))
+
+	return summary, nil
+}
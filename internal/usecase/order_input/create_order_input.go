@@ -41,8 +41,8 @@ func (uc *CreateOrderInput) Process(entity *domain.OrderInput) error {
 		zap.String("orderID", model.OrderID.String()),
 		zap.String("inputID", model.InputID.String()),
 		zap.Int("quantity", model.Quantity),
-		zap.Float64("unitPrice", model.UnitPrice),
-		zap.Float64("totalPrice", model.TotalPrice))
+		zap.Stringer("unitPrice", model.UnitPrice),
+		zap.Stringer("totalPrice", model.TotalPrice))
 
 	// Salva no banco
 	err := uc.SaveOrderInputToDB(model)
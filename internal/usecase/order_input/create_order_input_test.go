@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
@@ -39,14 +40,14 @@ func TestCreateOrderInput_Process_Success(t *testing.T) {
 	}
 
 	orderInput := &domain.OrderInput{
-		ID:        uuid.New(),
-		OrderID:   uuid.New(),
-		InputID:   uuid.New(),
-		Quantity:  5,
-		UnitPrice: 10.50,
-		TotalPrice: 52.50,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		OrderID:    uuid.New(),
+		InputID:    uuid.New(),
+		Quantity:   5,
+		UnitPrice:  money.MustParse("10.50"),
+		TotalPrice: money.MustParse("52.50"),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
 	// Act
@@ -113,14 +114,14 @@ func TestCreateOrderInput_Process_DatabaseError(t *testing.T) {
 	}
 
 	orderInput := &domain.OrderInput{
-		ID:        uuid.New(),
-		OrderID:   uuid.New(),
-		InputID:   uuid.New(),
-		Quantity:  3,
-		UnitPrice: 15.00,
-		TotalPrice: 45.00,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		OrderID:    uuid.New(),
+		InputID:    uuid.New(),
+		Quantity:   3,
+		UnitPrice:  money.MustParse("15.00"),
+		TotalPrice: money.MustParse("45.00"),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
 	// Act
@@ -184,8 +185,8 @@ func TestCreateOrderInput_SaveOrderInputToDB_Success(t *testing.T) {
 		OrderID:    uuid.New(),
 		InputID:    uuid.New(),
 		Quantity:   2,
-		UnitPrice:  25.00,
-		TotalPrice: 50.00,
+		UnitPrice:  money.MustParse("25.00"),
+		TotalPrice: money.MustParse("50.00"),
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
@@ -251,8 +252,8 @@ func TestCreateOrderInput_SaveOrderInputToDB_Error(t *testing.T) {
 		OrderID:    uuid.New(),
 		InputID:    uuid.New(),
 		Quantity:   1,
-		UnitPrice:  30.00,
-		TotalPrice: 30.00,
+		UnitPrice:  money.MustParse("30.00"),
+		TotalPrice: money.MustParse("30.00"),
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
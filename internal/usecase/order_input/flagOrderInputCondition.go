@@ -0,0 +1,116 @@
+package order_input
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+// FlagOrderInputCondition marca uma peça da order como usada ou danificada, impedindo que ela
+// volte ao estoque se a order for cancelada
+type FlagOrderInputCondition struct {
+	OrderRepository      repository.OrderRepository
+	OrderInputRepository repository.OrderInputRepository
+	Logger               logger.Logger
+}
+
+// FetchOrderFromDB busca a order e garante que ela ainda pode ser alterada
+func (uc *FlagOrderInputCondition) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, inputs cannot be flagged",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return nil, errors.New("order is finalized")
+	}
+
+	return order, nil
+}
+
+// FetchOrderInputsFromDB busca as linhas ativas da order referentes ao input. O mesmo input pode
+// ter uma linha reservada e outras já baixadas quando a order volta para aprovação.
+func (uc *FlagOrderInputCondition) FetchOrderInputsFromDB(orderID, inputID uuid.UUID) ([]models.OrderInput, error) {
+	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order inputs", zap.Error(err))
+		return nil, err
+	}
+
+	lines := []models.OrderInput{}
+	for _, orderInput := range orderInputs {
+		if orderInput.InputID == inputID && orderInput.VoidedAt == nil {
+			lines = append(lines, orderInput)
+		}
+	}
+
+	if len(lines) == 0 {
+		uc.Logger.Error("Order input not found",
+			zap.String("orderID", orderID.String()),
+			zap.String("inputID", inputID.String()))
+		return nil, errors.New("order input not found")
+	}
+	return lines, nil
+}
+
+// ValidateOrderInputConsumed valida se a peça já saiu do estoque. Peças apenas reservadas
+// continuam no almoxarifado e não podem ter sido usadas.
+func (uc *FlagOrderInputCondition) ValidateOrderInputConsumed(orderInput *models.OrderInput) error {
+	if orderInput.StockStatus != domain.StockStatusConsumed {
+		uc.Logger.Error("Order input is not consumed",
+			zap.String("orderInputID", orderInput.ID.String()),
+			zap.String("stockStatus", orderInput.StockStatus))
+		return errors.New("only consumed order inputs can be flagged")
+	}
+	return nil
+}
+
+func (uc *FlagOrderInputCondition) Process(orderID uuid.UUID, inputID uuid.UUID, usedOrDamaged bool) error {
+	uc.Logger.Info("Processing flag order input condition",
+		zap.String("orderID", orderID.String()),
+		zap.String("inputID", inputID.String()),
+		zap.Bool("usedOrDamaged", usedOrDamaged))
+
+	if _, err := uc.FetchOrderFromDB(orderID); err != nil {
+		return err
+	}
+
+	lines, err := uc.FetchOrderInputsFromDB(orderID, inputID)
+	if err != nil {
+		return err
+	}
+
+	// Só as linhas já baixadas recebem a marcação; se não houver nenhuma, a primeira explica o motivo
+	consumed := []models.OrderInput{}
+	for _, orderInput := range lines {
+		if orderInput.StockStatus == domain.StockStatusConsumed {
+			consumed = append(consumed, orderInput)
+		}
+	}
+	if len(consumed) == 0 {
+		return uc.ValidateOrderInputConsumed(&lines[0])
+	}
+
+	for _, orderInput := range consumed {
+		if err := uc.OrderInputRepository.SetUsedOrDamaged(orderInput.ID, usedOrDamaged); err != nil {
+			uc.Logger.Error("Database error flagging order input", zap.Error(err))
+			return err
+		}
+
+		uc.Logger.Info("Order input condition updated",
+			zap.String("orderInputID", orderInput.ID.String()),
+			zap.Bool("usedOrDamaged", usedOrDamaged))
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package order_input
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestFlagOrderInputCondition_Process_Success(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	inputID := uuid.New()
+	orderInputID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, Status: "In progress"}, nil
+	}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: orderInputID, OrderID: orderID, InputID: inputID, Quantity: 1, StockStatus: "consumed"},
+		}, nil
+	}
+
+	var flaggedID uuid.UUID
+	var flaggedValue bool
+	orderInputRepoMock.SetUsedOrDamagedFunc = func(id uuid.UUID, usedOrDamaged bool) error {
+		flaggedID = id
+		flaggedValue = usedOrDamaged
+		return nil
+	}
+
+	useCase := &FlagOrderInputCondition{
+		OrderRepository:      orderRepoMock,
+		OrderInputRepository: orderInputRepoMock,
+		Logger:               loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(orderID, inputID, true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flaggedID != orderInputID || !flaggedValue {
+		t.Errorf("Expected order input %s flagged as used or damaged, got %s=%v", orderInputID, flaggedID, flaggedValue)
+	}
+}
+
+func TestFlagOrderInputCondition_Process_ReservedOrderInput(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	inputID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Awaiting approval"}, nil
+	}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: id, InputID: inputID, Quantity: 1, StockStatus: "reserved"},
+		}, nil
+	}
+	orderInputRepoMock.SetUsedOrDamagedFunc = func(id uuid.UUID, usedOrDamaged bool) error {
+		t.Error("Expected reserved order input not to be flagged")
+		return nil
+	}
+
+	useCase := &FlagOrderInputCondition{
+		OrderRepository:      orderRepoMock,
+		OrderInputRepository: orderInputRepoMock,
+		Logger:               loggerMock,
+	}
+
+	// Act
+	err := useCase.Process(uuid.New(), inputID, true)
+
+	// Assert
+	if err == nil || err.Error() != "only consumed order inputs can be flagged" {
+		t.Errorf("Expected 'only consumed order inputs can be flagged' error, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package order_input
+
+import (
+	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
+	"go.uber.org/zap"
+)
+
+// ManageOrderReservations converte as reservas de estoque de uma order em baixa quando ela entra em execução.
+// Deve ser chamado dentro da transação que grava o novo status.
+type ManageOrderReservations struct {
+	OrderInputRepository   repository.OrderInputRepository
+	Logger                 logger.Logger
+	ManageInputReservation *input.ManageInputReservation
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *ManageOrderReservations) WithRepositories(repos repository.Repositories) *ManageOrderReservations {
+	if uc == nil {
+		return nil
+	}
+	return &ManageOrderReservations{
+		OrderInputRepository:   repos.OrderInputs,
+		Logger:                 uc.Logger,
+		ManageInputReservation: uc.ManageInputReservation.WithRepositories(repos),
+	}
+}
+
+// FetchReservedOrderInputs busca os itens da order que ainda estão apenas reservados
+func (uc *ManageOrderReservations) FetchReservedOrderInputs(orderID uuid.UUID) ([]models.OrderInput, error) {
+	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order inputs", zap.Error(err), zap.String("orderID", orderID.String()))
+		return nil, err
+	}
+
+	reserved := []models.OrderInput{}
+	for _, orderInput := range orderInputs {
+		if orderInput.StockStatus == domain.StockStatusReserved {
+			reserved = append(reserved, orderInput)
+		}
+	}
+
+	uc.Logger.Info("Reserved order inputs found",
+		zap.String("orderID", orderID.String()),
+		zap.Int("count", len(reserved)))
+
+	return reserved, nil
+}
+
+// UpdateStockStatus grava a nova situação do item no estoque
+func (uc *ManageOrderReservations) UpdateStockStatus(orderInput *models.OrderInput, stockStatus string) error {
+	orderInput.StockStatus = stockStatus
+
+	err := uc.OrderInputRepository.Update(orderInput)
+	if err != nil {
+		uc.Logger.Error("Database error updating order input stock status", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ConsumeReservations baixa do estoque todas as peças reservadas para a order, registrando cada
+// baixa no ledger. É chamado quando a order entra em execução.
+func (uc *ManageOrderReservations) ConsumeReservations(orderID uuid.UUID, userID *uuid.UUID) error {
+	orderInputs, err := uc.FetchReservedOrderInputs(orderID)
+	if err != nil {
+		return err
+	}
+
+	for i := range orderInputs {
+		orderInput := &orderInputs[i]
+		movement := inventoryMovement.MovementContext{
+			MovementType: inventoryMovement.MovementTypeOrderConsumption,
+			OrderID:      &orderID,
+			UserID:       userID,
+		}
+		if err := uc.ManageInputReservation.Consume(orderInput.InputID, orderInput.Quantity, movement); err != nil {
+			return err
+		}
+		if err := uc.UpdateStockStatus(orderInput, domain.StockStatusConsumed); err != nil {
+			return err
+		}
+	}
+
+	uc.Logger.Info("Order reservations consumed",
+		zap.String("orderID", orderID.String()),
+		zap.Int("count", len(orderInputs)))
+
+	return nil
+}
@@ -4,6 +4,10 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	inventoryMovement "github.com/ln0rd/tech_challenge_12soat/internal/domain/inventory_movement"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_input"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
@@ -17,11 +21,30 @@ type RemoveInputFromOrder struct {
 	OrderInputRepository  repository.OrderInputRepository
 	Logger                logger.Logger
 	IncreaseQuantityInput *input.IncreaseQuantityInput
+	UnitOfWork            repository.UnitOfWork
+
+	// ManageInputReservation libera a reserva dos itens de orders ainda não aprovadas
+	ManageInputReservation *input.ManageInputReservation
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *RemoveInputFromOrder) WithRepositories(repos repository.Repositories) *RemoveInputFromOrder {
+	return &RemoveInputFromOrder{
+		OrderRepository:       repos.Orders,
+		InputRepository:       repos.Inputs,
+		OrderInputRepository:  repos.OrderInputs,
+		Logger:                uc.Logger,
+		IncreaseQuantityInput: uc.IncreaseQuantityInput.WithRepositories(repos),
+		UnitOfWork:            repos.UnitOfWork,
+
+		ManageInputReservation: uc.ManageInputReservation.WithRepositories(repos),
+	}
 }
 
-// FetchOrderFromDB busca um order específico do banco de dados
+// FetchOrderFromDB busca a order bloqueando a linha; a remoção lê as linhas ativas e não pode
+// concorrer com um cancelamento que devolve essas mesmas linhas ao estoque
 func (uc *RemoveInputFromOrder) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
-	order, err := uc.OrderRepository.FindByID(orderID)
+	order, err := uc.OrderRepository.FindByIDForUpdate(orderID)
 	if err != nil {
 		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
 		return nil, errors.New("order not found")
@@ -30,6 +53,17 @@ func (uc *RemoveInputFromOrder) FetchOrderFromDB(orderID uuid.UUID) (*models.Ord
 	return order, nil
 }
 
+// ValidateOrderAcceptsChanges valida se os itens da order ainda podem ser alterados
+func (uc *RemoveInputFromOrder) ValidateOrderAcceptsChanges(order *models.Order) error {
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, inputs cannot be removed",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is finalized")
+	}
+	return nil
+}
+
 // FetchInputFromDB busca um input específico do banco de dados
 func (uc *RemoveInputFromOrder) FetchInputFromDB(inputID uuid.UUID) (*models.Input, error) {
 	input, err := uc.InputRepository.FindByID(inputID)
@@ -54,32 +88,77 @@ func (uc *RemoveInputFromOrder) ValidateQuantityToRemove(quantityToRemove int) e
 	return nil
 }
 
-// FetchOrderInputFromDB busca um order input específico do banco de dados
-func (uc *RemoveInputFromOrder) FetchOrderInputFromDB(orderID, inputID uuid.UUID) (*models.OrderInput, error) {
+// FetchOrderInputLinesFromDB busca as linhas ativas do input na order. As reservadas vêm
+// primeiro, para que a remoção libere reservas antes de devolver peças já baixadas.
+func (uc *RemoveInputFromOrder) FetchOrderInputLinesFromDB(orderID, inputID uuid.UUID) ([]models.OrderInput, error) {
 	// Busca todos os order inputs para este order
 	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Procura por um order input com o input_id específico
+	reserved := []models.OrderInput{}
+	consumed := []models.OrderInput{}
 	for _, orderInput := range orderInputs {
-		if orderInput.InputID == inputID {
-			uc.Logger.Info("Order input found",
-				zap.String("orderInputID", orderInput.ID.String()),
-				zap.String("orderID", orderInput.OrderID.String()),
-				zap.String("inputID", orderInput.InputID.String()),
-				zap.Int("quantity", orderInput.Quantity),
-				zap.Float64("unitPrice", orderInput.UnitPrice),
-				zap.Float64("totalPrice", orderInput.TotalPrice))
-			return &orderInput, nil
+		if orderInput.InputID != inputID || orderInput.VoidedAt != nil {
+			continue
+		}
+		switch orderInput.StockStatus {
+		case domain.StockStatusReserved:
+			reserved = append(reserved, orderInput)
+		case domain.StockStatusReleased, domain.StockStatusReturned:
+			// Linhas liberadas ou devolvidas já não têm peças da order
+		default:
+			consumed = append(consumed, orderInput)
 		}
 	}
 
-	uc.Logger.Error("Order input not found",
-		zap.String("orderID", orderID.String()),
-		zap.String("inputID", inputID.String()))
-	return nil, errors.New("order input not found")
+	lines := append(reserved, consumed...)
+	if len(lines) == 0 {
+		uc.Logger.Error("Order input not found",
+			zap.String("orderID", orderID.String()),
+			zap.String("inputID", inputID.String()))
+		return nil, errors.New("order input not found")
+	}
+
+	for _, orderInput := range lines {
+		uc.Logger.Info("Order input found",
+			zap.String("orderInputID", orderInput.ID.String()),
+			zap.String("orderID", orderInput.OrderID.String()),
+			zap.String("inputID", orderInput.InputID.String()),
+			zap.Int("quantity", orderInput.Quantity),
+			zap.Stringer("unitPrice", orderInput.UnitPrice),
+			zap.Stringer("totalPrice", orderInput.TotalPrice),
+			zap.String("stockStatus", orderInput.StockStatus))
+	}
+
+	return lines, nil
+}
+
+// FetchOrderInputFromDB busca a primeira linha ativa do input na order
+func (uc *RemoveInputFromOrder) FetchOrderInputFromDB(orderID, inputID uuid.UUID) (*models.OrderInput, error) {
+	lines, err := uc.FetchOrderInputLinesFromDB(orderID, inputID)
+	if err != nil {
+		return nil, err
+	}
+	return &lines[0], nil
+}
+
+// ValidateRemovableQuantity valida se as linhas do input somam a quantidade a remover
+func (uc *RemoveInputFromOrder) ValidateRemovableQuantity(lines []models.OrderInput, quantityToRemove int) error {
+	total := 0
+	for _, orderInput := range lines {
+		total += orderInput.Quantity
+	}
+
+	if total < quantityToRemove {
+		uc.Logger.Error("Insufficient quantity in order input",
+			zap.Int("currentQuantity", total),
+			zap.Int("quantityToRemove", quantityToRemove))
+		return errors.New("insufficient quantity in order input")
+	}
+
+	return nil
 }
 
 // ValidateOrderInputQuantity valida se a quantidade no order input é válida
@@ -104,7 +183,7 @@ func (uc *RemoveInputFromOrder) ValidateOrderInputQuantity(orderInput *models.Or
 }
 
 // IncreaseInputQuantity aumenta a quantidade do input
-func (uc *RemoveInputFromOrder) IncreaseInputQuantity(input *models.Input, quantityToRemove int) error {
+func (uc *RemoveInputFromOrder) IncreaseInputQuantity(input *models.Input, quantityToRemove int, movement inventoryMovement.MovementContext) error {
 	if input.InputType == "service" {
 		uc.Logger.Info("Skipping quantity increase for service type",
 			zap.String("inputID", input.ID.String()),
@@ -114,7 +193,7 @@ func (uc *RemoveInputFromOrder) IncreaseInputQuantity(input *models.Input, quant
 	}
 
 	// Usa o usecase de increase quantity
-	err := uc.IncreaseQuantityInput.Process(input.ID, quantityToRemove)
+	err := uc.IncreaseQuantityInput.Process(input.ID, quantityToRemove, movement)
 	if err != nil {
 		uc.Logger.Error("Error increasing input quantity", zap.Error(err))
 		return err
@@ -128,52 +207,60 @@ func (uc *RemoveInputFromOrder) IncreaseInputQuantity(input *models.Input, quant
 	return nil
 }
 
+// ReturnInputQuantity devolve a quantidade removida ao estoque: itens reservados têm a reserva
+// liberada e itens já baixados voltam ao saldo físico
+func (uc *RemoveInputFromOrder) ReturnInputQuantity(input *models.Input, orderInput *models.OrderInput, quantityToRemove int, movement inventoryMovement.MovementContext) error {
+	if orderInput.StockStatus != domain.StockStatusReserved || input.InputType == "service" {
+		return uc.IncreaseInputQuantity(input, quantityToRemove, movement)
+	}
+
+	err := uc.ManageInputReservation.Release(input.ID, quantityToRemove)
+	if err != nil {
+		uc.Logger.Error("Error releasing input reservation", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Input reservation released successfully",
+		zap.String("inputID", input.ID.String()),
+		zap.String("name", input.Name),
+		zap.Int("quantityReleased", quantityToRemove))
+
+	return nil
+}
+
 // CalculateNewOrderInputValues calcula os novos valores do order input
-func (uc *RemoveInputFromOrder) CalculateNewOrderInputValues(orderInput *models.OrderInput, quantityToRemove int) (int, float64) {
+func (uc *RemoveInputFromOrder) CalculateNewOrderInputValues(orderInput *models.OrderInput, quantityToRemove int) (int, money.Money) {
 	newQuantity := orderInput.Quantity - quantityToRemove
-	newTotalPrice := float64(newQuantity) * orderInput.UnitPrice
+	newTotalPrice := orderInput.UnitPrice.Mul(int64(newQuantity))
 
 	uc.Logger.Info("Calculated new order input values",
 		zap.String("orderInputID", orderInput.ID.String()),
 		zap.Int("oldQuantity", orderInput.Quantity),
 		zap.Int("newQuantity", newQuantity),
-		zap.Float64("oldTotalPrice", orderInput.TotalPrice),
-		zap.Float64("newTotalPrice", newTotalPrice))
+		zap.Stringer("oldTotalPrice", orderInput.TotalPrice),
+		zap.Stringer("newTotalPrice", newTotalPrice))
 
 	return newQuantity, newTotalPrice
 }
 
 // RemoveOrderInputFromDB remove o order input do banco de dados
-func (uc *RemoveInputFromOrder) RemoveOrderInputFromDB(orderID, inputID uuid.UUID, quantityToRemove int) error {
-	// Busca todos os order inputs para este order
-	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
+func (uc *RemoveInputFromOrder) RemoveOrderInputFromDB(orderInput *models.OrderInput, quantityToRemove int) error {
+	err := uc.OrderInputRepository.Delete(orderInput.ID)
 	if err != nil {
+		uc.Logger.Error("Database error removing order input", zap.Error(err))
 		return err
 	}
 
-	// Procura por um order input com o input_id específico
-	for _, orderInput := range orderInputs {
-		if orderInput.InputID == inputID {
-			err := uc.OrderInputRepository.Delete(orderInput.ID)
-			if err != nil {
-				uc.Logger.Error("Database error removing order input", zap.Error(err))
-				return err
-			}
-
-			uc.Logger.Info("Order input removed successfully",
-				zap.String("orderInputID", orderInput.ID.String()),
-				zap.String("orderID", orderID.String()),
-				zap.String("inputID", inputID.String()),
-				zap.Int("quantityRemoved", quantityToRemove))
-			return nil
-		}
-	}
-
-	return errors.New("order input not found")
+	uc.Logger.Info("Order input removed successfully",
+		zap.String("orderInputID", orderInput.ID.String()),
+		zap.String("orderID", orderInput.OrderID.String()),
+		zap.String("inputID", orderInput.InputID.String()),
+		zap.Int("quantityRemoved", quantityToRemove))
+	return nil
 }
 
 // UpdateOrderInputInDB atualiza o order input no banco de dados
-func (uc *RemoveInputFromOrder) UpdateOrderInputInDB(orderInput *models.OrderInput, newQuantity int, newTotalPrice float64, quantityToRemove int) error {
+func (uc *RemoveInputFromOrder) UpdateOrderInputInDB(orderInput *models.OrderInput, newQuantity int, newTotalPrice money.Money, quantityToRemove int) error {
 	// Atualiza os valores
 	orderInput.Quantity = newQuantity
 	orderInput.TotalPrice = newTotalPrice
@@ -188,13 +275,13 @@ func (uc *RemoveInputFromOrder) UpdateOrderInputInDB(orderInput *models.OrderInp
 		zap.String("orderInputID", orderInput.ID.String()),
 		zap.Int("oldQuantity", orderInput.Quantity+quantityToRemove),
 		zap.Int("newQuantity", newQuantity),
-		zap.Float64("oldTotalPrice", orderInput.TotalPrice+float64(quantityToRemove)*orderInput.UnitPrice),
-		zap.Float64("newTotalPrice", newTotalPrice))
+		zap.Stringer("oldTotalPrice", orderInput.TotalPrice.Add(orderInput.UnitPrice.Mul(int64(quantityToRemove)))),
+		zap.Stringer("newTotalPrice", newTotalPrice))
 
 	return nil
 }
 
-func (uc *RemoveInputFromOrder) Process(orderID uuid.UUID, inputID uuid.UUID, quantityToRemove int) error {
+func (uc *RemoveInputFromOrder) Process(orderID uuid.UUID, inputID uuid.UUID, quantityToRemove int, userID uuid.UUID) error {
 	uc.Logger.Info("Processing remove input from order",
 		zap.String("orderID", orderID.String()),
 		zap.String("inputID", inputID.String()),
@@ -205,45 +292,81 @@ func (uc *RemoveInputFromOrder) Process(orderID uuid.UUID, inputID uuid.UUID, qu
 		return err
 	}
 
-	// Busca order
-	_, err := uc.FetchOrderFromDB(orderID)
-	if err != nil {
-		return err
-	}
+	// Estoque e itens da order são alterados na mesma transação
+	return uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
 
-	// Busca input
-	input, err := uc.FetchInputFromDB(inputID)
-	if err != nil {
-		return err
-	}
+		// Busca order
+		order, err := tx.FetchOrderFromDB(orderID)
+		if err != nil {
+			return err
+		}
 
-	// Busca order input
-	orderInput, err := uc.FetchOrderInputFromDB(orderID, inputID)
-	if err != nil {
-		return err
-	}
+		// Orders finalizadas não têm mais os itens alterados
+		if err := tx.ValidateOrderAcceptsChanges(order); err != nil {
+			return err
+		}
 
-	// Valida quantidade no order input
-	if err := uc.ValidateOrderInputQuantity(orderInput, quantityToRemove); err != nil {
-		return err
-	}
+		// Busca input
+		input, err := tx.FetchInputFromDB(inputID)
+		if err != nil {
+			return err
+		}
 
-	uc.Logger.Info("Validation passed, proceeding with input quantity increase")
+		// Busca as linhas do input na order
+		lines, err := tx.FetchOrderInputLinesFromDB(orderID, inputID)
+		if err != nil {
+			return err
+		}
 
-	// Aumenta a quantidade do input
-	if err := uc.IncreaseInputQuantity(input, quantityToRemove); err != nil {
-		return err
-	}
+		// Valida quantidade nas linhas do input
+		if err := tx.ValidateRemovableQuantity(lines, quantityToRemove); err != nil {
+			return err
+		}
 
-	// Calcula novos valores
-	newQuantity, newTotalPrice := uc.CalculateNewOrderInputValues(orderInput, quantityToRemove)
+		uc.Logger.Info("Validation passed, proceeding with input quantity return")
 
-	// Se a nova quantidade for 0, remove o registro
-	if newQuantity == 0 {
-		uc.Logger.Info("New quantity is 0, removing order input record")
-		return uc.RemoveOrderInputFromDB(orderID, inputID, quantityToRemove)
-	}
+		movement := inventoryMovement.MovementContext{
+			MovementType: inventoryMovement.MovementTypeOrderReturn,
+			OrderID:      &orderID,
+			UserID:       &userID,
+		}
 
-	// Atualiza o order_input com a nova quantidade e total_price
-	return uc.UpdateOrderInputInDB(orderInput, newQuantity, newTotalPrice, quantityToRemove)
+		// Cada linha devolve a sua parte conforme a sua situação no estoque
+		remaining := quantityToRemove
+		for i := range lines {
+			if remaining == 0 {
+				break
+			}
+			orderInput := &lines[i]
+			quantity := min(remaining, orderInput.Quantity)
+
+			if err := tx.ValidateOrderInputQuantity(orderInput, quantity); err != nil {
+				return err
+			}
+
+			// Devolve a quantidade do input ao estoque
+			if err := tx.ReturnInputQuantity(input, orderInput, quantity, movement); err != nil {
+				return err
+			}
+
+			// Calcula novos valores
+			newQuantity, newTotalPrice := tx.CalculateNewOrderInputValues(orderInput, quantity)
+
+			// Se a nova quantidade for 0, remove o registro; senão atualiza a quantidade e o total_price
+			if newQuantity == 0 {
+				uc.Logger.Info("New quantity is 0, removing order input record")
+				err = tx.RemoveOrderInputFromDB(orderInput, quantity)
+			} else {
+				err = tx.UpdateOrderInputInDB(orderInput, newQuantity, newTotalPrice, quantity)
+			}
+			if err != nil {
+				return err
+			}
+
+			remaining -= quantity
+		}
+
+		return nil
+	})
 }
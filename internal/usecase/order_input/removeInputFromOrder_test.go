@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/input"
 	"go.uber.org/zap"
@@ -19,8 +21,9 @@ func TestRemoveInputFromOrder_Process_Success(t *testing.T) {
 	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
 	loggerMock := &mocks.LoggerMock{}
 	increaseQuantityInputMock := &input.IncreaseQuantityInput{
-		InputRepository: inputRepoMock,
-		Logger:          loggerMock,
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	var loggedInfo []string
@@ -58,7 +61,7 @@ func TestRemoveInputFromOrder_Process_Success(t *testing.T) {
 		Name:      "Test Input",
 		InputType: "material",
 		Quantity:  5,
-		Price:     15.50,
+		Price:     money.MustParse("15.50"),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -75,8 +78,8 @@ func TestRemoveInputFromOrder_Process_Success(t *testing.T) {
 		OrderID:    orderID,
 		InputID:    inputID,
 		Quantity:   5,
-		UnitPrice:  15.50,
-		TotalPrice: 77.50,
+		UnitPrice:  money.MustParse("15.50"),
+		TotalPrice: money.MustParse("77.50"),
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
@@ -93,8 +96,8 @@ func TestRemoveInputFromOrder_Process_Success(t *testing.T) {
 	}
 
 	// Mock Update Input quantity
-	inputRepoMock.UpdateFunc = func(input *models.Input) error {
-		return nil
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		return &models.Input{ID: id, Quantity: 5 + quantity}, nil
 	}
 
 	useCase := &RemoveInputFromOrder{
@@ -103,10 +106,16 @@ func TestRemoveInputFromOrder_Process_Success(t *testing.T) {
 		OrderInputRepository:  orderInputRepoMock,
 		Logger:                loggerMock,
 		IncreaseQuantityInput: increaseQuantityInputMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, inputID, quantityToRemove)
+	err := useCase.Process(orderID, inputID, quantityToRemove, uuid.New())
 
 	// Assert
 	if err != nil {
@@ -145,8 +154,9 @@ func TestRemoveInputFromOrder_Process_OrderNotFound(t *testing.T) {
 	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
 	loggerMock := &mocks.LoggerMock{}
 	increaseQuantityInputMock := &input.IncreaseQuantityInput{
-		InputRepository: inputRepoMock,
-		Logger:          loggerMock,
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	var loggedInfo []string
@@ -174,10 +184,16 @@ func TestRemoveInputFromOrder_Process_OrderNotFound(t *testing.T) {
 		OrderInputRepository:  orderInputRepoMock,
 		Logger:                loggerMock,
 		IncreaseQuantityInput: increaseQuantityInputMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, inputID, quantityToRemove)
+	err := useCase.Process(orderID, inputID, quantityToRemove, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -209,8 +225,9 @@ func TestRemoveInputFromOrder_Process_InputNotFound(t *testing.T) {
 	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
 	loggerMock := &mocks.LoggerMock{}
 	increaseQuantityInputMock := &input.IncreaseQuantityInput{
-		InputRepository: inputRepoMock,
-		Logger:          loggerMock,
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	var loggedInfo []string
@@ -253,10 +270,16 @@ func TestRemoveInputFromOrder_Process_InputNotFound(t *testing.T) {
 		OrderInputRepository:  orderInputRepoMock,
 		Logger:                loggerMock,
 		IncreaseQuantityInput: increaseQuantityInputMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, inputID, quantityToRemove)
+	err := useCase.Process(orderID, inputID, quantityToRemove, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -288,8 +311,9 @@ func TestRemoveInputFromOrder_Process_OrderInputNotFound(t *testing.T) {
 	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
 	loggerMock := &mocks.LoggerMock{}
 	increaseQuantityInputMock := &input.IncreaseQuantityInput{
-		InputRepository: inputRepoMock,
-		Logger:          loggerMock,
+		InputRepository:         inputRepoMock,
+		Logger:                  loggerMock,
+		RecordInventoryMovement: &input.RecordInventoryMovement{Logger: loggerMock},
 	}
 
 	var loggedInfo []string
@@ -327,7 +351,7 @@ func TestRemoveInputFromOrder_Process_OrderInputNotFound(t *testing.T) {
 		Name:      "Test Input",
 		InputType: "material",
 		Quantity:  5,
-		Price:     15.50,
+		Price:     money.MustParse("15.50"),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -349,10 +373,16 @@ func TestRemoveInputFromOrder_Process_OrderInputNotFound(t *testing.T) {
 		OrderInputRepository:  orderInputRepoMock,
 		Logger:                loggerMock,
 		IncreaseQuantityInput: increaseQuantityInputMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:             orderRepoMock,
+			Inputs:             inputRepoMock,
+			OrderInputs:        orderInputRepoMock,
+			InventoryMovements: &mocks.InventoryMovementRepositoryMock{},
+		}},
 	}
 
 	// Act
-	err := useCase.Process(orderID, inputID, quantityToRemove)
+	err := useCase.Process(orderID, inputID, quantityToRemove, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -444,8 +474,8 @@ func TestRemoveInputFromOrder_ValidateOrderInputQuantity_Valid(t *testing.T) {
 		OrderID:    uuid.New(),
 		InputID:    uuid.New(),
 		Quantity:   5,
-		UnitPrice:  15.50,
-		TotalPrice: 77.50,
+		UnitPrice:  money.MustParse("15.50"),
+		TotalPrice: money.MustParse("77.50"),
 	}
 
 	// Act
@@ -475,8 +505,8 @@ func TestRemoveInputFromOrder_ValidateOrderInputQuantity_InvalidQuantity(t *test
 		OrderID:    uuid.New(),
 		InputID:    uuid.New(),
 		Quantity:   0,
-		UnitPrice:  15.50,
-		TotalPrice: 0.0,
+		UnitPrice:  money.MustParse("15.50"),
+		TotalPrice: money.MustParse("0.0"),
 	}
 
 	// Act
@@ -523,8 +553,8 @@ func TestRemoveInputFromOrder_ValidateOrderInputQuantity_InsufficientQuantity(t
 		OrderID:    uuid.New(),
 		InputID:    uuid.New(),
 		Quantity:   2,
-		UnitPrice:  15.50,
-		TotalPrice: 31.00,
+		UnitPrice:  money.MustParse("15.50"),
+		TotalPrice: money.MustParse("31.00"),
 	}
 
 	// Act
@@ -565,8 +595,8 @@ func TestRemoveInputFromOrder_CalculateNewOrderInputValues(t *testing.T) {
 		OrderID:    uuid.New(),
 		InputID:    uuid.New(),
 		Quantity:   10,
-		UnitPrice:  15.50,
-		TotalPrice: 155.00,
+		UnitPrice:  money.MustParse("15.50"),
+		TotalPrice: money.MustParse("155.00"),
 	}
 
 	quantityToRemove := 3
@@ -576,14 +606,14 @@ func TestRemoveInputFromOrder_CalculateNewOrderInputValues(t *testing.T) {
 
 	// Assert
 	expectedQuantity := 7
-	expectedTotalPrice := 108.50
+	expectedTotalPrice := money.MustParse("108.50")
 
 	if newQuantity != expectedQuantity {
 		t.Errorf("Expected quantity %d, got %d", expectedQuantity, newQuantity)
 	}
 
-	if newTotalPrice != expectedTotalPrice {
-		t.Errorf("Expected total price %f, got %f", expectedTotalPrice, newTotalPrice)
+	if !newTotalPrice.Equal(expectedTotalPrice) {
+		t.Errorf("Expected total price %s, got %s", expectedTotalPrice, newTotalPrice)
 	}
 }
 
@@ -605,8 +635,8 @@ func TestRemoveInputFromOrder_FetchOrderInputFromDB_Success(t *testing.T) {
 		OrderID:    orderID,
 		InputID:    inputID,
 		Quantity:   5,
-		UnitPrice:  15.50,
-		TotalPrice: 77.50,
+		UnitPrice:  money.MustParse("15.50"),
+		TotalPrice: money.MustParse("77.50"),
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
@@ -704,3 +734,69 @@ func TestRemoveInputFromOrder_FetchOrderInputFromDB_NotFound(t *testing.T) {
 		t.Errorf("Expected error log message '%s' not found", expectedErrorLog)
 	}
 }
+
+func TestRemoveInputFromOrder_Process_ReservedLineReleasesReservation(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	inputID := uuid.New()
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: orderID, Status: "Awaiting approval"}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: inputID, Name: "Spark plug", InputType: "material", Quantity: 8, ReservedQuantity: 4, Price: money.MustParse("25.00")}, nil
+	}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: orderID, InputID: inputID, Quantity: 4, UnitPrice: money.MustParse("25.00"), TotalPrice: money.MustParse("100.00"), StockStatus: "reserved"},
+		}, nil
+	}
+
+	var released int
+	inputRepoMock.ReleaseReservationFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		released = quantity
+		return &models.Input{ID: id, Quantity: 8, ReservedQuantity: 4 - quantity}, nil
+	}
+	inputRepoMock.IncreaseQuantityFunc = func(id uuid.UUID, quantity int) (*models.Input, error) {
+		t.Error("Expected on-hand stock not to change when releasing a reservation")
+		return nil, errors.New("unexpected increase")
+	}
+
+	var updatedOrderInput *models.OrderInput
+	orderInputRepoMock.UpdateFunc = func(orderInput *models.OrderInput) error {
+		updatedOrderInput = orderInput
+		return nil
+	}
+
+	useCase := &RemoveInputFromOrder{
+		Logger:                 loggerMock,
+		ManageInputReservation: &input.ManageInputReservation{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:      orderRepoMock,
+			Inputs:      inputRepoMock,
+			OrderInputs: orderInputRepoMock,
+		}},
+	}
+
+	// Act
+	err := useCase.Process(orderID, inputID, 3, uuid.New())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if released != 3 {
+		t.Errorf("Expected 3 units released, got %d", released)
+	}
+	if updatedOrderInput == nil || updatedOrderInput.Quantity != 1 {
+		t.Errorf("Expected order input quantity 1, got %+v", updatedOrderInput)
+	}
+}
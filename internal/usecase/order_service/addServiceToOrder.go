@@ -0,0 +1,144 @@
+package order_service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_service"
+	userDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// AddServiceToOrder adiciona uma linha de mão de obra do catálogo à order
+type AddServiceToOrder struct {
+	OrderRepository        repository.OrderRepository
+	LaborServiceRepository repository.LaborServiceRepository
+	OrderServiceRepository repository.OrderServiceRepository
+	UserRepository         repository.UserRepository
+	Logger                 logger.Logger
+}
+
+// CalculateTotalPrice calcula o valor da linha com base nas horas cobradas, arredondado em centavos
+func CalculateTotalPrice(billedHours float64, hourlyRate money.Money) money.Money {
+	return hourlyRate.MulFloat(billedHours)
+}
+
+// FetchOrderFromDB busca a order e garante que ela ainda pode ser alterada
+func (uc *AddServiceToOrder) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, services cannot be added",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return nil, errors.New("order is finalized")
+	}
+
+	return order, nil
+}
+
+// FetchLaborServiceFromDB busca o serviço no catálogo
+func (uc *AddServiceToOrder) FetchLaborServiceFromDB(laborServiceID uuid.UUID) (*models.LaborService, error) {
+	laborService, err := uc.LaborServiceRepository.FindByID(laborServiceID)
+	if err != nil {
+		uc.Logger.Error("Labor service not found", zap.String("laborServiceID", laborServiceID.String()))
+		return nil, errors.New("labor service not found")
+	}
+
+	uc.Logger.Info("Labor service found",
+		zap.String("laborServiceID", laborService.ID.String()),
+		zap.String("name", laborService.Name),
+		zap.Stringer("hourlyRate", laborService.HourlyRate))
+	return laborService, nil
+}
+
+// ValidateMechanic valida se o usuário informado existe e é mecânico
+func (uc *AddServiceToOrder) ValidateMechanic(mechanicID *uuid.UUID) error {
+	if mechanicID == nil {
+		return nil
+	}
+
+	user, err := uc.UserRepository.FindByID(*mechanicID)
+	if err != nil {
+		uc.Logger.Error("Mechanic not found", zap.String("mechanicID", mechanicID.String()))
+		return errors.New("mechanic not found")
+	}
+
+	if user.UserType != userDomain.UserTypeMechanic {
+		uc.Logger.Error("User is not a mechanic",
+			zap.String("userID", user.ID.String()),
+			zap.String("userType", user.UserType))
+		return errors.New("user is not a mechanic")
+	}
+	return nil
+}
+
+// ResolveEstimatedHours usa as horas padrão do catálogo quando a estimativa não é informada
+func (uc *AddServiceToOrder) ResolveEstimatedHours(estimatedHours float64, laborService *models.LaborService) (float64, error) {
+	if estimatedHours == 0 {
+		return laborService.DefaultHours, nil
+	}
+	if estimatedHours < 0 {
+		uc.Logger.Error("Invalid estimated hours", zap.Float64("estimatedHours", estimatedHours))
+		return 0, errors.New("estimated hours must be greater than zero")
+	}
+	return estimatedHours, nil
+}
+
+func (uc *AddServiceToOrder) Process(orderID uuid.UUID, laborServiceID uuid.UUID, mechanicID *uuid.UUID, estimatedHours float64) (*domain.OrderService, error) {
+	uc.Logger.Info("Processing add service to order",
+		zap.String("orderID", orderID.String()),
+		zap.String("laborServiceID", laborServiceID.String()),
+		zap.Float64("estimatedHours", estimatedHours))
+
+	if _, err := uc.FetchOrderFromDB(orderID); err != nil {
+		return nil, err
+	}
+
+	laborService, err := uc.FetchLaborServiceFromDB(laborServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ValidateMechanic(mechanicID); err != nil {
+		return nil, err
+	}
+
+	hours, err := uc.ResolveEstimatedHours(estimatedHours, laborService)
+	if err != nil {
+		return nil, err
+	}
+
+	// O valor da hora é copiado do catálogo para a linha da order
+	model := &models.OrderService{
+		ID:             uuid.New(),
+		OrderID:        orderID,
+		LaborServiceID: laborService.ID,
+		MechanicID:     mechanicID,
+		EstimatedHours: hours,
+		HourlyRate:     laborService.HourlyRate,
+		TotalPrice:     CalculateTotalPrice(hours, laborService.HourlyRate),
+	}
+
+	if err := uc.OrderServiceRepository.Create(model); err != nil {
+		uc.Logger.Error("Database error creating order service", zap.Error(err))
+		return nil, err
+	}
+
+	uc.Logger.Info("Service added to order",
+		zap.String("orderID", orderID.String()),
+		zap.String("orderServiceID", model.ID.String()),
+		zap.Stringer("totalPrice", model.TotalPrice))
+
+	return persistence.OrderServicePersistence{}.ToEntity(model), nil
+}
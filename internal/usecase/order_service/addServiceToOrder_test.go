@@ -0,0 +1,184 @@
+package order_service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestAddServiceToOrder_Process_UsesCatalogDefaults(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	laborService := &models.LaborService{ID: uuid.New(), Name: "Alinhamento", DefaultHours: 1.5, HourlyRate: money.MustParse("80.0")}
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+
+	var created *models.OrderService
+	orderServiceRepoMock.CreateFunc = func(orderService *models.OrderService) error {
+		created = orderService
+		return nil
+	}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Undergoing diagnosis"}, nil
+	}
+
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return laborService, nil
+	}
+
+	useCase := &AddServiceToOrder{
+		OrderRepository:        orderRepoMock,
+		LaborServiceRepository: laborServiceRepoMock,
+		OrderServiceRepository: orderServiceRepoMock,
+		UserRepository:         &mocks.UserRepositoryMock{},
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(uuid.New(), laborService.ID, nil, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created == nil {
+		t.Fatal("Expected order service to be created")
+	}
+	if created.EstimatedHours != 1.5 || !created.HourlyRate.Equal(money.MustParse("80.0")) || !created.TotalPrice.Equal(money.MustParse("120.0")) {
+		t.Errorf("Expected 1.5h at 80.0 totaling 120.0, got %.1fh at %s totaling %s",
+			created.EstimatedHours, created.HourlyRate, created.TotalPrice)
+	}
+	if !result.TotalPrice.Equal(money.MustParse("120.0")) {
+		t.Errorf("Expected result total 120.0, got %s", result.TotalPrice)
+	}
+}
+
+func TestAddServiceToOrder_Process_WithMechanic(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	laborService := &models.LaborService{ID: uuid.New(), DefaultHours: 1, HourlyRate: money.MustParse("100.0")}
+	mechanicID := uuid.New()
+	userRepoMock := &mocks.UserRepositoryMock{}
+	userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id, UserType: "mechanic"}, nil
+	}
+
+	var created *models.OrderService
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	orderServiceRepoMock.CreateFunc = func(orderService *models.OrderService) error {
+		created = orderService
+		return nil
+	}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Received"}, nil
+	}
+
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return laborService, nil
+	}
+
+	useCase := &AddServiceToOrder{
+		OrderRepository:        orderRepoMock,
+		LaborServiceRepository: laborServiceRepoMock,
+		OrderServiceRepository: orderServiceRepoMock,
+		UserRepository:         userRepoMock,
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), laborService.ID, &mechanicID, 2.25)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.MechanicID == nil || *created.MechanicID != mechanicID {
+		t.Error("Expected mechanic to be assigned to the service line")
+	}
+	if !created.TotalPrice.Equal(money.MustParse("225.0")) {
+		t.Errorf("Expected total price 225.0, got %s", created.TotalPrice)
+	}
+}
+
+func TestAddServiceToOrder_Process_Errors(t *testing.T) {
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	laborService := &models.LaborService{ID: uuid.New(), DefaultHours: 1, HourlyRate: money.MustParse("100.0")}
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name           string
+		orderStatus    string
+		laborService   *models.LaborService
+		mechanicID     *uuid.UUID
+		estimatedHours float64
+		expected       string
+	}{
+		{"finalized order", "Delivered", laborService, nil, 1, "order is finalized"},
+		{"unknown labor service", "Received", nil, nil, 1, "labor service not found"},
+		{"negative hours", "Received", laborService, nil, -1, "estimated hours must be greater than zero"},
+		{"user is not a mechanic", "Received", laborService, &ownerID, 1, "user is not a mechanic"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepoMock := &mocks.UserRepositoryMock{}
+			userRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.User, error) {
+				return &models.User{ID: id, UserType: "vehicle_owner"}, nil
+			}
+			orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+			orderServiceRepoMock.CreateFunc = func(orderService *models.OrderService) error {
+				t.Error("Order service should not be created")
+				return nil
+			}
+
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: id, Status: tt.orderStatus}, nil
+			}
+
+			laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+			laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+				if tt.laborService == nil {
+					return nil, errors.New("record not found")
+				}
+				return tt.laborService, nil
+			}
+
+			useCase := &AddServiceToOrder{
+				OrderRepository:        orderRepoMock,
+				LaborServiceRepository: laborServiceRepoMock,
+				OrderServiceRepository: orderServiceRepoMock,
+				UserRepository:         userRepoMock,
+				Logger:                 loggerMock,
+			}
+
+			_, err := useCase.Process(uuid.New(), uuid.New(), tt.mechanicID, tt.estimatedHours)
+
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
+	}
+}
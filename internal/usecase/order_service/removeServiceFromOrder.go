@@ -0,0 +1,61 @@
+package order_service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+// RemoveServiceFromOrder remove uma linha de mão de obra de uma order ainda aberta
+type RemoveServiceFromOrder struct {
+	OrderRepository        repository.OrderRepository
+	OrderServiceRepository repository.OrderServiceRepository
+	Logger                 logger.Logger
+}
+
+// ValidateOrderAcceptsChanges valida se a order existe e ainda pode ser alterada
+func (uc *RemoveServiceFromOrder) ValidateOrderAcceptsChanges(orderID uuid.UUID) error {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, services cannot be removed",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return errors.New("order is finalized")
+	}
+	return nil
+}
+
+func (uc *RemoveServiceFromOrder) Process(orderID uuid.UUID, orderServiceID uuid.UUID) error {
+	uc.Logger.Info("Processing remove service from order",
+		zap.String("orderID", orderID.String()),
+		zap.String("orderServiceID", orderServiceID.String()))
+
+	if err := uc.ValidateOrderAcceptsChanges(orderID); err != nil {
+		return err
+	}
+
+	orderService, err := uc.OrderServiceRepository.FindByID(orderServiceID)
+	if err != nil || orderService.OrderID != orderID {
+		uc.Logger.Error("Order service not found",
+			zap.String("orderID", orderID.String()),
+			zap.String("orderServiceID", orderServiceID.String()))
+		return errors.New("order service not found")
+	}
+
+	if err := uc.OrderServiceRepository.Delete(orderService.ID); err != nil {
+		uc.Logger.Error("Database error deleting order service", zap.Error(err))
+		return err
+	}
+
+	uc.Logger.Info("Service removed from order", zap.String("orderServiceID", orderService.ID.String()))
+	return nil
+}
@@ -0,0 +1,68 @@
+package order_service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestRemoveServiceFromOrder_Process(t *testing.T) {
+	orderID := uuid.New()
+	orderServiceID := uuid.New()
+
+	tests := []struct {
+		name          string
+		orderStatus   string
+		lineOrderID   uuid.UUID
+		expectDeleted bool
+		expected      string
+	}{
+		{"open order", "Undergoing diagnosis", orderID, true, ""},
+		{"finalized order", "Canceled", orderID, false, "order is finalized"},
+		{"line from another order", "Received", uuid.New(), false, "order service not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerMock := &mocks.LoggerMock{}
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: id, Status: tt.orderStatus}, nil
+			}
+
+			deleted := false
+			orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+			orderServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.OrderService, error) {
+				return &models.OrderService{ID: id, OrderID: tt.lineOrderID}, nil
+			}
+			orderServiceRepoMock.DeleteFunc = func(id uuid.UUID) error {
+				deleted = true
+				return nil
+			}
+
+			useCase := &RemoveServiceFromOrder{
+				OrderRepository:        orderRepoMock,
+				OrderServiceRepository: orderServiceRepoMock,
+				Logger:                 loggerMock,
+			}
+
+			err := useCase.Process(orderID, orderServiceID)
+
+			if tt.expected == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.expected != "" && (err == nil || err.Error() != tt.expected) {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+			if deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
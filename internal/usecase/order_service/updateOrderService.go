@@ -0,0 +1,147 @@
+package order_service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_service"
+	userDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/user"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// OrderServiceChanges reúne os campos alteráveis de uma linha de mão de obra. Campos nil
+// mantêm o valor atual.
+type OrderServiceChanges struct {
+	MechanicID     *uuid.UUID
+	EstimatedHours *float64
+	ActualHours    *float64
+}
+
+// UpdateOrderService altera o mecânico e as horas de uma linha de mão de obra, recalculando o seu valor
+type UpdateOrderService struct {
+	OrderRepository        repository.OrderRepository
+	OrderServiceRepository repository.OrderServiceRepository
+	UserRepository         repository.UserRepository
+	Logger                 logger.Logger
+}
+
+// FetchOrderFromDB busca a order e garante que ela ainda pode ser alterada
+func (uc *UpdateOrderService) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, services cannot be changed",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return nil, errors.New("order is finalized")
+	}
+
+	return order, nil
+}
+
+// FetchOrderServiceFromDB busca a linha de mão de obra e garante que ela pertence à order
+func (uc *UpdateOrderService) FetchOrderServiceFromDB(orderID, orderServiceID uuid.UUID) (*models.OrderService, error) {
+	orderService, err := uc.OrderServiceRepository.FindByID(orderServiceID)
+	if err != nil || orderService.OrderID != orderID {
+		uc.Logger.Error("Order service not found",
+			zap.String("orderID", orderID.String()),
+			zap.String("orderServiceID", orderServiceID.String()))
+		return nil, errors.New("order service not found")
+	}
+	return orderService, nil
+}
+
+// ValidateMechanic valida se o usuário informado existe e é mecânico
+func (uc *UpdateOrderService) ValidateMechanic(mechanicID *uuid.UUID) error {
+	if mechanicID == nil {
+		return nil
+	}
+
+	user, err := uc.UserRepository.FindByID(*mechanicID)
+	if err != nil {
+		uc.Logger.Error("Mechanic not found", zap.String("mechanicID", mechanicID.String()))
+		return errors.New("mechanic not found")
+	}
+
+	if user.UserType != userDomain.UserTypeMechanic {
+		uc.Logger.Error("User is not a mechanic",
+			zap.String("userID", user.ID.String()),
+			zap.String("userType", user.UserType))
+		return errors.New("user is not a mechanic")
+	}
+	return nil
+}
+
+// ValidateHours valida as horas informadas
+func (uc *UpdateOrderService) ValidateHours(changes OrderServiceChanges) error {
+	if changes.EstimatedHours != nil && *changes.EstimatedHours <= 0 {
+		uc.Logger.Error("Invalid estimated hours", zap.Float64("estimatedHours", *changes.EstimatedHours))
+		return errors.New("estimated hours must be greater than zero")
+	}
+	if changes.ActualHours != nil && *changes.ActualHours < 0 {
+		uc.Logger.Error("Invalid actual hours", zap.Float64("actualHours", *changes.ActualHours))
+		return errors.New("actual hours cannot be negative")
+	}
+	return nil
+}
+
+// ApplyChanges aplica as alterações na linha e recalcula o valor com o valor da hora gravado nela
+func (uc *UpdateOrderService) ApplyChanges(orderService *models.OrderService, changes OrderServiceChanges) {
+	if changes.MechanicID != nil {
+		orderService.MechanicID = changes.MechanicID
+	}
+	if changes.EstimatedHours != nil {
+		orderService.EstimatedHours = *changes.EstimatedHours
+	}
+	if changes.ActualHours != nil {
+		orderService.ActualHours = changes.ActualHours
+	}
+
+	billedHours := domain.BilledHours(orderService.EstimatedHours, orderService.ActualHours)
+	orderService.TotalPrice = CalculateTotalPrice(billedHours, orderService.HourlyRate)
+}
+
+func (uc *UpdateOrderService) Process(orderID uuid.UUID, orderServiceID uuid.UUID, changes OrderServiceChanges) (*domain.OrderService, error) {
+	uc.Logger.Info("Processing update order service",
+		zap.String("orderID", orderID.String()),
+		zap.String("orderServiceID", orderServiceID.String()))
+
+	if err := uc.ValidateHours(changes); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.FetchOrderFromDB(orderID); err != nil {
+		return nil, err
+	}
+
+	orderService, err := uc.FetchOrderServiceFromDB(orderID, orderServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ValidateMechanic(changes.MechanicID); err != nil {
+		return nil, err
+	}
+
+	uc.ApplyChanges(orderService, changes)
+
+	if err := uc.OrderServiceRepository.Update(orderService); err != nil {
+		uc.Logger.Error("Database error updating order service", zap.Error(err))
+		return nil, err
+	}
+
+	uc.Logger.Info("Order service updated",
+		zap.String("orderServiceID", orderService.ID.String()),
+		zap.Stringer("totalPrice", orderService.TotalPrice))
+
+	return persistence.OrderServicePersistence{}.ToEntity(orderService), nil
+}
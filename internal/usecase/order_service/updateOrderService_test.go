@@ -0,0 +1,136 @@
+package order_service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestUpdateOrderService_Process_ActualHoursRecalculateTotal(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	existing := &models.OrderService{ID: uuid.New(), OrderID: orderID, EstimatedHours: 2, HourlyRate: money.MustParse("90.0"), TotalPrice: money.MustParse("180.0")}
+	actualHours := 2.5
+
+	var updated *models.OrderService
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	orderServiceRepoMock.UpdateFunc = func(orderService *models.OrderService) error {
+		updated = orderService
+		return nil
+	}
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+
+	orderServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.OrderService, error) {
+		return existing, nil
+	}
+
+	useCase := &UpdateOrderService{
+		OrderRepository:        orderRepoMock,
+		OrderServiceRepository: orderServiceRepoMock,
+		UserRepository:         &mocks.UserRepositoryMock{},
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(orderID, existing.ID, OrderServiceChanges{ActualHours: &actualHours})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated == nil || !updated.TotalPrice.Equal(money.MustParse("225.0")) {
+		t.Errorf("Expected total price 225.0 billed on actual hours, got %+v", updated)
+	}
+	if updated.EstimatedHours != 2 {
+		t.Errorf("Expected estimated hours to be kept, got %f", updated.EstimatedHours)
+	}
+}
+
+func TestUpdateOrderService_Process_LineFromAnotherOrder(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	existing := &models.OrderService{ID: uuid.New(), OrderID: uuid.New(), EstimatedHours: 1, HourlyRate: money.MustParse("90.0")}
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	orderServiceRepoMock.UpdateFunc = func(orderService *models.OrderService) error {
+		t.Error("Order service should not be updated")
+		return nil
+	}
+	actualHours := 1.0
+
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+
+	orderServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.OrderService, error) {
+		return existing, nil
+	}
+
+	useCase := &UpdateOrderService{
+		OrderRepository:        orderRepoMock,
+		OrderServiceRepository: orderServiceRepoMock,
+		UserRepository:         &mocks.UserRepositoryMock{},
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(uuid.New(), existing.ID, OrderServiceChanges{ActualHours: &actualHours})
+
+	// Assert
+	if err == nil || err.Error() != "order service not found" {
+		t.Errorf("Expected 'order service not found' error, got %v", err)
+	}
+}
+
+func TestUpdateOrderService_Process_NegativeActualHours(t *testing.T) {
+	// Arrange
+	// Mock logger
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	existing := &models.OrderService{ID: uuid.New(), OrderID: uuid.New()}
+	actualHours := -0.5
+
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress"}, nil
+	}
+
+	orderServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.OrderService, error) {
+		return existing, nil
+	}
+
+	useCase := &UpdateOrderService{
+		OrderRepository:        orderRepoMock,
+		OrderServiceRepository: orderServiceRepoMock,
+		UserRepository:         &mocks.UserRepositoryMock{},
+		Logger:                 loggerMock,
+	}
+
+	// Act
+	_, err := useCase.Process(existing.OrderID, existing.ID, OrderServiceChanges{ActualHours: &actualHours})
+
+	// Assert
+	if err == nil || err.Error() != "actual hours cannot be negative" {
+		t.Errorf("Expected 'actual hours cannot be negative' error, got %v", err)
+	}
+}
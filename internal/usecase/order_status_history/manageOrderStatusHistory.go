@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
@@ -17,6 +18,17 @@ type ManageOrderStatusHistory struct {
 	Logger                       logger.Logger
 }
 
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *ManageOrderStatusHistory) WithRepositories(repos repository.Repositories) *ManageOrderStatusHistory {
+	if uc == nil {
+		return nil
+	}
+	return &ManageOrderStatusHistory{
+		OrderStatusHistoryRepository: repos.OrderStatusHistory,
+		Logger:                       uc.Logger,
+	}
+}
+
 // IsFinalStatus verifica se o status é final
 func (uc *ManageOrderStatusHistory) IsFinalStatus(status string) bool {
 	return status == "Delivered" || status == "Canceled"
@@ -79,16 +91,24 @@ func (uc *ManageOrderStatusHistory) FinalizeCurrentStatus(orderID uuid.UUID) err
 	return nil
 }
 
+// newStatusEntry monta a etapa do histórico com os dados de quem realizou a mudança
+func newStatusEntry(orderID uuid.UUID, status string, change domain.StatusChange, startedAt time.Time) *models.OrderStatusHistory {
+	return &models.OrderStatusHistory{
+		ID:              uuid.New(),
+		OrderID:         orderID,
+		Status:          status,
+		ChangedByUserID: change.ChangedByUserID,
+		Note:            change.Note,
+		Source:          change.ResolvedSource(),
+		StartedAt:       startedAt,
+	}
+}
+
 // CreateNewStatus cria um novo status
-func (uc *ManageOrderStatusHistory) CreateNewStatus(orderID uuid.UUID, status string) error {
+func (uc *ManageOrderStatusHistory) CreateNewStatus(orderID uuid.UUID, status string, change domain.StatusChange) error {
 	now := time.Now()
 
-	newStatusHistory := &models.OrderStatusHistory{
-		ID:        uuid.New(),
-		OrderID:   orderID,
-		Status:    status,
-		StartedAt: now,
-	}
+	newStatusHistory := newStatusEntry(orderID, status, change, now)
 
 	err := uc.OrderStatusHistoryRepository.Create(newStatusHistory)
 	if err != nil {
@@ -99,13 +119,15 @@ func (uc *ManageOrderStatusHistory) CreateNewStatus(orderID uuid.UUID, status st
 	uc.Logger.Info("New status started",
 		zap.String("orderID", orderID.String()),
 		zap.String("status", status),
+		zap.String("source", newStatusHistory.Source),
 		zap.Time("startedAt", now))
 
 	return nil
 }
 
-// UpdateCurrentStatusToFinal atualiza o status atual para um status final
-func (uc *ManageOrderStatusHistory) UpdateCurrentStatusToFinal(orderID uuid.UUID, finalStatus string) error {
+// UpdateCurrentStatusToFinal encerra o status atual e grava o status final como uma etapa própria,
+// que começa e termina no mesmo instante. A etapa anterior é preservada no histórico.
+func (uc *ManageOrderStatusHistory) UpdateCurrentStatusToFinal(orderID uuid.UUID, finalStatus string, change domain.StatusChange) error {
 	currentStatus, err := uc.FetchCurrentStatusFromDB(orderID)
 	if err != nil {
 		return err
@@ -124,23 +146,30 @@ func (uc *ManageOrderStatusHistory) UpdateCurrentStatusToFinal(orderID uuid.UUID
 		zap.String("finalStatus", finalStatus),
 		zap.Time("startedAt", currentStatus.StartedAt))
 
-	// Para status finais, ended_at será igual ao started_at (duração = 0)
 	now := time.Now()
 
-	// Atualiza o registro atual com o status final
-	currentStatus.Status = finalStatus
+	// Encerra a etapa atual mantendo o seu status
 	currentStatus.EndedAt = &now
 	err = uc.OrderStatusHistoryRepository.Update(currentStatus)
 	if err != nil {
-		uc.Logger.Error("Error updating current status to final", zap.Error(err))
+		uc.Logger.Error("Error finalizing current status", zap.Error(err))
+		return err
+	}
+
+	// Status finais não têm duração: ended_at é igual ao started_at
+	finalEntry := newStatusEntry(orderID, finalStatus, change, now)
+	finalEntry.EndedAt = &now
+	err = uc.OrderStatusHistoryRepository.Create(finalEntry)
+	if err != nil {
+		uc.Logger.Error("Error creating final status history", zap.Error(err))
 		return err
 	}
 
-	uc.Logger.Info("Current status updated to final successfully",
+	uc.Logger.Info("Final status recorded successfully",
 		zap.String("orderID", orderID.String()),
-		zap.String("oldStatus", currentStatus.Status),
+		zap.String("previousStatus", currentStatus.Status),
 		zap.String("finalStatus", finalStatus),
-		zap.Time("startedAt", currentStatus.StartedAt),
+		zap.Time("previousStartedAt", currentStatus.StartedAt),
 		zap.Time("endedAt", now))
 
 	return nil
@@ -163,11 +192,16 @@ func (uc *ManageOrderStatusHistory) FetchOrderHistoryFromDB(orderID uuid.UUID) (
 
 // StartNewStatus inicia um novo status
 func (uc *ManageOrderStatusHistory) StartNewStatus(orderID uuid.UUID, status string) error {
-	return uc.CreateNewStatus(orderID, status)
+	return uc.CreateNewStatus(orderID, status, domain.StatusChange{})
 }
 
 // UpdateStatus finaliza o status atual e inicia um novo
 func (uc *ManageOrderStatusHistory) UpdateStatus(orderID uuid.UUID, newStatus string) error {
+	return uc.UpdateStatusWithChange(orderID, newStatus, domain.StatusChange{})
+}
+
+// UpdateStatusWithChange finaliza o status atual e inicia um novo registrando quem realizou a mudança
+func (uc *ManageOrderStatusHistory) UpdateStatusWithChange(orderID uuid.UUID, newStatus string, change domain.StatusChange) error {
 	uc.Logger.Info("Managing order status history",
 		zap.String("orderID", orderID.String()),
 		zap.String("newStatus", newStatus))
@@ -176,12 +210,12 @@ func (uc *ManageOrderStatusHistory) UpdateStatus(orderID uuid.UUID, newStatus st
 	isFinalStatus := uc.IsFinalStatus(newStatus)
 
 	if isFinalStatus {
-		uc.Logger.Info("Status is final, updating current status instead of creating new one",
+		uc.Logger.Info("Status is final, closing current status and recording final entry",
 			zap.String("orderID", orderID.String()),
 			zap.String("newStatus", newStatus))
 
-		// Para status finais, apenas atualiza o status atual
-		err := uc.UpdateCurrentStatusToFinal(orderID, newStatus)
+		// Para status finais, encerra a etapa atual e grava o status final já encerrado
+		err := uc.UpdateCurrentStatusToFinal(orderID, newStatus, change)
 		if err != nil {
 			uc.Logger.Error("Error updating current status to final", zap.Error(err))
 			return err
@@ -195,7 +229,7 @@ func (uc *ManageOrderStatusHistory) UpdateStatus(orderID uuid.UUID, newStatus st
 		}
 
 		// Inicia o novo status
-		err = uc.StartNewStatus(orderID, newStatus)
+		err = uc.CreateNewStatus(orderID, newStatus, change)
 		if err != nil {
 			uc.Logger.Error("Error starting new status", zap.Error(err))
 			return err
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_status_history"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
@@ -303,7 +304,7 @@ func TestManageOrderStatusHistory_CreateNewStatus_Success(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.CreateNewStatus(orderID, status)
+	err := useCase.CreateNewStatus(orderID, status, domain.StatusChange{})
 
 	// Assert
 	if err != nil {
@@ -311,6 +312,47 @@ func TestManageOrderStatusHistory_CreateNewStatus_Success(t *testing.T) {
 	}
 }
 
+func TestManageOrderStatusHistory_CreateNewStatus_RecordsChange(t *testing.T) {
+	// Arrange
+	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	var created []*models.OrderStatusHistory
+	orderStatusHistoryRepoMock.CreateFunc = func(statusHistory *models.OrderStatusHistory) error {
+		created = append(created, statusHistory)
+		return nil
+	}
+
+	useCase := &ManageOrderStatusHistory{
+		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
+		Logger:                       loggerMock,
+	}
+	orderID := uuid.New()
+	userID := uuid.New()
+
+	// Act
+	errCustomer := useCase.CreateNewStatus(orderID, "In progress", domain.StatusChange{
+		ChangedByUserID: &userID,
+		Note:            "Orçamento aprovado",
+		Source:          domain.SourceCustomer,
+	})
+	errDefault := useCase.CreateNewStatus(orderID, "Completed", domain.StatusChange{})
+
+	// Assert
+	if errCustomer != nil || errDefault != nil {
+		t.Fatalf("Expected no error, got %v / %v", errCustomer, errDefault)
+	}
+	if created[0].ChangedByUserID == nil || *created[0].ChangedByUserID != userID ||
+		created[0].Note != "Orçamento aprovado" || created[0].Source != domain.SourceCustomer {
+		t.Errorf("Expected user, note and customer source to be recorded, got %+v", created[0])
+	}
+	if created[1].Source != domain.SourceAPI {
+		t.Errorf("Expected source to default to api, got %q", created[1].Source)
+	}
+}
+
 func TestManageOrderStatusHistory_CreateNewStatus_Error(t *testing.T) {
 	// Arrange
 	orderStatusHistoryRepoMock := &mocks.OrderStatusHistoryRepositoryMock{}
@@ -334,7 +376,7 @@ func TestManageOrderStatusHistory_CreateNewStatus_Error(t *testing.T) {
 	}
 
 	// Act
-	err := useCase.CreateNewStatus(orderID, status)
+	err := useCase.CreateNewStatus(orderID, status, domain.StatusChange{})
 
 	// Assert
 	if err == nil {
@@ -374,18 +416,37 @@ func TestManageOrderStatusHistory_UpdateCurrentStatusToFinal_Success(t *testing.
 		return nil
 	}
 
+	var finalEntry *models.OrderStatusHistory
+	orderStatusHistoryRepoMock.CreateFunc = func(statusHistory *models.OrderStatusHistory) error {
+		finalEntry = statusHistory
+		return nil
+	}
+
 	useCase := &ManageOrderStatusHistory{
 		OrderStatusHistoryRepository: orderStatusHistoryRepoMock,
 		Logger:                       loggerMock,
 	}
 
 	// Act
-	err := useCase.UpdateCurrentStatusToFinal(orderID, finalStatus)
+	err := useCase.UpdateCurrentStatusToFinal(orderID, finalStatus, domain.StatusChange{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
+
+	// A etapa anterior é encerrada sem perder o seu status
+	if mockCurrentStatus.Status != "In progress" || mockCurrentStatus.EndedAt == nil {
+		t.Errorf("Expected previous entry to keep its status and be closed, got %s", mockCurrentStatus.Status)
+	}
+
+	// O status final vira uma etapa própria, sem duração
+	if finalEntry == nil || finalEntry.Status != finalStatus {
+		t.Fatal("Expected final status to be recorded as its own entry")
+	}
+	if finalEntry.EndedAt == nil || !finalEntry.EndedAt.Equal(finalEntry.StartedAt) {
+		t.Error("Expected final entry to start and end at the same instant")
+	}
 }
 
 func TestManageOrderStatusHistory_UpdateCurrentStatusToFinal_NoCurrentStatus(t *testing.T) {
@@ -411,7 +472,7 @@ func TestManageOrderStatusHistory_UpdateCurrentStatusToFinal_NoCurrentStatus(t *
 	}
 
 	// Act
-	err := useCase.UpdateCurrentStatusToFinal(orderID, finalStatus)
+	err := useCase.UpdateCurrentStatusToFinal(orderID, finalStatus, domain.StatusChange{})
 
 	// Assert
 	if err == nil {
@@ -457,7 +518,7 @@ func TestManageOrderStatusHistory_UpdateCurrentStatusToFinal_UpdateError(t *test
 	}
 
 	// Act
-	err := useCase.UpdateCurrentStatusToFinal(orderID, finalStatus)
+	err := useCase.UpdateCurrentStatusToFinal(orderID, finalStatus, domain.StatusChange{})
 
 	// Assert
 	if err == nil {
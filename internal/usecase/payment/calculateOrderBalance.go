@@ -0,0 +1,95 @@
+package payment
+
+import (
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// CalculateOrderBalance calcula quanto a order já recebeu e quanto ainda deve. O valor cobrado é o
+// da nota emitida, descontadas as notas de crédito; antes da emissão vale o orçamento aprovado.
+type CalculateOrderBalance struct {
+	InvoiceRepository repository.InvoiceRepository
+	QuoteRepository   repository.QuoteRepository
+	PaymentRepository repository.PaymentRepository
+	Logger            logger.Logger
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *CalculateOrderBalance) WithRepositories(repos repository.Repositories) *CalculateOrderBalance {
+	if uc == nil {
+		return nil
+	}
+	return &CalculateOrderBalance{
+		InvoiceRepository: repos.Invoices,
+		QuoteRepository:   repos.Quotes,
+		PaymentRepository: repos.Payments,
+		Logger:            uc.Logger,
+	}
+}
+
+// ResolveBilledAmount retorna a origem e o valor cobrado da order
+func (uc *CalculateOrderBalance) ResolveBilledAmount(order *models.Order) (string, money.Money, error) {
+	invoice, err := uc.InvoiceRepository.FindByOrderID(order.ID)
+	if err != nil {
+		uc.Logger.Error("Database error finding invoice", zap.Error(err), zap.String("orderID", order.ID.String()))
+		return "", money.Zero, err
+	}
+	if invoice != nil {
+		return domain.BilledFromInvoice, persistence.InvoicePersistence{}.ToEntity(invoice).CreditableAmount(), nil
+	}
+
+	if order.ApprovedQuoteID != nil {
+		quote, err := uc.QuoteRepository.FindByID(*order.ApprovedQuoteID)
+		if err != nil {
+			uc.Logger.Error("Database error finding approved quote", zap.Error(err), zap.String("orderID", order.ID.String()))
+			return "", money.Zero, err
+		}
+		return domain.BilledFromQuote, quote.TotalPrice, nil
+	}
+
+	return domain.BilledFromNone, money.Zero, nil
+}
+
+// FetchPayments busca os pagamentos registrados para a order
+func (uc *CalculateOrderBalance) FetchPayments(orderID uuid.UUID) ([]domain.Payment, error) {
+	records, err := uc.PaymentRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding payments", zap.Error(err), zap.String("orderID", orderID.String()))
+		return nil, err
+	}
+
+	payments := make([]domain.Payment, 0, len(records))
+	for i := range records {
+		payments = append(payments, *persistence.PaymentPersistence{}.ToEntity(&records[i]))
+	}
+	return payments, nil
+}
+
+// Process retorna o saldo da order junto com os pagamentos que o compõem
+func (uc *CalculateOrderBalance) Process(order *models.Order) (*domain.Balance, []domain.Payment, error) {
+	billedFrom, billed, err := uc.ResolveBilledAmount(order)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payments, err := uc.FetchPayments(order.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	balance := domain.NewBalance(billedFrom, billed, payments)
+	uc.Logger.Info("Order balance calculated",
+		zap.String("orderID", order.ID.String()),
+		zap.String("billedFrom", balance.BilledFrom),
+		zap.Stringer("amountBilled", balance.AmountBilled),
+		zap.Stringer("amountPaid", balance.AmountPaid),
+		zap.Stringer("amountDue", balance.AmountDue))
+
+	return &balance, payments, nil
+}
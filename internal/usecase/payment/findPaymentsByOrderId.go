@@ -0,0 +1,41 @@
+package payment
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+// OrderPayments reúne os pagamentos da order e o saldo resultante
+type OrderPayments struct {
+	Payments []domain.Payment `json:"payments"`
+	Balance  domain.Balance   `json:"balance"`
+}
+
+// FindPaymentsByOrderId lista os pagamentos de uma order com o saldo atual
+type FindPaymentsByOrderId struct {
+	OrderRepository repository.OrderRepository
+	Balance         *CalculateOrderBalance
+	Logger          logger.Logger
+}
+
+func (uc *FindPaymentsByOrderId) Process(orderID uuid.UUID) (*OrderPayments, error) {
+	uc.Logger.Info("Processing find payments by order ID", zap.String("orderID", orderID.String()))
+
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	balance, payments, err := uc.Balance.Process(order)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OrderPayments{Payments: payments, Balance: *balance}, nil
+}
@@ -0,0 +1,152 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// RecordPayment registra um pagamento total ou parcial de uma order. O valor não pode passar do
+// saldo em aberto.
+type RecordPayment struct {
+	OrderRepository   repository.OrderRepository
+	PaymentRepository repository.PaymentRepository
+	Balance           *CalculateOrderBalance
+	Logger            logger.Logger
+	UnitOfWork        repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *RecordPayment) WithRepositories(repos repository.Repositories) *RecordPayment {
+	if uc == nil {
+		return nil
+	}
+	return &RecordPayment{
+		OrderRepository:   repos.Orders,
+		PaymentRepository: repos.Payments,
+		Balance:           uc.Balance.WithRepositories(repos),
+		Logger:            uc.Logger,
+		UnitOfWork:        repos.UnitOfWork,
+	}
+}
+
+// ValidatePayment valida a forma de pagamento, o valor e a referência
+func (uc *RecordPayment) ValidatePayment(payment *domain.Payment) error {
+	if !domain.IsValidMethod(payment.Method) {
+		uc.Logger.Error("Invalid payment method",
+			zap.String("method", payment.Method),
+			zap.Strings("validMethods", domain.ValidMethods()))
+		return errors.New("invalid payment method")
+	}
+
+	if !payment.Amount.IsPositive() {
+		uc.Logger.Error("Invalid payment amount", zap.Stringer("amount", payment.Amount))
+		return errors.New("payment amount must be greater than zero")
+	}
+
+	payment.Reference = strings.TrimSpace(payment.Reference)
+	if payment.Reference == "" && domain.RequiresReference(payment.Method) {
+		uc.Logger.Error("Payment reference is required", zap.String("method", payment.Method))
+		return errors.New("payment reference is required")
+	}
+
+	if payment.PaidAt.After(time.Now()) {
+		uc.Logger.Error("Payment date in the future", zap.Time("paidAt", payment.PaidAt))
+		return errors.New("payment date cannot be in the future")
+	}
+	return nil
+}
+
+// FetchOrderFromDB busca a order bloqueando a linha, para que pagamentos simultâneos não
+// ultrapassem o saldo
+func (uc *RecordPayment) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByIDForUpdate(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	if order.Status == orderDomain.StatusCanceled {
+		uc.Logger.Error("Order is canceled, payment cannot be recorded", zap.String("orderID", order.ID.String()))
+		return nil, errors.New("order is canceled")
+	}
+	return order, nil
+}
+
+// ValidateAmountDue garante que existe saldo a receber e que o pagamento não passa dele
+func (uc *RecordPayment) ValidateAmountDue(order *models.Order, payment *domain.Payment) error {
+	balance, _, err := uc.Balance.Process(order)
+	if err != nil {
+		return err
+	}
+
+	if balance.IsSettled() {
+		uc.Logger.Error("Order has no amount due",
+			zap.String("orderID", order.ID.String()),
+			zap.String("billedFrom", balance.BilledFrom))
+		return errors.New("order has no amount due")
+	}
+
+	if payment.Amount.GreaterThan(balance.AmountDue) {
+		uc.Logger.Error("Payment exceeds amount due",
+			zap.String("orderID", order.ID.String()),
+			zap.Stringer("amount", payment.Amount),
+			zap.Stringer("amountDue", balance.AmountDue))
+		return errors.New("payment exceeds amount due")
+	}
+	return nil
+}
+
+func (uc *RecordPayment) Process(payment *domain.Payment) (*domain.Payment, error) {
+	uc.Logger.Info("Processing record payment",
+		zap.String("orderID", payment.OrderID.String()),
+		zap.String("method", payment.Method),
+		zap.Stringer("amount", payment.Amount))
+
+	if payment.PaidAt.IsZero() {
+		payment.PaidAt = time.Now()
+	}
+	if err := uc.ValidatePayment(payment); err != nil {
+		return nil, err
+	}
+
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		order, err := tx.FetchOrderFromDB(payment.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.ValidateAmountDue(order, payment); err != nil {
+			return err
+		}
+
+		payment.ID = uuid.New()
+		payment.CreatedAt = time.Now()
+		if err := tx.PaymentRepository.Create(persistence.PaymentPersistence{}.ToModel(payment)); err != nil {
+			uc.Logger.Error("Database error recording payment", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info("Payment recorded successfully",
+		zap.String("orderID", payment.OrderID.String()),
+		zap.String("paymentID", payment.ID.String()),
+		zap.Stringer("amount", payment.Amount))
+
+	return payment, nil
+}
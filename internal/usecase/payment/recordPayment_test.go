@@ -0,0 +1,248 @@
+package payment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/payment"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestRecordPayment_Process_RecordsPartialPayment(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	paymentRepoMock := &mocks.PaymentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	userID := uuid.New()
+
+	// Nota de 200.00 com 50.00 já recebidos
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Completed"}, nil
+	}
+	invoiceRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{ID: uuid.New(), OrderID: orderID, TotalPrice: money.MustParse("200.00")}, nil
+	}
+	paymentRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) ([]models.Payment, error) {
+		return []models.Payment{{ID: uuid.New(), OrderID: orderID, Method: "cash", Amount: money.MustParse("50.00")}}, nil
+	}
+	var created *models.Payment
+	paymentRepoMock.CreateFunc = func(payment *models.Payment) error {
+		created = payment
+		return nil
+	}
+
+	useCase := &RecordPayment{
+		Balance: &CalculateOrderBalance{Logger: loggerMock},
+		Logger:  loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:   orderRepoMock,
+			Invoices: invoiceRepoMock,
+			Payments: paymentRepoMock,
+		}},
+	}
+
+	// Act
+	result, err := useCase.Process(&domain.Payment{
+		OrderID:          orderID,
+		Method:           domain.MethodPix,
+		Amount:           money.MustParse("100.00"),
+		Reference:        " E123456789 ",
+		ReceivedByUserID: &userID,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created == nil {
+		t.Fatal("Expected payment to be saved")
+	}
+	if created.OrderID != orderID || !created.Amount.Equal(money.MustParse("100.00")) || created.Reference != "E123456789" {
+		t.Errorf("Unexpected payment saved: %+v", created)
+	}
+	if result.PaidAt.IsZero() {
+		t.Error("Expected paid_at to default to now")
+	}
+}
+
+func TestRecordPayment_Process_RejectsPaymentAboveAmountDue(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	paymentRepoMock := &mocks.PaymentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	// Nota de 200.00 com 150.00 já recebidos
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Completed"}, nil
+	}
+	invoiceRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) (*models.Invoice, error) {
+		return &models.Invoice{ID: uuid.New(), OrderID: orderID, TotalPrice: money.MustParse("200.00")}, nil
+	}
+	paymentRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) ([]models.Payment, error) {
+		return []models.Payment{{ID: uuid.New(), OrderID: orderID, Method: "cash", Amount: money.MustParse("150.00")}}, nil
+	}
+	paymentRepoMock.CreateFunc = func(payment *models.Payment) error {
+		t.Error("Payment should not be saved")
+		return nil
+	}
+
+	useCase := &RecordPayment{
+		Balance: &CalculateOrderBalance{Logger: loggerMock},
+		Logger:  loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:   orderRepoMock,
+			Invoices: invoiceRepoMock,
+			Payments: paymentRepoMock,
+		}},
+	}
+
+	// Act
+	_, err := useCase.Process(&domain.Payment{OrderID: uuid.New(), Method: domain.MethodCash, Amount: money.MustParse("50.01")})
+
+	// Assert
+	if err == nil || err.Error() != "payment exceeds amount due" {
+		t.Errorf("Expected 'payment exceeds amount due', got %v", err)
+	}
+}
+
+func TestRecordPayment_Process_UsesApprovedQuoteBeforeInvoice(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+	paymentRepoMock := &mocks.PaymentRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderID := uuid.New()
+	quoteID := uuid.New()
+
+	orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "In progress", ApprovedQuoteID: &quoteID}, nil
+	}
+	invoiceRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) (*models.Invoice, error) {
+		return nil, nil
+	}
+	quoteRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Quote, error) {
+		return &models.Quote{ID: id, TotalPrice: money.MustParse("80.00")}, nil
+	}
+	paymentRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) ([]models.Payment, error) {
+		return []models.Payment{}, nil
+	}
+	paymentRepoMock.CreateFunc = func(payment *models.Payment) error {
+		return nil
+	}
+
+	useCase := &RecordPayment{
+		Balance: &CalculateOrderBalance{Logger: loggerMock},
+		Logger:  loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:   orderRepoMock,
+			Invoices: invoiceRepoMock,
+			Quotes:   quoteRepoMock,
+			Payments: paymentRepoMock,
+		}},
+	}
+
+	// Act
+	_, errAbove := useCase.Process(&domain.Payment{OrderID: orderID, Method: domain.MethodCash, Amount: money.MustParse("80.01")})
+	_, errDeposit := useCase.Process(&domain.Payment{OrderID: orderID, Method: domain.MethodCash, Amount: money.MustParse("30.00")})
+
+	// Assert
+	if errAbove == nil || errAbove.Error() != "payment exceeds amount due" {
+		t.Errorf("Expected 'payment exceeds amount due', got %v", errAbove)
+	}
+	if errDeposit != nil {
+		t.Errorf("Expected deposit within the approved quote to be accepted, got %v", errDeposit)
+	}
+}
+
+func TestRecordPayment_Process_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		payment  domain.Payment
+		status   string
+		paid     []string
+		expected string
+	}{
+		{"invalid method", domain.Payment{Method: "cheque", Amount: money.MustParse("10")}, "Completed", nil, "invalid payment method"},
+		{"zero amount", domain.Payment{Method: domain.MethodCash, Amount: money.Zero}, "Completed", nil, "payment amount must be greater than zero"},
+		{"card without reference", domain.Payment{Method: domain.MethodCard, Amount: money.MustParse("10")}, "Completed", nil, "payment reference is required"},
+		{"future date", domain.Payment{Method: domain.MethodCash, Amount: money.MustParse("10"), PaidAt: time.Now().Add(time.Hour)}, "Completed", nil, "payment date cannot be in the future"},
+		{"canceled order", domain.Payment{Method: domain.MethodCash, Amount: money.MustParse("10")}, "Canceled", nil, "order is canceled"},
+		{"settled order", domain.Payment{Method: domain.MethodCash, Amount: money.MustParse("10")}, "Completed", []string{"200.00"}, "order has no amount due"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			invoiceRepoMock := &mocks.InvoiceRepositoryMock{}
+			paymentRepoMock := &mocks.PaymentRepositoryMock{}
+			loggerMock := &mocks.LoggerMock{}
+
+			// Mock logger
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			orderRepoMock.FindByIDForUpdateFunc = func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: id, Status: tt.status}, nil
+			}
+			invoiceRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) (*models.Invoice, error) {
+				return &models.Invoice{ID: uuid.New(), OrderID: orderID, TotalPrice: money.MustParse("200.00")}, nil
+			}
+			paymentRepoMock.FindByOrderIDFunc = func(orderID uuid.UUID) ([]models.Payment, error) {
+				records := []models.Payment{}
+				for _, amount := range tt.paid {
+					records = append(records, models.Payment{ID: uuid.New(), OrderID: orderID, Method: "cash", Amount: money.MustParse(amount)})
+				}
+				return records, nil
+			}
+			paymentRepoMock.CreateFunc = func(payment *models.Payment) error {
+				t.Error("Payment should not be saved")
+				return nil
+			}
+
+			useCase := &RecordPayment{
+				Balance: &CalculateOrderBalance{Logger: loggerMock},
+				Logger:  loggerMock,
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Orders:   orderRepoMock,
+					Invoices: invoiceRepoMock,
+					Payments: paymentRepoMock,
+				}},
+			}
+
+			payment := tt.payment
+			payment.OrderID = uuid.New()
+
+			// Act
+			_, err := useCase.Process(&payment)
+
+			// Assert
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s', got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package pricing
+
+import (
+	"github.com/google/uuid"
+	adjustmentDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order_adjustment"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// CalculateOrderPricing aplica às linhas da order os descontos e acréscimos aprovados e as
+// alíquotas configuradas, montando a composição do total
+type CalculateOrderPricing struct {
+	OrderAdjustmentRepository repository.OrderAdjustmentRepository
+	PricingRepository         repository.PricingRepository
+	Logger                    logger.Logger
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *CalculateOrderPricing) WithRepositories(repos repository.Repositories) *CalculateOrderPricing {
+	if uc == nil {
+		return nil
+	}
+	return &CalculateOrderPricing{
+		OrderAdjustmentRepository: repos.OrderAdjustments,
+		PricingRepository:         repos.Pricing,
+		Logger:                    uc.Logger,
+	}
+}
+
+// FetchAdjustments busca todos os ajustes da order, inclusive os pendentes de aprovação
+func (uc *CalculateOrderPricing) FetchAdjustments(orderID uuid.UUID) ([]adjustmentDomain.OrderAdjustment, error) {
+	models, err := uc.OrderAdjustmentRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order adjustments", zap.Error(err), zap.String("orderID", orderID.String()))
+		return nil, err
+	}
+
+	adjustments := make([]adjustmentDomain.OrderAdjustment, 0, len(models))
+	for i := range models {
+		adjustments = append(adjustments, *persistence.OrderAdjustmentPersistence{}.ToEntity(&models[i]))
+	}
+	return adjustments, nil
+}
+
+// FetchTaxRates busca as alíquotas configuradas por categoria
+func (uc *CalculateOrderPricing) FetchTaxRates() ([]domain.TaxRate, error) {
+	models, err := uc.PricingRepository.FindTaxRates()
+	if err != nil {
+		uc.Logger.Error("Database error finding tax rates", zap.Error(err))
+		return nil, err
+	}
+
+	rates := make([]domain.TaxRate, 0, len(models))
+	for i := range models {
+		rates = append(rates, *persistence.TaxRatePersistence{}.ToEntity(&models[i]))
+	}
+	return rates, nil
+}
+
+// Process calcula a composição do total das linhas informadas e retorna também os ajustes da order
+func (uc *CalculateOrderPricing) Process(orderID uuid.UUID, lines []domain.Line) (*domain.Breakdown, []adjustmentDomain.OrderAdjustment, error) {
+	adjustments, err := uc.FetchAdjustments(orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rates, err := uc.FetchTaxRates()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	breakdown := domain.Calculate(lines, adjustments, rates)
+
+	uc.Logger.Info("Order pricing calculated",
+		zap.String("orderID", orderID.String()),
+		zap.Stringer("subtotal", breakdown.Subtotal),
+		zap.Stringer("discountTotal", breakdown.DiscountTotal),
+		zap.Stringer("surchargeTotal", breakdown.SurchargeTotal),
+		zap.Stringer("taxTotal", breakdown.TaxTotal),
+		zap.Stringer("totalPrice", breakdown.TotalPrice))
+
+	return &breakdown, adjustments, nil
+}
@@ -0,0 +1,136 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestCalculateOrderPricing_Process_AdjustmentsAndTaxes(t *testing.T) {
+	// Arrange
+	orderID := uuid.New()
+	partLineID := uuid.New()
+	lines := []domain.Line{
+		{ID: partLineID, Category: domain.CategoryPart, Amount: money.MustParse("200")},
+		{ID: uuid.New(), Category: domain.CategoryLabor, Amount: money.MustParse("100")},
+	}
+
+	partPercentage, pendingPercentage := 10.0, 50.0
+	orderAmount := money.MustParse("28")
+	adjustments := []models.OrderAdjustment{
+		// 10% sobre a peça: 200 -> 180
+		{ID: uuid.New(), OrderID: orderID, LineID: &partLineID, Kind: "discount", ValueType: "percentage", Percentage: &partPercentage, Status: "approved"},
+		// 28 fixos na order, rateados 180/280 em peças e 100/280 em mão de obra
+		{ID: uuid.New(), OrderID: orderID, Kind: "discount", ValueType: "fixed", Amount: &orderAmount, Status: "approved"},
+		// Pendente, não entra no total
+		{ID: uuid.New(), OrderID: orderID, Kind: "discount", ValueType: "percentage", Percentage: &pendingPercentage, Status: "pending_approval"},
+	}
+	rates := []models.TaxRate{
+		{Category: "part", Name: "ICMS", Rate: 18},
+		{Category: "labor", Name: "ISS", Rate: 5},
+	}
+
+	adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+	pricingRepoMock := &mocks.PricingRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	adjustmentRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderAdjustment, error) {
+		return adjustments, nil
+	}
+	pricingRepoMock.FindTaxRatesFunc = func() ([]models.TaxRate, error) {
+		return rates, nil
+	}
+
+	useCase := &CalculateOrderPricing{
+		OrderAdjustmentRepository: adjustmentRepoMock,
+		PricingRepository:         pricingRepoMock,
+		Logger:                    loggerMock,
+	}
+
+	// Act
+	breakdown, found, err := useCase.Process(orderID, lines)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(found) != 3 {
+		t.Errorf("Expected every adjustment to be returned, got %d", len(found))
+	}
+	if !breakdown.Subtotal.Equal(money.MustParse("300")) || !breakdown.PartsSubtotal.Equal(money.MustParse("200")) || !breakdown.LaborSubtotal.Equal(money.MustParse("100")) {
+		t.Errorf("Expected subtotals 200 + 100 = 300, got %+v", breakdown)
+	}
+	if !breakdown.DiscountTotal.Equal(money.MustParse("48")) {
+		t.Errorf("Expected discount total 48, got %s", breakdown.DiscountTotal)
+	}
+	if len(breakdown.Taxes) != 2 {
+		t.Fatalf("Expected 2 tax lines, got %d", len(breakdown.Taxes))
+	}
+	// Peças: 180 - 18 = 162 -> ICMS 29.16. Mão de obra: 100 - 10 = 90 -> ISS 4.50
+	if !breakdown.Taxes[0].Base.Equal(money.MustParse("162")) || !breakdown.Taxes[0].Amount.Equal(money.MustParse("29.16")) {
+		t.Errorf("Expected ICMS 29.16 over 162, got %+v", breakdown.Taxes[0])
+	}
+	if !breakdown.Taxes[1].Base.Equal(money.MustParse("90")) || !breakdown.Taxes[1].Amount.Equal(money.MustParse("4.5")) {
+		t.Errorf("Expected ISS 4.50 over 90, got %+v", breakdown.Taxes[1])
+	}
+	if !breakdown.TaxTotal.Equal(money.MustParse("33.66")) || !breakdown.TotalPrice.Equal(money.MustParse("285.66")) {
+		t.Errorf("Expected tax 33.66 and total 285.66, got %s and %s", breakdown.TaxTotal, breakdown.TotalPrice)
+	}
+}
+
+func TestCalculateOrderPricing_Process_DiscountNeverMakesTotalNegative(t *testing.T) {
+	// Arrange
+	orderID := uuid.New()
+	lineID := uuid.New()
+	lines := []domain.Line{{ID: lineID, Category: domain.CategoryLabor, Amount: money.MustParse("50")}}
+	discountAmount, surchargeAmount := money.MustParse("80"), money.MustParse("15")
+	adjustments := []models.OrderAdjustment{
+		{ID: uuid.New(), OrderID: orderID, LineID: &lineID, Kind: "discount", ValueType: "fixed", Amount: &discountAmount, Status: "approved"},
+		{ID: uuid.New(), OrderID: orderID, Kind: "surcharge", ValueType: "fixed", Amount: &surchargeAmount, Status: "approved"},
+	}
+
+	adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+	pricingRepoMock := &mocks.PricingRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	adjustmentRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderAdjustment, error) {
+		return adjustments, nil
+	}
+	pricingRepoMock.FindTaxRatesFunc = func() ([]models.TaxRate, error) {
+		return []models.TaxRate{{Category: "labor", Name: "ISS", Rate: 5}}, nil
+	}
+
+	useCase := &CalculateOrderPricing{
+		OrderAdjustmentRepository: adjustmentRepoMock,
+		PricingRepository:         pricingRepoMock,
+		Logger:                    loggerMock,
+	}
+
+	// Act
+	breakdown, _, err := useCase.Process(orderID, lines)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !breakdown.DiscountTotal.Equal(money.MustParse("50")) {
+		t.Errorf("Expected discount limited to the line amount, got %s", breakdown.DiscountTotal)
+	}
+	// Sem base para ratear, o acréscimo entra sem imposto
+	if len(breakdown.Taxes) != 0 || !breakdown.TotalPrice.Equal(money.MustParse("15")) {
+		t.Errorf("Expected untaxed total of 15, got %s with %d taxes", breakdown.TotalPrice, len(breakdown.Taxes))
+	}
+}
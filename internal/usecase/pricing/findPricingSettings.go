@@ -0,0 +1,54 @@
+package pricing
+
+import (
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindPricingSettings retorna a configuração de preços, usando o limite de desconto padrão
+// enquanto nenhum admin o configurou
+type FindPricingSettings struct {
+	PricingRepository repository.PricingRepository
+	Logger            logger.Logger
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *FindPricingSettings) WithRepositories(repos repository.Repositories) *FindPricingSettings {
+	if uc == nil {
+		return nil
+	}
+	return &FindPricingSettings{
+		PricingRepository: repos.Pricing,
+		Logger:            uc.Logger,
+	}
+}
+
+func (uc *FindPricingSettings) Process() (*domain.Settings, error) {
+	settings := &domain.Settings{
+		MechanicDiscountCapPercent: domain.DefaultMechanicDiscountCapPercent,
+		TaxRates:                   []domain.TaxRate{},
+	}
+
+	model, err := uc.PricingRepository.FindSettings()
+	if err != nil {
+		uc.Logger.Error("Database error finding pricing settings", zap.Error(err))
+		return nil, err
+	}
+	if model != nil {
+		settings.MechanicDiscountCapPercent = model.MechanicDiscountCapPercent
+	}
+
+	rates, err := uc.PricingRepository.FindTaxRates()
+	if err != nil {
+		uc.Logger.Error("Database error finding tax rates", zap.Error(err))
+		return nil, err
+	}
+	for i := range rates {
+		settings.TaxRates = append(settings.TaxRates, *persistence.TaxRatePersistence{}.ToEntity(&rates[i]))
+	}
+
+	return settings, nil
+}
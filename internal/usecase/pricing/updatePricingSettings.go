@@ -0,0 +1,86 @@
+package pricing
+
+import (
+	"errors"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// UpdatePricingSettings substitui a configuração de preços: o limite de desconto dos mecânicos
+// e as alíquotas por categoria. Categorias sem alíquota deixam de ser tributadas.
+type UpdatePricingSettings struct {
+	Logger     logger.Logger
+	UnitOfWork repository.UnitOfWork
+}
+
+// ValidateSettings valida o limite de desconto e as alíquotas informadas
+func (uc *UpdatePricingSettings) ValidateSettings(settings domain.Settings) error {
+	if settings.MechanicDiscountCapPercent < 0 || settings.MechanicDiscountCapPercent > 100 {
+		uc.Logger.Error("Invalid mechanic discount cap", zap.Float64("cap", settings.MechanicDiscountCapPercent))
+		return errors.New("invalid discount cap")
+	}
+
+	seen := map[string]bool{}
+	for _, rate := range settings.TaxRates {
+		if !domain.IsValidCategory(rate.Category) {
+			uc.Logger.Error("Invalid tax rate category", zap.String("category", rate.Category))
+			return errors.New("invalid tax rate category")
+		}
+		if seen[rate.Category] {
+			uc.Logger.Error("Duplicated tax rate category", zap.String("category", rate.Category))
+			return errors.New("duplicated tax rate category")
+		}
+		seen[rate.Category] = true
+
+		if rate.Name == "" {
+			uc.Logger.Error("Tax rate without name", zap.String("category", rate.Category))
+			return errors.New("tax rate name is required")
+		}
+		if rate.Rate < 0 || rate.Rate > 100 {
+			uc.Logger.Error("Invalid tax rate", zap.String("category", rate.Category), zap.Float64("rate", rate.Rate))
+			return errors.New("invalid tax rate")
+		}
+	}
+	return nil
+}
+
+func (uc *UpdatePricingSettings) Process(settings domain.Settings) error {
+	uc.Logger.Info("Processing update pricing settings",
+		zap.Float64("mechanicDiscountCapPercent", settings.MechanicDiscountCapPercent),
+		zap.Int("taxRates", len(settings.TaxRates)))
+
+	if err := uc.ValidateSettings(settings); err != nil {
+		return err
+	}
+
+	rates := make([]models.TaxRate, 0, len(settings.TaxRates))
+	for i := range settings.TaxRates {
+		rates = append(rates, *persistence.TaxRatePersistence{}.ToModel(&settings.TaxRates[i]))
+	}
+
+	err := uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		if err := repos.Pricing.SaveSettings(&models.PricingSettings{
+			MechanicDiscountCapPercent: settings.MechanicDiscountCapPercent,
+		}); err != nil {
+			uc.Logger.Error("Database error saving pricing settings", zap.Error(err))
+			return err
+		}
+
+		if err := repos.Pricing.SaveTaxRates(rates); err != nil {
+			uc.Logger.Error("Database error saving tax rates", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.Logger.Info("Pricing settings updated successfully")
+	return nil
+}
@@ -0,0 +1,90 @@
+package pricing
+
+import (
+	"testing"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestUpdatePricingSettings_Process_Success(t *testing.T) {
+	// Arrange
+	pricingRepoMock := &mocks.PricingRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	var savedSettings *models.PricingSettings
+	var savedRates []models.TaxRate
+	pricingRepoMock.SaveSettingsFunc = func(settings *models.PricingSettings) error {
+		savedSettings = settings
+		return nil
+	}
+	pricingRepoMock.SaveTaxRatesFunc = func(rates []models.TaxRate) error {
+		savedRates = rates
+		return nil
+	}
+
+	useCase := &UpdatePricingSettings{
+		Logger:     loggerMock,
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{Pricing: pricingRepoMock}},
+	}
+
+	// Act
+	err := useCase.Process(domain.Settings{
+		MechanicDiscountCapPercent: 15,
+		TaxRates:                   []domain.TaxRate{{Category: "labor", Name: "ISS", Rate: 2}},
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if savedSettings == nil || savedSettings.MechanicDiscountCapPercent != 15 {
+		t.Errorf("Expected cap 15 to be saved, got %+v", savedSettings)
+	}
+	if len(savedRates) != 1 || savedRates[0].Name != "ISS" || savedRates[0].Rate != 2 {
+		t.Errorf("Expected ISS rate to be saved, got %+v", savedRates)
+	}
+}
+
+func TestUpdatePricingSettings_Process_InvalidSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings domain.Settings
+		expected string
+	}{
+		{"cap above 100", domain.Settings{MechanicDiscountCapPercent: 101}, "invalid discount cap"},
+		{"negative cap", domain.Settings{MechanicDiscountCapPercent: -1}, "invalid discount cap"},
+		{"unknown category", domain.Settings{TaxRates: []domain.TaxRate{{Category: "fuel", Name: "X", Rate: 1}}}, "invalid tax rate category"},
+		{"duplicated category", domain.Settings{TaxRates: []domain.TaxRate{{Category: "part", Name: "ICMS", Rate: 18}, {Category: "part", Name: "IPI", Rate: 5}}}, "duplicated tax rate category"},
+		{"missing name", domain.Settings{TaxRates: []domain.TaxRate{{Category: "part", Rate: 18}}}, "tax rate name is required"},
+		{"rate above 100", domain.Settings{TaxRates: []domain.TaxRate{{Category: "labor", Name: "ISS", Rate: 120}}}, "invalid tax rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerMock := &mocks.LoggerMock{}
+
+			// Mock logger
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			useCase := &UpdatePricingSettings{
+				Logger:     loggerMock,
+				UnitOfWork: &mocks.UnitOfWorkMock{},
+			}
+
+			err := useCase.Process(tt.settings)
+
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package quote
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindQuoteByVersion busca uma versão específica do orçamento de uma order
+type FindQuoteByVersion struct {
+	QuoteRepository repository.QuoteRepository
+	Logger          logger.Logger
+}
+
+func (uc *FindQuoteByVersion) Process(orderID uuid.UUID, version int) (*domain.Quote, error) {
+	uc.Logger.Info("Processing find quote by version",
+		zap.String("orderID", orderID.String()),
+		zap.Int("version", version))
+
+	quote, err := uc.QuoteRepository.FindByOrderIDAndVersion(orderID, version)
+	if err != nil {
+		uc.Logger.Error("Quote not found",
+			zap.String("orderID", orderID.String()),
+			zap.Int("version", version),
+			zap.Error(err))
+		return nil, errors.New("quote not found")
+	}
+
+	return persistence.QuotePersistence{}.ToEntity(quote), nil
+}
@@ -0,0 +1,45 @@
+package quote
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// FindQuotesByOrderId lista todas as versões do orçamento de uma order
+type FindQuotesByOrderId struct {
+	OrderRepository repository.OrderRepository
+	QuoteRepository repository.QuoteRepository
+	Logger          logger.Logger
+}
+
+func (uc *FindQuotesByOrderId) Process(orderID uuid.UUID) ([]domain.Quote, error) {
+	uc.Logger.Info("Processing find quotes by order ID", zap.String("orderID", orderID.String()))
+
+	if _, err := uc.OrderRepository.FindByID(orderID); err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	quotes, err := uc.QuoteRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding quotes", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]domain.Quote, 0, len(quotes))
+	for i := range quotes {
+		result = append(result, *persistence.QuotePersistence{}.ToEntity(&quotes[i]))
+	}
+
+	uc.Logger.Info("Successfully found quotes",
+		zap.String("orderID", orderID.String()),
+		zap.Int("count", len(result)))
+
+	return result, nil
+}
@@ -0,0 +1,150 @@
+package quote
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// GenerateQuote gera uma nova versão do orçamento da order copiando as peças, a mão de obra
+// e os ajustes atuais, com os impostos calculados. Versões anteriores continuam disponíveis
+// e nunca são alteradas.
+type GenerateQuote struct {
+	OrderRepository    repository.OrderRepository
+	QuoteRepository    repository.QuoteRepository
+	Logger             logger.Logger
+	SnapshotOrderItems *SnapshotOrderItems
+	UnitOfWork         repository.UnitOfWork
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *GenerateQuote) WithRepositories(repos repository.Repositories) *GenerateQuote {
+	return &GenerateQuote{
+		OrderRepository:    repos.Orders,
+		QuoteRepository:    repos.Quotes,
+		Logger:             uc.Logger,
+		SnapshotOrderItems: uc.SnapshotOrderItems.WithRepositories(repos),
+		UnitOfWork:         repos.UnitOfWork,
+	}
+}
+
+// ResolveValidityDays aplica a validade padrão quando não informada e rejeita valores fora do limite
+func (uc *GenerateQuote) ResolveValidityDays(validityDays int) (int, error) {
+	if validityDays == 0 {
+		return domain.DefaultValidityDays, nil
+	}
+	if validityDays < 0 || validityDays > domain.MaxValidityDays {
+		uc.Logger.Error("Invalid quote validity", zap.Int("validityDays", validityDays))
+		return 0, errors.New("invalid validity days")
+	}
+	return validityDays, nil
+}
+
+// FetchOrderFromDB busca a order e garante que ela ainda pode receber um orçamento
+func (uc *GenerateQuote) FetchOrderFromDB(orderID uuid.UUID) (*models.Order, error) {
+	order, err := uc.OrderRepository.FindByID(orderID)
+	if err != nil {
+		uc.Logger.Error("Order not found", zap.String("orderID", orderID.String()))
+		return nil, errors.New("order not found")
+	}
+
+	if orderDomain.IsFinalStatus(order.Status) {
+		uc.Logger.Error("Order is finalized, quote cannot be generated",
+			zap.String("orderID", order.ID.String()),
+			zap.String("status", order.Status))
+		return nil, errors.New("order is finalized")
+	}
+
+	return order, nil
+}
+
+// NextVersion calcula o número da próxima versão do orçamento da order
+func (uc *GenerateQuote) NextVersion(orderID uuid.UUID) (int, error) {
+	latest, err := uc.QuoteRepository.FindLatestByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding latest quote", zap.Error(err))
+		return 0, err
+	}
+	if latest == nil {
+		return 1, nil
+	}
+	return latest.Version + 1, nil
+}
+
+func (uc *GenerateQuote) Process(orderID uuid.UUID, userID uuid.UUID, validityDays int) (*domain.Quote, error) {
+	uc.Logger.Info("Processing generate quote",
+		zap.String("orderID", orderID.String()),
+		zap.String("userID", userID.String()),
+		zap.Int("validityDays", validityDays))
+
+	validityDays, err := uc.ResolveValidityDays(validityDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// A versão é calculada e gravada na mesma transação; a constraint única de (order_id, version)
+	// rejeita uma geração concorrente da mesma versão
+	var quote *domain.Quote
+	err = uc.UnitOfWork.Execute(func(repos repository.Repositories) error {
+		tx := uc.WithRepositories(repos)
+
+		if _, err := tx.FetchOrderFromDB(orderID); err != nil {
+			return err
+		}
+
+		items, breakdown, err := tx.SnapshotOrderItems.ProcessWithBreakdown(orderID)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			uc.Logger.Error("Order has no items to quote", zap.String("orderID", orderID.String()))
+			return errors.New("order has no items to quote")
+		}
+
+		version, err := tx.NextVersion(orderID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		quote = &domain.Quote{
+			ID:              uuid.New(),
+			OrderID:         orderID,
+			Version:         version,
+			ValidUntil:      now.AddDate(0, 0, validityDays),
+			CreatedByUserID: &userID,
+			Items:           items,
+			CreatedAt:       now,
+		}
+		for i := range quote.Items {
+			quote.Items[i].ID = uuid.New()
+			quote.Items[i].QuoteID = quote.ID
+		}
+		quote.ApplyBreakdown(*breakdown)
+
+		if err := tx.QuoteRepository.Create(persistence.QuotePersistence{}.ToModel(quote)); err != nil {
+			uc.Logger.Error("Database error creating quote", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info("Quote generated successfully",
+		zap.String("orderID", orderID.String()),
+		zap.String("quoteID", quote.ID.String()),
+		zap.Int("version", quote.Version),
+		zap.Stringer("totalPrice", quote.TotalPrice))
+
+	return quote, nil
+}
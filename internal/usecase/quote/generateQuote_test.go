@@ -0,0 +1,326 @@
+package quote
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/pricing"
+	"go.uber.org/zap"
+)
+
+func TestGenerateQuote_Process_SnapshotsPartsAndLabor(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Undergoing diagnosis"}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Name: "Pastilha de freio"}, nil
+	}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return &models.LaborService{ID: id, Name: "Troca de pastilhas"}, nil
+	}
+
+	orderID := uuid.New()
+	voidedAt := time.Now()
+	actualHours := 3.0
+
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{
+			{ID: uuid.New(), OrderID: orderID, InputID: uuid.New(), Quantity: 2, UnitPrice: money.MustParse("45.5"), TotalPrice: money.MustParse("91.0")},
+			{ID: uuid.New(), OrderID: orderID, InputID: uuid.New(), Quantity: 1, UnitPrice: money.MustParse("10"), TotalPrice: money.MustParse("10"), VoidedAt: &voidedAt},
+		}, nil
+	}
+	orderServiceRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderService, error) {
+		return []models.OrderService{
+			{ID: uuid.New(), OrderID: orderID, LaborServiceID: uuid.New(), EstimatedHours: 1.5, ActualHours: &actualHours, HourlyRate: money.MustParse("100"), TotalPrice: money.MustParse("300")},
+		}, nil
+	}
+	quoteRepoMock.FindLatestByOrderIDFunc = func(id uuid.UUID) (*models.Quote, error) {
+		return &models.Quote{ID: uuid.New(), OrderID: id, Version: 2}, nil
+	}
+
+	var created *models.Quote
+	quoteRepoMock.CreateFunc = func(quote *models.Quote) error {
+		created = quote
+		return nil
+	}
+
+	useCase := &GenerateQuote{
+		Logger:             loggerMock,
+		SnapshotOrderItems: &SnapshotOrderItems{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:        orderRepoMock,
+			OrderInputs:   orderInputRepoMock,
+			Inputs:        inputRepoMock,
+			OrderServices: orderServiceRepoMock,
+			LaborServices: laborServiceRepoMock,
+			Quotes:        quoteRepoMock,
+		}},
+	}
+
+	userID := uuid.New()
+
+	// Act
+	result, err := useCase.Process(orderID, userID, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created == nil {
+		t.Fatal("Expected quote to be saved")
+	}
+	if result.Version != 3 || created.Version != 3 {
+		t.Errorf("Expected version 3, got %d", result.Version)
+	}
+	if len(created.Items) != 2 {
+		t.Fatalf("Expected voided part to be left out and 2 items saved, got %d", len(created.Items))
+	}
+	for _, item := range created.Items {
+		if item.QuoteID != created.ID {
+			t.Error("Expected every item to reference the quote")
+		}
+	}
+	// A mão de obra é orçada pelas horas estimadas, não pelas horas reais
+	if !result.PartsSubtotal.Equal(money.MustParse("91.0")) || !result.LaborSubtotal.Equal(money.MustParse("150.0")) || !result.TotalPrice.Equal(money.MustParse("241.0")) {
+		t.Errorf("Expected 91.0 + 150.0 = 241.0, got %s + %s = %s", result.PartsSubtotal, result.LaborSubtotal, result.TotalPrice)
+	}
+	expectedValidity := time.Now().AddDate(0, 0, 15)
+	if result.ValidUntil.Sub(expectedValidity).Abs() > time.Minute {
+		t.Errorf("Expected default validity of 15 days, got %v", result.ValidUntil)
+	}
+	if result.CreatedByUserID == nil || *result.CreatedByUserID != userID {
+		t.Error("Expected quote to record the user who generated it")
+	}
+}
+
+func TestGenerateQuote_Process_FirstVersion(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Received"}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Name: "Pastilha de freio"}, nil
+	}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return &models.LaborService{ID: id, Name: "Troca de pastilhas"}, nil
+	}
+	orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+		return []models.OrderInput{{ID: uuid.New(), OrderID: id, InputID: uuid.New(), Quantity: 1, UnitPrice: money.MustParse("20"), TotalPrice: money.MustParse("20")}}, nil
+	}
+
+	useCase := &GenerateQuote{
+		Logger:             loggerMock,
+		SnapshotOrderItems: &SnapshotOrderItems{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:        orderRepoMock,
+			OrderInputs:   orderInputRepoMock,
+			Inputs:        inputRepoMock,
+			OrderServices: orderServiceRepoMock,
+			LaborServices: laborServiceRepoMock,
+			Quotes:        quoteRepoMock,
+		}},
+	}
+
+	// Act
+	result, err := useCase.Process(uuid.New(), uuid.New(), 30)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Version != 1 {
+		t.Errorf("Expected first version, got %d", result.Version)
+	}
+}
+
+func TestGenerateQuote_Process_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		orderStatus  string
+		withItems    bool
+		validityDays int
+		createErr    error
+		expected     string
+	}{
+		{"finalized order", "Delivered", true, 0, nil, "order is finalized"},
+		{"order without items", "Received", false, 0, nil, "order has no items to quote"},
+		{"validity too long", "Received", true, 91, nil, "invalid validity days"},
+		{"negative validity", "Received", true, -1, nil, "invalid validity days"},
+		{"database error", "Received", true, 0, errors.New("duplicate key"), "duplicate key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			orderRepoMock := &mocks.OrderRepositoryMock{}
+			orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+			inputRepoMock := &mocks.InputRepositoryMock{}
+			orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+			laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+			quoteRepoMock := &mocks.QuoteRepositoryMock{}
+			loggerMock := &mocks.LoggerMock{}
+
+			// Mock logger
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+				return &models.Order{ID: id, Status: tt.orderStatus}, nil
+			}
+			inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+				return &models.Input{ID: id, Name: "Pastilha de freio"}, nil
+			}
+			laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+				return &models.LaborService{ID: id, Name: "Troca de pastilhas"}, nil
+			}
+			if tt.withItems {
+				orderInputRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderInput, error) {
+					return []models.OrderInput{{ID: uuid.New(), OrderID: id, InputID: uuid.New(), Quantity: 1, UnitPrice: money.MustParse("20"), TotalPrice: money.MustParse("20")}}, nil
+				}
+			}
+			quoteRepoMock.CreateFunc = func(quote *models.Quote) error {
+				if tt.createErr == nil {
+					t.Error("Quote should not be created")
+				}
+				return tt.createErr
+			}
+
+			useCase := &GenerateQuote{
+				Logger:             loggerMock,
+				SnapshotOrderItems: &SnapshotOrderItems{Logger: loggerMock},
+				UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+					Orders:        orderRepoMock,
+					OrderInputs:   orderInputRepoMock,
+					Inputs:        inputRepoMock,
+					OrderServices: orderServiceRepoMock,
+					LaborServices: laborServiceRepoMock,
+					Quotes:        quoteRepoMock,
+				}},
+			}
+
+			// Act
+			result, err := useCase.Process(uuid.New(), uuid.New(), tt.validityDays)
+
+			// Assert
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+			if result != nil {
+				t.Error("Expected nil result on error")
+			}
+		})
+	}
+}
+
+func TestGenerateQuote_Process_AppliesAdjustmentsAndTaxes(t *testing.T) {
+	// Arrange
+	orderRepoMock := &mocks.OrderRepositoryMock{}
+	orderInputRepoMock := &mocks.OrderInputRepositoryMock{}
+	inputRepoMock := &mocks.InputRepositoryMock{}
+	orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+	laborServiceRepoMock := &mocks.LaborServiceRepositoryMock{}
+	quoteRepoMock := &mocks.QuoteRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	orderRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Order, error) {
+		return &models.Order{ID: id, Status: "Undergoing diagnosis"}, nil
+	}
+	inputRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.Input, error) {
+		return &models.Input{ID: id, Name: "Pastilha de freio"}, nil
+	}
+	laborServiceRepoMock.FindByIDFunc = func(id uuid.UUID) (*models.LaborService, error) {
+		return &models.LaborService{ID: id, Name: "Troca de pastilhas"}, nil
+	}
+
+	orderID := uuid.New()
+	adjustmentID := uuid.New()
+
+	orderServiceRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderService, error) {
+		return []models.OrderService{
+			{ID: uuid.New(), OrderID: orderID, LaborServiceID: uuid.New(), EstimatedHours: 2, HourlyRate: money.MustParse("100"), TotalPrice: money.MustParse("200")},
+		}, nil
+	}
+
+	adjustmentRepoMock := &mocks.OrderAdjustmentRepositoryMock{}
+	approvedPercentage, pendingPercentage := 10.0, 30.0
+	adjustmentRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderAdjustment, error) {
+		return []models.OrderAdjustment{
+			{ID: adjustmentID, OrderID: orderID, Kind: "discount", ValueType: "percentage", Percentage: &approvedPercentage, Reason: "Cliente fidelidade", Status: "approved"},
+			{ID: uuid.New(), OrderID: orderID, Kind: "discount", ValueType: "percentage", Percentage: &pendingPercentage, Reason: "Aguardando admin", Status: "pending_approval"},
+		}, nil
+	}
+	pricingRepoMock := &mocks.PricingRepositoryMock{}
+	pricingRepoMock.FindTaxRatesFunc = func() ([]models.TaxRate, error) {
+		return []models.TaxRate{{Category: "labor", Name: "ISS", Rate: 5}}, nil
+	}
+
+	useCase := &GenerateQuote{
+		Logger:             loggerMock,
+		SnapshotOrderItems: &SnapshotOrderItems{Logger: loggerMock},
+		UnitOfWork: &mocks.UnitOfWorkMock{Repositories: repository.Repositories{
+			Orders:           orderRepoMock,
+			OrderInputs:      orderInputRepoMock,
+			Inputs:           inputRepoMock,
+			OrderServices:    orderServiceRepoMock,
+			LaborServices:    laborServiceRepoMock,
+			Quotes:           quoteRepoMock,
+			OrderAdjustments: adjustmentRepoMock,
+			Pricing:          pricingRepoMock,
+		}},
+	}
+	useCase.SnapshotOrderItems.Pricing = &pricing.CalculateOrderPricing{Logger: loggerMock}
+
+	// Act
+	result, err := useCase.Process(orderID, uuid.New(), 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("Expected labor and approved discount items, got %d", len(result.Items))
+	}
+	discount := result.Items[1]
+	if discount.ItemType != "discount" || discount.ReferenceID != adjustmentID || !discount.TotalPrice.Equal(money.MustParse("20")) {
+		t.Errorf("Expected discount item of 20, got %+v", discount)
+	}
+	// 200 - 20 = 180, ISS de 5% = 9
+	if !result.DiscountTotal.Equal(money.MustParse("20")) || !result.TaxTotal.Equal(money.MustParse("9")) || !result.TotalPrice.Equal(money.MustParse("189")) {
+		t.Errorf("Expected discount 20, tax 9 and total 189, got %s, %s and %s", result.DiscountTotal, result.TaxTotal, result.TotalPrice)
+	}
+}
@@ -0,0 +1,174 @@
+package quote
+
+import (
+	"github.com/google/uuid"
+	pricingDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/pricing"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/order_service"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/pricing"
+	"go.uber.org/zap"
+)
+
+// SnapshotOrderItems monta as linhas de orçamento a partir do estado atual das peças, da
+// mão de obra e dos descontos e acréscimos aprovados da order. É usado para gerar uma nova
+// versão e para saber se a order mudou depois que uma versão foi gerada ou aprovada.
+type SnapshotOrderItems struct {
+	OrderInputRepository   repository.OrderInputRepository
+	InputRepository        repository.InputRepository
+	OrderServiceRepository repository.OrderServiceRepository
+	LaborServiceRepository repository.LaborServiceRepository
+	Logger                 logger.Logger
+	// Pricing aplica ajustes e impostos. Sem ele, o total é apenas a soma das linhas.
+	Pricing *pricing.CalculateOrderPricing
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *SnapshotOrderItems) WithRepositories(repos repository.Repositories) *SnapshotOrderItems {
+	if uc == nil {
+		return nil
+	}
+	return &SnapshotOrderItems{
+		OrderInputRepository:   repos.OrderInputs,
+		InputRepository:        repos.Inputs,
+		OrderServiceRepository: repos.OrderServices,
+		LaborServiceRepository: repos.LaborServices,
+		Logger:                 uc.Logger,
+		Pricing:                uc.Pricing.WithRepositories(repos),
+	}
+}
+
+// FetchPartItems copia as peças da order. Itens anulados pelo cancelamento não entram no orçamento.
+// As linhas de preço usam o id do item da order, referenciado pelos ajustes de linha.
+func (uc *SnapshotOrderItems) FetchPartItems(orderID uuid.UUID) ([]domain.QuoteItem, []pricingDomain.Line, error) {
+	orderInputs, err := uc.OrderInputRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order inputs", zap.Error(err), zap.String("orderID", orderID.String()))
+		return nil, nil, err
+	}
+
+	items := []domain.QuoteItem{}
+	lines := []pricingDomain.Line{}
+	for _, orderInput := range orderInputs {
+		if orderInput.VoidedAt != nil {
+			continue
+		}
+
+		// O nome é apenas a descrição da linha, o preço vem do item da order
+		description := ""
+		input, err := uc.InputRepository.FindByID(orderInput.InputID)
+		if err == nil && input != nil {
+			description = input.Name
+		}
+
+		items = append(items, domain.QuoteItem{
+			ItemType:    domain.ItemTypePart,
+			ReferenceID: orderInput.InputID,
+			Description: description,
+			Quantity:    float64(orderInput.Quantity),
+			UnitPrice:   orderInput.UnitPrice,
+			TotalPrice:  orderInput.TotalPrice,
+		})
+		lines = append(lines, pricingDomain.Line{ID: orderInput.ID, Category: pricingDomain.CategoryPart, Amount: orderInput.TotalPrice})
+	}
+
+	return items, lines, nil
+}
+
+// FetchLaborItems copia a mão de obra da order. O orçamento usa sempre as horas estimadas, as horas
+// reais informadas depois pelo mecânico não alteram o que foi aprovado.
+func (uc *SnapshotOrderItems) FetchLaborItems(orderID uuid.UUID) ([]domain.QuoteItem, []pricingDomain.Line, error) {
+	orderServices, err := uc.OrderServiceRepository.FindByOrderID(orderID)
+	if err != nil {
+		uc.Logger.Error("Database error finding order services", zap.Error(err), zap.String("orderID", orderID.String()))
+		return nil, nil, err
+	}
+
+	items := []domain.QuoteItem{}
+	lines := []pricingDomain.Line{}
+	for _, orderService := range orderServices {
+		description := ""
+		laborService, err := uc.LaborServiceRepository.FindByID(orderService.LaborServiceID)
+		if err == nil && laborService != nil {
+			description = laborService.Name
+		}
+
+		totalPrice := order_service.CalculateTotalPrice(orderService.EstimatedHours, orderService.HourlyRate)
+		items = append(items, domain.QuoteItem{
+			ItemType:    domain.ItemTypeLabor,
+			ReferenceID: orderService.LaborServiceID,
+			Description: description,
+			Quantity:    orderService.EstimatedHours,
+			UnitPrice:   orderService.HourlyRate,
+			TotalPrice:  totalPrice,
+		})
+		lines = append(lines, pricingDomain.Line{ID: orderService.ID, Category: pricingDomain.CategoryLabor, Amount: totalPrice})
+	}
+
+	return items, lines, nil
+}
+
+// CalculatePricing aplica ajustes e impostos às linhas e devolve os ajustes aprovados como
+// linhas do orçamento, com o valor já calculado
+func (uc *SnapshotOrderItems) CalculatePricing(orderID uuid.UUID, lines []pricingDomain.Line) ([]domain.QuoteItem, *pricingDomain.Breakdown, error) {
+	if uc.Pricing == nil {
+		breakdown := pricingDomain.Calculate(lines, nil, nil)
+		return []domain.QuoteItem{}, &breakdown, nil
+	}
+
+	breakdown, adjustments, err := uc.Pricing.Process(orderID, lines)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reasons := make(map[uuid.UUID]string, len(adjustments))
+	for _, adjustment := range adjustments {
+		reasons[adjustment.ID] = adjustment.Reason
+	}
+
+	items := []domain.QuoteItem{}
+	for _, applied := range breakdown.Adjustments {
+		items = append(items, domain.QuoteItem{
+			ItemType:    applied.Kind,
+			ReferenceID: applied.ID,
+			Description: reasons[applied.ID],
+			Quantity:    1,
+			UnitPrice:   applied.Amount,
+			TotalPrice:  applied.Amount,
+		})
+	}
+	return items, breakdown, nil
+}
+
+// ProcessWithBreakdown monta as linhas do orçamento e a composição do total da order
+func (uc *SnapshotOrderItems) ProcessWithBreakdown(orderID uuid.UUID) ([]domain.QuoteItem, *pricingDomain.Breakdown, error) {
+	parts, partLines, err := uc.FetchPartItems(orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labor, laborLines, err := uc.FetchLaborItems(orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adjustments, breakdown, err := uc.CalculatePricing(orderID, append(partLines, laborLines...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uc.Logger.Info("Order items snapshotted",
+		zap.String("orderID", orderID.String()),
+		zap.Int("parts", len(parts)),
+		zap.Int("labor", len(labor)),
+		zap.Int("adjustments", len(adjustments)))
+
+	items := append(parts, labor...)
+	return append(items, adjustments...), breakdown, nil
+}
+
+func (uc *SnapshotOrderItems) Process(orderID uuid.UUID) ([]domain.QuoteItem, error) {
+	items, _, err := uc.ProcessWithBreakdown(orderID)
+	return items, err
+}
@@ -0,0 +1,65 @@
+package quote
+
+import (
+	"errors"
+
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/quote"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"go.uber.org/zap"
+)
+
+// VerifyApprovedQuote confere se as peças e a mão de obra da order ainda correspondem ao
+// orçamento aprovado pelo cliente. Qualquer mudança depois da aprovação exige uma nova aprovação.
+type VerifyApprovedQuote struct {
+	QuoteRepository    repository.QuoteRepository
+	SnapshotOrderItems *SnapshotOrderItems
+	Logger             logger.Logger
+}
+
+// WithRepositories retorna uma cópia do usecase ligada aos repositórios da transação corrente
+func (uc *VerifyApprovedQuote) WithRepositories(repos repository.Repositories) *VerifyApprovedQuote {
+	if uc == nil {
+		return nil
+	}
+	return &VerifyApprovedQuote{
+		QuoteRepository:    repos.Quotes,
+		SnapshotOrderItems: uc.SnapshotOrderItems.WithRepositories(repos),
+		Logger:             uc.Logger,
+	}
+}
+
+func (uc *VerifyApprovedQuote) Process(order *models.Order) error {
+	// Orders aprovadas antes dos orçamentos versionados não têm versão para comparar
+	if order.ApprovedQuoteID == nil {
+		uc.Logger.Info("Order has no approved quote to verify", zap.String("orderID", order.ID.String()))
+		return nil
+	}
+
+	approved, err := uc.QuoteRepository.FindByID(*order.ApprovedQuoteID)
+	if err != nil {
+		uc.Logger.Error("Approved quote not found",
+			zap.String("orderID", order.ID.String()),
+			zap.String("quoteID", order.ApprovedQuoteID.String()))
+		return errors.New("quote not found")
+	}
+
+	current, err := uc.SnapshotOrderItems.Process(order.ID)
+	if err != nil {
+		return err
+	}
+
+	if !domain.SameItems(persistence.QuotePersistence{}.ToEntity(approved).Items, current) {
+		uc.Logger.Error("Order changed after approval",
+			zap.String("orderID", order.ID.String()),
+			zap.Int("approvedVersion", approved.Version))
+		return errors.New("order changed after approval")
+	}
+
+	uc.Logger.Info("Order matches approved quote",
+		zap.String("orderID", order.ID.String()),
+		zap.Int("approvedVersion", approved.Version))
+	return nil
+}
@@ -0,0 +1,91 @@
+package quote
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ln0rd/tech_challenge_12soat/internal/domain/money"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func TestVerifyApprovedQuote_Process(t *testing.T) {
+	orderID := uuid.New()
+	quoteID := uuid.New()
+	laborServiceID := uuid.New()
+	actualHours := 4.0
+
+	approvedQuote := &models.Quote{
+		ID:      quoteID,
+		OrderID: orderID,
+		Version: 1,
+		Items: []models.QuoteItem{
+			{ID: uuid.New(), QuoteID: quoteID, ItemType: "labor", ReferenceID: laborServiceID, Description: "Revisão", Quantity: 2, UnitPrice: money.MustParse("80"), TotalPrice: money.MustParse("160")},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		approvedQuoteID *uuid.UUID
+		orderServices   []models.OrderService
+		expected        string
+	}{
+		{
+			name:            "actual hours do not require a new approval",
+			approvedQuoteID: &quoteID,
+			orderServices:   []models.OrderService{{ID: uuid.New(), LaborServiceID: laborServiceID, EstimatedHours: 2, ActualHours: &actualHours, HourlyRate: money.MustParse("80"), TotalPrice: money.MustParse("320")}},
+		},
+		{
+			name:            "estimated hours changed",
+			approvedQuoteID: &quoteID,
+			orderServices:   []models.OrderService{{ID: uuid.New(), LaborServiceID: laborServiceID, EstimatedHours: 3, HourlyRate: money.MustParse("80"), TotalPrice: money.MustParse("240")}},
+			expected:        "order changed after approval",
+		},
+		{
+			name:            "service removed",
+			approvedQuoteID: &quoteID,
+			expected:        "order changed after approval",
+		},
+		{
+			name:            "order approved before versioned quotes",
+			approvedQuoteID: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerMock := &mocks.LoggerMock{}
+			loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+			loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+			orderServiceRepoMock := &mocks.OrderServiceRepositoryMock{}
+			orderServiceRepoMock.FindByOrderIDFunc = func(id uuid.UUID) ([]models.OrderService, error) {
+				return tt.orderServices, nil
+			}
+
+			useCase := &VerifyApprovedQuote{
+				QuoteRepository: &mocks.QuoteRepositoryMock{
+					FindByIDFunc: func(id uuid.UUID) (*models.Quote, error) { return approvedQuote, nil },
+				},
+				SnapshotOrderItems: &SnapshotOrderItems{
+					OrderInputRepository:   &mocks.OrderInputRepositoryMock{},
+					InputRepository:        &mocks.InputRepositoryMock{},
+					OrderServiceRepository: orderServiceRepoMock,
+					LaborServiceRepository: &mocks.LaborServiceRepositoryMock{},
+					Logger:                 loggerMock,
+				},
+				Logger: loggerMock,
+			}
+
+			err := useCase.Process(&models.Order{ID: orderID, Status: "In progress", ApprovedQuoteID: tt.approvedQuoteID})
+
+			if tt.expected == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.expected != "" && (err == nil || err.Error() != tt.expected) {
+				t.Errorf("Expected '%s' error, got %v", tt.expected, err)
+			}
+		})
+	}
+}
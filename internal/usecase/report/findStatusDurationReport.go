@@ -0,0 +1,163 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"time"
+
+	orderDomain "github.com/ln0rd/tech_challenge_12soat/internal/domain/order"
+	domain "github.com/ln0rd/tech_challenge_12soat/internal/domain/report"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// csvHeader lista as colunas da exportação; cada linha preenche só as colunas da sua seção
+var csvHeader = []string{"section", "status", "day", "count", "mean_seconds", "median_seconds", "p90_seconds", "opened", "delivered"}
+
+// FindStatusDurationReport gera o relatório de tempo por status, vazão diária e trabalho em andamento
+type FindStatusDurationReport struct {
+	ReportRepository repository.ReportRepository
+	Logger           logger.Logger
+}
+
+// ResolveRange aplica o período padrão e valida o intervalo. As datas são dias em UTC e o
+// fim é inclusivo: o retorno é o início do primeiro dia e o início do dia seguinte ao último.
+func ResolveRange(from *time.Time, to *time.Time) (time.Time, time.Time, error) {
+	end := time.Now().UTC()
+	if to != nil {
+		end = *to
+	}
+	end = truncateDay(end)
+
+	start := end.AddDate(0, 0, -(domain.DefaultRangeDays - 1))
+	if from != nil {
+		start = truncateDay(*from)
+	}
+
+	if start.After(end) {
+		return time.Time{}, time.Time{}, errors.New("invalid date range")
+	}
+	if end.Sub(start) >= domain.MaxRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("date range too long")
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// FillThroughput devolve um dia por linha no período, com zero nos dias sem movimento
+func FillThroughput(rows []repository.DailyThroughputRow, start time.Time, end time.Time) []domain.DailyThroughput {
+	byDay := make(map[time.Time]repository.DailyThroughputRow, len(rows))
+	for _, row := range rows {
+		byDay[truncateDay(row.Day)] = row
+	}
+
+	throughput := []domain.DailyThroughput{}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		row := byDay[day]
+		throughput = append(throughput, domain.DailyThroughput{Day: day, Opened: row.Opened, Delivered: row.Delivered})
+	}
+	return throughput
+}
+
+func (uc *FindStatusDurationReport) Process(from *time.Time, to *time.Time) (*domain.StatusDurationReport, error) {
+	start, end, err := ResolveRange(from, to)
+	if err != nil {
+		uc.Logger.Error("Invalid report range", zap.Error(err))
+		return nil, err
+	}
+
+	uc.Logger.Info("Processing status duration report",
+		zap.Time("from", start),
+		zap.Time("to", end))
+
+	// Status finais são gravados como etapas sem duração e distorceriam as estatísticas
+	durations, err := uc.ReportRepository.StatusDurations(start, end, []string{orderDomain.StatusDelivered, orderDomain.StatusCanceled})
+	if err != nil {
+		uc.Logger.Error("Error aggregating status durations", zap.Error(err))
+		return nil, err
+	}
+
+	throughput, err := uc.ReportRepository.DailyThroughput(start, end, orderDomain.StatusDelivered)
+	if err != nil {
+		uc.Logger.Error("Error aggregating daily throughput", zap.Error(err))
+		return nil, err
+	}
+
+	wip, err := uc.ReportRepository.WorkInProgress()
+	if err != nil {
+		uc.Logger.Error("Error aggregating work in progress", zap.Error(err))
+		return nil, err
+	}
+
+	report := &domain.StatusDurationReport{
+		From:            start,
+		To:              end.AddDate(0, 0, -1),
+		StatusDurations: []domain.StatusDuration{},
+		Throughput:      FillThroughput(throughput, start, end),
+		WorkInProgress:  []domain.StatusCount{},
+		GeneratedAt:     time.Now().UTC(),
+	}
+	for _, row := range durations {
+		report.StatusDurations = append(report.StatusDurations, domain.StatusDuration{
+			Status:        row.Status,
+			Count:         row.Count,
+			MeanSeconds:   row.MeanSeconds,
+			MedianSeconds: row.MedianSeconds,
+			P90Seconds:    row.P90Seconds,
+		})
+	}
+	for _, row := range wip {
+		report.WorkInProgress = append(report.WorkInProgress, domain.StatusCount{Status: row.Status, Count: row.Count})
+	}
+
+	uc.Logger.Info("Status duration report generated",
+		zap.Int("statuses", len(report.StatusDurations)),
+		zap.Int("days", len(report.Throughput)))
+
+	return report, nil
+}
+
+// RenderCSV exporta o relatório em um único CSV, com a seção de cada linha na primeira coluna
+func RenderCSV(report *domain.StatusDurationReport) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	records := [][]string{csvHeader}
+	for _, duration := range report.StatusDurations {
+		records = append(records, []string{"status_duration", duration.Status, "",
+			strconv.FormatInt(duration.Count, 10),
+			formatSeconds(duration.MeanSeconds),
+			formatSeconds(duration.MedianSeconds),
+			formatSeconds(duration.P90Seconds),
+			"", ""})
+	}
+	for _, day := range report.Throughput {
+		records = append(records, []string{"throughput", "", day.Day.Format("2006-01-02"), "", "", "", "",
+			strconv.FormatInt(day.Opened, 10),
+			strconv.FormatInt(day.Delivered, 10)})
+	}
+	for _, wip := range report.WorkInProgress {
+		records = append(records, []string{"work_in_progress", wip.Status, "", strconv.FormatInt(wip.Count, 10), "", "", "", "", ""})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 0, 64)
+}
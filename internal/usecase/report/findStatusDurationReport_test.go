@@ -0,0 +1,151 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
+	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
+	"go.uber.org/zap"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	value := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &value
+}
+
+func TestFindStatusDurationReport_Process_AggregatesRangeAndFillsMissingDays(t *testing.T) {
+	// Arrange
+	reportRepoMock := &mocks.ReportRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	var queriedFrom, queriedTo time.Time
+	var excluded []string
+	reportRepoMock.StatusDurationsFunc = func(from time.Time, to time.Time, excludeStatuses []string) ([]repository.StatusDurationRow, error) {
+		queriedFrom, queriedTo, excluded = from, to, excludeStatuses
+		return []repository.StatusDurationRow{
+			{Status: "In progress", Count: 4, MeanSeconds: 5400, MedianSeconds: 3600, P90Seconds: 10800},
+		}, nil
+	}
+	var deliveredStatus string
+	reportRepoMock.DailyThroughputFunc = func(from time.Time, to time.Time, status string) ([]repository.DailyThroughputRow, error) {
+		deliveredStatus = status
+		return []repository.DailyThroughputRow{
+			{Day: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Opened: 3, Delivered: 1},
+			{Day: time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC), Opened: 1},
+		}, nil
+	}
+	reportRepoMock.WorkInProgressFunc = func() ([]repository.StatusCountRow, error) {
+		return []repository.StatusCountRow{{Status: "Awaiting approval", Count: 2}}, nil
+	}
+
+	useCase := &FindStatusDurationReport{
+		ReportRepository: reportRepoMock,
+		Logger:           loggerMock,
+	}
+
+	// Act
+	result, err := useCase.Process(date(2026, 10, 1), date(2026, 10, 3))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// O fim do período é inclusivo: a consulta vai até o início do dia seguinte
+	if !queriedFrom.Equal(*date(2026, 10, 1)) || !queriedTo.Equal(*date(2026, 10, 4)) {
+		t.Errorf("Expected query range [2026-10-01, 2026-10-04), got [%s, %s)", queriedFrom, queriedTo)
+	}
+	if len(excluded) != 2 || deliveredStatus != "Delivered" {
+		t.Errorf("Expected final statuses excluded and Delivered as throughput status, got %v and %s", excluded, deliveredStatus)
+	}
+	if len(result.Throughput) != 3 {
+		t.Fatalf("Expected one throughput row per day, got %d", len(result.Throughput))
+	}
+	if result.Throughput[1].Opened != 0 || result.Throughput[1].Delivered != 0 || result.Throughput[2].Opened != 1 {
+		t.Errorf("Expected missing day filled with zeros, got %+v", result.Throughput)
+	}
+	if result.StatusDurations[0].P90Seconds != 10800 || result.WorkInProgress[0].Count != 2 {
+		t.Errorf("Expected aggregates to be mapped, got %+v", result)
+	}
+	if !result.To.Equal(*date(2026, 10, 3)) {
+		t.Errorf("Expected report to end on 2026-10-03, got %s", result.To)
+	}
+}
+
+func TestFindStatusDurationReport_Process_ValidatesRange(t *testing.T) {
+	// Arrange
+	reportRepoMock := &mocks.ReportRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	useCase := &FindStatusDurationReport{
+		ReportRepository: reportRepoMock,
+		Logger:           loggerMock,
+	}
+
+	// Act & Assert
+	if _, err := useCase.Process(date(2026, 10, 5), date(2026, 10, 1)); err == nil || err.Error() != "invalid date range" {
+		t.Errorf("Expected 'invalid date range', got %v", err)
+	}
+	if _, err := useCase.Process(date(2025, 1, 1), date(2026, 10, 1)); err == nil || err.Error() != "date range too long" {
+		t.Errorf("Expected 'date range too long', got %v", err)
+	}
+}
+
+func TestRenderCSV_WritesOneSectionPerRow(t *testing.T) {
+	// Arrange
+	reportRepoMock := &mocks.ReportRepositoryMock{}
+	loggerMock := &mocks.LoggerMock{}
+
+	// Mock logger
+	loggerMock.InfoFunc = func(msg string, fields ...zap.Field) {}
+	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
+
+	reportRepoMock.StatusDurationsFunc = func(from time.Time, to time.Time, excludeStatuses []string) ([]repository.StatusDurationRow, error) {
+		return []repository.StatusDurationRow{{Status: "In progress", Count: 2, MeanSeconds: 90.4, MedianSeconds: 90, P90Seconds: 120.6}}, nil
+	}
+	reportRepoMock.WorkInProgressFunc = func() ([]repository.StatusCountRow, error) {
+		return []repository.StatusCountRow{{Status: "Received", Count: 5}}, nil
+	}
+
+	useCase := &FindStatusDurationReport{
+		ReportRepository: reportRepoMock,
+		Logger:           loggerMock,
+	}
+
+	result, err := useCase.Process(date(2026, 10, 1), date(2026, 10, 1))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	content, err := RenderCSV(result)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	expected := []string{
+		"section,status,day,count,mean_seconds,median_seconds,p90_seconds,opened,delivered",
+		"status_duration,In progress,,2,90,90,121,,",
+		"throughput,,2026-10-01,,,,,0,0",
+		"work_in_progress,Received,,5,,,,,",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d: %v", len(expected), len(lines), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Line %d: expected %q, got %q", i, expected[i], lines[i])
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/logger"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/interface/persistence"
+	"github.com/ln0rd/tech_challenge_12soat/internal/usecase/auth"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -15,7 +16,9 @@ import (
 
 type CreateUser struct {
 	UserRepository repository.UserRepository
-	Logger         logger.Logger
+	// EmailVerification envia o link de confirmação após o cadastro; é opcional
+	EmailVerification *auth.SendEmailVerificationUseCase
+	Logger            logger.Logger
 }
 
 // ValidateEmailUniqueness verifica se o email é único
@@ -87,6 +90,14 @@ func (uc *CreateUser) Process(entity *domain.User) error {
 	if err != nil {
 		return err
 	}
+	entity.ID = model.ID
+
+	// Falha no envio não desfaz o cadastro; o usuário pode pedir um novo link
+	if uc.EmailVerification != nil {
+		if err := uc.EmailVerification.Execute(model.ID); err != nil {
+			uc.Logger.Error("Error sending e-mail verification", zap.Error(err), zap.String("userID", model.ID.String()))
+		}
+	}
 
 	return nil
 }
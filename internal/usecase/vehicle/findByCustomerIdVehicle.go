@@ -15,36 +15,50 @@ type FindByCustomerIdVehicle struct {
 	Logger            logger.Logger
 }
 
-// FetchVehiclesFromDB busca vehicles por customer ID do banco de dados
-func (uc *FindByCustomerIdVehicle) FetchVehiclesFromDB(customerID uuid.UUID) ([]models.Vehicle, error) {
-	vehicles, err := uc.VehicleRepository.FindByCustomerID(customerID)
+// ValidateQuery valida a paginação da listagem
+func (uc *FindByCustomerIdVehicle) ValidateQuery(spec *repository.QuerySpec) error {
+	if err := spec.Validate(); err != nil {
+		uc.Logger.Error("Invalid query", zap.Error(err), zap.Int("limit", spec.Limit))
+		return err
+	}
+	return nil
+}
+
+// FetchVehiclesFromDB busca uma página de vehicles por customer ID do banco de dados
+func (uc *FindByCustomerIdVehicle) FetchVehiclesFromDB(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error) {
+	page, err := uc.VehicleRepository.FindByCustomerID(customerID, spec)
 	if err != nil {
 		uc.Logger.Error("Database error finding vehicles by customer ID", zap.Error(err), zap.String("customerID", customerID.String()))
-		return []models.Vehicle{}, err
+		return nil, err
 	}
 
-	uc.Logger.Info("Found vehicles in database", zap.Int("count", len(vehicles)), zap.String("customerID", customerID.String()))
-	return vehicles, nil
+	uc.Logger.Info("Found vehicles in database",
+		zap.Int("count", len(page.Items)),
+		zap.Int64("total", page.Total),
+		zap.String("customerID", customerID.String()))
+	return page, nil
 }
 
-func (uc *FindByCustomerIdVehicle) Process(customerID uuid.UUID) ([]domain.Vehicle, error) {
+func (uc *FindByCustomerIdVehicle) Process(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[domain.Vehicle], error) {
 	uc.Logger.Info("Processing find vehicles by customer ID", zap.String("customerID", customerID.String()))
 
+	if err := uc.ValidateQuery(&spec); err != nil {
+		return nil, err
+	}
+
 	// Busca vehicles do banco
-	vehicles, err := uc.FetchVehiclesFromDB(customerID)
+	page, err := uc.FetchVehiclesFromDB(customerID, spec)
 	if err != nil {
-		return []domain.Vehicle{}, err
+		return nil, err
 	}
 
 	// Mapeia para o domínio usando persistence
-	domainVehicles := make([]domain.Vehicle, 0)
-	for _, vehicle := range vehicles {
-		domainVehicle := persistence.VehiclePersistence{}.ToEntity(&vehicle)
-		domainVehicles = append(domainVehicles, *domainVehicle)
-	}
+	domainPage := repository.MapPage(page, func(vehicle *models.Vehicle) domain.Vehicle {
+		return *persistence.VehiclePersistence{}.ToEntity(vehicle)
+	})
 
-	uc.Logger.Info("Successfully mapped vehicles to domain", zap.Int("count", len(domainVehicles)))
+	uc.Logger.Info("Successfully mapped vehicles to domain", zap.Int("count", len(domainPage.Items)))
 
 	// Sempre retorna uma lista (vazia se não encontrou veículos)
-	return domainVehicles, nil
+	return domainPage, nil
 }
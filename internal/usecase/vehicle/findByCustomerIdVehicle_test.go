@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/db/models"
+	"github.com/ln0rd/tech_challenge_12soat/internal/infrastructure/repository"
 	"github.com/ln0rd/tech_challenge_12soat/internal/test/mocks"
 	"go.uber.org/zap"
 )
@@ -49,8 +50,8 @@ func TestFindByCustomerIdVehicle_Process_Success(t *testing.T) {
 	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
 
 	// Mock VehicleRepository
-	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID) ([]models.Vehicle, error) {
-		return mockVehicles, nil
+	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error) {
+		return &repository.Page[models.Vehicle]{Items: mockVehicles}, nil
 	}
 
 	useCase := &FindByCustomerIdVehicle{
@@ -59,23 +60,23 @@ func TestFindByCustomerIdVehicle_Process_Success(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process(customerID)
+	result, err := useCase.Process(customerID, repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 2 {
-		t.Errorf("Expected 2 vehicles, got %d", len(result))
+	if len(result.Items) != 2 {
+		t.Errorf("Expected 2 vehicles, got %d", len(result.Items))
 	}
 
-	if result[0].Model != "Corolla" {
-		t.Errorf("Expected first vehicle model 'Corolla', got '%s'", result[0].Model)
+	if result.Items[0].Model != "Corolla" {
+		t.Errorf("Expected first vehicle model 'Corolla', got '%s'", result.Items[0].Model)
 	}
 
-	if result[1].Model != "Civic" {
-		t.Errorf("Expected second vehicle model 'Civic', got '%s'", result[1].Model)
+	if result.Items[1].Model != "Civic" {
+		t.Errorf("Expected second vehicle model 'Civic', got '%s'", result.Items[1].Model)
 	}
 }
 
@@ -91,8 +92,8 @@ func TestFindByCustomerIdVehicle_Process_EmptyResult(t *testing.T) {
 	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
 
 	// Mock VehicleRepository to return empty list
-	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID) ([]models.Vehicle, error) {
-		return []models.Vehicle{}, nil
+	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error) {
+		return &repository.Page[models.Vehicle]{Items: []models.Vehicle{}}, nil
 	}
 
 	useCase := &FindByCustomerIdVehicle{
@@ -101,15 +102,15 @@ func TestFindByCustomerIdVehicle_Process_EmptyResult(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process(customerID)
+	result, err := useCase.Process(customerID, repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 0 {
-		t.Errorf("Expected 0 vehicles, got %d", len(result))
+	if len(result.Items) != 0 {
+		t.Errorf("Expected 0 vehicles, got %d", len(result.Items))
 	}
 }
 
@@ -125,8 +126,8 @@ func TestFindByCustomerIdVehicle_Process_DatabaseError(t *testing.T) {
 	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
 
 	// Mock VehicleRepository to return error
-	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID) ([]models.Vehicle, error) {
-		return []models.Vehicle{}, errors.New("database error")
+	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error) {
+		return nil, errors.New("database error")
 	}
 
 	useCase := &FindByCustomerIdVehicle{
@@ -135,15 +136,15 @@ func TestFindByCustomerIdVehicle_Process_DatabaseError(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.Process(customerID)
+	result, err := useCase.Process(customerID, repository.QuerySpec{})
 
 	// Assert
 	if err == nil {
 		t.Error("Expected error for database error, got nil")
 	}
 
-	if len(result) != 0 {
-		t.Errorf("Expected empty result for error, got %d vehicles", len(result))
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
 	}
 
 	if err.Error() != "database error" {
@@ -177,8 +178,8 @@ func TestFindByCustomerIdVehicle_FetchVehiclesFromDB_Success(t *testing.T) {
 	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
 
 	// Mock VehicleRepository
-	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID) ([]models.Vehicle, error) {
-		return mockVehicles, nil
+	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error) {
+		return &repository.Page[models.Vehicle]{Items: mockVehicles}, nil
 	}
 
 	useCase := &FindByCustomerIdVehicle{
@@ -187,19 +188,19 @@ func TestFindByCustomerIdVehicle_FetchVehiclesFromDB_Success(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.FetchVehiclesFromDB(customerID)
+	result, err := useCase.FetchVehiclesFromDB(customerID, repository.QuerySpec{})
 
 	// Assert
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
 
-	if len(result) != 1 {
-		t.Errorf("Expected 1 vehicle, got %d", len(result))
+	if len(result.Items) != 1 {
+		t.Errorf("Expected 1 vehicle, got %d", len(result.Items))
 	}
 
-	if result[0].Model != "Corolla" {
-		t.Errorf("Expected vehicle model 'Corolla', got '%s'", result[0].Model)
+	if result.Items[0].Model != "Corolla" {
+		t.Errorf("Expected vehicle model 'Corolla', got '%s'", result.Items[0].Model)
 	}
 }
 
@@ -215,8 +216,8 @@ func TestFindByCustomerIdVehicle_FetchVehiclesFromDB_Error(t *testing.T) {
 	loggerMock.ErrorFunc = func(msg string, fields ...zap.Field) {}
 
 	// Mock VehicleRepository to return error
-	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID) ([]models.Vehicle, error) {
-		return []models.Vehicle{}, errors.New("database error")
+	vehicleRepoMock.FindByCustomerIDFunc = func(customerID uuid.UUID, spec repository.QuerySpec) (*repository.Page[models.Vehicle], error) {
+		return nil, errors.New("database error")
 	}
 
 	useCase := &FindByCustomerIdVehicle{
@@ -225,15 +226,15 @@ func TestFindByCustomerIdVehicle_FetchVehiclesFromDB_Error(t *testing.T) {
 	}
 
 	// Act
-	result, err := useCase.FetchVehiclesFromDB(customerID)
+	result, err := useCase.FetchVehiclesFromDB(customerID, repository.QuerySpec{})
 
 	// Assert
 	if err == nil {
 		t.Error("Expected error for database error, got nil")
 	}
 
-	if len(result) != 0 {
-		t.Errorf("Expected empty result for error, got %d vehicles", len(result))
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
 	}
 
 	if err.Error() != "database error" {
@@ -0,0 +1,17 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE auth_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    type VARCHAR NOT NULL,
+    user_id UUID NULL,
+    email VARCHAR,
+    ip VARCHAR,
+    user_agent VARCHAR,
+    detail VARCHAR,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auth_events_type_created_at ON auth_events(type, created_at);
+CREATE INDEX idx_auth_events_user_id_created_at ON auth_events(user_id, created_at);
+CREATE INDEX idx_auth_events_email_created_at ON auth_events(email, created_at);
+CREATE INDEX idx_auth_events_ip_created_at ON auth_events(ip, created_at);
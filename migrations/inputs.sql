@@ -6,6 +6,7 @@ CREATE TABLE inputs (
     description TEXT,
     price DECIMAL(10,2) NOT NULL,
     quantity INTEGER NOT NULL,
+    reserved_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reserved_quantity >= 0),
     input_type VARCHAR NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
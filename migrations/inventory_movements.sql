@@ -0,0 +1,16 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- Sem FOREIGN KEY em input_id para que o histórico sobreviva à exclusão do input
+CREATE TABLE inventory_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    input_id UUID NOT NULL,
+    order_id UUID NULL,
+    user_id UUID NULL,
+    movement_type VARCHAR NOT NULL,
+    delta INTEGER NOT NULL,
+    balance_after INTEGER NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_inventory_movements_input_id ON inventory_movements (input_id, created_at);
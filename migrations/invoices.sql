@@ -0,0 +1,85 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- Último número emitido de cada tipo de documento por oficina. A linha é bloqueada pelo
+-- UPDATE da emissão até o commit, então um rollback não consome o número.
+CREATE TABLE document_sequences (
+    workshop_code VARCHAR NOT NULL,
+    document_type VARCHAR NOT NULL,
+    last_number BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (workshop_code, document_type)
+);
+
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workshop_code VARCHAR NOT NULL,
+    number BIGINT NOT NULL,
+    order_id UUID NOT NULL UNIQUE,
+    quote_id UUID,
+    customer_id UUID NOT NULL,
+    customer_name VARCHAR NOT NULL,
+    customer_document_number VARCHAR NOT NULL,
+    customer_type VARCHAR NOT NULL,
+    vehicle_id UUID NOT NULL,
+    vehicle_model VARCHAR NOT NULL,
+    vehicle_brand VARCHAR NOT NULL,
+    vehicle_release_year INTEGER NOT NULL,
+    vehicle_identification_number VARCHAR NOT NULL,
+    vehicle_number_plate VARCHAR NOT NULL,
+    vehicle_color VARCHAR,
+    parts_subtotal DECIMAL(10,2) NOT NULL,
+    labor_subtotal DECIMAL(10,2) NOT NULL,
+    discount_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    surcharge_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    tax_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    total_price DECIMAL(10,2) NOT NULL,
+    created_by_user_id UUID,
+    issued_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (quote_id) REFERENCES quotes(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+    CONSTRAINT idx_invoices_workshop_number UNIQUE (workshop_code, number)
+);
+
+CREATE TABLE invoice_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL,
+    item_type VARCHAR NOT NULL,
+    reference_id UUID NOT NULL,
+    description VARCHAR,
+    quantity DECIMAL(10,2) NOT NULL,
+    unit_price DECIMAL(10,2) NOT NULL,
+    total_price DECIMAL(10,2) NOT NULL,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id)
+);
+
+CREATE INDEX idx_invoice_items_invoice_id ON invoice_items (invoice_id);
+
+CREATE TABLE credit_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL,
+    workshop_code VARCHAR NOT NULL,
+    number BIGINT NOT NULL,
+    reason VARCHAR NOT NULL,
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    created_by_user_id UUID,
+    issued_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+    CONSTRAINT idx_credit_notes_workshop_number UNIQUE (workshop_code, number)
+);
+
+CREATE INDEX idx_credit_notes_invoice_id ON credit_notes (invoice_id);
+
+-- Notas e notas de crédito são imutáveis depois de emitidas
+CREATE OR REPLACE FUNCTION reject_billing_document_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'billing documents are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_invoices_immutable BEFORE UPDATE OR DELETE ON invoices
+    FOR EACH ROW EXECUTE FUNCTION reject_billing_document_change();
+CREATE TRIGGER trg_invoice_items_immutable BEFORE UPDATE OR DELETE ON invoice_items
+    FOR EACH ROW EXECUTE FUNCTION reject_billing_document_change();
+CREATE TRIGGER trg_credit_notes_immutable BEFORE UPDATE OR DELETE ON credit_notes
+    FOR EACH ROW EXECUTE FUNCTION reject_billing_document_change();
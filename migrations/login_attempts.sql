@@ -0,0 +1,9 @@
+CREATE TABLE login_attempts (
+    scope VARCHAR NOT NULL,
+    attempt_key VARCHAR NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NULL,
+    PRIMARY KEY (scope, attempt_key),
+    CONSTRAINT chk_login_attempts_scope CHECK (scope IN ('email', 'ip'))
+);
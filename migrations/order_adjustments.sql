@@ -0,0 +1,28 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE order_adjustments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL,
+    line_id UUID,
+    kind VARCHAR NOT NULL CHECK (kind IN ('discount', 'surcharge')),
+    value_type VARCHAR NOT NULL CHECK (value_type IN ('percentage', 'fixed')),
+    percentage DECIMAL(5,2) CHECK (percentage > 0 AND percentage <= 100),
+    amount DECIMAL(10,2) CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    status VARCHAR NOT NULL CHECK (status IN ('approved', 'pending_approval')),
+    created_by_user_id UUID NOT NULL,
+    approved_by_user_id UUID,
+    approved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+    FOREIGN KEY (approved_by_user_id) REFERENCES users(id),
+    -- O percentual e o valor fixo ficam em colunas próprias, e só a do tipo do ajuste é preenchida
+    CHECK (
+        (value_type = 'percentage' AND percentage IS NOT NULL AND amount IS NULL) OR
+        (value_type = 'fixed' AND amount IS NOT NULL AND percentage IS NULL)
+    )
+);
+
+CREATE INDEX idx_order_adjustments_order_id ON order_adjustments(order_id);
@@ -0,0 +1,22 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE order_assignments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL,
+    mechanic_id UUID NOT NULL,
+    assigned_by_user_id UUID NOT NULL,
+    assigned_at TIMESTAMP NOT NULL,
+    unassigned_by_user_id UUID,
+    unassigned_at TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (mechanic_id) REFERENCES users(id),
+    FOREIGN KEY (assigned_by_user_id) REFERENCES users(id),
+    FOREIGN KEY (unassigned_by_user_id) REFERENCES users(id),
+    CONSTRAINT chk_order_assignments_period CHECK (unassigned_at IS NULL OR unassigned_at >= assigned_at)
+);
+
+CREATE INDEX idx_order_assignments_order_id ON order_assignments(order_id);
+CREATE INDEX idx_order_assignments_mechanic_id ON order_assignments(mechanic_id);
+
+-- Um mecânico tem no máximo uma designação ativa por order
+CREATE UNIQUE INDEX idx_order_assignments_active ON order_assignments(order_id, mechanic_id) WHERE unassigned_at IS NULL;
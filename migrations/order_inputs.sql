@@ -7,6 +7,9 @@ CREATE TABLE order_inputs (
     quantity INTEGER NOT NULL,
     unit_price DECIMAL(10,2) NOT NULL,
     total_price DECIMAL(10,2) NOT NULL,
+    stock_status VARCHAR NOT NULL DEFAULT 'consumed',
+    used_or_damaged BOOLEAN NOT NULL DEFAULT FALSE,
+    voided_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (order_id) REFERENCES orders(id),
@@ -0,0 +1,17 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE order_services (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL,
+    labor_service_id UUID NOT NULL,
+    mechanic_id UUID,
+    estimated_hours DECIMAL(6,2) NOT NULL CHECK (estimated_hours > 0),
+    actual_hours DECIMAL(6,2) CHECK (actual_hours >= 0),
+    hourly_rate DECIMAL(10,2) NOT NULL,
+    total_price DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (labor_service_id) REFERENCES labor_services(id),
+    FOREIGN KEY (mechanic_id) REFERENCES users(id)
+);
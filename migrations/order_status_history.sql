@@ -4,8 +4,17 @@ CREATE TABLE order_status_history (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     order_id UUID NOT NULL,
     status VARCHAR NOT NULL,
+    changed_by_user_id UUID NULL,
+    note TEXT,
+    source VARCHAR NOT NULL DEFAULT 'api',
     started_at TIMESTAMP NOT NULL,
     ended_at TIMESTAMP NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    FOREIGN KEY (order_id) REFERENCES orders(id)
-); 
\ No newline at end of file
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (changed_by_user_id) REFERENCES users(id),
+    CONSTRAINT chk_order_status_history_source CHECK (source IN ('api', 'automation', 'customer'))
+); 
+
+-- Apoiam as agregações do relatório de tempo por status e vazão diária
+CREATE INDEX idx_order_status_history_status_ended_at ON order_status_history (status, ended_at);
+CREATE INDEX idx_order_status_history_status_started_at ON order_status_history (status, started_at);
@@ -5,6 +5,9 @@ CREATE TABLE orders (
     customer_id UUID NOT NULL,
     vehicle_id UUID NOT NULL,
     status VARCHAR NOT NULL,
+    approved_quote_id UUID,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
\ No newline at end of file
+);
+
+CREATE INDEX idx_orders_created_at ON orders (created_at);
@@ -0,0 +1,18 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL,
+    method VARCHAR NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    reference VARCHAR,
+    received_by_user_id UUID,
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (received_by_user_id) REFERENCES users(id),
+    CONSTRAINT chk_payments_method CHECK (method IN ('cash', 'card', 'pix', 'bank_slip')),
+    CONSTRAINT chk_payments_amount CHECK (amount > 0)
+);
+
+CREATE INDEX idx_payments_order_id ON payments(order_id);
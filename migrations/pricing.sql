@@ -0,0 +1,19 @@
+CREATE TABLE tax_rates (
+    category VARCHAR PRIMARY KEY CHECK (category IN ('part', 'labor')),
+    name VARCHAR NOT NULL,
+    rate DECIMAL(5,2) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE pricing_settings (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    mechanic_discount_cap_percent DECIMAL(5,2) NOT NULL CHECK (mechanic_discount_cap_percent >= 0 AND mechanic_discount_cap_percent <= 100),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Valores iniciais, ajustáveis pelos admins em PUT /pricing/settings
+INSERT INTO tax_rates (category, name, rate) VALUES
+    ('part', 'ICMS', 18.00),
+    ('labor', 'ISS', 5.00);
+
+INSERT INTO pricing_settings (id, mechanic_discount_cap_percent) VALUES (1, 10.00);
@@ -0,0 +1,36 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE quotes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    valid_until TIMESTAMP NOT NULL,
+    parts_subtotal DECIMAL(10,2) NOT NULL,
+    labor_subtotal DECIMAL(10,2) NOT NULL,
+    discount_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    surcharge_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    tax_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    total_price DECIMAL(10,2) NOT NULL,
+    created_by_user_id UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id),
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+    CONSTRAINT idx_quotes_order_version UNIQUE (order_id, version)
+);
+
+CREATE TABLE quote_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    quote_id UUID NOT NULL,
+    item_type VARCHAR NOT NULL,
+    reference_id UUID NOT NULL,
+    description VARCHAR,
+    quantity DECIMAL(10,2) NOT NULL,
+    unit_price DECIMAL(10,2) NOT NULL,
+    total_price DECIMAL(10,2) NOT NULL,
+    FOREIGN KEY (quote_id) REFERENCES quotes(id)
+);
+
+CREATE INDEX idx_quote_items_quote_id ON quote_items (quote_id);
+
+-- A order guarda a versão do orçamento aprovada pelo cliente
+ALTER TABLE orders ADD CONSTRAINT fk_orders_approved_quote FOREIGN KEY (approved_quote_id) REFERENCES quotes(id);
@@ -0,0 +1,14 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE token_revocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    token_id UUID NULL UNIQUE,
+    user_id UUID NOT NULL,
+    revoked_before TIMESTAMP NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_token_revocations_target CHECK (token_id IS NOT NULL OR revoked_before IS NOT NULL)
+);
+
+CREATE INDEX idx_token_revocations_user_id ON token_revocations(user_id);
+CREATE INDEX idx_token_revocations_expires_at ON token_revocations(expires_at);
@@ -7,6 +7,7 @@ CREATE TABLE "users" (
     username VARCHAR NOT NULL,
     user_type VARCHAR NOT NULL CHECK (user_type IN ('admin', 'mechanic', 'vehicle_owner')),
     customer_id UUID,
+    email_verified_at TIMESTAMP NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
\ No newline at end of file